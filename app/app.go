@@ -7,6 +7,7 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -312,7 +313,7 @@ func NewWasmApp(
 		icacontrollertypes.StoreKey,
 	)
 
-	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey, wasmtypes.TStoreKey)
 
 	// register streaming services
 	if err := bApp.RegisterStreamingServices(appOpts, keys); err != nil {
@@ -583,12 +584,22 @@ func NewWasmApp(
 	}
 
 	ibcRouterV2 := ibcapi.NewRouter()
+	// the custom queries wrap the custom querier of the wasm options, see the TxMemoDecorator in the ante handler
+	// for the tx memo. The gas price queries are opt-in with wasmkeeper.WithGasPriceQueries as this app has no
+	// consensus min gas prices; the node local min gas prices of the app.toml must not be exposed to contracts.
+	wasmOpts = append(slices.Clone(wasmOpts),
+		wasmkeeper.WithTxMemoQueries(),
+		wasmkeeper.WithContractMetadataQueries(),
+		wasmkeeper.WithBondedValidatorsQueries(app.StakingKeeper),
+		wasmkeeper.WithBlockBeaconQueries(),
+	)
 
 	// The last arguments can contain custom message handlers, and custom query handlers,
 	// if we want to allow any custom callbacks
 	app.WasmKeeper = wasmkeeper.NewKeeper(
 		appCodec,
 		runtime.NewKVStoreService(keys[wasmtypes.StoreKey]),
+		runtime.NewTransientStoreService(tkeys[wasmtypes.TStoreKey]),
 		app.AccountKeeper,
 		app.BankKeeper,
 		app.StakingKeeper,
@@ -875,6 +886,10 @@ func NewWasmApp(
 		if err := app.WasmKeeper.InitializePinnedCodes(ctx); err != nil {
 			panic(fmt.Sprintf("failed initialize pinned codes %s", err))
 		}
+		// Warm the wasmvm cache with the codes used before the last shutdown
+		if err := app.WasmKeeper.WarmCache(ctx); err != nil {
+			logger.Error("failed to warm wasm cache", "error", err)
+		}
 	}
 
 	return app
@@ -957,6 +972,14 @@ func (app *WasmApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height)
 }
 
+// Close writes the wasm warm list and closes the app
+func (app *WasmApp) Close() error {
+	if err := app.WasmKeeper.WriteWarmList(); err != nil {
+		app.Logger().Error("failed to write wasm warm list", "error", err)
+	}
+	return app.BaseApp.Close()
+}
+
 // LegacyAmino returns legacy amino codec.
 //
 // NOTE: This is solely to be used for testing purposes as it may be desirable
@@ -1,17 +1,25 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"time"
 
 	cmtcfg "github.com/cometbft/cometbft/config"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtstore "github.com/cometbft/cometbft/store"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	confixcmd "cosmossdk.io/tools/confix/cmd"
 
@@ -33,6 +41,7 @@ import (
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	genutilcli "github.com/cosmos/cosmos-sdk/x/genutil/client/cli"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 
 	"github.com/CosmWasm/wasmd/app"
 	"github.com/CosmWasm/wasmd/x/wasm"
@@ -103,10 +112,13 @@ func initRootCmd(
 	cfg := sdk.GetConfig()
 	cfg.Seal()
 
+	debugCmd := debug.Cmd()
+	debugCmd.AddCommand(replayContractCallCmd(app.DefaultNodeHome))
+
 	rootCmd.AddCommand(
 		genutilcli.InitCmd(basicManager, app.DefaultNodeHome),
 		NewTestnetCmd(basicManager, banktypes.GenesisBalancesIterator{}),
-		debug.Cmd(),
+		debugCmd,
 		confixcmd.ConfigCommand(),
 		pruning.Cmd(newApp, app.DefaultNodeHome),
 		snapshot.Cmd(newApp),
@@ -114,11 +126,12 @@ func initRootCmd(
 
 	server.AddCommands(rootCmd, app.DefaultNodeHome, newApp, appExport, addModuleInitFlags)
 	wasmcli.ExtendUnsafeResetAllCmd(rootCmd)
+	rootCmd.AddCommand(exportWasmStateCmd(app.DefaultNodeHome))
 
 	// add keybase, auxiliary RPC, query, genesis, and tx child commands
 	rootCmd.AddCommand(
 		server.StatusCommand(),
-		genesisCommand(txConfig, basicManager),
+		genesisCommand(txConfig, basicManager, wasmcli.GenesisImportContractsCmd(app.DefaultNodeHome), wasmcli.GenesisReplaceContractStateCmd(app.DefaultNodeHome)),
 		queryCommand(),
 		txCommand(),
 		keys.Commands(),
@@ -255,6 +268,184 @@ func appExport(
 	return wasmApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs, modulesToExport)
 }
 
+// exportWasmStateCmd streams the state of all contracts as newline delimited JSON, independent of a full genesis export.
+func exportWasmStateCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-wasm-state",
+		Short: "Export the state of all wasm contracts as newline delimited JSON",
+		Long: `Export the state of all wasm contracts as newline delimited JSON for offline analysis.
+Each line is a record with the contract address, the hex encoded key and the base64 encoded value.
+The node must not be running.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			homeDir, _ := cmd.Flags().GetString(flags.FlagHome)
+			config.SetRoot(homeDir)
+
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(serverCtx.Viper), filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			height, _ := cmd.Flags().GetInt64(server.FlagHeight)
+			var emptyWasmOpts []wasmkeeper.Option
+			wasmApp := app.NewWasmApp(serverCtx.Logger, db, nil, height == -1, serverCtx.Viper, emptyWasmOpts)
+			if height != -1 {
+				if err := wasmApp.LoadHeight(height); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if outputDocument, _ := cmd.Flags().GetString(flags.FlagOutputDocument); outputDocument != "" {
+				f, err := os.Create(outputDocument)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			w := bufio.NewWriter(out)
+			ctx := wasmApp.NewContextLegacy(true, cmtproto.Header{Height: wasmApp.LastBlockHeight()})
+			if err := wasmApp.WasmKeeper.StreamExportContractState(ctx, w); err != nil {
+				return err
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(server.FlagHeight, -1, "Export state from a particular height (-1 means latest height)")
+	cmd.Flags().String(flags.FlagOutputDocument, "", "Exported state is written to the given file instead of STDOUT")
+	return cmd
+}
+
+const (
+	flagReplaySender    = "sender"
+	flagReplayAmount    = "amount"
+	flagReplayGasLimit  = "gas-limit"
+	flagReplayBlockTime = "block-time"
+)
+
+// replayContractCallCmd re-runs a contract execution against the committed state of a past height for debugging.
+func replayContractCallCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-contract-call [contract_addr_bech32] [json_encoded_msg]",
+		Short: "Replay a contract execution against the committed state of a height",
+		Long: `Replay a contract execution against the committed state after the given height and print the result,
+the events and the gas used as JSON. The execution runs as in the next block and nothing is written to state.
+The chain id is read from the genesis file and the block time from the stored block after the height, unless
+they are set with the flags. The height must not be pruned. The node must not be running.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contractAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return errorsmod.Wrap(err, "contract")
+			}
+			senderArg, _ := cmd.Flags().GetString(flagReplaySender)
+			sender, err := sdk.AccAddressFromBech32(senderArg)
+			if err != nil {
+				return errorsmod.Wrap(err, "sender")
+			}
+			amountArg, _ := cmd.Flags().GetString(flagReplayAmount)
+			amount, err := sdk.ParseCoinsNormalized(amountArg)
+			if err != nil {
+				return errorsmod.Wrap(err, "amount")
+			}
+			gasLimit, _ := cmd.Flags().GetUint64(flagReplayGasLimit)
+			height, _ := cmd.Flags().GetInt64(server.FlagHeight)
+			if height <= 0 {
+				return errors.New("height must be positive")
+			}
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			homeDir, _ := cmd.Flags().GetString(flags.FlagHome)
+			config.SetRoot(homeDir)
+
+			header, headerHash, err := replayHeader(cmd, config, height)
+			if err != nil {
+				return err
+			}
+
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(serverCtx.Viper), filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var emptyWasmOpts []wasmkeeper.Option
+			wasmApp := app.NewWasmApp(serverCtx.Logger, db, nil, false, serverCtx.Viper, emptyWasmOpts)
+			if err := wasmApp.LoadHeight(height); err != nil {
+				return errorsmod.Wrapf(err, "load height %d, it may be pruned", height)
+			}
+
+			ctx := sdk.NewContext(wasmApp.CommitMultiStore().CacheMultiStore(), header, false, serverCtx.Logger).
+				WithHeaderHash(headerHash).
+				WithExecMode(sdk.ExecModeFinalize)
+			r := wasmApp.WasmKeeper.ReplayExecute(ctx, contractAddr, sender, []byte(args[1]), amount, gasLimit)
+			bz, err := json.MarshalIndent(r, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(server.FlagHeight, 0, "Replay against the committed state of this height")
+	cmd.Flags().String(flagReplaySender, "", "The bech32 address of the sender of the execution")
+	cmd.Flags().String(flagReplayAmount, "", "Coins to send to the contract with the execution")
+	cmd.Flags().Uint64(flagReplayGasLimit, 100_000_000, "The gas limit of the execution")
+	cmd.Flags().String(flagReplayBlockTime, "", "The RFC3339 block time of the execution (default the time of the stored block after the height)")
+	cmd.Flags().String(flags.FlagChainID, "", "The chain id of the execution (default the chain id of the genesis file)")
+	_ = cmd.MarkFlagRequired(server.FlagHeight)
+	_ = cmd.MarkFlagRequired(flagReplaySender)
+	return cmd
+}
+
+// replayHeader returns the header and header hash of the block after the height, in which the execution is replayed.
+// The block is loaded from the block store when it exists. The chain id flag overrides the chain id of the genesis
+// file and the block time flag the time of the stored block.
+func replayHeader(cmd *cobra.Command, config *cmtcfg.Config, height int64) (cmtproto.Header, []byte, error) {
+	header := cmtproto.Header{Height: height + 1}
+	header.ChainID, _ = cmd.Flags().GetString(flags.FlagChainID)
+	if header.ChainID == "" {
+		appGenesis, err := genutiltypes.AppGenesisFromFile(config.GenesisFile())
+		if err != nil {
+			return header, nil, errorsmod.Wrapf(err, "read chain id from genesis, set it with the %s flag", flags.FlagChainID)
+		}
+		header.ChainID = appGenesis.ChainID
+	}
+
+	blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return header, nil, err
+	}
+	defer blockStoreDB.Close()
+	var headerHash []byte
+	if meta := cmtstore.NewBlockStore(blockStoreDB).LoadBlockMeta(height + 1); meta != nil {
+		header.Time = meta.Header.Time
+		header.ProposerAddress = meta.Header.ProposerAddress
+		headerHash = meta.BlockID.Hash
+	}
+
+	if blockTimeArg, _ := cmd.Flags().GetString(flagReplayBlockTime); blockTimeArg != "" {
+		if header.Time, err = time.Parse(time.RFC3339, blockTimeArg); err != nil {
+			return header, nil, errorsmod.Wrap(err, "block time")
+		}
+	}
+	if header.Time.IsZero() {
+		return header, nil, fmt.Errorf("block %d not found, set the block time with the %s flag", height+1, flagReplayBlockTime)
+	}
+	return header, headerHash, nil
+}
+
 var tempDir = func() string {
 	dir, err := os.MkdirTemp("", "wasmd")
 	if err != nil {
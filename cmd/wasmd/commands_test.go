@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtcfg "github.com/cometbft/cometbft/config"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtstore "github.com/cometbft/cometbft/store"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"github.com/CosmWasm/wasmd/app"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const replayChainID = "replay-chain"
+
+func TestReplayContractCallCmd(t *testing.T) {
+	specs := map[string]struct {
+		sender   func(verifier sdk.AccAddress) sdk.AccAddress
+		expError bool
+	}{
+		"success": {
+			sender: func(verifier sdk.AccAddress) sdk.AccAddress { return verifier },
+		},
+		"contract error": {
+			sender:   func(sdk.AccAddress) sdk.AccAddress { return bytes.Repeat([]byte{1}, 20) },
+			expError: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// the command keeps the lock of the wasm directory, so that each run needs a new home
+			home, contractAddr, verifier := setupReplayHome(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+			serverCtx := server.NewDefaultContext()
+			serverCtx.Viper.Set(flags.FlagHome, home)
+			cmd := replayContractCallCmd(home)
+			cmd.SetArgs([]string{
+				contractAddr.String(), `{"release":{}}`,
+				"--" + server.FlagHeight + "=1",
+				"--" + flagReplaySender + "=" + spec.sender(verifier).String(),
+				"--" + flags.FlagHome + "=" + home,
+			})
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			// when
+			err := cmd.ExecuteContext(context.WithValue(context.Background(), server.ServerContextKey, serverCtx))
+
+			// then
+			require.NoError(t, err)
+			var got wasmkeeper.ExecuteReplay
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.NotZero(t, got.GasUsed)
+			if spec.expError {
+				assert.Contains(t, got.Error, "Unauthorized")
+				assert.Empty(t, got.Events)
+				return
+			}
+			assert.Empty(t, got.Error)
+			var eventTypes []string
+			for _, e := range got.Events {
+				eventTypes = append(eventTypes, e.Type)
+			}
+			assert.Contains(t, eventTypes, types.EventTypeExecute)
+		})
+	}
+}
+
+func TestReplayHeader(t *testing.T) {
+	blockTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	home, _, _ := setupReplayHome(t, blockTime)
+	blockHash := loadBlockHash(t, home, 2)
+
+	specs := map[string]struct {
+		home          string
+		height        int64
+		flags         map[string]string
+		expChainID    string
+		expTime       time.Time
+		expHeaderHash []byte
+		expErr        bool
+	}{
+		"from genesis and block store": {
+			home:          home,
+			height:        1,
+			expChainID:    replayChainID,
+			expTime:       blockTime,
+			expHeaderHash: blockHash,
+		},
+		"chain id and block time flags": {
+			home:          home,
+			height:        1,
+			flags:         map[string]string{flags.FlagChainID: "other-chain", flagReplayBlockTime: "2025-01-01T00:00:00Z"},
+			expChainID:    "other-chain",
+			expTime:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expHeaderHash: blockHash,
+		},
+		"block not stored": {
+			home:   home,
+			height: 2,
+			expErr: true,
+		},
+		"block not stored with block time flag": {
+			home:       home,
+			height:     2,
+			flags:      map[string]string{flagReplayBlockTime: "2025-01-01T00:00:00Z"},
+			expChainID: replayChainID,
+			expTime:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"invalid block time flag": {
+			home:   home,
+			height: 1,
+			flags:  map[string]string{flagReplayBlockTime: "yesterday"},
+			expErr: true,
+		},
+		"no genesis": {
+			home:   t.TempDir(),
+			height: 1,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := replayContractCallCmd(spec.home)
+			for k, v := range spec.flags {
+				require.NoError(t, cmd.Flags().Set(k, v))
+			}
+			config := cmtcfg.DefaultConfig()
+			config.SetRoot(spec.home)
+
+			// when
+			gotHeader, gotHeaderHash, gotErr := replayHeader(cmd, config, spec.height)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.height+1, gotHeader.Height)
+			assert.Equal(t, spec.expChainID, gotHeader.ChainID)
+			assert.Equal(t, spec.expTime, gotHeader.Time.UTC())
+			assert.Equal(t, spec.expHeaderHash, gotHeaderHash)
+		})
+	}
+}
+
+// setupReplayHome commits the hackatom contract at height 1 to the application db of a new home directory, writes the
+// genesis file and stores the block at height 2 with the block time. It returns the home, the contract and its verifier.
+func setupReplayHome(t *testing.T, blockTime time.Time) (string, sdk.AccAddress, sdk.AccAddress) {
+	t.Helper()
+	home := t.TempDir()
+	wasmCode, err := os.ReadFile("../../x/wasm/keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	// the vm is released before the command creates its own one in the same directory
+	vm, err := wasmvm.NewVM(filepath.Join(home, "wasm", "wasm"), wasmkeeper.BuiltInCapabilities(), 32, false, 0)
+	require.NoError(t, err)
+	defer vm.Cleanup()
+	db, err := dbm.NewGoLevelDB("application", filepath.Join(home, "data"), nil)
+	require.NoError(t, err)
+	wasmApp := app.NewWasmApp(log.NewNopLogger(), db, nil, true, simtestutil.NewAppOptionsWithFlagHome(home),
+		[]wasmkeeper.Option{wasmkeeper.WithWasmEngine(vm)}, baseapp.SetChainID(replayChainID))
+	defer wasmApp.Close()
+
+	genesisState := app.GenesisStateWithSingleValidator(t, wasmApp)
+	stateBytes, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "config"), 0o755))
+	require.NoError(t, genutiltypes.NewAppGenesisWithVersion(replayChainID, stateBytes).SaveAs(filepath.Join(home, "config", "genesis.json")))
+	_, err = wasmApp.InitChain(&abci.RequestInitChain{
+		ChainId:         replayChainID,
+		Time:            blockTime.Add(-time.Minute),
+		ConsensusParams: simtestutil.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	require.NoError(t, err)
+	_, err = wasmApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: 1, Time: blockTime.Add(-time.Minute)})
+	require.NoError(t, err)
+
+	verifier := sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	ctx := wasmApp.NewUncachedContext(false, cmtproto.Header{ChainID: replayChainID, Height: 1, Time: blockTime.Add(-time.Minute)})
+	contractKeeper := wasmkeeper.NewDefaultPermissionKeeper(wasmApp.WasmKeeper)
+	codeID, _, err := contractKeeper.Create(ctx, verifier, wasmCode, nil)
+	require.NoError(t, err)
+	initMsg, err := json.Marshal(wasmkeeper.HackatomExampleInitMsg{Verifier: verifier, Beneficiary: verifier})
+	require.NoError(t, err)
+	contractAddr, _, err := contractKeeper.Instantiate(ctx, codeID, verifier, nil, initMsg, "replay", nil)
+	require.NoError(t, err)
+	_, err = wasmApp.Commit()
+	require.NoError(t, err)
+
+	config := cmtcfg.DefaultConfig()
+	config.SetRoot(home)
+	blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	block := cmttypes.MakeBlock(2, nil, &cmttypes.Commit{}, nil)
+	block.ChainID = replayChainID
+	block.Time = blockTime
+	block.ProposerAddress = bytes.Repeat([]byte{3}, 20)
+	partSet, err := block.MakePartSet(cmttypes.BlockPartSizeBytes)
+	require.NoError(t, err)
+	seenCommit := &cmttypes.Commit{Height: 2, BlockID: cmttypes.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}}
+	cmtstore.NewBlockStore(blockStoreDB).SaveBlock(block, partSet, seenCommit)
+	return home, contractAddr, verifier
+}
+
+func loadBlockHash(t *testing.T, home string, height int64) []byte {
+	t.Helper()
+	config := cmtcfg.DefaultConfig()
+	config.SetRoot(home)
+	blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: config})
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+	meta := cmtstore.NewBlockStore(blockStoreDB).LoadBlockMeta(height)
+	require.NotNil(t, meta)
+	return meta.BlockID.Hash
+}
@@ -0,0 +1,88 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	ibctesting "github.com/cosmos/ibc-go/v10/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	wasmibctesting "github.com/CosmWasm/wasmd/tests/wasmibctesting"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestTxMemoQuery(t *testing.T) {
+	// Given a contract that reads the tx memo with the custom query on execute
+	// And   the forward tx memo param enabled
+	// When  the contract is executed in a transaction with a memo
+	// Then  the contract reads the memo of the transaction
+	var gotMemo string
+	mock := &wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		bz, err := querier.Query(wasmvmtypes.QueryRequest{Custom: []byte(`{"tx_memo":{}}`)}, gasLimit)
+		if err != nil {
+			return &wasmvmtypes.ContractResult{Err: err.Error()}, 0, nil
+		}
+		var rsp wasmkeeper.TxMemoResponse
+		if err := json.Unmarshal(bz, &rsp); err != nil {
+			return nil, 0, err
+		}
+		gotMemo = rsp.Memo
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	coord := wasmibctesting.NewCoordinator(t, 1, []wasmkeeper.Option{wasmkeeper.WithWasmEngine(mock)})
+	chain := wasmibctesting.NewWasmTestChain(coord.GetChain(ibctesting.GetChainID(1)))
+	contractAddr := chain.SeedNewContractInstance()
+	app := chain.GetWasmApp()
+	params := app.WasmKeeper.GetParams(chain.GetContext())
+	params.ForwardTxMemo = true
+	require.NoError(t, app.WasmKeeper.SetParams(chain.GetContext(), params))
+
+	// the test chain sends transactions with a random memo so that the tx is built here to know the memo
+	execMsg := &types.MsgExecuteContract{
+		Sender:   chain.SenderAccount.GetAddress().String(),
+		Contract: contractAddr.String(),
+		Msg:      []byte(`{}`),
+	}
+	tx, err := simtestutil.GenSignedMockTx(
+		rand.New(rand.NewSource(1)),
+		chain.TxConfig,
+		[]sdk.Msg{execMsg},
+		sdk.Coins{sdk.NewInt64Coin(sdk.DefaultBondDenom, 0)},
+		simtestutil.DefaultGenTxGas,
+		chain.ChainID,
+		[]uint64{chain.SenderAccount.GetAccountNumber()},
+		[]uint64{chain.SenderAccount.GetSequence()},
+		chain.SenderPrivKey,
+	)
+	require.NoError(t, err)
+	expMemo := tx.(sdk.TxWithMemo).GetMemo()
+	require.NotEmpty(t, expMemo)
+	txBytes, err := chain.TxConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+
+	// when
+	rsp, err := app.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height:             app.LastBlockHeight() + 1,
+		Time:               chain.ProposedHeader.GetTime(),
+		NextValidatorsHash: chain.NextVals.Hash(),
+		Txs:                [][]byte{txBytes},
+	})
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, rsp.TxResults, 1)
+	require.Zero(t, rsp.TxResults[0].Code, rsp.TxResults[0].Log)
+	assert.Equal(t, expMemo, gotMemo)
+}
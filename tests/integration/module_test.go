@@ -480,8 +480,9 @@ func TestReadNodeConfig(t *testing.T) {
 				"wasm.query_gas_limit": 1,
 			},
 			exp: types.NodeConfig{
-				SmartQueryGasLimit: 1,
-				MemoryCacheSize:    defaults.MemoryCacheSize,
+				SmartQueryGasLimit:    1,
+				MemoryCacheSize:       defaults.MemoryCacheSize,
+				MetricsSampleInterval: defaults.MetricsSampleInterval,
 			},
 		},
 		"set cache via opts": {
@@ -489,8 +490,41 @@ func TestReadNodeConfig(t *testing.T) {
 				"wasm.memory_cache_size": 2,
 			},
 			exp: types.NodeConfig{
-				MemoryCacheSize:    2,
-				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
+				MemoryCacheSize:       2,
+				SmartQueryGasLimit:    defaults.SmartQueryGasLimit,
+				MetricsSampleInterval: defaults.MetricsSampleInterval,
+			},
+		},
+		"set metrics sample interval via opts": {
+			src: AppOptionsMock{
+				"wasm.metrics_sample_interval": 0,
+			},
+			exp: types.NodeConfig{
+				SmartQueryGasLimit:    defaults.SmartQueryGasLimit,
+				MemoryCacheSize:       defaults.MemoryCacheSize,
+				MetricsSampleInterval: 0,
+			},
+		},
+		"set warm cache size via opts": {
+			src: AppOptionsMock{
+				"wasm.warm_cache_size": 5,
+			},
+			exp: types.NodeConfig{
+				SmartQueryGasLimit:    defaults.SmartQueryGasLimit,
+				MemoryCacheSize:       defaults.MemoryCacheSize,
+				MetricsSampleInterval: defaults.MetricsSampleInterval,
+				WarmCacheSize:         5,
+			},
+		},
+		"set wasm simulation gas limit via opts": {
+			src: AppOptionsMock{
+				"wasm.wasm_simulation_gas_limit": 6,
+			},
+			exp: types.NodeConfig{
+				SmartQueryGasLimit:     defaults.SmartQueryGasLimit,
+				MemoryCacheSize:        defaults.MemoryCacheSize,
+				MetricsSampleInterval:  defaults.MetricsSampleInterval,
+				WasmSimulationGasLimit: 6,
 			},
 		},
 		"set debug via opts": {
@@ -498,9 +532,10 @@ func TestReadNodeConfig(t *testing.T) {
 				"trace": true,
 			},
 			exp: types.NodeConfig{
-				SmartQueryGasLimit: defaults.SmartQueryGasLimit,
-				MemoryCacheSize:    defaults.MemoryCacheSize,
-				ContractDebugMode:  true,
+				SmartQueryGasLimit:    defaults.SmartQueryGasLimit,
+				MemoryCacheSize:       defaults.MemoryCacheSize,
+				ContractDebugMode:     true,
+				MetricsSampleInterval: defaults.MetricsSampleInterval,
 			},
 		},
 		"all defaults when no options set": {
@@ -513,15 +548,21 @@ func TestReadNodeConfig(t *testing.T) {
 		},
 		"custom config template values": {
 			src: withViper(types.ConfigTemplate(types.NodeConfig{
-				SimulationGasLimit: &one,
-				SmartQueryGasLimit: 2,
-				MemoryCacheSize:    3,
+				SimulationGasLimit:     &one,
+				SmartQueryGasLimit:     2,
+				MemoryCacheSize:        3,
+				MetricsSampleInterval:  4,
+				WarmCacheSize:          5,
+				WasmSimulationGasLimit: 6,
 			})),
 			exp: types.NodeConfig{
-				SimulationGasLimit: &one,
-				SmartQueryGasLimit: 2,
-				MemoryCacheSize:    3,
-				ContractDebugMode:  false,
+				SimulationGasLimit:     &one,
+				SmartQueryGasLimit:     2,
+				MemoryCacheSize:        3,
+				ContractDebugMode:      false,
+				MetricsSampleInterval:  4,
+				WarmCacheSize:          5,
+				WasmSimulationGasLimit: 6,
 			},
 		},
 	}
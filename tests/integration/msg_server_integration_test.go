@@ -1,17 +1,23 @@
 package integration
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v3"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	abci "github.com/cometbft/cometbft/abci/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	storetypes "cosmossdk.io/store/types"
+
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -53,6 +59,125 @@ func TestStoreCode(t *testing.T) {
 	assert.Equal(t, expHash[:], wasmvmtypes.Checksum(info.CodeHash))
 	assert.Equal(t, sender.String(), info.Creator)
 	assert.Equal(t, types.DefaultParams().InstantiateDefaultPermission.With(sender), info.InstantiateConfig)
+	assert.Empty(t, info.Source)
+	assert.Empty(t, info.Builder)
+}
+
+func TestStoreCodeWithProvenance(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContext(false)
+	_, _, sender := testdata.KeyTestPubAddr()
+	msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = sender.String()
+		m.Source = "https://example.com/reflect"
+		m.Builder = "cosmwasm/optimizer:0.16.0"
+	})
+
+	// when
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+	// then
+	require.NoError(t, err)
+	var result types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+
+	source, builder, err := wasmApp.WasmKeeper.GetCodeProvenance(ctx, result.CodeID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/reflect", source)
+	assert.Equal(t, "cosmwasm/optimizer:0.16.0", builder)
+	// and
+	info := wasmApp.WasmKeeper.GetCodeInfo(ctx, result.CodeID)
+	require.NotNil(t, info)
+	assert.Equal(t, wasmvmtypes.Checksum(result.Checksum), wasmvmtypes.Checksum(info.CodeHash))
+}
+
+func TestStoreCodes(t *testing.T) {
+	wasmApp := app.Setup(t)
+	_, _, sender := testdata.KeyTestPubAddr()
+	_, _, other := testdata.KeyTestPubAddr()
+	expHashes := make([][]byte, 0, 2)
+	for _, code := range [][]byte{wasmContract, hackatomContract} {
+		h, err := wasmvm.CreateChecksum(code)
+		require.NoError(t, err)
+		expHashes = append(expHashes, h[:])
+	}
+
+	specs := map[string]struct {
+		codes        [][]byte
+		uploadAccess types.AccessConfig
+		sender       sdk.AccAddress
+		expErr       bool
+	}{
+		"all stored": {
+			codes:        [][]byte{wasmContract, hackatomContract},
+			uploadAccess: types.AllowEverybody,
+			sender:       sender,
+		},
+		"invalid code rolls back all": {
+			codes:        [][]byte{wasmContract, []byte("invalid")},
+			uploadAccess: types.AllowEverybody,
+			sender:       sender,
+			expErr:       true,
+		},
+		"unauthorized sender": {
+			codes:        [][]byte{wasmContract, hackatomContract},
+			uploadAccess: types.AccessTypeAnyOfAddresses.With(other),
+			sender:       sender,
+			expErr:       true,
+		},
+	}
+	parentCtx := wasmApp.BaseApp.NewContext(false)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := wasmApp.WasmKeeper.GetParams(ctx)
+			params.CodeUploadAccess = spec.uploadAccess
+			require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+			msg := &types.MsgStoreCodes{
+				Sender:        spec.sender.String(),
+				WASMByteCodes: spec.codes,
+			}
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				stats, err := wasmApp.WasmKeeper.GetModuleStats(ctx)
+				require.NoError(t, err)
+				assert.Zero(t, stats.CodeCount)
+				assert.Zero(t, stats.LastCodeID)
+				return
+			}
+			require.NoError(t, err)
+			var result types.MsgStoreCodesResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Equal(t, []uint64{1, 2}, result.CodeIDs)
+			assert.Equal(t, expHashes, result.Checksums)
+			for i, codeID := range result.CodeIDs {
+				info := wasmApp.WasmKeeper.GetCodeInfo(ctx, codeID)
+				require.NotNil(t, info)
+				assert.Equal(t, expHashes[i], []byte(info.CodeHash))
+				assert.Equal(t, spec.sender.String(), info.Creator)
+			}
+			// and the summary event is emitted
+			var found bool
+			for _, e := range rsp.GetEvents() {
+				if e.Type != types.EventTypeStoreCodes {
+					continue
+				}
+				found = true
+				for _, attr := range e.Attributes {
+					if attr.Key == types.AttributeKeyCodeIDs {
+						assert.Equal(t, "1,2", attr.Value)
+					}
+				}
+			}
+			assert.True(t, found)
+		})
+	}
 }
 
 func TestUpdateParams(t *testing.T) {
@@ -145,6 +270,75 @@ func TestUpdateParams(t *testing.T) {
 	}
 }
 
+func TestUpdateInstantiateDefaultPermission(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContext(false)
+
+	var (
+		myAddress    sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		govAuthority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		src    types.MsgUpdateInstantiateDefaultPermission
+		expErr error
+	}{
+		"update to nobody": {
+			src: types.MsgUpdateInstantiateDefaultPermission{
+				Authority:  govAuthority,
+				Permission: types.AccessTypeNobody,
+			},
+		},
+		"update to any of addresses": {
+			src: types.MsgUpdateInstantiateDefaultPermission{
+				Authority:  govAuthority,
+				Permission: types.AccessTypeAnyOfAddresses,
+			},
+		},
+		"unspecified permission": {
+			src: types.MsgUpdateInstantiateDefaultPermission{
+				Authority: govAuthority,
+			},
+			expErr: types.ErrEmpty,
+		},
+		"unknown permission": {
+			src: types.MsgUpdateInstantiateDefaultPermission{
+				Authority:  govAuthority,
+				Permission: types.AccessType(99),
+			},
+			expErr: types.ErrInvalid,
+		},
+		"other address": {
+			src: types.MsgUpdateInstantiateDefaultPermission{
+				Authority:  myAddress.String(),
+				Permission: types.AccessTypeNobody,
+			},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			params := types.DefaultParams()
+			params.CodeUploadAccess = types.AllowNobody
+			require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+
+			// when
+			_, err := wasmApp.MsgServiceRouter().Handler(&spec.src)(ctx, &spec.src) //nolint:gosec
+
+			// then
+			got := wasmApp.WasmKeeper.GetParams(ctx)
+			assert.Equal(t, types.AllowNobody, got.CodeUploadAccess)
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				assert.Equal(t, types.AccessTypeEverybody, got.InstantiateDefaultPermission)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.src.Permission, got.InstantiateDefaultPermission)
+		})
+	}
+}
+
 func TestAddCodeUploadParamsAddresses(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContext(false)
@@ -484,8 +678,9 @@ func TestSudoContract(t *testing.T) {
 	require.NoError(t, err)
 
 	specs := map[string]struct {
-		addr   string
-		expErr bool
+		addr     string
+		selfCall bool
+		expErr   bool
 	}{
 		"authority can call sudo on a contract": {
 			addr:   authority,
@@ -495,6 +690,10 @@ func TestSudoContract(t *testing.T) {
 			addr:   myAddress.String(),
 			expErr: true,
 		},
+		"contract cannot call sudo on itself": {
+			selfCall: true,
+			expErr:   true,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -532,9 +731,14 @@ func TestSudoContract(t *testing.T) {
 			var instantiateResponse types.MsgInstantiateContractResponse
 			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
 
+			sudoSender := spec.addr
+			if spec.selfCall {
+				sudoSender = instantiateResponse.Address
+			}
+
 			// when
 			msgSudoContract := &types.MsgSudoContract{
-				Authority: spec.addr,
+				Authority: sudoSender,
 				Msg:       stealMsgBz,
 				Contract:  instantiateResponse.Address,
 			}
@@ -542,7 +746,7 @@ func TestSudoContract(t *testing.T) {
 
 			// then
 			if spec.expErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, types.ErrInvalid)
 			} else {
 				require.NoError(t, err)
 			}
@@ -737,203 +941,480 @@ func TestClearAdmin(t *testing.T) {
 	}
 }
 
-func TestMigrateContract(t *testing.T) {
+func TestClearAdmins(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
 
-	var (
-		myAddress       sdk.AccAddress = make([]byte, types.ContractAddrLen)
-		authority                      = wasmApp.WasmKeeper.GetAuthority()
-		_, _, otherAddr                = testdata.KeyTestPubAddr()
-	)
+	_, _, myAddr := testdata.KeyTestPubAddr()
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+
+	// store code
+	msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = myAddr.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+	instantiate := func(t *testing.T, admin sdk.AccAddress) string {
+		msgInstantiate := &types.MsgInstantiateContract{
+			Sender: myAddr.String(),
+			Admin:  admin.String(),
+			CodeID: storeCodeResponse.CodeID,
+			Label:  "test",
+			Msg:    []byte(`{}`),
+			Funds:  sdk.Coins{},
+		}
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+		require.NoError(t, err)
+		var instantiateResponse types.MsgInstantiateContractResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+		return instantiateResponse.Address
+	}
+	adminOf := func(contract string) string {
+		return wasmApp.WasmKeeper.GetContractInfo(ctx, sdk.MustAccAddressFromBech32(contract)).Admin
+	}
 
 	specs := map[string]struct {
-		addr   string
-		expErr bool
+		skipUnauthorized bool
+		expErr           bool
 	}{
-		"authority can migrate a contract": {
-			addr:   authority,
-			expErr: false,
-		},
-		"admin can migrate a contract": {
-			addr:   myAddress.String(),
-			expErr: false,
-		},
-		"other address cannot migrate a contract": {
-			addr:   otherAddr.String(),
+		"fail on unauthorized": {
 			expErr: true,
 		},
+		"skip unauthorized": {
+			skipUnauthorized: true,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
 			// setup
-			_, _, sender := testdata.KeyTestPubAddr()
-			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
-				m.WASMByteCode = hackatomContract
-				m.Sender = sender.String()
-			})
-
-			// store code
-			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
-			require.NoError(t, err)
-			var storeCodeResponse types.MsgStoreCodeResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
-
-			// instantiate contract
-			initMsg := keeper.HackatomExampleInitMsg{
-				Verifier:    sender,
-				Beneficiary: myAddress,
-			}
-			initMsgBz, err := json.Marshal(initMsg)
-			require.NoError(t, err)
-
-			msgInstantiate := &types.MsgInstantiateContract{
-				Sender: sender.String(),
-				Admin:  myAddress.String(),
-				CodeID: storeCodeResponse.CodeID,
-				Label:  "test",
-				Msg:    initMsgBz,
-				Funds:  sdk.Coins{},
-			}
-			rsp, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
-			require.NoError(t, err)
-			var instantiateResponse types.MsgInstantiateContractResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+			myContracts := []string{instantiate(t, myAddr), instantiate(t, myAddr)}
+			otherContract := instantiate(t, otherAddr)
 
 			// when
-			migMsg := struct {
-				Verifier sdk.AccAddress `json:"verifier"`
-			}{Verifier: myAddress}
-			migMsgBz, err := json.Marshal(migMsg)
-			require.NoError(t, err)
-			msgMigrateContract := &types.MsgMigrateContract{
-				Sender:   spec.addr,
-				Msg:      migMsgBz,
-				Contract: instantiateResponse.Address,
-				CodeID:   storeCodeResponse.CodeID,
+			msgClearAdmins := &types.MsgClearAdmins{
+				Sender:           myAddr.String(),
+				Contracts:        []string{myContracts[0], otherContract, myContracts[1]},
+				SkipUnauthorized: spec.skipUnauthorized,
 			}
-			_, err = wasmApp.MsgServiceRouter().Handler(msgMigrateContract)(ctx, msgMigrateContract)
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msgClearAdmins)(ctx, msgClearAdmins)
 
 			// then
+			assert.Equal(t, otherAddr.String(), adminOf(otherContract))
 			if spec.expErr {
 				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
+				// and all changes rolled back
+				for _, c := range myContracts {
+					assert.Equal(t, myAddr.String(), adminOf(c))
+				}
+				return
 			}
+			require.NoError(t, err)
+			for _, c := range myContracts {
+				assert.Empty(t, adminOf(c))
+			}
+			var result types.MsgClearAdminsResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Equal(t, myContracts, result.Cleared)
+			assert.Equal(t, []string{otherContract}, result.Skipped)
+
+			var adminEvents int
+			for _, e := range rsp.Events {
+				if e.Type == types.EventTypeUpdateContractAdmin {
+					adminEvents++
+				}
+			}
+			assert.Equal(t, 2, adminEvents)
+			assert.Equal(t, "2", eventAttribute(t, rsp.Events, types.EventTypeClearContractAdmins, types.AttributeKeyClearedCount))
+			assert.Equal(t, "1", eventAttribute(t, rsp.Events, types.EventTypeClearContractAdmins, types.AttributeKeySkippedCount))
 		})
 	}
 }
 
-func TestInstantiateContract(t *testing.T) {
+func TestUpdateAdmins(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
 
-	var (
-		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
-		authority                = wasmApp.WasmKeeper.GetAuthority()
-	)
+	_, _, myAddr := testdata.KeyTestPubAddr()
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+	_, _, newAdminAddr := testdata.KeyTestPubAddr()
+	missingContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+
+	// store code
+	msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = myAddr.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+	instantiate := func(t *testing.T, admin sdk.AccAddress) string {
+		msgInstantiate := &types.MsgInstantiateContract{
+			Sender: myAddr.String(),
+			Admin:  admin.String(),
+			CodeID: storeCodeResponse.CodeID,
+			Label:  "test",
+			Msg:    []byte(`{}`),
+			Funds:  sdk.Coins{},
+		}
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+		require.NoError(t, err)
+		var instantiateResponse types.MsgInstantiateContractResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+		return instantiateResponse.Address
+	}
+	adminOf := func(contract string) string {
+		return wasmApp.WasmKeeper.GetContractInfo(ctx, sdk.MustAccAddressFromBech32(contract)).Admin
+	}
 
 	specs := map[string]struct {
-		addr       string
-		permission *types.AccessConfig
-		expErr     bool
+		skipMissing  bool
+		unauthorized bool
+		expErr       bool
 	}{
-		"authority can instantiate a contract when permission is nobody": {
-			addr:       authority,
-			permission: &types.AllowNobody,
-			expErr:     false,
-		},
-		"other address cannot instantiate a contract when permission is nobody": {
-			addr:       myAddress.String(),
-			permission: &types.AllowNobody,
-			expErr:     true,
+		"fail on missing": {
+			expErr: true,
 		},
-		"authority can instantiate a contract when permission is everybody": {
-			addr:       authority,
-			permission: &types.AllowEverybody,
-			expErr:     false,
+		"skip missing": {
+			skipMissing: true,
 		},
-		"other address can  instantiate a contract when permission is everybody": {
-			addr:       myAddress.String(),
-			permission: &types.AllowEverybody,
-			expErr:     false,
+		"fail on unauthorized": {
+			skipMissing:  true,
+			unauthorized: true,
+			expErr:       true,
 		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
 			// setup
-			_, _, sender := testdata.KeyTestPubAddr()
-			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
-				m.WASMByteCode = wasmContract
-				m.Sender = sender.String()
-				m.InstantiatePermission = spec.permission
-			})
-
-			// store code
-			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
-			require.NoError(t, err)
-			var result types.MsgStoreCodeResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			myContracts := []string{instantiate(t, myAddr), instantiate(t, myAddr)}
+			contracts := []string{myContracts[0], missingContract, myContracts[1]}
+			if spec.unauthorized {
+				contracts = append(contracts, instantiate(t, otherAddr))
+			}
 
 			// when
-			msgInstantiate := &types.MsgInstantiateContract{
-				Sender: spec.addr,
-				Admin:  myAddress.String(),
-				CodeID: result.CodeID,
-				Label:  "test",
-				Msg:    []byte(`{}`),
-				Funds:  sdk.Coins{},
+			msgUpdateAdmins := &types.MsgUpdateAdmins{
+				Sender:      myAddr.String(),
+				NewAdmin:    newAdminAddr.String(),
+				Contracts:   contracts,
+				SkipMissing: spec.skipMissing,
 			}
-			_, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msgUpdateAdmins)(ctx, msgUpdateAdmins)
 
 			// then
 			if spec.expErr {
 				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
+				// and all changes rolled back
+				for _, c := range myContracts {
+					assert.Equal(t, myAddr.String(), adminOf(c))
+				}
+				return
+			}
+			require.NoError(t, err)
+			for _, c := range myContracts {
+				assert.Equal(t, newAdminAddr.String(), adminOf(c))
 			}
+			var result types.MsgUpdateAdminsResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Equal(t, myContracts, result.Updated)
+			assert.Equal(t, []string{missingContract}, result.Skipped)
+
+			var adminEvents int
+			for _, e := range rsp.Events {
+				if e.Type == types.EventTypeUpdateContractAdmin {
+					adminEvents++
+				}
+			}
+			assert.Equal(t, 2, adminEvents)
+			assert.Equal(t, "2", eventAttribute(t, rsp.Events, types.EventTypeUpdateContractAdmins, types.AttributeKeyUpdatedCount))
+			assert.Equal(t, "1", eventAttribute(t, rsp.Events, types.EventTypeUpdateContractAdmins, types.AttributeKeySkippedCount))
 		})
 	}
 }
 
-func TestInstantiateContract2(t *testing.T) {
+func TestExecuteContractGasTrace(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
 
-	var (
-		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
-		authority                = wasmApp.WasmKeeper.GetAuthority()
-	)
+	// the reflect contract owner is the instantiating authority
+	authority := wasmApp.WasmKeeper.GetAuthority()
+	msgStoreAndInstantiate := &types.MsgStoreAndInstantiateContract{
+		Authority:             authority,
+		WASMByteCode:          wasmContract,
+		InstantiatePermission: &types.AllowEverybody,
+		Label:                 "test",
+		Msg:                   []byte(`{}`),
+		Funds:                 sdk.Coins{},
+	}
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msgStoreAndInstantiate)(ctx, msgStoreAndInstantiate)
+	require.NoError(t, err)
+	var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
 
 	specs := map[string]struct {
-		addr       string
-		salt       string
-		permission *types.AccessConfig
-		expErr     bool
+		mode     sdk.ExecMode
+		expTrace bool
 	}{
-		"authority can instantiate a contract when permission is nobody": {
-			addr:       authority,
-			permission: &types.AllowNobody,
-			salt:       "salt1",
-			expErr:     false,
+		"simulate": {
+			mode:     sdk.ExecModeSimulate,
+			expTrace: true,
 		},
-		"other address cannot instantiate a contract when permission is nobody": {
-			addr:       myAddress.String(),
-			permission: &types.AllowNobody,
-			salt:       "salt2",
-			expErr:     true,
+		"finalize": {
+			mode: sdk.ExecModeFinalize,
 		},
-		"authority can instantiate a contract when permission is everybody": {
-			addr:       authority,
-			permission: &types.AllowEverybody,
-			salt:       "salt3",
-			expErr:     false,
+		"check": {
+			mode: sdk.ExecModeCheck,
 		},
-		"other address can  instantiate a contract when permission is everybody": {
-			addr:       myAddress.String(),
-			permission: &types.AllowEverybody,
-			salt:       "salt4",
-			expErr:     false,
+	}
+	gasUsed := make(map[sdk.ExecMode]uint64, len(specs))
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			xCtx, _ := ctx.CacheContext()
+			xCtx = xCtx.WithExecMode(spec.mode).WithGasMeter(storetypes.NewInfiniteGasMeter())
+			msg := &types.MsgExecuteContract{
+				Sender:   authority,
+				Contract: storeAndInstantiateResponse.Address,
+				Msg:      []byte(fmt.Sprintf(`{"change_owner":{"owner":%q}}`, authority)),
+			}
+
+			// when
+			// the msg server is called without the router to exclude the gas of the circuit breaker check
+			executeResponse, err := keeper.NewMsgServerImpl(&wasmApp.WasmKeeper).ExecuteContract(xCtx, msg)
+			require.NoError(t, err)
+			gasUsed[spec.mode] = xCtx.GasMeter().GasConsumed()
+
+			// then
+			if !spec.expTrace {
+				assert.Empty(t, executeResponse.GasTrace)
+				return
+			}
+			var trace types.GasTrace
+			require.NoError(t, json.Unmarshal(executeResponse.GasTrace, &trace))
+			assert.NotZero(t, trace.Total)
+			assert.NotEmpty(t, trace.Categories)
+			assert.Equal(t, gasUsed[spec.mode], trace.Total)
+		})
+	}
+	// and tracing does not change the gas consumed
+	assert.Equal(t, gasUsed[sdk.ExecModeFinalize], gasUsed[sdk.ExecModeSimulate])
+}
+
+func TestExecuteContracts(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	_, _, sender := testdata.KeyTestPubAddr()
+	_, _, newOwner := testdata.KeyTestPubAddr()
+	changeOwnerMsg := []byte(fmt.Sprintf(`{"change_owner":{"owner":%q}}`, newOwner.String()))
+
+	// store code
+	msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = sender.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+	instantiate := func(t *testing.T) string {
+		msgInstantiate := &types.MsgInstantiateContract{
+			Sender: sender.String(),
+			CodeID: storeCodeResponse.CodeID,
+			Label:  "test",
+			Msg:    []byte(`{}`),
+			Funds:  sdk.Coins{},
+		}
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+		require.NoError(t, err)
+		var instantiateResponse types.MsgInstantiateContractResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+		return instantiateResponse.Address
+	}
+	queryOwner := func(t *testing.T, contract string) string {
+		res, err := wasmApp.WasmKeeper.QuerySmart(ctx, sdk.MustAccAddressFromBech32(contract), []byte(`{"owner":{}}`))
+		require.NoError(t, err)
+		var owner struct {
+			Owner string `json:"owner"`
+		}
+		require.NoError(t, json.Unmarshal(res, &owner))
+		return owner.Owner
+	}
+
+	specs := map[string]struct {
+		secondMsg []byte
+		expErr    bool
+		expOwner  string
+	}{
+		"all executions succeed": {
+			secondMsg: changeOwnerMsg,
+			expOwner:  newOwner.String(),
+		},
+		"all executions reverted on failure": {
+			secondMsg: []byte(`{"unknown":{}}`),
+			expErr:    true,
+			expOwner:  sender.String(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			firstContract, secondContract := instantiate(t), instantiate(t)
+
+			// when
+			msgExecuteContracts := &types.MsgExecuteContracts{
+				Sender: sender.String(),
+				Executions: []types.ContractExecution{
+					{Contract: firstContract, Msg: changeOwnerMsg, Funds: sdk.Coins{}},
+					{Contract: secondContract, Msg: spec.secondMsg, Funds: sdk.Coins{}},
+				},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msgExecuteContracts)(ctx, msgExecuteContracts)
+
+			// then
+			assert.Equal(t, spec.expOwner, queryOwner(t, firstContract))
+			if spec.expErr {
+				require.Error(t, err)
+				assert.Equal(t, sender.String(), queryOwner(t, secondContract))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expOwner, queryOwner(t, secondContract))
+
+			var result types.MsgExecuteContractsResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Len(t, result.Data, 2)
+
+			var executeEvents, batchEvents int
+			for _, e := range rsp.Events {
+				switch e.Type {
+				case types.EventTypeExecute:
+					executeEvents++
+				case types.EventTypeExecuteBatch:
+					batchEvents++
+				}
+			}
+			assert.Equal(t, 2, executeEvents)
+			assert.Equal(t, 1, batchEvents)
+		})
+	}
+}
+
+func TestContractMaxMsgSize(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	// the reflect contract owner is the instantiating authority
+	authority := wasmApp.WasmKeeper.GetAuthority()
+	msgStoreAndInstantiate := &types.MsgStoreAndInstantiateContract{
+		Authority:             authority,
+		WASMByteCode:          wasmContract,
+		InstantiatePermission: &types.AllowEverybody,
+		Label:                 "test",
+		Msg:                   []byte(`{}`),
+		Funds:                 sdk.Coins{},
+	}
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msgStoreAndInstantiate)(ctx, msgStoreAndInstantiate)
+	require.NoError(t, err)
+	var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+	reflectMsg := []byte(fmt.Sprintf(`{"change_owner":{"owner":%q}}`, authority))
+
+	contractAddr := sdk.MustAccAddressFromBech32(storeAndInstantiateResponse.Address)
+	codeID := wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).CodeID
+	execute := &types.MsgExecuteContract{
+		Sender:   authority,
+		Contract: storeAndInstantiateResponse.Address,
+		Msg:      reflectMsg,
+		Funds:    sdk.Coins{},
+	}
+	instantiate := &types.MsgInstantiateContract{
+		Sender: authority,
+		CodeID: codeID,
+		Label:  "other",
+		Msg:    []byte(`{}`),
+		Funds:  sdk.Coins{},
+	}
+
+	specs := map[string]struct {
+		msg     sdk.Msg
+		maxSize uint64
+		expErr  error
+	}{
+		"execute within limit": {
+			msg:     execute,
+			maxSize: uint64(len(reflectMsg)),
+		},
+		"execute exceeds limit": {
+			msg:     execute,
+			maxSize: uint64(len(reflectMsg) - 1),
+			expErr:  types.ErrExceedMaxMsgSize,
+		},
+		"execute disabled": {
+			msg:     execute,
+			maxSize: 0,
+		},
+		"instantiate within limit": {
+			msg:     instantiate,
+			maxSize: 2,
+		},
+		"instantiate exceeds limit": {
+			msg:     instantiate,
+			maxSize: 1,
+			expErr:  types.ErrExceedMaxMsgSize,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			xCtx, _ := ctx.CacheContext()
+			params := wasmApp.WasmKeeper.GetParams(xCtx)
+			params.MaxExecuteMsgSize = spec.maxSize
+			require.NoError(t, wasmApp.WasmKeeper.SetParams(xCtx, params))
+
+			// when
+			_, gotErr := wasmApp.MsgServiceRouter().Handler(spec.msg)(xCtx, spec.msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestMigrateContract(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress       sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                      = wasmApp.WasmKeeper.GetAuthority()
+		_, _, otherAddr                = testdata.KeyTestPubAddr()
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can migrate a contract": {
+			addr:   authority,
+			expErr: false,
+		},
+		"admin can migrate a contract": {
+			addr:   myAddress.String(),
+			expErr: false,
+		},
+		"other address cannot migrate a contract": {
+			addr:   otherAddr.String(),
+			expErr: true,
 		},
 	}
 	for name, spec := range specs {
@@ -941,289 +1422,1303 @@ func TestInstantiateContract2(t *testing.T) {
 			// setup
 			_, _, sender := testdata.KeyTestPubAddr()
 			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
-				m.WASMByteCode = wasmContract
+				m.WASMByteCode = hackatomContract
 				m.Sender = sender.String()
-				m.InstantiatePermission = spec.permission
 			})
 
 			// store code
 			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
 			require.NoError(t, err)
-			var result types.MsgStoreCodeResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			var storeCodeResponse types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+			// instantiate contract
+			initMsg := keeper.HackatomExampleInitMsg{
+				Verifier:    sender,
+				Beneficiary: myAddress,
+			}
+			initMsgBz, err := json.Marshal(initMsg)
+			require.NoError(t, err)
+
+			msgInstantiate := &types.MsgInstantiateContract{
+				Sender: sender.String(),
+				Admin:  myAddress.String(),
+				CodeID: storeCodeResponse.CodeID,
+				Label:  "test",
+				Msg:    initMsgBz,
+				Funds:  sdk.Coins{},
+			}
+			rsp, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+			require.NoError(t, err)
+			var instantiateResponse types.MsgInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+
+			// when
+			migMsg := struct {
+				Verifier sdk.AccAddress `json:"verifier"`
+			}{Verifier: myAddress}
+			migMsgBz, err := json.Marshal(migMsg)
+			require.NoError(t, err)
+			msgMigrateContract := &types.MsgMigrateContract{
+				Sender:   spec.addr,
+				Msg:      migMsgBz,
+				Contract: instantiateResponse.Address,
+				CodeID:   storeCodeResponse.CodeID,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgMigrateContract)(ctx, msgMigrateContract)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMigrateContractAndExecute(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	_, _, sender := testdata.KeyTestPubAddr()
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+	storeCode := func() uint64 {
+		msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+			m.WASMByteCode = hackatomContract
+			m.Sender = sender.String()
+		})
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+		var storeCodeResponse types.MsgStoreCodeResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+		return storeCodeResponse.CodeID
+	}
+	oldCodeID, newCodeID := storeCode(), storeCode()
+
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{Verifier: otherAddr, Beneficiary: sender})
+	require.NoError(t, err)
+	msgInstantiate := &types.MsgInstantiateContract{
+		Sender: sender.String(),
+		Admin:  sender.String(),
+		CodeID: oldCodeID,
+		Label:  "test",
+		Msg:    initMsgBz,
+		Funds:  sdk.Coins{},
+	}
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+	require.NoError(t, err)
+	var instantiateResponse types.MsgInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+	contractAddr := sdk.MustAccAddressFromBech32(instantiateResponse.Address)
+
+	specs := map[string]struct {
+		sender      sdk.AccAddress
+		newVerifier sdk.AccAddress
+		expErr      bool
+	}{
+		// the hackatom contract can only be released by the verifier that is set on migration
+		"migrate and execute": {
+			sender:      sender,
+			newVerifier: sender,
+		},
+		"execute fails": {
+			sender:      sender,
+			newVerifier: otherAddr,
+			expErr:      true,
+		},
+		"not the admin": {
+			sender:      otherAddr,
+			newVerifier: otherAddr,
+			expErr:      true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			xCtx, _ := ctx.CacheContext()
+			migMsgBz, err := json.Marshal(struct {
+				Verifier sdk.AccAddress `json:"verifier"`
+			}{Verifier: spec.newVerifier})
+			require.NoError(t, err)
+
+			// when
+			msg := &types.MsgMigrateContractAndExecute{
+				Sender:     spec.sender.String(),
+				Contract:   contractAddr.String(),
+				NewCodeID:  newCodeID,
+				MigrateMsg: migMsgBz,
+				ExecuteMsg: []byte(`{"release":{}}`),
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(xCtx, msg)
+
+			// then
+			info := wasmApp.WasmKeeper.GetContractInfo(xCtx, contractAddr)
+			if spec.expErr {
+				require.Error(t, err)
+				// and the migration rolled back
+				assert.Equal(t, oldCodeID, info.CodeID)
+				assert.Len(t, wasmApp.WasmKeeper.GetContractHistory(xCtx, contractAddr), 1)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, newCodeID, info.CodeID)
+			var eventTypes []string
+			for _, e := range rsp.Events {
+				eventTypes = append(eventTypes, e.Type)
+			}
+			assert.Contains(t, eventTypes, types.EventTypeMigrate)
+			assert.Contains(t, eventTypes, types.EventTypeExecute)
+		})
+	}
+}
+
+func TestMigrateContractDowngrade(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	_, _, sender := testdata.KeyTestPubAddr()
+	storeCode := func() uint64 {
+		msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+			m.WASMByteCode = hackatomContract
+			m.Sender = sender.String()
+		})
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+		var storeCodeResponse types.MsgStoreCodeResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+		return storeCodeResponse.CodeID
+	}
+	olderCodeID, newerCodeID := storeCode(), storeCode()
+
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{Verifier: sender, Beneficiary: sender})
+	require.NoError(t, err)
+	msgInstantiate := &types.MsgInstantiateContract{
+		Sender: sender.String(),
+		Admin:  sender.String(),
+		CodeID: newerCodeID,
+		Label:  "test",
+		Msg:    initMsgBz,
+		Funds:  sdk.Coins{},
+	}
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+	require.NoError(t, err)
+	var instantiateResponse types.MsgInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+
+	specs := map[string]struct {
+		codeID         uint64
+		allowDowngrade bool
+		expErr         error
+	}{
+		"same code id": {
+			codeID: newerCodeID,
+		},
+		"lower code id rejected": {
+			codeID: olderCodeID,
+			expErr: types.ErrInvalid,
+		},
+		"lower code id with allow downgrade": {
+			codeID:         olderCodeID,
+			allowDowngrade: true,
+		},
+	}
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.AccAddress `json:"verifier"`
+	}{Verifier: sender})
+	require.NoError(t, err)
+	// the downgrade check applies to both migrate messages
+	msgs := map[string]func(codeID uint64, allowDowngrade bool) sdk.Msg{
+		"migrate": func(codeID uint64, allowDowngrade bool) sdk.Msg {
+			return &types.MsgMigrateContract{
+				Sender:         sender.String(),
+				Msg:            migMsgBz,
+				Contract:       instantiateResponse.Address,
+				CodeID:         codeID,
+				AllowDowngrade: allowDowngrade,
+			}
+		},
+		"migrate and execute": func(codeID uint64, allowDowngrade bool) sdk.Msg {
+			return &types.MsgMigrateContractAndExecute{
+				Sender:         sender.String(),
+				Contract:       instantiateResponse.Address,
+				NewCodeID:      codeID,
+				MigrateMsg:     migMsgBz,
+				ExecuteMsg:     []byte(`{"release":{}}`),
+				AllowDowngrade: allowDowngrade,
+			}
+		},
+	}
+	for msgName, newMsg := range msgs {
+		for name, spec := range specs {
+			t.Run(msgName+" "+name, func(t *testing.T) {
+				xCtx, _ := ctx.CacheContext()
+
+				// when
+				msg := newMsg(spec.codeID, spec.allowDowngrade)
+				_, err := wasmApp.MsgServiceRouter().Handler(msg)(xCtx, msg)
+
+				// then
+				if spec.expErr != nil {
+					require.ErrorIs(t, err, spec.expErr)
+					return
+				}
+				require.NoError(t, err)
+				info := wasmApp.WasmKeeper.GetContractInfo(xCtx, sdk.MustAccAddressFromBech32(instantiateResponse.Address))
+				assert.Equal(t, spec.codeID, info.CodeID)
+			})
+		}
+	}
+}
+
+func TestInstantiateContract(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr       string
+		permission *types.AccessConfig
+		expErr     bool
+	}{
+		"authority can instantiate a contract when permission is nobody": {
+			addr:       authority,
+			permission: &types.AllowNobody,
+			expErr:     false,
+		},
+		"other address cannot instantiate a contract when permission is nobody": {
+			addr:       myAddress.String(),
+			permission: &types.AllowNobody,
+			expErr:     true,
+		},
+		"authority can instantiate a contract when permission is everybody": {
+			addr:       authority,
+			permission: &types.AllowEverybody,
+			expErr:     false,
+		},
+		"other address can  instantiate a contract when permission is everybody": {
+			addr:       myAddress.String(),
+			permission: &types.AllowEverybody,
+			expErr:     false,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			_, _, sender := testdata.KeyTestPubAddr()
+			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+				m.WASMByteCode = wasmContract
+				m.Sender = sender.String()
+				m.InstantiatePermission = spec.permission
+			})
+
+			// store code
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var result types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+
+			// when
+			msgInstantiate := &types.MsgInstantiateContract{
+				Sender: spec.addr,
+				Admin:  myAddress.String(),
+				CodeID: result.CodeID,
+				Label:  "test",
+				Msg:    []byte(`{}`),
+				Funds:  sdk.Coins{},
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestInstantiateContract2(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr       string
+		salt       string
+		permission *types.AccessConfig
+		expErr     bool
+	}{
+		"authority can instantiate a contract when permission is nobody": {
+			addr:       authority,
+			permission: &types.AllowNobody,
+			salt:       "salt1",
+			expErr:     false,
+		},
+		"other address cannot instantiate a contract when permission is nobody": {
+			addr:       myAddress.String(),
+			permission: &types.AllowNobody,
+			salt:       "salt2",
+			expErr:     true,
+		},
+		"authority can instantiate a contract when permission is everybody": {
+			addr:       authority,
+			permission: &types.AllowEverybody,
+			salt:       "salt3",
+			expErr:     false,
+		},
+		"other address can  instantiate a contract when permission is everybody": {
+			addr:       myAddress.String(),
+			permission: &types.AllowEverybody,
+			salt:       "salt4",
+			expErr:     false,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			_, _, sender := testdata.KeyTestPubAddr()
+			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+				m.WASMByteCode = wasmContract
+				m.Sender = sender.String()
+				m.InstantiatePermission = spec.permission
+			})
+
+			// store code
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var result types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+
+			// when
+			msgInstantiate := &types.MsgInstantiateContract2{
+				Sender: spec.addr,
+				Admin:  myAddress.String(),
+				CodeID: result.CodeID,
+				Label:  "label",
+				Msg:    []byte(`{}`),
+				Funds:  sdk.Coins{},
+				Salt:   []byte(spec.salt),
+				FixMsg: true,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateInstantiateConfig(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		creator   sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr       string
+		permission *types.AccessConfig
+		expErr     bool
+	}{
+		"authority can update instantiate config when permission is subset": {
+			addr:       authority,
+			permission: &types.AllowNobody,
+			expErr:     false,
+		},
+		"creator can update instantiate config when permission is subset": {
+			addr:       creator.String(),
+			permission: &types.AllowNobody,
+			expErr:     false,
+		},
+		"authority can update instantiate config when permission is not subset": {
+			addr:       authority,
+			permission: &types.AllowEverybody,
+			expErr:     false,
+		},
+		"creator cannot  update instantiate config when permission is not subset": {
+			addr:       creator.String(),
+			permission: &types.AllowEverybody,
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			err := wasmApp.WasmKeeper.SetParams(ctx, types.Params{
+				CodeUploadAccess:             types.AllowEverybody,
+				InstantiateDefaultPermission: types.AccessTypeNobody,
+			})
+			require.NoError(t, err)
+
+			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+				m.WASMByteCode = wasmContract
+				m.Sender = creator.String()
+				m.InstantiatePermission = &types.AllowNobody
+			})
+
+			// store code
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var result types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+
+			// when
+			msgUpdateInstantiateConfig := &types.MsgUpdateInstantiateConfig{
+				Sender:                   spec.addr,
+				CodeID:                   result.CodeID,
+				NewInstantiatePermission: spec.permission,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgUpdateInstantiateConfig)(ctx, msgUpdateInstantiateConfig)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateInstantiateConfigs(t *testing.T) {
+	wasmApp := app.Setup(t)
+	parentCtx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		creator      sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		otherCreator sdk.AccAddress = bytes.Repeat([]byte{1}, types.ContractAddrLen)
+	)
+	require.NoError(t, wasmApp.WasmKeeper.SetParams(parentCtx, types.Params{
+		CodeUploadAccess:             types.AllowEverybody,
+		InstantiateDefaultPermission: types.AccessTypeEverybody,
+	}))
+	storeCode := func(sender sdk.AccAddress) uint64 {
+		msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+			m.WASMByteCode = wasmContract
+			m.Sender = sender.String()
+			m.InstantiatePermission = &types.AllowEverybody
+		})
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(parentCtx, msg)
+		require.NoError(t, err)
+		var result types.MsgStoreCodeResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+		return result.CodeID
+	}
+	myCodeID, myOtherCodeID, otherCodeID := storeCode(creator), storeCode(creator), storeCode(otherCreator)
+
+	specs := map[string]struct {
+		codeIDs []uint64
+		expErr  bool
+	}{
+		"all codes of the creator": {
+			codeIDs: []uint64{myCodeID, myOtherCodeID},
+		},
+		"code of other creator rolls back all": {
+			codeIDs: []uint64{myCodeID, otherCodeID},
+			expErr:  true,
+		},
+		"unknown code rolls back all": {
+			codeIDs: []uint64{myCodeID, 100},
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			msg := &types.MsgUpdateInstantiateConfigs{Sender: creator.String()}
+			for _, id := range spec.codeIDs {
+				msg.Updates = append(msg.Updates, types.InstantiateConfigUpdate{CodeID: id, NewInstantiatePermission: &types.AllowNobody})
+			}
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				assert.Equal(t, types.AllowEverybody, wasmApp.WasmKeeper.GetCodeInfo(ctx, myCodeID).InstantiateConfig)
+				return
+			}
+			require.NoError(t, err)
+			var gotCodeIDs []string
+			for _, e := range rsp.Events {
+				if e.Type != types.EventTypeUpdateCodeAccessConfig {
+					continue
+				}
+				for _, attr := range e.Attributes {
+					if attr.Key == types.AttributeKeyCodeID {
+						gotCodeIDs = append(gotCodeIDs, attr.Value)
+					}
+				}
+			}
+			assert.Len(t, gotCodeIDs, len(spec.codeIDs))
+			for _, id := range spec.codeIDs {
+				assert.Equal(t, types.AllowNobody, wasmApp.WasmKeeper.GetCodeInfo(ctx, id).InstantiateConfig)
+				assert.Contains(t, gotCodeIDs, strconv.FormatUint(id, 10))
+			}
+		})
+	}
+}
+
+func TestStoreAndMigrateContract(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	checksum, err := wasmvm.CreateChecksum(hackatomContract)
+	require.NoError(t, err)
+
+	var (
+		myAddress    sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		otherAddress sdk.AccAddress = bytes.Repeat([]byte{1}, types.ContractAddrLen)
+		authority                   = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr        string
+		admin       sdk.AccAddress
+		permission  *types.AccessConfig
+		expChecksum []byte
+		expErr      bool
+	}{
+		"authority can store and migrate a contract when permission is nobody": {
+			addr:        authority,
+			admin:       myAddress,
+			permission:  &types.AllowNobody,
+			expChecksum: checksum,
+		},
+		"authority can store and migrate a contract when permission is everybody": {
+			addr:        authority,
+			admin:       myAddress,
+			permission:  &types.AllowEverybody,
+			expChecksum: checksum,
+		},
+		"other address can store and migrate a contract when permission is everybody": {
+			addr:        myAddress.String(),
+			admin:       myAddress,
+			permission:  &types.AllowEverybody,
+			expChecksum: checksum,
+		},
+		"other address cannot store and migrate a contract when permission is nobody": {
+			addr:       myAddress.String(),
+			admin:      myAddress,
+			permission: &types.AllowNobody,
+			expErr:     true,
+		},
+		"other address cannot store and migrate a contract without being admin": {
+			addr:       myAddress.String(),
+			admin:      otherAddress,
+			permission: &types.AllowEverybody,
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			initMsg := keeper.HackatomExampleInitMsg{
+				Verifier:    myAddress,
+				Beneficiary: myAddress,
+			}
+			initMsgBz, err := json.Marshal(initMsg)
+			require.NoError(t, err)
+			storeAndInstantiateMsg := &types.MsgStoreAndInstantiateContract{
+				Authority:             spec.addr,
+				WASMByteCode:          hackatomContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Admin:                 spec.admin.String(),
+				UnpinCode:             false,
+				Label:                 "test",
+				Msg:                   initMsgBz,
+				Funds:                 sdk.Coins{},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(storeAndInstantiateMsg)(ctx, storeAndInstantiateMsg)
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+
+			contractAddr := storeAndInstantiateResponse.Address
+
+			// when
+			migMsg := struct {
+				Verifier sdk.AccAddress `json:"verifier"`
+			}{Verifier: myAddress}
+			migMsgBz, err := json.Marshal(migMsg)
+			require.NoError(t, err)
+			msg := &types.MsgStoreAndMigrateContract{
+				Authority:             spec.addr,
+				WASMByteCode:          hackatomContract,
+				InstantiatePermission: spec.permission,
+				Msg:                   migMsgBz,
+				Contract:              contractAddr,
+			}
+			rsp, err = wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				require.Nil(t, rsp)
+				return
+			}
+
+			require.NoError(t, err)
+			var result types.MsgStoreAndMigrateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Equal(t, spec.expChecksum, result.Checksum)
+			require.NotZero(t, result.CodeID)
+			// and both events reference the new code id
+			expCodeID := strconv.FormatUint(result.CodeID, 10)
+			assert.Equal(t, expCodeID, eventAttribute(t, rsp.Events, types.EventTypeStoreCode, types.AttributeKeyCodeID))
+			assert.Equal(t, expCodeID, eventAttribute(t, rsp.Events, types.EventTypeMigrate, types.AttributeKeyCodeID))
+		})
+	}
+}
+
+// eventAttribute returns the value of the attribute of the first event with the given type
+func eventAttribute(t *testing.T, events []abci.Event, eventType, attrKey string) string {
+	t.Helper()
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		for _, a := range e.Attributes {
+			if a.Key == attrKey {
+				return a.Value
+			}
+		}
+	}
+	t.Fatalf("attribute %q of event %q not found", attrKey, eventType)
+	return ""
+}
+
+func TestUpdateContractLabel(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress       sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                      = wasmApp.WasmKeeper.GetAuthority()
+		_, _, otherAddr                = testdata.KeyTestPubAddr()
+	)
+
+	specs := map[string]struct {
+		addr     string
+		newLabel string
+		expErr   bool
+	}{
+		"authority can update contract label": {
+			addr:     authority,
+			newLabel: "new label",
+			expErr:   false,
+		},
+		"admin can update contract label": {
+			addr:     myAddress.String(),
+			newLabel: "new label",
+			expErr:   false,
+		},
+		"other address cannot update contract label": {
+			addr:     otherAddr.String(),
+			newLabel: "new label",
+			expErr:   true,
+		},
+		"empty new label": {
+			addr:   authority,
+			expErr: true,
+		},
+		"invalid new label": {
+			addr:     authority,
+			newLabel: " start with space ",
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:             spec.addr,
+				WASMByteCode:          wasmContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Admin:                 myAddress.String(),
+				UnpinCode:             false,
+				Label:                 "old label",
+				Msg:                   []byte(`{}`),
+				Funds:                 sdk.Coins{},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+
+			contract := storeAndInstantiateResponse.Address
+			contractAddr, err := sdk.AccAddressFromBech32(contract)
+			require.NoError(t, err)
+			require.Equal(t, "old label", wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).Label)
+
+			// when
+			msgUpdateLabel := &types.MsgUpdateContractLabel{
+				Sender:   spec.addr,
+				NewLabel: spec.newLabel,
+				Contract: storeAndInstantiateResponse.Address,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgUpdateLabel)(ctx, msgUpdateLabel)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				require.Equal(t, "old label", wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).Label)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, spec.newLabel, wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).Label)
+				// and the new label is returned by queries
+				res, err := keeper.Querier(&wasmApp.WasmKeeper).ContractInfo(ctx, &types.QueryContractInfoRequest{Address: contractAddr.String()})
+				require.NoError(t, err)
+				assert.Equal(t, spec.newLabel, res.Label)
+				// and exported to genesis
+				var exported []string
+				for _, c := range keeper.ExportGenesis(ctx, &wasmApp.WasmKeeper).Contracts {
+					if c.ContractAddress == contractAddr.String() {
+						exported = append(exported, c.ContractInfo.Label)
+					}
+				}
+				assert.Equal(t, []string{spec.newLabel}, exported)
+			}
+		})
+	}
+}
+
+func TestSetContractGasMultiplier(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+		half                     = types.GasMultiplier{Numerator: 1, Denominator: 2}
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can set gas multiplier": {
+			addr:   authority,
+			expErr: false,
+		},
+		"other address cannot set gas multiplier": {
+			addr:   myAddress.String(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:             authority,
+				WASMByteCode:          wasmContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Label:                 "test",
+				Msg:                   []byte(`{}`),
+				Funds:                 sdk.Coins{},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+			contractAddr, err := sdk.AccAddressFromBech32(storeAndInstantiateResponse.Address)
+			require.NoError(t, err)
+
+			// when
+			msgSetGasMultiplier := &types.MsgSetContractGasMultiplier{
+				Authority:  spec.addr,
+				Contract:   storeAndInstantiateResponse.Address,
+				Multiplier: half,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgSetGasMultiplier)(ctx, msgSetGasMultiplier)
+
+			// then
+			if spec.expErr {
+				require.Error(t, err)
+				assert.Equal(t, types.DefaultContractGasMultiplier(), wasmApp.WasmKeeper.GetContractGasMultiplier(ctx, contractAddr))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, half, wasmApp.WasmKeeper.GetContractGasMultiplier(ctx, contractAddr))
+			}
+		})
+	}
+}
+
+func TestSetContractStorageQuota(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can set storage quota": {
+			addr:   authority,
+			expErr: false,
+		},
+		"other address cannot set storage quota": {
+			addr:   myAddress.String(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:             authority,
+				WASMByteCode:          wasmContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Label:                 "test",
+				Msg:                   []byte(`{}`),
+				Funds:                 sdk.Coins{},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+			contractAddr, err := sdk.AccAddressFromBech32(storeAndInstantiateResponse.Address)
+			require.NoError(t, err)
+
+			// when
+			msgSetStorageQuota := &types.MsgSetContractStorageQuota{
+				Authority: spec.addr,
+				Contract:  storeAndInstantiateResponse.Address,
+				MaxBytes:  1 << 20,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgSetStorageQuota)(ctx, msgSetStorageQuota)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, err, types.ErrInvalid)
+				assert.Zero(t, wasmApp.WasmKeeper.GetContractStorageQuota(ctx, contractAddr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1<<20), wasmApp.WasmKeeper.GetContractStorageQuota(ctx, contractAddr))
+			assert.NotZero(t, wasmApp.WasmKeeper.GetContractStorageUsage(ctx, contractAddr))
+		})
+	}
+}
+
+func TestPruneUnusedCodes(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can prune codes": {
+			addr:   authority,
+			expErr: false,
+		},
+		"other address cannot prune codes": {
+			addr:   myAddress.String(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			// setup
+			msgStoreCode := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+				m.WASMByteCode = wasmContract
+				m.Sender = myAddress.String()
+			})
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msgStoreCode)(ctx, msgStoreCode)
+			require.NoError(t, err)
+			var storeCodeResponse types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+			codeID := storeCodeResponse.CodeID
+
+			// when
+			msgPrune := &types.MsgPruneUnusedCodes{
+				Authority: spec.addr,
+			}
+			rsp, err = wasmApp.MsgServiceRouter().Handler(msgPrune)(ctx, msgPrune)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, err, types.ErrInvalid)
+				assert.NotNil(t, wasmApp.WasmKeeper.GetCodeInfo(ctx, codeID))
+				return
+			}
+			require.NoError(t, err)
+			var result types.MsgPruneUnusedCodesResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Contains(t, result.CodeIDs, codeID)
+			assert.Nil(t, wasmApp.WasmKeeper.GetCodeInfo(ctx, codeID))
+		})
+	}
+}
+
+func TestSetContractLock(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can lock contract": {
+			addr:   authority,
+			expErr: false,
+		},
+		"other address cannot lock contract": {
+			addr:   myAddress.String(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// setup
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:             authority,
+				WASMByteCode:          wasmContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Label:                 "test",
+				Msg:                   []byte(`{}`),
+				Funds:                 sdk.Coins{},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+			contractAddr, err := sdk.AccAddressFromBech32(storeAndInstantiateResponse.Address)
+			require.NoError(t, err)
+
+			// when
+			msgSetContractLock := &types.MsgSetContractLock{
+				Authority: spec.addr,
+				Contract:  storeAndInstantiateResponse.Address,
+				Locked:    true,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgSetContractLock)(ctx, msgSetContractLock)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, err, types.ErrInvalid)
+				assert.False(t, wasmApp.WasmKeeper.IsContractLocked(ctx, contractAddr))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, wasmApp.WasmKeeper.IsContractLocked(ctx, contractAddr))
+		})
+	}
+}
+
+func TestReplaceContractState(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+	)
+
+	specs := map[string]struct {
+		addr   string
+		expErr bool
+	}{
+		"authority can replace contract state": {
+			addr:   authority,
+			expErr: false,
+		},
+		"other address cannot replace contract state": {
+			addr:   myAddress.String(),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			// setup
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:             authority,
+				WASMByteCode:          wasmContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Label:                 "test",
+				Msg:                   []byte(`{}`),
+				Funds:                 sdk.Coins{},
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+			contractAddr, err := sdk.AccAddressFromBech32(storeAndInstantiateResponse.Address)
+			require.NoError(t, err)
 
 			// when
-			msgInstantiate := &types.MsgInstantiateContract2{
-				Sender: spec.addr,
-				Admin:  myAddress.String(),
-				CodeID: result.CodeID,
-				Label:  "label",
-				Msg:    []byte(`{}`),
-				Funds:  sdk.Coins{},
-				Salt:   []byte(spec.salt),
-				FixMsg: true,
+			msgReplace := &types.MsgReplaceContractState{
+				Authority: spec.addr,
+				Contract:  storeAndInstantiateResponse.Address,
+				Models:    []types.Model{{Key: []byte("foo"), Value: []byte("bar")}},
+				Mode:      types.StateImportModeMerge,
 			}
-			_, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+			_, err = wasmApp.MsgServiceRouter().Handler(msgReplace)(ctx, msgReplace)
 
 			// then
 			if spec.expErr {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
+				require.ErrorIs(t, err, types.ErrInvalid)
+				assert.Nil(t, wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("foo")))
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte("bar"), wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("foo")))
 		})
 	}
 }
 
-func TestUpdateInstantiateConfig(t *testing.T) {
+func TestRegisterAndRemoveBlockSudoHook(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
 
 	var (
-		creator   sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
 		authority                = wasmApp.WasmKeeper.GetAuthority()
 	)
 
 	specs := map[string]struct {
-		addr       string
-		permission *types.AccessConfig
-		expErr     bool
+		addr   string
+		expErr bool
 	}{
-		"authority can update instantiate config when permission is subset": {
-			addr:       authority,
-			permission: &types.AllowNobody,
-			expErr:     false,
-		},
-		"creator can update instantiate config when permission is subset": {
-			addr:       creator.String(),
-			permission: &types.AllowNobody,
-			expErr:     false,
-		},
-		"authority can update instantiate config when permission is not subset": {
-			addr:       authority,
-			permission: &types.AllowEverybody,
-			expErr:     false,
+		"authority can register and remove hooks": {
+			addr:   authority,
+			expErr: false,
 		},
-		"creator cannot  update instantiate config when permission is not subset": {
-			addr:       creator.String(),
-			permission: &types.AllowEverybody,
-			expErr:     true,
+		"other address cannot register or remove hooks": {
+			addr:   myAddress.String(),
+			expErr: true,
 		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
 			// setup
-			err := wasmApp.WasmKeeper.SetParams(ctx, types.Params{
-				CodeUploadAccess:             types.AllowEverybody,
-				InstantiateDefaultPermission: types.AccessTypeNobody,
-			})
-			require.NoError(t, err)
-
-			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
-				m.WASMByteCode = wasmContract
-				m.Sender = creator.String()
-				m.InstantiatePermission = &types.AllowNobody
-			})
-
-			// store code
+			msg := &types.MsgStoreAndInstantiateContract{
+				Authority:             authority,
+				WASMByteCode:          wasmContract,
+				InstantiatePermission: &types.AllowEverybody,
+				Label:                 "test",
+				Msg:                   []byte(`{}`),
+				Funds:                 sdk.Coins{},
+			}
 			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
 			require.NoError(t, err)
-			var result types.MsgStoreCodeResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
 
 			// when
-			msgUpdateInstantiateConfig := &types.MsgUpdateInstantiateConfig{
-				Sender:                   spec.addr,
-				CodeID:                   result.CodeID,
-				NewInstantiatePermission: spec.permission,
+			msgRegister := &types.MsgRegisterBlockSudoHook{
+				Authority: spec.addr,
+				Phase:     types.BlockSudoPhaseEndBlock,
+				Contract:  storeAndInstantiateResponse.Address,
+				Msg:       []byte(`{}`),
 			}
-			_, err = wasmApp.MsgServiceRouter().Handler(msgUpdateInstantiateConfig)(ctx, msgUpdateInstantiateConfig)
+			_, err = wasmApp.MsgServiceRouter().Handler(msgRegister)(ctx, msgRegister)
 
 			// then
+			hooks := wasmApp.WasmKeeper.GetBlockSudoHooks(ctx, types.BlockSudoPhaseEndBlock)
 			if spec.expErr {
 				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
+				assert.Empty(t, hooks)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, hooks, 1)
+			assert.Equal(t, storeAndInstantiateResponse.Address, hooks[0].Contract)
+
+			// and when removed
+			msgRemove := &types.MsgRemoveBlockSudoHook{
+				Authority: spec.addr,
+				Phase:     types.BlockSudoPhaseEndBlock,
+				Contract:  storeAndInstantiateResponse.Address,
 			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgRemove)(ctx, msgRemove)
+			require.NoError(t, err)
+			assert.Empty(t, wasmApp.WasmKeeper.GetBlockSudoHooks(ctx, types.BlockSudoPhaseEndBlock))
 		})
 	}
 }
 
-func TestStoreAndMigrateContract(t *testing.T) {
+func TestMigrateContractWithBackupAndRestore(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
 
-	checksum, err := wasmvm.CreateChecksum(hackatomContract)
-	require.NoError(t, err)
-
 	var (
 		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
 		authority                = wasmApp.WasmKeeper.GetAuthority()
 	)
+	params := wasmApp.WasmKeeper.GetParams(ctx)
+	params.MaxMigrationCheckpoints = 1
+	require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
 
 	specs := map[string]struct {
-		addr        string
-		permission  *types.AccessConfig
-		expChecksum []byte
-		expErr      bool
+		addr   string
+		expErr bool
 	}{
-		"authority can store and migrate a contract when permission is nobody": {
-			addr:        authority,
-			permission:  &types.AllowNobody,
-			expChecksum: checksum,
-		},
-		"authority can store and migrate a contract when permission is everybody": {
-			addr:        authority,
-			permission:  &types.AllowEverybody,
-			expChecksum: checksum,
-		},
-		"other address can store and migrate a contract when permission is everybody": {
-			addr:        myAddress.String(),
-			permission:  &types.AllowEverybody,
-			expChecksum: checksum,
+		"authority can restore the contract state": {
+			addr:   authority,
+			expErr: false,
 		},
-		"other address cannot store and migrate a contract when permission is nobody": {
-			addr:       myAddress.String(),
-			permission: &types.AllowNobody,
-			expErr:     true,
+		"other address cannot restore the contract state": {
+			addr:   myAddress.String(),
+			expErr: true,
 		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
 			// setup
-			initMsg := keeper.HackatomExampleInitMsg{
-				Verifier:    myAddress,
+			_, _, sender := testdata.KeyTestPubAddr()
+			msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+				m.WASMByteCode = hackatomContract
+				m.Sender = sender.String()
+			})
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			require.NoError(t, err)
+			var storeCodeResponse types.MsgStoreCodeResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+			initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{
+				Verifier:    sender,
 				Beneficiary: myAddress,
-			}
-			initMsgBz, err := json.Marshal(initMsg)
+			})
 			require.NoError(t, err)
-			storeAndInstantiateMsg := &types.MsgStoreAndInstantiateContract{
-				Authority:             spec.addr,
-				WASMByteCode:          hackatomContract,
-				InstantiatePermission: &types.AllowEverybody,
-				Admin:                 myAddress.String(),
-				UnpinCode:             false,
-				Label:                 "test",
-				Msg:                   initMsgBz,
-				Funds:                 sdk.Coins{},
+			msgInstantiate := &types.MsgInstantiateContract{
+				Sender: sender.String(),
+				Admin:  myAddress.String(),
+				CodeID: storeCodeResponse.CodeID,
+				Label:  "test",
+				Msg:    initMsgBz,
+				Funds:  sdk.Coins{},
 			}
-			rsp, err := wasmApp.MsgServiceRouter().Handler(storeAndInstantiateMsg)(ctx, storeAndInstantiateMsg)
+			rsp, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
 			require.NoError(t, err)
-			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
-
-			contractAddr := storeAndInstantiateResponse.Address
+			var instantiateResponse types.MsgInstantiateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+			contractAddr := sdk.MustAccAddressFromBech32(instantiateResponse.Address)
+			expState := wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("config"))
+			require.NotEmpty(t, expState)
 
-			// when
-			migMsg := struct {
+			// and migrated with backup
+			migMsgBz, err := json.Marshal(struct {
 				Verifier sdk.AccAddress `json:"verifier"`
-			}{Verifier: myAddress}
-			migMsgBz, err := json.Marshal(migMsg)
+			}{Verifier: myAddress})
 			require.NoError(t, err)
-			msg := &types.MsgStoreAndMigrateContract{
-				Authority:             spec.addr,
-				WASMByteCode:          hackatomContract,
-				InstantiatePermission: spec.permission,
-				Msg:                   migMsgBz,
-				Contract:              contractAddr,
+			msgMigrateContract := &types.MsgMigrateContract{
+				Sender:     myAddress.String(),
+				Msg:        migMsgBz,
+				Contract:   instantiateResponse.Address,
+				CodeID:     storeCodeResponse.CodeID,
+				WithBackup: true,
 			}
-			rsp, err = wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+			rsp, err = wasmApp.MsgServiceRouter().Handler(msgMigrateContract)(ctx, msgMigrateContract)
+			require.NoError(t, err)
+			var migrateResponse types.MsgMigrateContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &migrateResponse))
+			require.Equal(t, uint64(1), migrateResponse.CheckpointID)
+			require.NotEqual(t, expState, wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("config")))
+
+			// when
+			msgRestore := &types.MsgRestoreContractState{
+				Authority:    spec.addr,
+				Contract:     instantiateResponse.Address,
+				CheckpointID: migrateResponse.CheckpointID,
+			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgRestore)(ctx, msgRestore)
 
 			// then
 			if spec.expErr {
 				require.Error(t, err)
-				require.Nil(t, rsp)
 				return
 			}
-
 			require.NoError(t, err)
-			var result types.MsgStoreAndMigrateContractResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
-			assert.Equal(t, spec.expChecksum, result.Checksum)
-			require.NotZero(t, result.CodeID)
+			assert.Equal(t, expState, wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("config")))
 		})
 	}
 }
 
-func TestUpdateContractLabel(t *testing.T) {
+func TestUpdateStargateAllowlist(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
 
 	var (
-		myAddress       sdk.AccAddress = make([]byte, types.ContractAddrLen)
-		authority                      = wasmApp.WasmKeeper.GetAuthority()
-		_, _, otherAddr                = testdata.KeyTestPubAddr()
+		myAddress sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                = wasmApp.WasmKeeper.GetAuthority()
+		myPath                   = "/cosmos.bank.v1beta1.Query/Balance"
 	)
 
 	specs := map[string]struct {
-		addr     string
-		newLabel string
-		expErr   bool
+		addr   string
+		expErr bool
 	}{
-		"authority can update contract label": {
-			addr:     authority,
-			newLabel: "new label",
-			expErr:   false,
-		},
-		"admin can update contract label": {
-			addr:     myAddress.String(),
-			newLabel: "new label",
-			expErr:   false,
-		},
-		"other address cannot update contract label": {
-			addr:     otherAddr.String(),
-			newLabel: "new label",
-			expErr:   true,
-		},
-		"empty new label": {
+		"authority can update the allowlist": {
 			addr:   authority,
-			expErr: true,
+			expErr: false,
 		},
-		"invalid new label": {
-			addr:     authority,
-			newLabel: " start with space ",
-			expErr:   true,
+		"other address cannot update the allowlist": {
+			addr:   myAddress.String(),
+			expErr: true,
 		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			// setup
-			msg := &types.MsgStoreAndInstantiateContract{
-				Authority:             spec.addr,
-				WASMByteCode:          wasmContract,
-				InstantiatePermission: &types.AllowEverybody,
-				Admin:                 myAddress.String(),
-				UnpinCode:             false,
-				Label:                 "old label",
-				Msg:                   []byte(`{}`),
-				Funds:                 sdk.Coins{},
-			}
-			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
-			require.NoError(t, err)
-			var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
-			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
-
-			contract := storeAndInstantiateResponse.Address
-			contractAddr, err := sdk.AccAddressFromBech32(contract)
-			require.NoError(t, err)
-			require.Equal(t, "old label", wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).Label)
+			ctx, _ := ctx.CacheContext()
 
 			// when
-			msgUpdateLabel := &types.MsgUpdateContractLabel{
-				Sender:   spec.addr,
-				NewLabel: spec.newLabel,
-				Contract: storeAndInstantiateResponse.Address,
+			msgAdd := &types.MsgUpdateStargateAllowlist{
+				Authority: spec.addr,
+				Add:       []string{myPath},
 			}
-			_, err = wasmApp.MsgServiceRouter().Handler(msgUpdateLabel)(ctx, msgUpdateLabel)
+			_, err := wasmApp.MsgServiceRouter().Handler(msgAdd)(ctx, msgAdd)
 
 			// then
 			if spec.expErr {
 				require.Error(t, err)
-				require.Equal(t, "old label", wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).Label)
-			} else {
-				require.NoError(t, err)
-				require.Equal(t, spec.newLabel, wasmApp.WasmKeeper.GetContractInfo(ctx, contractAddr).Label)
+				assert.Empty(t, wasmApp.WasmKeeper.GetStargateAllowlist(ctx))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{myPath}, wasmApp.WasmKeeper.GetStargateAllowlist(ctx))
+
+			// and when removed
+			msgRemove := &types.MsgUpdateStargateAllowlist{
+				Authority: spec.addr,
+				Remove:    []string{myPath},
 			}
+			_, err = wasmApp.MsgServiceRouter().Handler(msgRemove)(ctx, msgRemove)
+			require.NoError(t, err)
+			assert.Empty(t, wasmApp.WasmKeeper.GetStargateAllowlist(ctx))
 		})
 	}
 }
@@ -1010,6 +1010,80 @@ func TestAcceptListStargateQuerier(t *testing.T) {
 	}
 }
 
+func TestAllowlistStargateQuerier(t *testing.T) {
+	wasmApp := app.SetupWithEmptyStore(t)
+	ctx := wasmApp.NewUncachedContext(false, cmtproto.Header{ChainID: "foo", Height: 1, Time: time.Now()})
+	err := wasmApp.StakingKeeper.SetParams(ctx, stakingtypes.DefaultParams())
+	require.NoError(t, err)
+
+	addrs := app.AddTestAddrsIncremental(wasmApp, ctx, 1, sdkmath.NewInt(1_000_000))
+	accepted := wasmKeeper.AcceptedQueries{
+		"/cosmos.auth.v1beta1.Query/Account":       func() proto.Message { return &authtypes.QueryAccountResponse{} },
+		"/cosmos.bank.v1beta1.Query/AllBalances":   func() proto.Message { return &banktypes.QueryAllBalancesResponse{} },
+		"/cosmos.staking.v1beta1.Query/Validators": func() proto.Message { return &stakingtypes.QueryValidatorsResponse{} },
+	}
+	require.NoError(t, wasmApp.WasmKeeper.UpdateStargateAllowlist(ctx, []string{
+		"/cosmos.auth.v1beta1.Query/Account",
+		"/cosmos.bank.v1beta1.Query/AllBalances",
+		"/cosmos.bank.v1beta1.Query/Balance",
+	}, nil))
+	// and removed again
+	require.NoError(t, wasmApp.WasmKeeper.UpdateStargateAllowlist(ctx, nil, []string{"/cosmos.bank.v1beta1.Query/AllBalances"}))
+
+	marshal := func(pb proto.Message) []byte {
+		b, err := proto.Marshal(pb)
+		require.NoError(t, err)
+		return b
+	}
+
+	specs := map[string]struct {
+		req     *wasmvmtypes.StargateQuery
+		expErr  bool
+		expResp string
+	}{
+		"in allowlist - success result": {
+			req: &wasmvmtypes.StargateQuery{
+				Path: "/cosmos.auth.v1beta1.Query/Account",
+				Data: marshal(&authtypes.QueryAccountRequest{Address: addrs[0].String()}),
+			},
+			expResp: fmt.Sprintf(`{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":%q,"pub_key":null,"account_number":"1","sequence":"0"}}`, addrs[0].String()),
+		},
+		"removed from allowlist": {
+			req: &wasmvmtypes.StargateQuery{
+				Path: "/cosmos.bank.v1beta1.Query/AllBalances",
+				Data: marshal(&banktypes.QueryAllBalancesRequest{Address: addrs[0].String()}),
+			},
+			expErr: true,
+		},
+		"not in allowlist": {
+			req: &wasmvmtypes.StargateQuery{
+				Path: "/cosmos.staking.v1beta1.Query/Validators",
+				Data: marshal(&stakingtypes.QueryValidatorsRequest{}),
+			},
+			expErr: true,
+		},
+		"in allowlist without response type": {
+			req: &wasmvmtypes.StargateQuery{
+				Path: "/cosmos.bank.v1beta1.Query/Balance",
+				Data: marshal(&banktypes.QueryBalanceRequest{Address: addrs[0].String(), Denom: "stake"}),
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := wasmKeeper.AllowlistStargateQuerier(accepted, wasmApp.WasmKeeper, wasmApp.GRPCQueryRouter(), wasmApp.AppCodec())
+			gotBz, gotErr := q(ctx, spec.req)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expResp, string(gotBz), string(gotBz))
+		})
+	}
+}
+
 func TestResetProtoMarshalerAfterJsonMarshal(t *testing.T) {
 	appCodec := app.MakeEncodingConfig(t).Codec
 
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagOverwrite = "overwrite"
+	flagMerge     = "merge"
+)
+
+// GenesisImportContractsCmd merges the wasm codes and contracts of an exported genesis file into the local genesis file
+func GenesisImportContractsCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-wasm-contracts [exported genesis file]",
+		Short: "Merge the wasm codes and contracts of an exported genesis into the local genesis file",
+		Long: `Merge the wasm codes, contracts and sequences of an exported genesis into the local genesis file,
+for example to bootstrap a testnet with the contracts of another chain. The existing wasm genesis is kept.
+Code ids and contract addresses that exist already fail the import unless --overwrite is set.
+Codes that are used by existing contracts are never overwritten.
+The accounts and balances of the contracts are not imported.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			src, err := readWasmGenesis(clientCtx, args[0])
+			if err != nil {
+				return fmt.Errorf("exported genesis: %w", err)
+			}
+			overwrite, err := cmd.Flags().GetBool(flagOverwrite)
+			if err != nil {
+				return err
+			}
+			return updateWasmGenesis(cmd, func(wasmGenesis *types.GenesisState) error {
+				return wasmGenesis.MergeContracts(src, overwrite)
+			})
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Bool(flagOverwrite, false, "Replace the codes and contracts that exist already")
+	return cmd
+}
+
+// GenesisReplaceContractStateCmd replaces the state of a contract in the local genesis file with the models of a JSON file
+func GenesisReplaceContractStateCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replace-contract-state [contract_addr_bech32] [models json file]",
+		Short: "Replace the state of a contract in the local genesis file",
+		Long: `Replace the state of a contract in the local genesis file with the models of a JSON file, for example
+the output of "query wasm contract-state all". The existing state is removed unless --merge is set, in which case the
+models are stored on top of the existing state and replace the values of existing keys.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return fmt.Errorf("contract: %w", err)
+			}
+			bz, err := os.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+			var src types.QueryAllContractStateResponse
+			if err := clientCtx.Codec.UnmarshalJSON(bz, &src); err != nil {
+				return fmt.Errorf("models: %w", err)
+			}
+			mode := types.StateImportModeOverwrite
+			if merge, err := cmd.Flags().GetBool(flagMerge); err != nil {
+				return err
+			} else if merge {
+				mode = types.StateImportModeMerge
+			}
+			return updateWasmGenesis(cmd, func(wasmGenesis *types.GenesisState) error {
+				return wasmGenesis.ReplaceContractState(args[0], src.Models, mode)
+			})
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Bool(flagMerge, false, "Keep the existing state and store the models on top of it")
+	return cmd
+}
+
+// updateWasmGenesis applies the modification to the wasm genesis of the local genesis file
+func updateWasmGenesis(cmd *cobra.Command, modify func(*types.GenesisState) error) error {
+	clientCtx := client.GetClientContextFromCmd(cmd)
+	config := server.GetServerContextFromCmd(cmd).Config
+	config.SetRoot(clientCtx.HomeDir)
+
+	genFile := config.GenesisFile()
+	appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
+	if err != nil {
+		return fmt.Errorf("genesis file: %w", err)
+	}
+	appState, err := genutiltypes.GenesisStateFromAppGenesis(appGenesis)
+	if err != nil {
+		return err
+	}
+	var wasmGenesis types.GenesisState
+	if err := clientCtx.Codec.UnmarshalJSON(appState[types.ModuleName], &wasmGenesis); err != nil {
+		return fmt.Errorf("wasm genesis: %w", err)
+	}
+	if err := modify(&wasmGenesis); err != nil {
+		return err
+	}
+	if err := wasmGenesis.ValidateBasic(); err != nil {
+		return err
+	}
+	if appState[types.ModuleName], err = clientCtx.Codec.MarshalJSON(&wasmGenesis); err != nil {
+		return err
+	}
+	if appGenesis.AppState, err = json.Marshal(appState); err != nil {
+		return err
+	}
+	return genutil.ExportGenesisFile(appGenesis, genFile)
+}
+
+// readWasmGenesis reads the wasm genesis state from a genesis file
+func readWasmGenesis(clientCtx client.Context, file string) (types.GenesisState, error) {
+	appGenesis, err := genutiltypes.AppGenesisFromFile(file)
+	if err != nil {
+		return types.GenesisState{}, err
+	}
+	appState, err := genutiltypes.GenesisStateFromAppGenesis(appGenesis)
+	if err != nil {
+		return types.GenesisState{}, err
+	}
+	var r types.GenesisState
+	if err := clientCtx.Codec.UnmarshalJSON(appState[types.ModuleName], &r); err != nil {
+		return types.GenesisState{}, err
+	}
+	return r, r.ValidateBasic()
+}
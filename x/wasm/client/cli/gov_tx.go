@@ -53,6 +53,18 @@ func SubmitProposalCmd() *cobra.Command {
 		ProposalAddCodeUploadParamsAddresses(),
 		ProposalRemoveCodeUploadParamsAddresses(),
 		ProposalStoreAndMigrateContractCmd(),
+		ProposalSetContractGasMultiplierCmd(),
+		ProposalSetContractStorageQuotaCmd(),
+		ProposalLockContractCmd(),
+		ProposalUnlockContractCmd(),
+		ProposalUpdateInstantiateDefaultPermissionCmd(),
+		ProposalPruneUnusedCodesCmd(),
+		ProposalForfeitCodeDepositCmd(),
+		ProposalPruneContractDependenciesCmd(),
+		ProposalRegisterBlockSudoHookCmd(),
+		ProposalRemoveBlockSudoHookCmd(),
+		ProposalRestoreContractStateCmd(),
+		ProposalUpdateStargateAllowlistCmd(),
 	)
 	return cmd
 }
@@ -128,10 +140,10 @@ func parseVerificationFlags(gzippedWasm []byte, flags *flag.FlagSet) (string, st
 		if len(codeHash) == 0 {
 			return "", "", nil, errors.New("code hash is required")
 		}
-		// wasm is gzipped in parseStoreCodeArgs
+		// wasm is gzipped in parseStoreCodeArgs unless it was zstd compressed already
 		// checksum generation will be decoupled here
 		// reference https://github.com/CosmWasm/wasmvm/issues/359
-		raw, err := ioutils.Uncompress(gzippedWasm, int64(types.MaxWasmSize))
+		raw, err := ioutils.Decompress(gzippedWasm, int64(types.MaxWasmSize))
 		if err != nil {
 			return "", "", nil, fmt.Errorf("invalid zip: %w", err)
 		}
@@ -407,6 +419,9 @@ func ProposalMigrateContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if migrateMsg.WithBackup, err = cmd.Flags().GetBool(flagWithBackup); err != nil {
+				return fmt.Errorf("with backup: %s", err)
+			}
 
 			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&migrateMsg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
 			if err != nil {
@@ -417,6 +432,7 @@ func ProposalMigrateContractCmd() *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagWithBackup, false, "Store a checkpoint of the contract state before the migration")
 	// proposal flags
 	addCommonProposalFlags(cmd)
 	return cmd
@@ -991,3 +1007,566 @@ func ProposalStoreAndMigrateContractCmd() *cobra.Command {
 	addCommonProposalFlags(cmd)
 	return cmd
 }
+
+func ProposalSetContractGasMultiplierCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-gas-multiplier [contract_addr_bech32] [numerator] [denominator] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to override the gas multiplier of a contract",
+		Long:  "Submit a proposal to override the gas multiplier of a contract. The SDK gas charged for the contract's wasm execution is scaled by numerator/denominator. Use 1 1 to remove the override.",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			numerator, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("numerator: %s", err)
+			}
+			denominator, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("denominator: %s", err)
+			}
+
+			msg := types.MsgSetContractGasMultiplier{
+				Authority:  authority,
+				Contract:   args[0],
+				Multiplier: types.GasMultiplier{Numerator: numerator, Denominator: denominator},
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalSetContractStorageQuotaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-storage-quota [contract_addr_bech32] [max_bytes] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to limit the total size of the state stored by a contract",
+		Long:  "Submit a proposal to limit the total size of the keys and values stored by a contract. Contract calls that exceed the quota fail. Use 0 to remove the quota.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			maxBytes, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("max bytes: %s", err)
+			}
+
+			msg := types.MsgSetContractStorageQuota{
+				Authority: authority,
+				Contract:  args[0],
+				MaxBytes:  maxBytes,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalLockContractCmd() *cobra.Command {
+	return proposalSetContractLockCmd(
+		"lock-contract",
+		"Submit a proposal to lock a contract",
+		"Submit a proposal to lock a contract. All execute, migrate, sudo and IBC calls to a locked contract fail until it is unlocked.",
+		true,
+	)
+}
+
+func ProposalUnlockContractCmd() *cobra.Command {
+	return proposalSetContractLockCmd(
+		"unlock-contract",
+		"Submit a proposal to unlock a contract",
+		"Submit a proposal to unlock a locked contract.",
+		false,
+	)
+}
+
+func proposalSetContractLockCmd(use, short, long string, locked bool) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use + " [contract_addr_bech32] --title [text] --summary [text] --authority [address]",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			msg := types.MsgSetContractLock{
+				Authority: authority,
+				Contract:  args[0],
+				Locked:    locked,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalUpdateInstantiateDefaultPermissionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-instantiate-default-permission [permission] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to update the instantiate default permission param",
+		Long:  "Submit a proposal to update the instantiate default permission param only. The permission is one of Nobody, Everybody or AnyOfAddresses.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			var permission types.AccessType
+			if err := permission.UnmarshalText([]byte(args[0])); err != nil {
+				return err
+			}
+
+			msg := types.MsgUpdateInstantiateDefaultPermission{
+				Authority:  authority,
+				Permission: permission,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalPruneUnusedCodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-unused-codes --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to delete all codes without contract instances",
+		Long:  "Submit a proposal to delete all codes without contract instances. Use --older-than-height to restrict the pruning to codes stored before a block height.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			olderThanHeight, err := cmd.Flags().GetUint64(flagOlderThanHeight)
+			if err != nil {
+				return fmt.Errorf("older than height: %s", err)
+			}
+
+			msg := types.MsgPruneUnusedCodes{
+				Authority:       authority,
+				OlderThanHeight: olderThanHeight,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagOlderThanHeight, 0, "Only prune codes stored before this block height, optional")
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalForfeitCodeDepositCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "forfeit-code-deposit [code_id] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to burn the storage deposit of a code",
+		Long:  "Submit a proposal to burn the storage deposit that was escrowed when the code was stored. The code is not removed.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgForfeitCodeDeposit{
+				Authority: authority,
+				CodeID:    codeID,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalPruneContractDependenciesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-contract-dependencies [contract_addr_bech32] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to delete the recorded code dependencies of a contract",
+		Long:  "Submit a proposal to delete the code dependencies that were recorded for a contract, so that the codes can be pruned.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			msg := types.MsgPruneContractDependencies{
+				Authority: authority,
+				Contract:  args[0],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalRegisterBlockSudoHookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-block-sudo-hook [begin-block|end-block] [contract_addr_bech32] [json_encoded_sudo_args] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to sudo call a contract each block",
+		Long:  "Submit a proposal to register a contract to be sudo called with the given message in each BeginBlock or EndBlock.",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			phase, err := parseBlockSudoPhase(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgRegisterBlockSudoHook{
+				Authority: authority,
+				Phase:     phase,
+				Contract:  args[1],
+				Msg:       []byte(args[2]),
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalRemoveBlockSudoHookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-block-sudo-hook [begin-block|end-block] [contract_addr_bech32] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to stop sudo calling a contract each block",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			phase, err := parseBlockSudoPhase(args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgRemoveBlockSudoHook{
+				Authority: authority,
+				Phase:     phase,
+				Contract:  args[1],
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalRestoreContractStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-contract-state [contract_addr_bech32] [checkpoint_id] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to restore the contract state from a migration checkpoint",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			checkpointID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("checkpoint id: %s", err)
+			}
+
+			msg := types.MsgRestoreContractState{
+				Authority:    authority,
+				Contract:     args[0],
+				CheckpointID: checkpointID,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalUpdateStargateAllowlistCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-stargate-allowlist --add [paths] --remove [paths] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to update the stargate query paths that contracts are allowed to query",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			add, err := cmd.Flags().GetStringSlice(flagAddQueryPaths)
+			if err != nil {
+				return fmt.Errorf("add: %s", err)
+			}
+			remove, err := cmd.Flags().GetStringSlice(flagRemoveQueryPaths)
+			if err != nil {
+				return fmt.Errorf("remove: %s", err)
+			}
+
+			msg := types.MsgUpdateStargateAllowlist{
+				Authority: authority,
+				Add:       add,
+				Remove:    remove,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSlice(flagAddQueryPaths, []string{}, "Query paths to allow, e.g. /cosmos.bank.v1beta1.Query/Balance")
+	cmd.Flags().StringSlice(flagRemoveQueryPaths, []string{}, "Query paths to disallow")
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func parseBlockSudoPhase(raw string) (types.BlockSudoPhase, error) {
+	switch raw {
+	case "begin-block":
+		return types.BlockSudoPhaseBeginBlock, nil
+	case "end-block":
+		return types.BlockSudoPhaseEndBlock, nil
+	default:
+		return types.BlockSudoPhaseUnspecified, fmt.Errorf("unknown block phase: %q", raw)
+	}
+}
@@ -1,15 +1,21 @@
 package cli
 
 import (
+	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	errorsmod "cosmossdk.io/errors"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -31,10 +37,22 @@ func MigrateContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if msg.Funds, err = parseMigrateFunds(cmd.Flags()); err != nil {
+				return err
+			}
+			if msg.WithBackup, err = cmd.Flags().GetBool(flagWithBackup); err != nil {
+				return fmt.Errorf("with backup: %s", err)
+			}
+			if msg.AllowDowngrade, err = cmd.Flags().GetBool(flagAllowDowngrade); err != nil {
+				return fmt.Errorf("allow downgrade: %s", err)
+			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagWithBackup, false, "Store a checkpoint of the contract state before the migration")
+	cmd.Flags().Bool(flagAllowDowngrade, false, "Allow migrating to a code id lower than the current one")
+	cmd.Flags().String(flagAmount, "", "Coins to send to the contract before the migration")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -57,6 +75,108 @@ func parseMigrateContractArgs(args []string, sender string) (types.MsgMigrateCon
 	return msg, msg.ValidateBasic()
 }
 
+// parseMigrateFunds reads the coins to send to the contract before the migration
+func parseMigrateFunds(flags *flag.FlagSet) (sdk.Coins, error) {
+	amountStr, err := flags.GetString(flagAmount)
+	if err != nil {
+		return nil, fmt.Errorf("amount: %s", err)
+	}
+	amount, err := sdk.ParseCoinsNormalized(amountStr)
+	if err != nil {
+		return nil, fmt.Errorf("amount: %s", err)
+	}
+	return amount, nil
+}
+
+// MigrateContractAndExecuteCmd will migrate a contract and execute it afterwards in a single message
+func MigrateContractAndExecuteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-execute [contract_addr_bech32] [new_code_id_int64] [json_encoded_migration_args] [json_encoded_send_args] --amount [coins,optional]",
+		Short: "Migrate a wasm contract to a new code version and execute it afterwards",
+		Long:  "Migrate a wasm contract to a new code version and execute it afterwards. Either both succeed or none is applied. The coins are sent with the execution.",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg, err := parseMigrateContractAndExecuteArgs(args, clientCtx.GetFromAddress().String(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagAllowDowngrade, false, "Allow migrating to a code id lower than the current one")
+	cmd.Flags().String(flagAmount, "", "Coins to send to the contract along with the execution")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseMigrateContractAndExecuteArgs(args []string, sender string, flags *flag.FlagSet) (types.MsgMigrateContractAndExecute, error) {
+	codeID, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return types.MsgMigrateContractAndExecute{}, errorsmod.Wrap(err, "code id")
+	}
+	amount, err := parseMigrateFunds(flags)
+	if err != nil {
+		return types.MsgMigrateContractAndExecute{}, err
+	}
+	allowDowngrade, err := flags.GetBool(flagAllowDowngrade)
+	if err != nil {
+		return types.MsgMigrateContractAndExecute{}, fmt.Errorf("allow downgrade: %s", err)
+	}
+	msg := types.MsgMigrateContractAndExecute{
+		Sender:         sender,
+		Contract:       args[0],
+		NewCodeID:      codeID,
+		MigrateMsg:     []byte(args[2]),
+		ExecuteMsg:     []byte(args[3]),
+		Funds:          amount,
+		AllowDowngrade: allowDowngrade,
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// StoreAndMigrateContractCmd will upload code and migrate a contract to it in a single message
+func StoreAndMigrateContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "store-migrate [wasm file] [contract_addr_bech32] [json_encoded_migration_args]",
+		Short:   "Upload a wasm binary and migrate a wasm contract to it",
+		Aliases: []string{"store-mig"},
+		Args:    cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			// Variable storeCodeMsg is not really used. But this allows us to reuse parseStoreCodeArgs.
+			storeCodeMsg, err := parseStoreCodeArgs(args[0], clientCtx.GetFromAddress().String(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			msg := types.MsgStoreAndMigrateContract{
+				Authority:             clientCtx.GetFromAddress().String(),
+				WASMByteCode:          storeCodeMsg.WASMByteCode,
+				InstantiatePermission: storeCodeMsg.InstantiatePermission,
+				Msg:                   []byte(args[2]),
+				Contract:              args[1],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	addInstantiatePermissionFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 // UpdateContractAdminCmd sets an new admin for a contract
 func UpdateContractAdminCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -91,34 +211,109 @@ func parseUpdateContractAdminArgs(args []string, sender string) (types.MsgUpdate
 	return msg, msg.ValidateBasic()
 }
 
-// ClearContractAdminCmd clears an admin for a contract
+// UpdateContractAdminsCmd sets a new admin for multiple contracts
+func UpdateContractAdminsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-admins [new_admin_addr_bech32] [contract_addr_bech32]... --contracts-file [file,optional] --skip-missing [bool,optional]",
+		Short: "Set new admin for multiple contracts",
+		Long: `Set new admin for multiple contracts. Either all admins are updated or none.
+The contract addresses are read from the args and from the optional contracts file that contains
+the addresses separated by whitespace or newlines.`,
+		Aliases: []string{"set-admins"},
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg, err := parseUpdateContractAdminsArgs(args, clientCtx.GetFromAddress().String(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagContractsFile, "", "File with the contract addresses separated by whitespace")
+	cmd.Flags().Bool(flagSkipMissing, false, "Skip the addresses without a contract instead of failing")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseUpdateContractAdminsArgs(args []string, sender string, flags *flag.FlagSet) (types.MsgUpdateAdmins, error) {
+	skipMissing, err := flags.GetBool(flagSkipMissing)
+	if err != nil {
+		return types.MsgUpdateAdmins{}, fmt.Errorf("skip missing: %w", err)
+	}
+	file, err := flags.GetString(flagContractsFile)
+	if err != nil {
+		return types.MsgUpdateAdmins{}, fmt.Errorf("contracts file: %w", err)
+	}
+	contracts := args[1:]
+	if file != "" {
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return types.MsgUpdateAdmins{}, fmt.Errorf("contracts file: %w", err)
+		}
+		contracts = append(contracts, strings.Fields(string(bz))...)
+	}
+	msg := types.MsgUpdateAdmins{
+		Sender:      sender,
+		NewAdmin:    args[0],
+		Contracts:   contracts,
+		SkipMissing: skipMissing,
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// ClearContractAdminCmd clears an admin for one or more contracts
 func ClearContractAdminCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "clear-contract-admin [contract_addr_bech32]",
-		Short:   "Clears admin for a contract to prevent further migrations",
+		Use:     "clear-contract-admin [contract_addr_bech32]... --skip-unauthorized [bool,optional]",
+		Short:   "Clears admin for one or more contracts to prevent further migrations",
 		Aliases: []string{"clear-admin", "clr-adm"},
-		Args:    cobra.ExactArgs(1),
+		Args:    cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			msg := types.MsgClearAdmin{
-				Sender:   clientCtx.GetFromAddress().String(),
-				Contract: args[0],
-			}
-			if err := msg.ValidateBasic(); err != nil {
+			msg, err := parseClearContractAdminArgs(args, clientCtx.GetFromAddress().String(), cmd.Flags())
+			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagSkipUnauthorized, false, "Skip the contracts that the sender is not allowed to modify instead of failing")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// parseClearContractAdminArgs returns a MsgClearAdmin for a single contract and a MsgClearAdmins otherwise
+func parseClearContractAdminArgs(args []string, sender string, flags *flag.FlagSet) (sdk.Msg, error) {
+	skipUnauthorized, err := flags.GetBool(flagSkipUnauthorized)
+	if err != nil {
+		return nil, fmt.Errorf("skip unauthorized: %w", err)
+	}
+	if len(args) == 1 && !skipUnauthorized {
+		msg := &types.MsgClearAdmin{
+			Sender:   sender,
+			Contract: args[0],
+		}
+		return msg, msg.ValidateBasic()
+	}
+	msg := &types.MsgClearAdmins{
+		Sender:           sender,
+		Contracts:        args,
+		SkipUnauthorized: skipUnauthorized,
+	}
+	return msg, msg.ValidateBasic()
+}
+
 // UpdateInstantiateConfigCmd updates instantiate config for a smart contract.
 func UpdateInstantiateConfigCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -158,6 +353,48 @@ func UpdateInstantiateConfigCmd() *cobra.Command {
 	return cmd
 }
 
+// UpdateInstantiateConfigsCmd updates the instantiate config of multiple codes from a JSON file.
+func UpdateInstantiateConfigsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-instantiate-configs [json_file]",
+		Short: "Update the instantiate config of multiple codes",
+		Long: `Update the instantiate config of multiple codes. Either all configs are updated or none.
+The file contains a JSON list of updates, for example:
+[{"code_id": "1", "new_instantiate_permission": {"permission": "Nobody"}},
+ {"code_id": "2", "new_instantiate_permission": {"permission": "AnyOfAddresses", "addresses": ["wasm1..."]}}]`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			msg, err := parseUpdateInstantiateConfigsArgs(clientCtx.Codec, clientCtx.GetFromAddress().String(), bz)
+			if err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseUpdateInstantiateConfigsArgs decodes the JSON list of updates into a MsgUpdateInstantiateConfigs
+func parseUpdateInstantiateConfigsArgs(cdc codec.JSONCodec, sender string, updatesJSON []byte) (*types.MsgUpdateInstantiateConfigs, error) {
+	msg := &types.MsgUpdateInstantiateConfigs{}
+	wrapped := append(append([]byte(`{"updates":`), updatesJSON...), '}')
+	if err := cdc.UnmarshalJSON(wrapped, msg); err != nil {
+		return nil, errorsmod.Wrap(err, "updates")
+	}
+	msg.Sender = sender
+	return msg, msg.ValidateBasic()
+}
+
 // UpdateContractLabelCmd sets an new label for a contract
 func UpdateContractLabelCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -185,3 +422,65 @@ func UpdateContractLabelCmd() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+// PinCodesCmd pins codes in the wasmvm cache. The sender must be the module authority.
+func PinCodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pin-codes [code-ids]",
+		Short: "Pin codes in the wasmvm cache as the module authority",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			codeIDs, err := parsePinCodesArgs(args)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgPinCodes{
+				Authority: clientCtx.GetFromAddress().String(),
+				CodeIDs:   codeIDs,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// UnpinCodesCmd unpins codes from the wasmvm cache. The sender must be the module authority.
+func UnpinCodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unpin-codes [code-ids]",
+		Short: "Unpin codes from the wasmvm cache as the module authority",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			codeIDs, err := parsePinCodesArgs(args)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgUnpinCodes{
+				Authority: clientCtx.GetFromAddress().String(),
+				CodeIDs:   codeIDs,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
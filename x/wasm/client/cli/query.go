@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/hex"
@@ -9,14 +10,20 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/version"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -34,12 +41,29 @@ func GetQueryCmd() *cobra.Command {
 	queryCmd.AddCommand(
 		GetCmdListCode(),
 		GetCmdListContractByCode(),
+		GetCmdListContractByLabel(),
+		GetCmdListCodeInstantiations(),
+		GetCmdQueryContractCountByCode(),
+		GetCmdQueryBlockSudoHooks(),
+		GetCmdQueryStargateAllowlist(),
+		GetCmdQueryModuleStats(),
+		GetCmdQueryTotalContractFunds(),
 		GetCmdQueryCode(),
 		GetCmdQueryCodeInfo(),
+		GetCmdQueryCodeAccessConfig(),
+		GetCmdQueryAnalyzeCode(),
+		GetCmdQueryCodeExports(),
+		GetCmdQueryCodeStateBytes(),
+		GetCmdQueryCodeProvenance(),
 		GetCmdGetContractInfo(),
+		GetCmdGetContractInfoBatch(),
+		GetCmdIsContract(),
 		GetCmdGetContractHistory(),
+		GetCmdGetContractAdminChain(),
+		GetCmdQueryMigrationCheckpoints(),
 		GetCmdGetContractState(),
 		GetCmdListPinnedCode(),
+		GetCmdListUnusedCode(),
 		GetCmdLibVersion(),
 		GetCmdQueryParams(),
 		GetCmdBuildAddress(),
@@ -73,13 +97,31 @@ func GetCmdLibVersion() *cobra.Command {
 func GetCmdBuildAddress() *cobra.Command {
 	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
-		Use:     "build-address [code-hash] [creator-address] [salt-hex-encoded] [json_encoded_init_args (required when set as fixed)]",
-		Short:   "build contract address",
+		Use:   "build-address [code-hash] [creator-address] [salt-hex-encoded] [json_encoded_init_args (required when set as fixed)] --fix-msg [bool,optional]",
+		Short: "build contract address",
+		Long: fmt.Sprintf(`Builds the predictable address of a contract that is instantiated with instantiate2.
+The init args are only part of the address when the '--fix-msg' flag is set, the same as for instantiate2.
+
+Example:
+$ %s query wasm build-address 3f4cd47c39c57fe1733fb41ed176eebd9d5c67baf5df8a1eeda1455e758f8514 "$(%s keys show mykey -a)" \
+  $(echo -n "testing" | xxd -ps) '{"foo":"bar"}' --fix-msg
+`, version.AppName, version.AppName),
 		Aliases: []string{"address"},
 		Args:    cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			salt, err := decoder.DecodeString(args[2])
+			if err != nil {
+				return fmt.Errorf("salt: %w", err)
+			}
+			fixMsg, err := cmd.Flags().GetBool(flagFixMsg)
+			if err != nil {
+				return fmt.Errorf("fix msg: %w", err)
+			}
 			var initArgs []byte
-			if len(args) == 4 {
+			switch {
+			case fixMsg && len(args) != 4:
+				return errors.New("init args are required with fix msg")
+			case fixMsg:
 				initArgs = types.RawContractMessage(args[3])
 			}
 
@@ -87,67 +129,576 @@ func GetCmdBuildAddress() *cobra.Command {
 				&types.QueryBuildAddressRequest{
 					CodeHash:       args[0],
 					CreatorAddress: args[1],
-					Salt:           args[2],
+					Salt:           hex.EncodeToString(salt),
 					InitArgs:       initArgs,
 				},
 			)
 			if err != nil {
 				return err
 			}
-			fmt.Println(res.Address)
+			bz, err := json.Marshal(res)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
 			return nil
 		},
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
+	cmd.Flags().Bool(flagFixMsg, false, "An optional flag to include the json_encoded_init_args for the predictable address generation mode")
+	return cmd
+}
+
+// GetCmdListCode lists all wasm code uploaded
+func GetCmdListCode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-code",
+		Short:   "List all wasm bytecode on the chain",
+		Long:    "List all wasm bytecode on the chain",
+		Aliases: []string{"list-codes", "codes", "lco"},
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Codes(
+				context.Background(),
+				&types.QueryCodesRequest{
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list codes")
+	return cmd
+}
+
+// GetCmdListContractByCode lists all wasm code uploaded for given code id
+func GetCmdListContractByCode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-contract-by-code [code_id]",
+		Short:   "List wasm all bytecode on the chain for given code id",
+		Long:    "List wasm all bytecode on the chain for given code id",
+		Aliases: []string{"list-contracts-by-code", "list-contracts", "contracts", "lca"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			if codeID == 0 {
+				return errors.New("empty code id")
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByCode(
+				context.Background(),
+				&types.QueryContractsByCodeRequest{
+					CodeId:     codeID,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by code")
+	return cmd
+}
+
+// GetCmdListContractByLabel lists all contracts of the given code id with the given label
+func GetCmdListContractByLabel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-contract-by-label [code_id] [label]",
+		Short:   "List all contracts of the given code id with the given label",
+		Long:    "List all contracts of the given code id with the given label. Labels are not unique so that multiple contracts can be returned",
+		Aliases: []string{"list-contracts-by-label"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			if codeID == 0 {
+				return errors.New("empty code id")
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByLabel(
+				context.Background(),
+				&types.QueryContractsByLabelRequest{
+					CodeId:     codeID,
+					Label:      args[1],
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by label")
+	return cmd
+}
+
+// GetCmdListCodeInstantiations lists the instantiation log of the given code id
+func GetCmdListCodeInstantiations() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-code-instantiations [code_id]",
+		Short:   "List the contract instantiations of the given code id ordered by height",
+		Long:    "List the contract instantiations of the given code id ordered by height. Use --reverse for the latest first. The log is only recorded when enabled by the record_code_instantiations param",
+		Aliases: []string{"code-instantiations"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			if codeID == 0 {
+				return errors.New("empty code id")
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeInstantiations(
+				context.Background(),
+				&types.QueryCodeInstantiationsRequest{
+					CodeId:     codeID,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list code instantiations")
+	return cmd
+}
+
+// GetCmdQueryContractCountByCode returns the number of contracts instantiated from a given code id
+func GetCmdQueryContractCountByCode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-count-by-code [code_id]",
+		Short: "Prints out the number of contracts for a code id",
+		Long:  "Prints out the number of contracts that currently run the given code id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractCountByCode(
+				context.Background(),
+				&types.QueryContractCountByCodeRequest{
+					CodeId: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryBlockSudoHooks lists the contracts sudo called each block
+func GetCmdQueryBlockSudoHooks() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "block-sudo-hooks",
+		Short: "List the contracts sudo called each block",
+		Long:  "List the contracts sudo called in BeginBlock and EndBlock in execution order",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.BlockSudoHooks(
+				context.Background(),
+				&types.QueryBlockSudoHooksRequest{},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryStargateAllowlist lists the stargate query paths that contracts are allowed to query
+func GetCmdQueryStargateAllowlist() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stargate-allowlist",
+		Short: "List the stargate query paths that contracts are allowed to query",
+		Long:  "List the stargate query paths that contracts are allowed to query",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.StargateAllowlist(
+				context.Background(),
+				&types.QueryStargateAllowlistRequest{},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryModuleStats returns the aggregated code, contract and pinned code counts of the module
+func GetCmdQueryModuleStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "module-stats",
+		Short: "Get the number of codes, contracts and pinned codes and the last assigned ids",
+		Long:  "Get the number of codes, contracts and pinned codes and the last assigned ids",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ModuleStats(
+				context.Background(),
+				&types.QueryModuleStatsRequest{},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryTotalContractFunds returns the total of the bank balances of a page of contracts
+func GetCmdQueryTotalContractFunds() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "total-contract-funds",
+		Short: "Get the total of the bank balances of a page of contracts",
+		Long:  "Get the total of the bank balances of a page of contracts. The total of all contracts is the sum of all pages",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.TotalContractFunds(
+				context.Background(),
+				&types.QueryTotalContractFundsRequest{Pagination: pageReq},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "total contract funds")
+	return cmd
+}
+
+// GetCmdQueryCode returns the bytecode for a given contract
+func GetCmdQueryCode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "code [code_id] [output filename]",
+		Short:   "Downloads wasm bytecode for given code id",
+		Long:    "Downloads wasm bytecode for given code id",
+		Aliases: []string{"source-code", "source"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Code(
+				context.Background(),
+				&types.QueryCodeRequest{
+					CodeId: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			if len(res.Data) == 0 {
+				return errors.New("contract not found")
+			}
+
+			fmt.Printf("Downloading wasm code to %s\n", args[1])
+			return os.WriteFile(args[1], res.Data, 0o600)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryCodeInfo returns the code info for a given code id
+func GetCmdQueryCodeInfo() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-info [code_id]",
+		Short: "Prints out metadata of a code id",
+		Long:  "Prints out metadata of a code id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeInfo(
+				context.Background(),
+				&types.QueryCodeInfoRequest{
+					CodeId: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryCodeAccessConfig returns the instantiate permission for a given code id
+func GetCmdQueryCodeAccessConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-access-config [code_id]",
+		Short: "Prints out the instantiate permission of a code id",
+		Long:  "Prints out the instantiate permission type and the addresses allowed to instantiate a code id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeAccessConfig(
+				context.Background(),
+				&types.QueryCodeAccessConfigRequest{
+					CodeId: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryAnalyzeCode returns the required capabilities of a code id and optionally the predicted instantiate2 address
+func GetCmdQueryAnalyzeCode() *cobra.Command {
+	decoder := newArgDecoder(hex.DecodeString)
+	cmd := &cobra.Command{
+		Use:   "analyze-code [code_id] [creator-address (optional)] [salt-hex-encoded (optional)]",
+		Short: "Prints out the required capabilities of a code id and whether they are available",
+		Long: `Prints out the required capabilities of a code id and whether they are available on this chain.
+The predicted instantiate2 address is included when the creator address and salt are set.`,
+		Args: cobra.MatchAll(cobra.RangeArgs(1, 3), func(_ *cobra.Command, args []string) error {
+			if len(args) == 2 {
+				return errors.New("salt is required with creator address")
+			}
+			return nil
+		}),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			req := &types.QueryAnalyzeCodeRequest{CodeId: codeID}
+			if len(args) == 3 {
+				salt, err := decoder.DecodeString(args[2])
+				if err != nil {
+					return fmt.Errorf("salt: %w", err)
+				}
+				req.CreatorAddress = args[1]
+				req.Salt = hex.EncodeToString(salt)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.AnalyzeCode(context.Background(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
+	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
-// GetCmdListCode lists all wasm code uploaded
-func GetCmdListCode() *cobra.Command {
+// GetCmdQueryCodeExports returns the exported entrypoints of a given code id
+func GetCmdQueryCodeExports() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list-code",
-		Short:   "List all wasm bytecode on the chain",
-		Long:    "List all wasm bytecode on the chain",
-		Aliases: []string{"list-codes", "codes", "lco"},
-		Args:    cobra.ExactArgs(0),
+		Use:   "code-exports [code_id]",
+		Short: "Prints out the exported entrypoints of a code id",
+		Long:  "Prints out the entrypoints that are exported by a code id, e.g. migrate or sudo",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
 			if err != nil {
 				return err
 			}
+
 			queryClient := types.NewQueryClient(clientCtx)
-			res, err := queryClient.Codes(
+			res, err := queryClient.CodeExports(
 				context.Background(),
-				&types.QueryCodesRequest{
-					Pagination: pageReq,
+				&types.QueryCodeExportsRequest{
+					CodeId: codeID,
 				},
 			)
 			if err != nil {
 				return err
 			}
+
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	addPaginationFlags(cmd, "list codes")
 	return cmd
 }
 
-// GetCmdListContractByCode lists all wasm code uploaded for given code id
-func GetCmdListContractByCode() *cobra.Command {
+// GetCmdQueryCodeStateBytes returns the total size of the state of all contracts of a given code id
+func GetCmdQueryCodeStateBytes() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list-contract-by-code [code_id]",
-		Short:   "List wasm all bytecode on the chain for given code id",
-		Long:    "List wasm all bytecode on the chain for given code id",
-		Aliases: []string{"list-contracts-by-code", "list-contracts", "contracts", "lca"},
-		Args:    cobra.ExactArgs(1),
+		Use:   "code-state-bytes [code_id]",
+		Short: "Prints out the total size of the state of a page of the contracts of a code id",
+		Long: `Prints out the total size of the keys and values stored by a page of the contracts of a code id and the number
+of contracts in the page. The state of contracts without a storage quota is iterated by the node, so the totals of all
+pages must be summed up to get the size of the state of all contracts.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
@@ -158,18 +709,15 @@ func GetCmdListContractByCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if codeID == 0 {
-				return errors.New("empty code id")
-			}
 
 			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
 			if err != nil {
 				return err
 			}
 			queryClient := types.NewQueryClient(clientCtx)
-			res, err := queryClient.ContractsByCode(
+			res, err := queryClient.StateBytesByCode(
 				context.Background(),
-				&types.QueryContractsByCodeRequest{
+				&types.QueryStateBytesByCodeRequest{
 					CodeId:     codeID,
 					Pagination: pageReq,
 				},
@@ -177,23 +725,23 @@ func GetCmdListContractByCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
+
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
 	flags.AddQueryFlagsToCmd(cmd)
-	addPaginationFlags(cmd, "list contracts by code")
+	addPaginationFlags(cmd, "code state bytes")
 	return cmd
 }
 
-// GetCmdQueryCode returns the bytecode for a given contract
-func GetCmdQueryCode() *cobra.Command {
+// GetCmdQueryCodeProvenance returns the reproducible build metadata for a given code id
+func GetCmdQueryCodeProvenance() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "code [code_id] [output filename]",
-		Short:   "Downloads wasm bytecode for given code id",
-		Long:    "Downloads wasm bytecode for given code id",
-		Aliases: []string{"source-code", "source"},
-		Args:    cobra.ExactArgs(2),
+		Use:   "code-provenance [code_id]",
+		Short: "Prints out the source url and builder of a code id",
+		Long:  "Prints out the source url and builder that were provided on upload of a code id",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
@@ -206,21 +754,17 @@ func GetCmdQueryCode() *cobra.Command {
 			}
 
 			queryClient := types.NewQueryClient(clientCtx)
-			res, err := queryClient.Code(
+			res, err := queryClient.CodeProvenance(
 				context.Background(),
-				&types.QueryCodeRequest{
+				&types.QueryCodeProvenanceRequest{
 					CodeId: codeID,
 				},
 			)
 			if err != nil {
 				return err
 			}
-			if len(res.Data) == 0 {
-				return errors.New("contract not found")
-			}
 
-			fmt.Printf("Downloading wasm code to %s\n", args[1])
-			return os.WriteFile(args[1], res.Data, 0o600)
+			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
@@ -228,35 +772,70 @@ func GetCmdQueryCode() *cobra.Command {
 	return cmd
 }
 
-// GetCmdQueryCodeInfo returns the code info for a given code id
-func GetCmdQueryCodeInfo() *cobra.Command {
+// GetCmdGetContractInfo gets details about a given contract
+func GetCmdGetContractInfo() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "code-info [code_id]",
-		Short: "Prints out metadata of a code id",
-		Long:  "Prints out metadata of a code id",
-		Args:  cobra.ExactArgs(1),
+		Use:     "contract [bech32_address]",
+		Short:   "Prints out metadata of a contract given its address",
+		Long:    "Prints out metadata of a contract given its address",
+		Aliases: []string{"meta", "c"},
+		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			_, err = sdk.AccAddressFromBech32(args[0])
 			if err != nil {
 				return err
 			}
-
 			queryClient := types.NewQueryClient(clientCtx)
-			res, err := queryClient.CodeInfo(
+			res, err := queryClient.ContractInfo(
 				context.Background(),
-				&types.QueryCodeInfoRequest{
-					CodeId: codeID,
+				&types.QueryContractInfoRequest{
+					Address: args[0],
 				},
 			)
 			if err != nil {
 				return err
 			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdGetContractInfoBatch gets details about a list of contracts
+func GetCmdGetContractInfoBatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contracts-info [bech32_address]...",
+		Short: "Prints out metadata of a list of contracts given their addresses",
+		Long:  "Prints out metadata of a list of contracts given their addresses. Addresses without a contract are marked as not found.",
+		Args:  cobra.RangeArgs(1, keeper.MaxContractInfoBatchSize),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
 
+			for _, a := range args {
+				if _, err := sdk.AccAddressFromBech32(a); err != nil {
+					return fmt.Errorf("address %s: %w", a, err)
+				}
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractInfoBatch(
+				context.Background(),
+				&types.QueryContractInfoBatchRequest{
+					Addresses: args,
+				},
+			)
+			if err != nil {
+				return err
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
@@ -265,14 +844,13 @@ func GetCmdQueryCodeInfo() *cobra.Command {
 	return cmd
 }
 
-// GetCmdGetContractInfo gets details about a given contract
-func GetCmdGetContractInfo() *cobra.Command {
+// GetCmdQueryMigrationCheckpoints lists the migration checkpoints of a contract
+func GetCmdQueryMigrationCheckpoints() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "contract [bech32_address]",
-		Short:   "Prints out metadata of a contract given its address",
-		Long:    "Prints out metadata of a contract given its address",
-		Aliases: []string{"meta", "c"},
-		Args:    cobra.ExactArgs(1),
+		Use:   "migration-checkpoints [bech32_address]",
+		Short: "Prints out the migration checkpoints of a contract given its address",
+		Long:  "Prints out the pre-migration state checkpoints of a contract given its address",
+		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
@@ -284,9 +862,9 @@ func GetCmdGetContractInfo() *cobra.Command {
 				return err
 			}
 			queryClient := types.NewQueryClient(clientCtx)
-			res, err := queryClient.ContractInfo(
+			res, err := queryClient.MigrationCheckpoints(
 				context.Background(),
-				&types.QueryContractInfoRequest{
+				&types.QueryMigrationCheckpointsRequest{
 					Address: args[0],
 				},
 			)
@@ -316,6 +894,7 @@ func GetCmdGetContractState() *cobra.Command {
 		GetCmdGetContractStateAll(),
 		GetCmdGetContractStateRaw(),
 		GetCmdGetContractStateSmart(),
+		GetCmdGetContractStateDecode(),
 	)
 	return cmd
 }
@@ -337,6 +916,15 @@ func GetCmdGetContractStateAll() *cobra.Command {
 				return err
 			}
 
+			keyPrefixHex, err := cmd.Flags().GetString(flagKeyPrefix)
+			if err != nil {
+				return err
+			}
+			keyPrefix, err := hex.DecodeString(keyPrefixHex)
+			if err != nil {
+				return fmt.Errorf("key prefix: %s", err)
+			}
+
 			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
 			if err != nil {
 				return err
@@ -347,6 +935,7 @@ func GetCmdGetContractStateAll() *cobra.Command {
 				&types.QueryAllContractStateRequest{
 					Address:    args[0],
 					Pagination: pageReq,
+					KeyPrefix:  keyPrefix,
 				},
 			)
 			if err != nil {
@@ -356,11 +945,153 @@ func GetCmdGetContractStateAll() *cobra.Command {
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().String(flagKeyPrefix, "", "Hex encoded prefix to limit the result to keys starting with it")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "contract state")
 	return cmd
 }
 
+// GetCmdGetContractStateDecode fetches all pages of the contract state and prints the models in a human readable form
+func GetCmdGetContractStateDecode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode [bech32_address]",
+		Short: "Prints out all internal state of a contract in a human readable form",
+		Long: `Prints out all internal state of a contract given its address. Keys and values are rendered as UTF-8 strings when
+printable and as hex otherwise. JSON values are pretty printed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			keyPrefixHex, err := cmd.Flags().GetString(flagKeyPrefix)
+			if err != nil {
+				return err
+			}
+			keyPrefix, err := hex.DecodeString(keyPrefixHex)
+			if err != nil {
+				return fmt.Errorf("key prefix: %s", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			var models []decodedModel
+			var nextKey []byte
+			for {
+				res, err := queryClient.AllContractState(
+					cmd.Context(),
+					&types.QueryAllContractStateRequest{
+						Address:    args[0],
+						Pagination: &query.PageRequest{Key: nextKey},
+						KeyPrefix:  keyPrefix,
+					},
+				)
+				if err != nil {
+					return err
+				}
+				for _, m := range res.Models {
+					models = append(models, decodeModel(m))
+				}
+				if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+					break
+				}
+				nextKey = res.Pagination.NextKey
+			}
+
+			if clientCtx.OutputFormat == flags.OutputFormatJSON {
+				bz, err := json.Marshal(struct {
+					Models []decodedModel `json:"models"`
+				}{Models: models})
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintRaw(bz)
+			}
+			return clientCtx.PrintString(formatDecodedModels(models))
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagKeyPrefix, "", "Hex encoded prefix to limit the result to keys starting with it")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+const (
+	encodingUTF8 = "utf8"
+	encodingHex  = "hex"
+	encodingJSON = "json"
+)
+
+// decodedModel is a contract state entry with key and value rendered in a human readable form
+type decodedModel struct {
+	Key           string          `json:"key"`
+	KeyEncoding   string          `json:"key_encoding"`
+	Value         json.RawMessage `json:"value"`
+	ValueEncoding string          `json:"value_encoding"`
+}
+
+func decodeModel(m types.Model) decodedModel {
+	r := decodedModel{}
+	r.Key, r.KeyEncoding = decodeBytes(m.Key)
+	if len(m.Value) != 0 && json.Valid(m.Value) {
+		r.Value, r.ValueEncoding = json.RawMessage(m.Value), encodingJSON
+		return r
+	}
+	value, encoding := decodeBytes(m.Value)
+	r.Value, _ = json.Marshal(value)
+	r.ValueEncoding = encoding
+	return r
+}
+
+// decodeBytes returns the bytes as string when they are printable UTF-8 and hex encoded otherwise
+func decodeBytes(bz []byte) (string, string) {
+	if isPrintable(bz) {
+		return string(bz), encodingUTF8
+	}
+	return hex.EncodeToString(bz), encodingHex
+}
+
+func isPrintable(bz []byte) bool {
+	if !utf8.Valid(bz) {
+		return false
+	}
+	for _, r := range string(bz) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatDecodedModels(models []decodedModel) string {
+	var b strings.Builder
+	for i, m := range models {
+		if i != 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "key (%s): %s\n", m.KeyEncoding, m.Key)
+		fmt.Fprintf(&b, "value (%s): ", m.ValueEncoding)
+		if m.ValueEncoding == encodingJSON {
+			var pretty bytes.Buffer
+			if err := json.Indent(&pretty, m.Value, "", "  "); err == nil {
+				b.Write(pretty.Bytes())
+				b.WriteString("\n")
+				continue
+			}
+		}
+		var s string
+		_ = json.Unmarshal(m.Value, &s)
+		b.WriteString(s)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func GetCmdGetContractStateRaw() *cobra.Command {
 	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
@@ -374,7 +1105,7 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 				return err
 			}
 
-			_, err = sdk.AccAddressFromBech32(args[0])
+			contractAddr, err := sdk.AccAddressFromBech32(args[0])
 			if err != nil {
 				return err
 			}
@@ -382,6 +1113,23 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			prove, err := cmd.Flags().GetBool(flagProve)
+			if err != nil {
+				return err
+			}
+			if prove {
+				// the proof is provided by the store query of the full key in the wasm module store
+				res, err := clientCtx.QueryABCI(abci.RequestQuery{
+					Path:   fmt.Sprintf("/store/%s/key", types.StoreKey),
+					Data:   types.GetContractStateKey(contractAddr, queryData),
+					Height: clientCtx.Height,
+					Prove:  true,
+				})
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintProto(&res)
+			}
 
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.RawContractState(
@@ -399,6 +1147,7 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "key argument")
+	cmd.Flags().Bool(flagProve, false, "Return the value with the merkle proof of the wasm store key from the node")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
@@ -452,6 +1201,41 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 	return cmd
 }
 
+// GetCmdIsContract gets whether an address is a contract
+func GetCmdIsContract() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "is-contract [bech32_address]",
+		Short: "Prints out whether an address is a contract",
+		Long:  "Prints out whether an address is a contract and the code id of the contract",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.IsContract(
+				context.Background(),
+				&types.QueryIsContractRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetContractHistory prints the code history for a given contract
 func GetCmdGetContractHistory() *cobra.Command {
 	cmd := &cobra.Command{
@@ -497,13 +1281,57 @@ func GetCmdGetContractHistory() *cobra.Command {
 	return cmd
 }
 
+// GetCmdGetContractAdminChain resolves the admins of a contract up to the account that controls the migrations
+func GetCmdGetContractAdminChain() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-admin-chain [bech32_address]",
+		Short: "Prints out the admin chain of a contract given its address",
+		Long:  "Prints out the admin of a contract and the admins of the admins that are contracts, up to the account that controls the migrations",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			maxDepth, err := cmd.Flags().GetUint32(flagMaxDepth)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractAdminChain(
+				context.Background(),
+				&types.QueryContractAdminChainRequest{
+					Address:  args[0],
+					MaxDepth: maxDepth,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint32(flagMaxDepth, 0, "Max number of admins to resolve, optional")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdListPinnedCode lists all wasm code ids that are pinned
 func GetCmdListPinnedCode() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "pinned",
-		Short: "List all pinned code ids",
-		Long:  "List all pinned code ids",
-		Args:  cobra.ExactArgs(0),
+		Use:     "pinned",
+		Aliases: []string{"pinned-codes"},
+		Short:   "List all pinned code ids",
+		Long:    "List all pinned code ids",
+		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
@@ -533,6 +1361,49 @@ func GetCmdListPinnedCode() *cobra.Command {
 	return cmd
 }
 
+// GetCmdListUnusedCode lists all wasm code ids without contract instances
+func GetCmdListUnusedCode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unused",
+		Aliases: []string{"unused-codes"},
+		Short:   "List all code ids without contract instances",
+		Long:    "List all code ids without contract instances that would be deleted by pruning unused codes",
+		Args:    cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			olderThanHeight, err := cmd.Flags().GetUint64(flagOlderThanHeight)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.UnusedCodes(
+				context.Background(),
+				&types.QueryUnusedCodesRequest{
+					OlderThanHeight: olderThanHeight,
+					Pagination:      pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagOlderThanHeight, 0, "Only list codes stored before this block height, optional")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list codes")
+	return cmd
+}
+
 // GetCmdListContractsByCreator lists all contracts by creator
 func GetCmdListContractsByCreator() *cobra.Command {
 	cmd := &cobra.Command{
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestGetCmdBuildAddress(t *testing.T) {
+	creator := sdk.MustAccAddressFromBech32("cosmos1wyqh3n50ecatjg4vww5crmtd0nmyzusnwckw4at4gluc0m5m477q4arfek")
+	checksum, err := hex.DecodeString(testdata.ChecksumHackatom)
+	require.NoError(t, err)
+	initMsg := []byte(`{"foo":"bar"}`)
+
+	specs := map[string]struct {
+		args    []string
+		expAddr sdk.AccAddress
+		expErr  bool
+	}{
+		"hex salt": {
+			args:    []string{testdata.ChecksumHackatom, creator.String(), "74657374696e67"},
+			expAddr: keeper.BuildContractAddressPredictable(checksum, creator, []byte("testing"), []byte{}),
+		},
+		"ascii salt": {
+			args:    []string{testdata.ChecksumHackatom, creator.String(), "testing", "--ascii"},
+			expAddr: keeper.BuildContractAddressPredictable(checksum, creator, []byte("testing"), []byte{}),
+		},
+		"init msg not fixed": {
+			args:    []string{testdata.ChecksumHackatom, creator.String(), "74657374696e67", string(initMsg)},
+			expAddr: keeper.BuildContractAddressPredictable(checksum, creator, []byte("testing"), []byte{}),
+		},
+		"init msg fixed": {
+			args:    []string{testdata.ChecksumHackatom, creator.String(), "74657374696e67", string(initMsg), "--fix-msg"},
+			expAddr: keeper.BuildContractAddressPredictable(checksum, creator, []byte("testing"), initMsg),
+		},
+		"fixed without init msg": {
+			args:   []string{testdata.ChecksumHackatom, creator.String(), "74657374696e67", "--fix-msg"},
+			expErr: true,
+		},
+		"invalid salt": {
+			args:   []string{testdata.ChecksumHackatom, creator.String(), "not hex"},
+			expErr: true,
+		},
+		"invalid creator": {
+			args:   []string{testdata.ChecksumHackatom, "invalid", "74657374696e67"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := GetCmdBuildAddress()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(spec.args)
+
+			// when
+			gotErr := cmd.Execute()
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, `{"address":"`+spec.expAddr.String()+`"}`, out.String())
+		})
+	}
+}
+
+func TestDecodeModel(t *testing.T) {
+	specs := map[string]struct {
+		src types.Model
+		exp decodedModel
+	}{
+		"utf8 key with json value": {
+			src: types.Model{Key: []byte("config"), Value: []byte(`{"owner":"foo"}`)},
+			exp: decodedModel{Key: "config", KeyEncoding: "utf8", Value: json.RawMessage(`{"owner":"foo"}`), ValueEncoding: "json"},
+		},
+		"utf8 key with string value": {
+			src: types.Model{Key: []byte("name"), Value: []byte("foo bar")},
+			exp: decodedModel{Key: "name", KeyEncoding: "utf8", Value: json.RawMessage(`"foo bar"`), ValueEncoding: "utf8"},
+		},
+		"binary key with binary value": {
+			src: types.Model{Key: []byte{0x0, 0x6, 'b'}, Value: []byte{0xff, 0x1}},
+			exp: decodedModel{Key: "000662", KeyEncoding: "hex", Value: json.RawMessage(`"ff01"`), ValueEncoding: "hex"},
+		},
+		"invalid utf8": {
+			src: types.Model{Key: []byte{0xc3, 0x28}, Value: []byte("\n")},
+			exp: decodedModel{Key: "c328", KeyEncoding: "hex", Value: json.RawMessage(`"0a"`), ValueEncoding: "hex"},
+		},
+		"empty value": {
+			src: types.Model{Key: []byte("a"), Value: []byte{}},
+			exp: decodedModel{Key: "a", KeyEncoding: "utf8", Value: json.RawMessage(`""`), ValueEncoding: "utf8"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := decodeModel(spec.src)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestFormatDecodedModels(t *testing.T) {
+	models := []decodedModel{
+		decodeModel(types.Model{Key: []byte("config"), Value: []byte(`{"owner":"foo"}`)}),
+		decodeModel(types.Model{Key: []byte{0x1}, Value: []byte("bar")}),
+	}
+	exp := `key (utf8): config
+value (json): {
+  "owner": "foo"
+}
+
+key (hex): 01
+value (utf8): bar
+`
+	assert.Equal(t, exp, formatDecodedModels(models))
+}
@@ -38,7 +38,13 @@ const (
 	flagInstantiateNobody         = "instantiate-nobody"
 	flagInstantiateByAddress      = "instantiate-only-address"
 	flagInstantiateByAnyOfAddress = "instantiate-anyof-addresses"
+	flagMaxInstancesPerAddress    = "instantiate-max-instances-per-address"
 	flagUnpinCode                 = "unpin-code"
+	flagWithBackup                = "with-backup"
+	flagAllowDowngrade            = "allow-downgrade"
+	flagAddQueryPaths             = "add"
+	flagRemoveQueryPaths          = "remove"
+	flagSkipUnauthorized          = "skip-unauthorized"
 	flagAllowedMsgKeys            = "allow-msg-keys"
 	flagAllowedRawMsgs            = "allow-raw-msgs"
 	flagExpiration                = "expiration"
@@ -48,6 +54,13 @@ const (
 	flagNoTokenTransfer           = "no-token-transfer"
 	flagAuthority                 = "authority"
 	flagExpedite                  = "expedite"
+	flagKeyPrefix                 = "key-prefix"
+	flagProve                     = "prove"
+	flagOlderThanHeight           = "older-than-height"
+	flagIdempotent                = "idempotent"
+	flagContractsFile             = "contracts-file"
+	flagSkipMissing               = "skip-missing"
+	flagMaxDepth                  = "max-depth"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -62,16 +75,23 @@ func GetTxCmd() *cobra.Command {
 	}
 	txCmd.AddCommand(
 		StoreCodeCmd(),
+		StoreCodesCmd(),
 		InstantiateContractCmd(),
 		InstantiateContract2Cmd(),
 		ExecuteContractCmd(),
 		MigrateContractCmd(),
+		MigrateContractAndExecuteCmd(),
+		StoreAndMigrateContractCmd(),
 		UpdateContractAdminCmd(),
 		ClearContractAdminCmd(),
+		UpdateContractAdminsCmd(),
 		GrantCmd(),
 		UpdateInstantiateConfigCmd(),
+		UpdateInstantiateConfigsCmd(),
 		SubmitProposalCmd(),
 		UpdateContractLabelCmd(),
+		PinCodesCmd(),
+		UnpinCodesCmd(),
 	)
 	return txCmd
 }
@@ -92,16 +112,71 @@ func StoreCodeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if msg.Source, msg.Builder, err = parseProvenanceFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().String(flagSource, "", "Code Source URL is a valid absolute HTTPS URI to the contract's source code, optional")
+	cmd.Flags().String(flagBuilder, "", "Builder is a valid docker image name with tag, such as \"cosmwasm/optimizer:0.16.0\", optional")
 	addInstantiatePermissionFlags(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// StoreCodesCmd will upload multiple codes in a single message
+func StoreCodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store-codes [wasm file]...",
+		Short: "Upload multiple wasm binaries in a single message",
+		Long:  "Upload multiple wasm binaries with the same instantiate permission in a single message. Either all codes are stored or none.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgStoreCodes{Sender: clientCtx.GetFromAddress().String()}
+			for _, file := range args {
+				storeMsg, err := parseStoreCodeArgs(file, msg.Sender, cmd.Flags())
+				if err != nil {
+					return fmt.Errorf("%s: %w", file, err)
+				}
+				msg.WASMByteCodes = append(msg.WASMByteCodes, storeMsg.WASMByteCode)
+				msg.InstantiatePermission = storeMsg.InstantiatePermission
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+
+	addInstantiatePermissionFlags(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseProvenanceFlags reads the optional reproducible build metadata
+func parseProvenanceFlags(flags *flag.FlagSet) (source, builder string, err error) {
+	source, err = flags.GetString(flagSource)
+	if err != nil {
+		return "", "", fmt.Errorf("source: %s", err)
+	}
+	builder, err = flags.GetString(flagBuilder)
+	if err != nil {
+		return "", "", fmt.Errorf("builder: %s", err)
+	}
+	return source, builder, nil
+}
+
 // Prepares MsgStoreCode object from flags with gzipped wasm byte code field
 func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStoreCode, error) {
 	wasm, err := os.ReadFile(file)
@@ -115,8 +190,8 @@ func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStor
 		if err != nil {
 			return types.MsgStoreCode{}, err
 		}
-	} else if !ioutils.IsGzip(wasm) {
-		return types.MsgStoreCode{}, errors.New("invalid input file. Use wasm binary or gzip")
+	} else if !ioutils.IsCompressed(wasm) {
+		return types.MsgStoreCode{}, errors.New("invalid input file. Use wasm binary, gzip or zstd")
 	}
 
 	perm, err := parseAccessConfigFlags(flags)
@@ -146,8 +221,17 @@ func parseAccessConfigFlags(flags *flag.FlagSet) (*types.AccessConfig, error) {
 			}
 		}
 		x := types.AccessTypeAnyOfAddresses.With(acceptedAddrs...)
+		x.MaxInstancesPerAddress, err = flags.GetUint64(flagMaxInstancesPerAddress)
+		if err != nil {
+			return nil, fmt.Errorf("max instances per address: %s", err)
+		}
 		return &x, nil
 	}
+	if maxInstances, err := flags.GetUint64(flagMaxInstancesPerAddress); err != nil {
+		return nil, fmt.Errorf("max instances per address: %s", err)
+	} else if maxInstances != 0 {
+		return nil, fmt.Errorf("%s requires %s", flagMaxInstancesPerAddress, flagInstantiateByAnyOfAddress)
+	}
 
 	onlyAddrStr, err := flags.GetString(flagInstantiateByAddress)
 	if err != nil {
@@ -191,6 +275,7 @@ func addInstantiatePermissionFlags(cmd *cobra.Command) {
 	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", fmt.Sprintf("Removed: use %s instead", flagInstantiateByAnyOfAddress))
 	cmd.Flags().StringSlice(flagInstantiateByAnyOfAddress, []string{}, "Any of the addresses can instantiate a contract from the code, optional")
+	cmd.Flags().Uint64(flagMaxInstancesPerAddress, 0, fmt.Sprintf("Max number of contracts each of the %s can instantiate from the code, optional", flagInstantiateByAnyOfAddress))
 }
 
 // InstantiateContractCmd will instantiate a contract from previously uploaded code.
@@ -233,7 +318,7 @@ func InstantiateContract2Cmd() *cobra.Command {
 	decoder := newArgDecoder(hex.DecodeString)
 	cmd := &cobra.Command{
 		Use: "instantiate2 [code_id_int64] [json_encoded_init_args] [salt] --label [text] --admin [address,optional] --amount [coins,optional] " +
-			"--fix-msg [bool,optional]",
+			"--fix-msg [bool,optional] --idempotent [bool,optional]",
 		Short: "Instantiate a wasm contract with predictable address",
 		Long: fmt.Sprintf(`Creates a new instance of an uploaded wasm code with the given 'constructor' message.
 Each contract instance has a unique address assigned. They are assigned automatically but in order to have predictable addresses
@@ -243,6 +328,9 @@ Predictable address example (also see '%s query wasm build-address -h'):
 $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --admin="$(%s keys show mykey -a)" \
   --from mykey --amount="100ustake" --label "local0.1.0" \
    --fix-msg
+
+With '--idempotent' the tx succeeds without a new instance when the address is taken by a contract of the same code
+and creator. Otherwise a taken address fails with the "contract address already exists" error.
 `, version.AppName, version.AppName, version.AppName),
 		Aliases: []string{"start", "init", "inst", "i"},
 		Args:    cobra.ExactArgs(3),
@@ -259,19 +347,24 @@ $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --adm
 			if err != nil {
 				return fmt.Errorf("fix msg: %w", err)
 			}
+			idempotent, err := cmd.Flags().GetBool(flagIdempotent)
+			if err != nil {
+				return fmt.Errorf("idempotent: %w", err)
+			}
 			data, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), cmd.Flags())
 			if err != nil {
 				return err
 			}
 			msg := &types.MsgInstantiateContract2{
-				Sender: data.Sender,
-				Admin:  data.Admin,
-				CodeID: data.CodeID,
-				Label:  data.Label,
-				Msg:    data.Msg,
-				Funds:  data.Funds,
-				Salt:   salt,
-				FixMsg: fixMsg,
+				Sender:     data.Sender,
+				Admin:      data.Admin,
+				CodeID:     data.CodeID,
+				Label:      data.Label,
+				Msg:        data.Msg,
+				Funds:      data.Funds,
+				Salt:       salt,
+				FixMsg:     fixMsg,
+				Idempotent: idempotent,
 			}
 			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
@@ -283,6 +376,7 @@ $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --adm
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
 	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
 	cmd.Flags().Bool(flagFixMsg, false, "An optional flag to include the json_encoded_init_args for the predictable address generation mode")
+	cmd.Flags().Bool(flagIdempotent, false, "An optional flag to succeed without a new instance when the address is taken by a contract of the same code and creator")
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
@@ -102,6 +107,14 @@ func TestParseAccessConfigFlags(t *testing.T) {
 			args:   []string{"--instantiate-anyof-addresses=cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x,cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"},
 			expCfg: &types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{"cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x", "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"}},
 		},
+		"any of address with max instances": {
+			args:   []string{"--instantiate-anyof-addresses=cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x", "--instantiate-max-instances-per-address=3"},
+			expCfg: &types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{"cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"}, MaxInstancesPerAddress: 3},
+		},
+		"max instances without any of address": {
+			args:   []string{"--instantiate-everybody=true", "--instantiate-max-instances-per-address=3"},
+			expErr: true,
+		},
 		"any of address - invalid": {
 			args:   []string{"--instantiate-anyof-addresses=cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x,foo"},
 			expErr: true,
@@ -227,3 +240,206 @@ func TestParseStoreCodeGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestParseClearContractAdminArgs(t *testing.T) {
+	mySender := "cosmos1wyqh3n50ecatjg4vww5crmtd0nmyzusnwckw4at4gluc0m5m477q4arfek"
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	otherContract := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+
+	specs := map[string]struct {
+		args   []string
+		expMsg sdk.Msg
+		expErr bool
+	}{
+		"single contract": {
+			args:   []string{myContract},
+			expMsg: &types.MsgClearAdmin{Sender: mySender, Contract: myContract},
+		},
+		"single contract with skip": {
+			args:   []string{myContract, "--skip-unauthorized"},
+			expMsg: &types.MsgClearAdmins{Sender: mySender, Contracts: []string{myContract}, SkipUnauthorized: true},
+		},
+		"multiple contracts": {
+			args:   []string{myContract, otherContract},
+			expMsg: &types.MsgClearAdmins{Sender: mySender, Contracts: []string{myContract, otherContract}},
+		},
+		"duplicate contracts": {
+			args:   []string{myContract, myContract},
+			expErr: true,
+		},
+		"invalid contract": {
+			args:   []string{"invalid"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := ClearContractAdminCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotMsg, gotErr := parseClearContractAdminArgs(flags.Args(), mySender, flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMsg, gotMsg)
+		})
+	}
+}
+
+func TestParseUpdateContractAdminsArgs(t *testing.T) {
+	mySender := "cosmos1wyqh3n50ecatjg4vww5crmtd0nmyzusnwckw4at4gluc0m5m477q4arfek"
+	myAdmin := sdk.AccAddress(bytes.Repeat([]byte{3}, 20)).String()
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	otherContract := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+	contractsFile := filepath.Join(t.TempDir(), "contracts.txt")
+	require.NoError(t, os.WriteFile(contractsFile, []byte(otherContract+"\n"+myContract+"\n"), 0o600))
+
+	specs := map[string]struct {
+		args   []string
+		expMsg types.MsgUpdateAdmins
+		expErr bool
+	}{
+		"contracts from args": {
+			args:   []string{myAdmin, myContract, otherContract},
+			expMsg: types.MsgUpdateAdmins{Sender: mySender, NewAdmin: myAdmin, Contracts: []string{myContract, otherContract}},
+		},
+		"contracts from file": {
+			args:   []string{myAdmin, "--contracts-file", contractsFile, "--skip-missing"},
+			expMsg: types.MsgUpdateAdmins{Sender: mySender, NewAdmin: myAdmin, Contracts: []string{otherContract, myContract}, SkipMissing: true},
+		},
+		"duplicate contracts from args and file": {
+			args:   []string{myAdmin, myContract, "--contracts-file", contractsFile},
+			expErr: true,
+		},
+		"no contracts": {
+			args:   []string{myAdmin},
+			expErr: true,
+		},
+		"invalid admin": {
+			args:   []string{"invalid", myContract},
+			expErr: true,
+		},
+		"missing file": {
+			args:   []string{myAdmin, "--contracts-file", filepath.Join(t.TempDir(), "missing.txt")},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := UpdateContractAdminsCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotMsg, gotErr := parseUpdateContractAdminsArgs(flags.Args(), mySender, flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMsg, gotMsg)
+		})
+	}
+}
+
+func TestParseMigrateContractAndExecuteArgs(t *testing.T) {
+	mySender := "cosmos1wyqh3n50ecatjg4vww5crmtd0nmyzusnwckw4at4gluc0m5m477q4arfek"
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+
+	specs := map[string]struct {
+		args   []string
+		expMsg types.MsgMigrateContractAndExecute
+		expErr bool
+	}{
+		"all good": {
+			args: []string{myContract, "2", `{"foo":"bar"}`, `{"bar":"foo"}`},
+			expMsg: types.MsgMigrateContractAndExecute{
+				Sender:     mySender,
+				Contract:   myContract,
+				NewCodeID:  2,
+				MigrateMsg: []byte(`{"foo":"bar"}`),
+				ExecuteMsg: []byte(`{"bar":"foo"}`),
+			},
+		},
+		"with funds and downgrade": {
+			args: []string{myContract, "2", `{}`, `{}`, "--amount", "100stake", "--allow-downgrade"},
+			expMsg: types.MsgMigrateContractAndExecute{
+				Sender:         mySender,
+				Contract:       myContract,
+				NewCodeID:      2,
+				MigrateMsg:     []byte(`{}`),
+				ExecuteMsg:     []byte(`{}`),
+				Funds:          sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				AllowDowngrade: true,
+			},
+		},
+		"invalid code id": {
+			args:   []string{myContract, "foo", `{}`, `{}`},
+			expErr: true,
+		},
+		"invalid execute msg": {
+			args:   []string{myContract, "2", `{}`, "not json"},
+			expErr: true,
+		},
+		"invalid contract": {
+			args:   []string{"invalid", "2", `{}`, `{}`},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := MigrateContractAndExecuteCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotMsg, gotErr := parseMigrateContractAndExecuteArgs(flags.Args(), mySender, flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMsg, gotMsg)
+		})
+	}
+}
+
+func TestParseUpdateInstantiateConfigsArgs(t *testing.T) {
+	mySender := "cosmos1wyqh3n50ecatjg4vww5crmtd0nmyzusnwckw4at4gluc0m5m477q4arfek"
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	specs := map[string]struct {
+		src    string
+		exp    []types.InstantiateConfigUpdate
+		expErr bool
+	}{
+		"multiple codes": {
+			src: `[{"code_id": "1", "new_instantiate_permission": {"permission": "Nobody"}},
+				{"code_id": 2, "new_instantiate_permission": {"permission": "AnyOfAddresses", "addresses": ["` + myAddr + `"]}}]`,
+			exp: []types.InstantiateConfigUpdate{
+				{CodeID: 1, NewInstantiatePermission: &types.AllowNobody},
+				{CodeID: 2, NewInstantiatePermission: &types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{myAddr}}},
+			},
+		},
+		"empty list": {
+			src:    `[]`,
+			expErr: true,
+		},
+		"missing permission": {
+			src:    `[{"code_id": "1"}]`,
+			expErr: true,
+		},
+		"invalid json": {
+			src:    `{"code_id": "1"}`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotMsg, gotErr := parseUpdateInstantiateConfigsArgs(cdc, mySender, []byte(spec.src))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, mySender, gotMsg.Sender)
+			assert.Equal(t, spec.exp, gotMsg.Updates)
+		})
+	}
+}
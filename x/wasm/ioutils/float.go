@@ -0,0 +1,512 @@
+package ioutils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// wasm value types
+// See https://webassembly.github.io/spec/core/binary/types.html#value-types
+const (
+	valTypeF32 byte = 0x7D
+	valTypeF64 byte = 0x7C
+)
+
+// floatOpcodes are the single byte instructions that operate on floats
+// See https://webassembly.github.io/spec/core/binary/instructions.html#numeric-instructions
+var floatOpcodes = map[byte]string{
+	0x2A: "f32.load", 0x2B: "f64.load", 0x38: "f32.store", 0x39: "f64.store",
+	0x43: "f32.const", 0x44: "f64.const",
+	0x5B: "f32.eq", 0x5C: "f32.ne", 0x5D: "f32.lt", 0x5E: "f32.gt", 0x5F: "f32.le", 0x60: "f32.ge",
+	0x61: "f64.eq", 0x62: "f64.ne", 0x63: "f64.lt", 0x64: "f64.gt", 0x65: "f64.le", 0x66: "f64.ge",
+	0x8B: "f32.abs", 0x8C: "f32.neg", 0x8D: "f32.ceil", 0x8E: "f32.floor", 0x8F: "f32.trunc", 0x90: "f32.nearest",
+	0x91: "f32.sqrt", 0x92: "f32.add", 0x93: "f32.sub", 0x94: "f32.mul", 0x95: "f32.div", 0x96: "f32.min",
+	0x97: "f32.max", 0x98: "f32.copysign",
+	0x99: "f64.abs", 0x9A: "f64.neg", 0x9B: "f64.ceil", 0x9C: "f64.floor", 0x9D: "f64.trunc", 0x9E: "f64.nearest",
+	0x9F: "f64.sqrt", 0xA0: "f64.add", 0xA1: "f64.sub", 0xA2: "f64.mul", 0xA3: "f64.div", 0xA4: "f64.min",
+	0xA5: "f64.max", 0xA6: "f64.copysign",
+	0xA8: "i32.trunc_f32_s", 0xA9: "i32.trunc_f32_u", 0xAA: "i32.trunc_f64_s", 0xAB: "i32.trunc_f64_u",
+	0xAE: "i64.trunc_f32_s", 0xAF: "i64.trunc_f32_u", 0xB0: "i64.trunc_f64_s", 0xB1: "i64.trunc_f64_u",
+	0xB2: "f32.convert_i32_s", 0xB3: "f32.convert_i32_u", 0xB4: "f32.convert_i64_s", 0xB5: "f32.convert_i64_u",
+	0xB6: "f32.demote_f64",
+	0xB7: "f64.convert_i32_s", 0xB8: "f64.convert_i32_u", 0xB9: "f64.convert_i64_s", 0xBA: "f64.convert_i64_u",
+	0xBB: "f64.promote_f32",
+	0xBC: "i32.reinterpret_f32", 0xBD: "i64.reinterpret_f64", 0xBE: "f32.reinterpret_i32", 0xBF: "f64.reinterpret_i64",
+}
+
+// saturatingTruncOpcodes are the 0xFC prefixed instructions 0 to 7 that convert floats to integers
+var saturatingTruncOpcodes = []string{
+	"i32.trunc_sat_f32_s", "i32.trunc_sat_f32_u", "i32.trunc_sat_f64_s", "i32.trunc_sat_f64_u",
+	"i64.trunc_sat_f32_s", "i64.trunc_sat_f32_u", "i64.trunc_sat_f64_s", "i64.trunc_sat_f64_u",
+}
+
+// FindFloatUsage scans the uncompressed wasm binary for float value types and instructions.
+// It returns a description of the first usage found or an empty string when the code is free of floats.
+func FindFloatUsage(wasm []byte) (string, error) {
+	if !IsWasm(wasm) || len(wasm) < 8 {
+		return "", errors.New("not a wasm binary")
+	}
+	r := &wasmReader{b: wasm[8:]}
+	var importedFuncs uint32
+	for !r.done() {
+		id, err := r.byte()
+		if err != nil {
+			return "", err
+		}
+		size, err := r.u32()
+		if err != nil {
+			return "", err
+		}
+		payload, err := r.bytes(size)
+		if err != nil {
+			return "", err
+		}
+		s := &wasmReader{b: payload}
+		var found string
+		switch id {
+		case 1:
+			found, err = findFloatInTypes(s)
+		case 2:
+			found, importedFuncs, err = findFloatInImports(s)
+		case 6:
+			found, err = findFloatInGlobals(s)
+		case 10:
+			found, err = findFloatInCode(s, importedFuncs)
+		}
+		if err != nil {
+			return "", fmt.Errorf("section %d: %w", id, err)
+		}
+		if found != "" {
+			return found, nil
+		}
+	}
+	return "", nil
+}
+
+func findFloatInTypes(r *wasmReader) (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	for i := uint32(0); i < n; i++ {
+		form, err := r.byte()
+		if err != nil {
+			return "", err
+		}
+		if form != 0x60 {
+			return "", fmt.Errorf("unsupported type form %#x", form)
+		}
+		// params and results
+		for j := 0; j < 2; j++ {
+			types, err := r.vecBytes()
+			if err != nil {
+				return "", err
+			}
+			for _, t := range types {
+				if isFloatType(t) {
+					return fmt.Sprintf("%s value in type %d", valTypeName(t), i), nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+func findFloatInImports(r *wasmReader) (string, uint32, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", 0, err
+	}
+	var funcs uint32
+	for i := uint32(0); i < n; i++ {
+		// module and field name
+		for j := 0; j < 2; j++ {
+			if _, err := r.vecBytes(); err != nil {
+				return "", 0, err
+			}
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return "", 0, err
+		}
+		switch kind {
+		case 0x00: // func
+			if _, err := r.u32(); err != nil {
+				return "", 0, err
+			}
+			funcs++
+		case 0x01: // table
+			if _, err := r.byte(); err != nil {
+				return "", 0, err
+			}
+			if err := r.skipLimits(); err != nil {
+				return "", 0, err
+			}
+		case 0x02: // memory
+			if err := r.skipLimits(); err != nil {
+				return "", 0, err
+			}
+		case 0x03: // global
+			t, err := r.byte()
+			if err != nil {
+				return "", 0, err
+			}
+			if isFloatType(t) {
+				return fmt.Sprintf("%s value in imported global %d", valTypeName(t), i), 0, nil
+			}
+			if _, err := r.byte(); err != nil {
+				return "", 0, err
+			}
+		default:
+			return "", 0, fmt.Errorf("unsupported import kind %#x", kind)
+		}
+	}
+	return "", funcs, nil
+}
+
+func findFloatInGlobals(r *wasmReader) (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	for i := uint32(0); i < n; i++ {
+		t, err := r.byte()
+		if err != nil {
+			return "", err
+		}
+		if isFloatType(t) {
+			return fmt.Sprintf("%s value in global %d", valTypeName(t), i), nil
+		}
+		if _, err := r.byte(); err != nil {
+			return "", err
+		}
+		op, err := findFloatInExpr(r)
+		if err != nil {
+			return "", err
+		}
+		if op != "" {
+			return fmt.Sprintf("%s in global %d", op, i), nil
+		}
+	}
+	return "", nil
+}
+
+func findFloatInCode(r *wasmReader, importedFuncs uint32) (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	for i := uint32(0); i < n; i++ {
+		size, err := r.u32()
+		if err != nil {
+			return "", err
+		}
+		body, err := r.bytes(size)
+		if err != nil {
+			return "", err
+		}
+		found, err := findFloatInFunc(&wasmReader{b: body})
+		if err != nil {
+			return "", fmt.Errorf("function %d: %w", importedFuncs+i, err)
+		}
+		if found != "" {
+			return fmt.Sprintf("%s in function %d", found, importedFuncs+i), nil
+		}
+	}
+	return "", nil
+}
+
+func findFloatInFunc(r *wasmReader) (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	for i := uint32(0); i < n; i++ {
+		if _, err := r.u32(); err != nil {
+			return "", err
+		}
+		t, err := r.byte()
+		if err != nil {
+			return "", err
+		}
+		if isFloatType(t) {
+			return fmt.Sprintf("%s local", valTypeName(t)), nil
+		}
+	}
+	for !r.done() {
+		op, err := findFloatInExpr(r)
+		if err != nil || op != "" {
+			return op, err
+		}
+	}
+	return "", nil
+}
+
+// findFloatInExpr decodes the instructions up to and including the next end instruction.
+// Nested blocks are decoded in the same pass as their immediates do not differ from other instructions.
+func findFloatInExpr(r *wasmReader) (string, error) {
+	for {
+		op, err := r.byte()
+		if err != nil {
+			return "", err
+		}
+		if name, ok := floatOpcodes[op]; ok {
+			return name, nil
+		}
+		switch {
+		case op == 0x0B: // end
+			return "", nil
+		case op == 0x02 || op == 0x03 || op == 0x04: // block, loop, if
+			t, err := r.byte()
+			if err != nil {
+				return "", err
+			}
+			if isFloatType(t) {
+				return fmt.Sprintf("%s block result", valTypeName(t)), nil
+			}
+			if t != 0x40 && !isValType(t) {
+				// type index encoded as s33
+				r.pos--
+				if err := r.skipLEB(); err != nil {
+					return "", err
+				}
+			}
+		case op == 0x0C || op == 0x0D || op == 0x10 || op == 0xD2 || (op >= 0x20 && op <= 0x26):
+			// br, br_if, call, ref.func, local/global/table get and set
+			if err := r.skipLEB(); err != nil {
+				return "", err
+			}
+		case op == 0x0E: // br_table
+			n, err := r.u32()
+			if err != nil {
+				return "", err
+			}
+			for i := uint32(0); i <= n; i++ {
+				if err := r.skipLEB(); err != nil {
+					return "", err
+				}
+			}
+		case op == 0x11: // call_indirect
+			if err := r.skipLEB(); err != nil {
+				return "", err
+			}
+			if err := r.skipLEB(); err != nil {
+				return "", err
+			}
+		case op == 0x1C: // select with types
+			types, err := r.vecBytes()
+			if err != nil {
+				return "", err
+			}
+			for _, t := range types {
+				if isFloatType(t) {
+					return fmt.Sprintf("%s select", valTypeName(t)), nil
+				}
+			}
+		case op >= 0x28 && op <= 0x3E: // memory load and store with align and offset
+			if err := r.skipLEB(); err != nil {
+				return "", err
+			}
+			if err := r.skipLEB(); err != nil {
+				return "", err
+			}
+		case op == 0x3F || op == 0x40 || op == 0xD0: // memory.size, memory.grow, ref.null
+			if _, err := r.byte(); err != nil {
+				return "", err
+			}
+		case op == 0x41 || op == 0x42: // i32.const, i64.const
+			if err := r.skipLEB(); err != nil {
+				return "", err
+			}
+		case op == 0xFC:
+			name, err := r.skipPrefixed()
+			if err != nil || name != "" {
+				return name, err
+			}
+		case op == 0xFD:
+			name, err := r.skipSIMD()
+			if err != nil || name != "" {
+				return name, err
+			}
+		case op <= 0x01 || op == 0x05 || op == 0x0F || op == 0x1A || op == 0x1B || (op >= 0x45 && op <= 0xC4) || op == 0xD1:
+			// no immediates
+		default:
+			return "", fmt.Errorf("unsupported opcode %#x", op)
+		}
+	}
+}
+
+// skipPrefixed decodes a 0xFC prefixed instruction and returns its name when it operates on floats.
+func (r *wasmReader) skipPrefixed() (string, error) {
+	sub, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	var immediates int
+	switch {
+	case sub < uint32(len(saturatingTruncOpcodes)):
+		return saturatingTruncOpcodes[sub], nil
+	case sub == 8 || sub == 10 || sub == 12 || sub == 14: // memory.init, memory.copy, table.init, table.copy
+		immediates = 2
+	case sub <= 17:
+		immediates = 1
+	default:
+		return "", fmt.Errorf("unsupported opcode 0xfc %d", sub)
+	}
+	for i := 0; i < immediates; i++ {
+		if err := r.skipLEB(); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// skipSIMD decodes a 0xFD prefixed vector instruction and returns its name when it operates on float lanes.
+// See https://webassembly.github.io/spec/core/binary/instructions.html#vector-instructions
+func (r *wasmReader) skipSIMD() (string, error) {
+	sub, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	if sub > 0x113 {
+		return "", fmt.Errorf("unsupported opcode 0xfd %d", sub)
+	}
+	if isFloatSIMDOpcode(sub) {
+		return fmt.Sprintf("f32x4 or f64x2 instruction 0xfd %d", sub), nil
+	}
+	switch {
+	case sub <= 0x0B || sub == 0x5C || sub == 0x5D: // loads and stores with align and offset
+		if err := r.skipLEB(); err != nil {
+			return "", err
+		}
+		return "", r.skipLEB()
+	case sub >= 0x54 && sub <= 0x5B: // lane loads and stores with align, offset and lane index
+		if err := r.skipLEB(); err != nil {
+			return "", err
+		}
+		if err := r.skipLEB(); err != nil {
+			return "", err
+		}
+		_, err := r.byte()
+		return "", err
+	case sub == 0x0C || sub == 0x0D: // v128.const, i8x16.shuffle
+		_, err := r.bytes(16)
+		return "", err
+	case sub >= 0x15 && sub <= 0x22: // extract and replace lane
+		_, err := r.byte()
+		return "", err
+	}
+	return "", nil
+}
+
+// isFloatSIMDOpcode returns true for the vector instructions that operate on f32x4 or f64x2 lanes,
+// including the conversions from and to integer lanes and the relaxed simd float instructions.
+func isFloatSIMDOpcode(sub uint32) bool {
+	switch {
+	case sub == 0x13 || sub == 0x14: // splat
+	case sub >= 0x1F && sub <= 0x22: // extract and replace lane
+	case sub >= 0x41 && sub <= 0x4C: // comparisons
+	case sub == 0x5E || sub == 0x5F: // demote and promote
+	case sub >= 0x67 && sub <= 0x6A, sub == 0x74, sub == 0x75, sub == 0x7A, sub == 0x94: // rounding
+	case sub >= 0xE0 && sub <= 0xFF: // arithmetic and conversions
+	case sub >= 0x101 && sub <= 0x108, sub >= 0x10D && sub <= 0x110: // relaxed simd
+	default:
+		return false
+	}
+	return true
+}
+
+func isFloatType(t byte) bool {
+	return t == valTypeF32 || t == valTypeF64
+}
+
+func isValType(t byte) bool {
+	return t == 0x7F || t == 0x7E || isFloatType(t) || t == 0x7B || t == 0x70 || t == 0x6F
+}
+
+func valTypeName(t byte) string {
+	if t == valTypeF32 {
+		return "f32"
+	}
+	return "f64"
+}
+
+var errUnexpectedEOF = errors.New("unexpected end of wasm binary")
+
+// wasmReader decodes the wasm binary format
+type wasmReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *wasmReader) done() bool {
+	return r.pos >= len(r.b)
+}
+
+func (r *wasmReader) byte() (byte, error) {
+	if r.done() {
+		return 0, errUnexpectedEOF
+	}
+	r.pos++
+	return r.b[r.pos-1], nil
+}
+
+func (r *wasmReader) bytes(n uint32) ([]byte, error) {
+	if uint64(len(r.b)-r.pos) < uint64(n) {
+		return nil, errUnexpectedEOF
+	}
+	r.pos += int(n)
+	return r.b[r.pos-int(n) : r.pos], nil
+}
+
+func (r *wasmReader) vecBytes() ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	return r.bytes(n)
+}
+
+// u32 decodes an unsigned LEB128 encoded integer
+func (r *wasmReader) u32() (uint32, error) {
+	var result uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		result |= uint32(b&0x7F) << shift
+		if b&0x80 == 0 {
+			return result, nil
+		}
+	}
+	return 0, errors.New("invalid leb128 integer")
+}
+
+// skipLEB skips a signed or unsigned LEB128 encoded integer of up to 64 bits
+func (r *wasmReader) skipLEB() error {
+	for i := 0; i < 10; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+	return errors.New("invalid leb128 integer")
+}
+
+func (r *wasmReader) skipLimits() error {
+	flags, err := r.byte()
+	if err != nil {
+		return err
+	}
+	if err := r.skipLEB(); err != nil {
+		return err
+	}
+	if flags&0x01 != 0 {
+		return r.skipLEB()
+	}
+	return nil
+}
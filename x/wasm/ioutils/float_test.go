@@ -0,0 +1,79 @@
+package ioutils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFloatUsage(t *testing.T) {
+	wasmCode, someRandomStr, gzipData, err := GetTestData()
+	require.NoError(t, err)
+	floatCode, err := os.ReadFile("../keeper/testdata/float.wasm")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src    []byte
+		exp    string
+		expErr bool
+	}{
+		"no floats": {
+			src: wasmCode,
+		},
+		"float instruction": {
+			src: floatCode,
+			exp: "f32.const in function 3",
+		},
+		"simd instruction": {
+			src: wasmModule(0xFD, 0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x1A),
+		},
+		"simd lane load": {
+			src: wasmModule(0x41, 0, 0xFD, 0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xFD, 0x54, 0, 0, 3, 0x1A),
+		},
+		"simd float instruction": {
+			src: wasmModule(0xFD, 0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xFD, 0xE0, 0x01, 0x1A),
+			exp: "f32x4 or f64x2 instruction 0xfd 224 in function 0",
+		},
+		"bulk memory instructions": {
+			src: wasmModule(0x41, 0, 0x41, 0, 0x41, 0, 0xFC, 0x0B, 0, 0x41, 0, 0x41, 0, 0x41, 0, 0xFC, 0x0A, 0, 0),
+		},
+		"truncated": {
+			src:    floatCode[:len(floatCode)-4],
+			expErr: true,
+		},
+		"compressed": {
+			src:    gzipData,
+			expErr: true,
+		},
+		"not wasm": {
+			src:    someRandomStr,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := FindFloatUsage(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+// wasmModule builds a module with a memory and a single function with the given instructions
+func wasmModule(instructions ...byte) []byte {
+	body := append(append([]byte{0}, instructions...), 0x0B)
+	module := []byte{
+		0x00, 0x61, 0x73, 0x6D, 0x01, 0x00, 0x00, 0x00,
+		1, 4, 1, 0x60, 0, 0, // type () -> ()
+		3, 2, 1, 0, // function of type 0
+		5, 3, 1, 0, 1, // memory with 1 page
+	}
+	module = append(module, 10, byte(len(body)+2), 1, byte(len(body)))
+	return append(module, body...)
+}
@@ -6,10 +6,33 @@ import (
 	"errors"
 	"io"
 
+	"github.com/klauspost/compress/zstd"
+
 	errorsmod "cosmossdk.io/errors"
 )
 
-var errLimit = errors.New("exceeds limit")
+var (
+	errLimit              = errors.New("exceeds limit")
+	errUnknownCompression = errors.New("unknown compression")
+)
+
+// Decompress unpacks a gzip or zstd compressed source. An uncompressed wasm source is returned as is.
+// Any other input fails with an unknown compression error.
+func Decompress(src []byte, limit int64) ([]byte, error) {
+	switch {
+	case IsGzip(src):
+		return Uncompress(src, limit)
+	case IsZstd(src):
+		return UncompressZstd(src, limit)
+	case IsWasm(src):
+		if int64(len(src)) > limit {
+			return nil, errorsmod.Wrapf(errLimit, "max %d bytes", limit)
+		}
+		return src, nil
+	default:
+		return nil, errorsmod.Wrap(errUnknownCompression, "expected wasm, gzip or zstd")
+	}
+}
 
 // Uncompress expects a valid gzip source to unpack or fails. See IsGzip
 func Uncompress(gzipSrc []byte, limit int64) ([]byte, error) {
@@ -29,6 +52,27 @@ func Uncompress(gzipSrc []byte, limit int64) ([]byte, error) {
 	return bz, err
 }
 
+// UncompressZstd expects a valid zstd source to unpack or fails. See IsZstd
+// The decoded size is capped by the limit while streaming, so that a small archive can not expand without bounds.
+func UncompressZstd(zstdSrc []byte, limit int64) ([]byte, error) {
+	if int64(len(zstdSrc)) > limit {
+		return nil, errorsmod.Wrapf(errLimit, "max %d bytes", limit)
+	}
+	zr, err := zstd.NewReader(bytes.NewReader(zstdSrc),
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(uint64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	bz, err := io.ReadAll(LimitReader(zr, limit))
+	if errors.Is(err, errLimit) || errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		return nil, errorsmod.Wrapf(errLimit, "max %d bytes", limit)
+	}
+	return bz, err
+}
+
 // LimitReader returns a Reader that reads from r
 // but stops with "limit error" after n bytes.
 // The underlying implementation is a *io.LimitedReader.
@@ -68,6 +68,117 @@ func TestUncompress(t *testing.T) {
 	}
 }
 
+func TestUncompressZstd(t *testing.T) {
+	wasmRaw, err := os.ReadFile("../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	wasmZstd, err := ZstdIt(wasmRaw)
+	require.NoError(t, err)
+
+	const maxSize = 400_000
+
+	specs := map[string]struct {
+		src       []byte
+		expError  error
+		expResult []byte
+	}{
+		"handle wasm compressed": {
+			src:       wasmZstd,
+			expResult: wasmRaw,
+		},
+		"handle zstd identifier only": {
+			src:      zstdIdent,
+			expError: io.ErrUnexpectedEOF,
+		},
+		"handle incomplete zstd": {
+			src:      wasmZstd[:len(wasmZstd)-5],
+			expError: io.ErrUnexpectedEOF,
+		},
+		"handle limit zstd output": {
+			src:       asZstd(bytes.Repeat([]byte{0x1}, maxSize-1)),
+			expResult: bytes.Repeat([]byte{0x1}, maxSize-1),
+		},
+		"handle big zstd output": {
+			src:      asZstd(bytes.Repeat([]byte{0x1}, maxSize+1)),
+			expError: errLimit,
+		},
+		"handle decompression bomb": {
+			src:      asZstd(make([]byte, 100*maxSize)),
+			expError: errLimit,
+		},
+		"handle big zstd archive": {
+			src:      asZstd(rand.Bytes(2 * maxSize)),
+			expError: errLimit,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			r, err := UncompressZstd(spec.src, maxSize)
+			require.True(t, errors.Is(err, spec.expError), "exp %v got %+v", spec.expError, err)
+			if spec.expError != nil {
+				return
+			}
+			assert.Equal(t, spec.expResult, r)
+		})
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	wasmRaw, err := os.ReadFile("../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src       []byte
+		limit     int64
+		expError  error
+		expResult []byte
+	}{
+		"gzip": {
+			src:       asGzip(wasmRaw),
+			limit:     int64(len(wasmRaw)) + 1,
+			expResult: wasmRaw,
+		},
+		"zstd": {
+			src:       asZstd(wasmRaw),
+			limit:     int64(len(wasmRaw)) + 1,
+			expResult: wasmRaw,
+		},
+		"wasm": {
+			src:       wasmRaw,
+			limit:     int64(len(wasmRaw)),
+			expResult: wasmRaw,
+		},
+		"wasm exceeds limit": {
+			src:      wasmRaw,
+			limit:    int64(len(wasmRaw)) - 1,
+			expError: errLimit,
+		},
+		"zstd exceeds limit": {
+			src:      asZstd(wasmRaw),
+			limit:    int64(len(wasmRaw)) - 1,
+			expError: errLimit,
+		},
+		"unknown compression": {
+			src:      []byte("BZh91AY&SY"),
+			limit:    int64(len(wasmRaw)),
+			expError: errUnknownCompression,
+		},
+		"empty": {
+			limit:    int64(len(wasmRaw)),
+			expError: errUnknownCompression,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			r, err := Decompress(spec.src, spec.limit)
+			require.True(t, errors.Is(err, spec.expError), "exp %v got %+v", spec.expError, err)
+			if spec.expError != nil {
+				return
+			}
+			assert.Equal(t, spec.expResult, r)
+		})
+	}
+}
+
 func asGzip(src []byte) []byte {
 	var buf bytes.Buffer
 	zipper := gzip.NewWriter(&buf)
@@ -79,3 +190,11 @@ func asGzip(src []byte) []byte {
 	}
 	return buf.Bytes()
 }
+
+func asZstd(src []byte) []byte {
+	bz, err := ZstdIt(src)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
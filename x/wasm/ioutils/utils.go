@@ -3,6 +3,8 @@ package ioutils
 import (
 	"bytes"
 	"compress/gzip"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 // Note: []byte can never be const as they are inherently mutable
@@ -12,6 +14,10 @@ var (
 	// and https://github.com/golang/go/blob/master/src/net/http/sniff.go#L186
 	gzipIdent = []byte("\x1F\x8B\x08")
 
+	// magic number to identify a zstd frame.
+	// See https://www.rfc-editor.org/rfc/rfc8878#section-3.1.1
+	zstdIdent = []byte("\x28\xB5\x2F\xFD")
+
 	// magic number for Wasm is "\0asm"
 	// See https://webassembly.github.io/spec/core/binary/modules.html#binary-module
 	wasmIdent = []byte("\x00\x61\x73\x6D")
@@ -22,9 +28,19 @@ func IsGzip(input []byte) bool {
 	return len(input) >= 3 && bytes.Equal(gzipIdent, input[0:3])
 }
 
+// IsZstd checks if the file contents are zstd compressed
+func IsZstd(input []byte) bool {
+	return len(input) >= 4 && bytes.Equal(zstdIdent, input[0:4])
+}
+
+// IsCompressed checks if the file contents are compressed with a supported format
+func IsCompressed(input []byte) bool {
+	return IsGzip(input) || IsZstd(input)
+}
+
 // IsWasm checks if the file contents are of wasm binary
 func IsWasm(input []byte) bool {
-	return bytes.Equal(input[:4], wasmIdent)
+	return len(input) >= 4 && bytes.Equal(input[:4], wasmIdent)
 }
 
 // GzipIt compresses the input ([]byte)
@@ -43,3 +59,13 @@ func GzipIt(input []byte) ([]byte, error) {
 
 	return b.Bytes(), nil
 }
+
+// ZstdIt compresses the input ([]byte) into a single zstd frame
+func ZstdIt(input []byte) ([]byte, error) {
+	w, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	return w.EncodeAll(input, nil), nil
+}
@@ -46,6 +46,20 @@ func TestIsGzip(t *testing.T) {
 	require.True(t, IsGzip(gzipData))
 }
 
+func TestIsZstd(t *testing.T) {
+	wasmCode, someRandomStr, gzipData, err := GetTestData()
+	require.NoError(t, err)
+	zstdData, err := ZstdIt(wasmCode)
+	require.NoError(t, err)
+
+	require.False(t, IsZstd(wasmCode))
+	require.False(t, IsZstd(someRandomStr))
+	require.False(t, IsZstd(gzipData))
+	require.False(t, IsZstd(nil))
+	require.True(t, IsZstd(zstdData[0:4]))
+	require.True(t, IsZstd(zstdData))
+}
+
 func TestGzipIt(t *testing.T) {
 	wasmCode, someRandomStr, _, err := GetTestData()
 	originalGzipData := []byte{
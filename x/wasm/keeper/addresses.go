@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	errorsmod "cosmossdk.io/errors"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
 
@@ -14,21 +16,49 @@ import (
 // AddressGenerator abstract address generator to be used for a single contract address
 type AddressGenerator func(ctx context.Context, codeID uint64, checksum []byte) sdk.AccAddress
 
+// ContractAddrGenerator derives the addresses of new contract instances. It can be replaced via the
+// WithContractAddrGenerator keeper option, for example on chains with a non-standard address length.
+//
+// Implementations must be deterministic: the same input must return the same address on every node and
+// for every call, otherwise the nodes end up with a different app hash. The returned address must not be
+// used by any other account or contract.
+type ContractAddrGenerator interface {
+	// ClassicAddress returns the address for the given code id and the instance id sequence value
+	ClassicAddress(codeID, instanceID uint64) sdk.AccAddress
+	// PredictableAddress returns the address for instantiate2. The init msg is empty when it is not fixed.
+	PredictableAddress(checksum []byte, creator sdk.AccAddress, salt, initMsg types.RawContractMessage) sdk.AccAddress
+}
+
+var _ ContractAddrGenerator = DefaultContractAddrGenerator{}
+
+// DefaultContractAddrGenerator is the default ContractAddrGenerator that builds addresses with len = types.ContractAddrLen
+type DefaultContractAddrGenerator struct{}
+
+// ClassicAddress builds the address via BuildContractAddressClassic
+func (DefaultContractAddrGenerator) ClassicAddress(codeID, instanceID uint64) sdk.AccAddress {
+	return BuildContractAddressClassic(codeID, instanceID)
+}
+
+// PredictableAddress builds the address via BuildContractAddressPredictable
+func (DefaultContractAddrGenerator) PredictableAddress(checksum []byte, creator sdk.AccAddress, salt, initMsg types.RawContractMessage) sdk.AccAddress {
+	return BuildContractAddressPredictable(checksum, creator, salt, initMsg)
+}
+
 // ClassicAddressGenerator generates a contract address using codeID and instanceID sequence
 func (k Keeper) ClassicAddressGenerator() AddressGenerator {
 	return func(ctx context.Context, codeID uint64, _ []byte) sdk.AccAddress {
 		instanceID := k.mustAutoIncrementID(ctx, types.KeySequenceInstanceID)
-		return BuildContractAddressClassic(codeID, instanceID)
+		return k.contractAddrGenerator.ClassicAddress(codeID, instanceID)
 	}
 }
 
 // PredictableAddressGenerator generates a predictable contract address
-func PredictableAddressGenerator(creator sdk.AccAddress, salt, msg []byte, fixMsg bool) AddressGenerator {
+func (k Keeper) PredictableAddressGenerator(creator sdk.AccAddress, salt, msg []byte, fixMsg bool) AddressGenerator {
 	return func(_ context.Context, _ uint64, checksum []byte) sdk.AccAddress {
 		if !fixMsg { // clear msg to not be included in the address generation
 			msg = []byte{}
 		}
-		return BuildContractAddressPredictable(checksum, creator, salt, msg)
+		return k.contractAddrGenerator.PredictableAddress(checksum, creator, salt, msg)
 	}
 }
 
@@ -75,3 +105,42 @@ func BuildContractAddressPredictable(checksum []byte, creator sdk.AccAddress, sa
 func UInt64LengthPrefix(bz []byte) []byte {
 	return append(sdk.Uint64ToBigEndian(uint64(len(bz))), bz...)
 }
+
+// subAccountDomain separates the sub-account address space from the instantiate2 contract addresses. Its length
+// differs from the checksum length that instantiate2 keys start with, so that the keys can never collide.
+var subAccountDomain = []byte("sub_account")
+
+// BuildSubAccountAddress generates the address of a contract sub-account with len = types.ContractAddrLen using the
+// Cosmos SDK address.Module function, like BuildContractAddressPredictable.
+// Internally a key is built containing:
+// (len("sub_account") | "sub_account" | len(contract_address) | contract_address | len(salt) | salt).
+//
+// All method parameter values must be valid and not nil.
+func BuildSubAccountAddress(contractAddr sdk.AccAddress, salt []byte) sdk.AccAddress {
+	if err := sdk.VerifyAddressFormat(contractAddr); err != nil {
+		panic(fmt.Sprintf("contract: %s", err))
+	}
+	if err := types.ValidateSalt(salt); err != nil {
+		panic(fmt.Sprintf("salt: %s", err))
+	}
+	domain := UInt64LengthPrefix(subAccountDomain)
+	contract := UInt64LengthPrefix(contractAddr)
+	salt = UInt64LengthPrefix(salt)
+	key := make([]byte, len(domain)+len(contract)+len(salt))
+	copy(key[0:], domain)
+	copy(key[len(domain):], contract)
+	copy(key[len(domain)+len(contract):], salt)
+	return address.Module(types.ModuleName, key)[:types.ContractAddrLen]
+}
+
+// DeriveSubAccount returns the deterministic sub-account address for the contract and salt. The contract must exist.
+// The sub-account is a plain address: funds can be sent to it but the contract can not yet move them out.
+func (k Keeper) DeriveSubAccount(ctx context.Context, contractAddr sdk.AccAddress, salt []byte) (sdk.AccAddress, error) {
+	if err := types.ValidateSalt(salt); err != nil {
+		return nil, errorsmod.Wrap(err, "salt")
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr)
+	}
+	return BuildSubAccountAddress(contractAddr, salt), nil
+}
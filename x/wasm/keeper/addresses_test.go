@@ -507,3 +507,98 @@ const goldenMasterPredictableContractAddr = `[
   }
 ]
 `
+
+func TestContractAddrGenerator(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithContractAddrGenerator(shortContractAddrGenerator{}))
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	initMsg := mustMarshal(t, HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)})
+	mySalt := []byte("my salt")
+
+	specs := map[string]struct {
+		instantiate func(ctx sdk.Context) (sdk.AccAddress, error)
+		expAddr     sdk.AccAddress
+	}{
+		"classic": {
+			instantiate: func(ctx sdk.Context) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, initMsg, "classic", nil)
+				return addr, err
+			},
+			expAddr: shortContractAddrGenerator{}.ClassicAddress(example.CodeID, 1),
+		},
+		"predictable": {
+			instantiate: func(ctx sdk.Context) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate2(ctx, example.CodeID, example.CreatorAddr, nil, initMsg, "predictable", nil, mySalt, false)
+				return addr, err
+			},
+			expAddr: shortContractAddrGenerator{}.PredictableAddress(example.Checksum, example.CreatorAddr, mySalt, []byte{}),
+		},
+		"predictable with fixed msg": {
+			instantiate: func(ctx sdk.Context) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate2(ctx, example.CodeID, example.CreatorAddr, nil, initMsg, "predictable", nil, mySalt, true)
+				return addr, err
+			},
+			expAddr: shortContractAddrGenerator{}.PredictableAddress(example.Checksum, example.CreatorAddr, mySalt, initMsg),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// instantiate on independent branches of the same state
+			for i := 0; i < 2; i++ {
+				ctx, _ := parentCtx.CacheContext()
+
+				// when
+				gotAddr, gotErr := spec.instantiate(ctx)
+
+				// then
+				require.NoError(t, gotErr)
+				assert.Equal(t, spec.expAddr, gotAddr)
+				assert.Len(t, gotAddr, 20)
+				assert.NotNil(t, keepers.WasmKeeper.GetContractInfo(ctx, gotAddr))
+			}
+		})
+	}
+
+	// and the build address query uses the same generator
+	gotRsp, err := Querier(keepers.WasmKeeper).BuildAddress(parentCtx, &types.QueryBuildAddressRequest{
+		CodeHash:       hex.EncodeToString(example.Checksum),
+		CreatorAddress: example.CreatorAddr.String(),
+		Salt:           hex.EncodeToString(mySalt),
+		InitArgs:       initMsg,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, shortContractAddrGenerator{}.PredictableAddress(example.Checksum, example.CreatorAddr, mySalt, initMsg).String(), gotRsp.Address)
+	// and so does the analyze code query
+	gotAnalyzeRsp, err := Querier(keepers.WasmKeeper).AnalyzeCode(parentCtx, &types.QueryAnalyzeCodeRequest{
+		CodeId:         example.CodeID,
+		CreatorAddress: example.CreatorAddr.String(),
+		Salt:           hex.EncodeToString(mySalt),
+		InitArgs:       initMsg,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, shortContractAddrGenerator{}.PredictableAddress(example.Checksum, example.CreatorAddr, mySalt, initMsg).String(), gotAnalyzeRsp.PredictedAddress)
+}
+
+// shortContractAddrGenerator builds 20 byte contract addresses
+type shortContractAddrGenerator struct{}
+
+func (shortContractAddrGenerator) ClassicAddress(codeID, instanceID uint64) sdk.AccAddress {
+	return BuildContractAddressClassic(codeID, instanceID)[:20]
+}
+
+func (shortContractAddrGenerator) PredictableAddress(checksum []byte, creator sdk.AccAddress, salt, initMsg types.RawContractMessage) sdk.AccAddress {
+	return BuildContractAddressPredictable(checksum, creator, salt, initMsg)[:20]
+}
+
+func TestBuildSubAccountAddress(t *testing.T) {
+	contract := BuildContractAddressClassic(1, 1)
+	otherContract := BuildContractAddressClassic(1, 2)
+
+	got := BuildSubAccountAddress(contract, []byte("alice"))
+
+	assert.Len(t, got, types.ContractAddrLen)
+	assert.Equal(t, got, BuildSubAccountAddress(contract, []byte("alice")))
+	assert.NotEqual(t, got, BuildSubAccountAddress(contract, []byte("bob")))
+	assert.NotEqual(t, got, BuildSubAccountAddress(otherContract, []byte("alice")))
+	assert.Panics(t, func() { BuildSubAccountAddress(contract, nil) })
+	assert.Panics(t, func() { BuildSubAccountAddress(nil, []byte("alice")) })
+}
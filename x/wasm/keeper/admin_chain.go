@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MaxAdminChainDepth is the max number of admins that the ContractAdminChain query resolves
+const MaxAdminChainDepth = 10
+
+// ResolveAdminChain walks the admin relationship of a contract up to maxDepth admins. The chain starts with the admin
+// of the contract and continues with the admin of each admin that is a contract itself. The returned bool is true when
+// the last admin in the chain is not a contract, so that it is the account that controls the migrations. It is false
+// when the chain ends with a contract without admin or the depth is reached, which also stops admin cycles.
+func (k Keeper) ResolveAdminChain(ctx context.Context, contractAddr sdk.AccAddress, maxDepth uint32) ([]sdk.AccAddress, bool, error) {
+	if maxDepth == 0 {
+		return nil, false, errorsmod.Wrap(types.ErrInvalid, "max depth must not be 0")
+	}
+	info := k.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return nil, false, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	var chain []sdk.AccAddress
+	for info.Admin != "" {
+		admin, err := sdk.AccAddressFromBech32(info.Admin)
+		if err != nil {
+			return nil, false, errorsmod.Wrap(err, "admin")
+		}
+		chain = append(chain, admin)
+		if info = k.GetContractInfo(ctx, admin); info == nil {
+			return chain, true, nil
+		}
+		if len(chain) >= int(maxDepth) {
+			break
+		}
+	}
+	return chain, false, nil
+}
@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestResolveAdminChain(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreRandomContract(t, ctx, keepers, &m)
+	instantiate := func(admin sdk.AccAddress) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, admin, []byte(`{}`), "testing", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	human := RandomAccountAddress(t)
+
+	direct := instantiate(human)
+	nested := instantiate(direct)
+	withoutAdmin := instantiate(nil)
+	adminWithoutAdmin := instantiate(withoutAdmin)
+	// cycle1 and cycle2 are the admins of each other
+	cycle1 := instantiate(example.CreatorAddr)
+	cycle2 := instantiate(cycle1)
+	require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(ctx, cycle1, example.CreatorAddr, cycle2))
+
+	specs := map[string]struct {
+		contract        sdk.AccAddress
+		maxDepth        uint32
+		expChain        []sdk.AccAddress
+		expTopIsAccount bool
+		expErr          error
+	}{
+		"direct admin": {
+			contract:        direct,
+			maxDepth:        10,
+			expChain:        []sdk.AccAddress{human},
+			expTopIsAccount: true,
+		},
+		"nested admin": {
+			contract:        nested,
+			maxDepth:        10,
+			expChain:        []sdk.AccAddress{direct, human},
+			expTopIsAccount: true,
+		},
+		"nested admin exceeds depth": {
+			contract: nested,
+			maxDepth: 1,
+			expChain: []sdk.AccAddress{direct},
+		},
+		"no admin": {
+			contract: withoutAdmin,
+			maxDepth: 10,
+		},
+		"admin contract without admin": {
+			contract: adminWithoutAdmin,
+			maxDepth: 10,
+			expChain: []sdk.AccAddress{withoutAdmin},
+		},
+		"cyclic admins": {
+			contract: cycle1,
+			maxDepth: 3,
+			expChain: []sdk.AccAddress{cycle2, cycle1, cycle2},
+		},
+		"unknown contract": {
+			contract: RandomAccountAddress(t),
+			maxDepth: 10,
+			expErr:   types.ErrNoSuchContractFn(""),
+		},
+		"zero depth": {
+			contract: direct,
+			expErr:   types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotChain, gotTopIsAccount, gotErr := k.ResolveAdminChain(ctx, spec.contract, spec.maxDepth)
+			if spec.expErr != nil {
+				assert.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expChain, gotChain)
+			assert.Equal(t, spec.expTopIsAccount, gotTopIsAccount)
+		})
+	}
+}
+
+func TestQueryContractAdminChain(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := StoreRandomContract(t, ctx, keepers, &m)
+	instantiate := func(admin sdk.AccAddress) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, admin, []byte(`{}`), "testing", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	human := RandomAccountAddress(t)
+	direct := instantiate(human)
+	nested := instantiate(direct)
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		src    *types.QueryContractAdminChainRequest
+		exp    *types.QueryContractAdminChainResponse
+		expErr bool
+	}{
+		"default depth": {
+			src: &types.QueryContractAdminChainRequest{Address: nested.String()},
+			exp: &types.QueryContractAdminChainResponse{Admins: []string{direct.String(), human.String()}, TopIsAccount: true},
+		},
+		"custom depth": {
+			src: &types.QueryContractAdminChainRequest{Address: nested.String(), MaxDepth: 1},
+			exp: &types.QueryContractAdminChainResponse{Admins: []string{direct.String()}},
+		},
+		"depth exceeds max": {
+			src:    &types.QueryContractAdminChainRequest{Address: nested.String(), MaxDepth: MaxAdminChainDepth + 1},
+			expErr: true,
+		},
+		"invalid address": {
+			src:    &types.QueryContractAdminChainRequest{Address: "invalid"},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractAdminChain(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	storetypes "cosmossdk.io/store/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -64,7 +65,9 @@ func decodeHeightCounter(bz []byte) (int64, uint32) {
 	return int64(sdk.BigEndianToUint64(bz[0:8])), binary.BigEndian.Uint32(bz[8:])
 }
 
-// LimitSimulationGasDecorator ante decorator to limit gas in simulation calls
+// LimitSimulationGasDecorator ante decorator to limit gas in simulation calls.
+// The limit applies to the whole tx so that store code, instantiate, execute and migrate
+// messages can not exceed it. Simulations that run out of gas are aborted with an out of gas error.
 type LimitSimulationGasDecorator struct {
 	gasLimit *storetypes.Gas
 }
@@ -106,6 +109,52 @@ func (d LimitSimulationGasDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simu
 	return next(ctx, tx, simulate)
 }
 
+// LimitWasmSimulationGasDecorator ante decorator to limit the gas in simulations of txs with store code,
+// instantiate, execute or migrate messages to a ceiling, so that public nodes can not be stressed with
+// wasm simulations that request enormous gas. It complements the LimitSimulationGasDecorator that limits
+// all simulations to the node or block gas limit and must be added after it.
+type LimitWasmSimulationGasDecorator struct {
+	gasLimit storetypes.Gas
+}
+
+// NewLimitWasmSimulationGasDecorator constructor
+func NewLimitWasmSimulationGasDecorator(gasLimit storetypes.Gas) *LimitWasmSimulationGasDecorator {
+	if gasLimit == 0 {
+		panic("gas limit must not be zero")
+	}
+	return &LimitWasmSimulationGasDecorator{gasLimit: gasLimit}
+}
+
+// AnteHandle rejects simulations of wasm txs that request more gas than the ceiling and aborts the ones that
+// consume more with an out of gas error. Only simulations in check tx are limited.
+// Different values on nodes are not consensus breaking as they affect only simulations.
+func (d LimitWasmSimulationGasDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if !simulate || !ctx.IsCheckTx() || !hasWasmCallMsg(tx) {
+		return next(ctx, tx, simulate)
+	}
+	if feeTx, ok := tx.(sdk.FeeTx); ok && feeTx.GetGas() > d.gasLimit {
+		return ctx, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "simulation gas %d exceeds the wasm simulation gas limit %d", feeTx.GetGas(), d.gasLimit)
+	}
+	if ctx.GasMeter().Limit() <= d.gasLimit {
+		return next(ctx, tx, simulate)
+	}
+	gasMeter := storetypes.NewGasMeter(d.gasLimit)
+	gasMeter.ConsumeGas(ctx.GasMeter().GasConsumed(), "ante")
+	return next(ctx.WithGasMeter(gasMeter), tx, simulate)
+}
+
+// hasWasmCallMsg returns true when the tx contains a message that stores code or calls a contract
+func hasWasmCallMsg(tx sdk.Tx) bool {
+	for _, msg := range tx.GetMsgs() {
+		switch msg.(type) {
+		case *types.MsgStoreCode, *types.MsgStoreCodes, *types.MsgInstantiateContract, *types.MsgInstantiateContract2,
+			*types.MsgExecuteContract, *types.MsgExecuteContracts, *types.MsgMigrateContract, *types.MsgMigrateContractAndExecute:
+			return true
+		}
+	}
+	return false
+}
+
 // GasRegisterDecorator ante decorator to store gas register in the context
 type GasRegisterDecorator struct {
 	gasRegister types.GasRegister
@@ -134,3 +183,34 @@ func (d TxContractsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bo
 	txContracts := types.NewTxContracts()
 	return next(types.WithTxContracts(ctx, txContracts), tx, simulate)
 }
+
+// TraceSequenceDecorator ante handler to count the top level contract calls of a tx so that each of them gets a
+// distinct trace id in the events. See `types.AttributeKeyTraceID`.
+type TraceSequenceDecorator struct{}
+
+// NewTraceSequenceDecorator constructor
+func NewTraceSequenceDecorator() *TraceSequenceDecorator {
+	return &TraceSequenceDecorator{}
+}
+
+// AnteHandle initializes a new TraceSequence object to the context.
+func (d TraceSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	return next(types.WithTraceSequence(ctx, types.NewTraceSequence()), tx, simulate)
+}
+
+// TxMemoDecorator ante handler to make the tx memo available to contracts.
+// The memo is truncated to MaxForwardedMemoSize bytes. See `types.TxMemo(ctx)` to read the value.
+type TxMemoDecorator struct{}
+
+// NewTxMemoDecorator constructor
+func NewTxMemoDecorator() *TxMemoDecorator {
+	return &TxMemoDecorator{}
+}
+
+// AnteHandle stores the memo of the tx in the context when the tx has one.
+func (d TxMemoDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if memoTx, ok := tx.(sdk.TxWithMemo); ok && memoTx.GetMemo() != "" {
+		ctx = types.WithTxMemo(ctx, truncateMemo(memoTx.GetMemo()))
+	}
+	return next(ctx, tx, simulate)
+}
@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -181,6 +183,97 @@ func TestLimitSimulationGasDecorator(t *testing.T) {
 	}
 }
 
+func TestLimitWasmSimulationGasDecorator(t *testing.T) {
+	const limit storetypes.Gas = 100
+	wasmTx := msgsTx{msgs: []sdk.Msg{&types.MsgExecuteContract{}}}
+	specs := map[string]struct {
+		tx         sdk.Tx
+		consumeGas storetypes.Gas
+		simulation bool
+		checkTx    bool
+		expErr     error
+		expPanic   any
+	}{
+		"within limit": {
+			tx:         wasmTx,
+			consumeGas: limit,
+			simulation: true,
+			checkTx:    true,
+		},
+		"consumes more than the limit": {
+			tx:         wasmTx,
+			consumeGas: limit + 1,
+			simulation: true,
+			checkTx:    true,
+			expPanic:   storetypes.ErrorOutOfGas{Descriptor: "testing"},
+		},
+		"requests more than the limit": {
+			tx:         msgsTx{msgs: wasmTx.msgs, gas: limit + 1},
+			simulation: true,
+			checkTx:    true,
+			expErr:     sdkerrors.ErrInvalidRequest,
+		},
+		"no wasm call msg": {
+			tx:         msgsTx{msgs: []sdk.Msg{&types.MsgUpdateAdmin{}}},
+			consumeGas: limit + 1,
+			simulation: true,
+			checkTx:    true,
+		},
+		"not a simulation": {
+			tx:         wasmTx,
+			consumeGas: limit + 1,
+			checkTx:    true,
+		},
+		"not check tx": {
+			tx:         wasmTx,
+			consumeGas: limit + 1,
+			simulation: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.
+				WithGasMeter(storetypes.NewInfiniteGasMeter()).
+				WithIsCheckTx(spec.checkTx)
+			ante := keeper.NewLimitWasmSimulationGasDecorator(limit)
+			nextAnte := consumeGasAnteHandler(spec.consumeGas)
+
+			// when
+			if spec.expPanic != nil {
+				require.PanicsWithValue(t, spec.expPanic, func() {
+					_, _ = ante.AnteHandle(ctx, spec.tx, spec.simulation, nextAnte)
+				})
+				return
+			}
+			_, gotErr := ante.AnteHandle(ctx, spec.tx, spec.simulation, nextAnte)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+	require.Panics(t, func() {
+		keeper.NewLimitWasmSimulationGasDecorator(0)
+	})
+}
+
+type msgsTx struct {
+	sdk.FeeTx
+	msgs []sdk.Msg
+	gas  uint64
+}
+
+func (m msgsTx) GetMsgs() []sdk.Msg {
+	return m.msgs
+}
+
+func (m msgsTx) GetGas() uint64 {
+	return m.gas
+}
+
 func consumeGasAnteHandler(gasToConsume storetypes.Gas) sdk.AnteHandler {
 	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
 		ctx.GasMeter().ConsumeGas(gasToConsume, "testing")
@@ -311,3 +404,79 @@ func TestTxContractsDecorator(t *testing.T) {
 		})
 	}
 }
+
+func TestTraceSequenceDecorator(t *testing.T) {
+	ms := store.NewCommitMultiStore(dbm.NewMemDB(), log.NewTestLogger(t), storemetrics.NewNoOpMetrics())
+	ctx := sdk.NewContext(ms, cmtproto.Header{Height: 100}, false, log.NewNopLogger())
+	var anyTx sdk.Tx
+	var seqs []uint32
+	next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		seq, ok := types.TraceSequenceFromContext(ctx)
+		require.True(t, ok)
+		seqs = append(seqs, seq.Next(), seq.Next())
+		return ctx, nil
+	}
+
+	// when called for two txs
+	for range 2 {
+		_, err := keeper.NewTraceSequenceDecorator().AnteHandle(ctx, anyTx, false, next)
+		require.NoError(t, err)
+	}
+
+	// then each tx starts a new sequence
+	assert.Equal(t, []uint32{0, 1, 0, 1}, seqs)
+}
+
+func TestTxMemoDecorator(t *testing.T) {
+	ms := store.NewCommitMultiStore(dbm.NewMemDB(), log.NewTestLogger(t), storemetrics.NewNoOpMetrics())
+	longMemo := strings.Repeat("a", keeper.MaxForwardedMemoSize-1) + "é"
+
+	specs := map[string]struct {
+		tx       sdk.Tx
+		expMemo  string
+		expFound bool
+	}{
+		"memo set": {
+			tx:       memoTx{memo: "my memo"},
+			expMemo:  "my memo",
+			expFound: true,
+		},
+		"memo truncated": {
+			tx:       memoTx{memo: longMemo},
+			expMemo:  strings.Repeat("a", keeper.MaxForwardedMemoSize-1),
+			expFound: true,
+		},
+		"empty memo": {
+			tx: memoTx{},
+		},
+		"tx without memo": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.NewContext(ms, cmtproto.Header{}, false, log.NewNopLogger())
+			var gotMemo string
+			var gotFound bool
+			next := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				gotMemo, gotFound = types.TxMemo(ctx)
+				return ctx, nil
+			}
+
+			// when
+			_, gotErr := keeper.NewTxMemoDecorator().AnteHandle(ctx, spec.tx, false, next)
+
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expFound, gotFound)
+			assert.Equal(t, spec.expMemo, gotMemo)
+		})
+	}
+}
+
+type memoTx struct {
+	sdk.Tx
+	memo string
+}
+
+func (m memoTx) GetMemo() string {
+	return m.memo
+}
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// AuthQueryCapability is the capability that contracts declare with `requires_auth_query` to use the auth queries.
+// It is added to the available capabilities by the WithAuthQueries option and can be disabled by governance
+// with the disabled capabilities param.
+const AuthQueryCapability = "auth_query"
+
+// AuthQuery is a custom query to read the auth state of an account.
+// It is sent by contracts as `{"auth":{"account_info":{"address":<bech32 address>}}}`.
+type AuthQuery struct {
+	AccountInfo *AccountInfoQuery `json:"account_info,omitempty"`
+}
+
+// AccountInfoQuery requests the account number, sequence and public key of an account
+type AccountInfoQuery struct {
+	Address string `json:"address"`
+}
+
+// AccountInfoResponse is the response to the AccountInfoQuery
+type AccountInfoResponse struct {
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	// PubKey is set when the account has signed a transaction before
+	PubKey *AccountPubKey `json:"pub_key,omitempty"`
+}
+
+// AccountPubKey is the public key of an account
+type AccountPubKey struct {
+	// TypeURL is the proto type of the key, for example "/cosmos.crypto.secp256k1.PubKey"
+	TypeURL string `json:"type_url"`
+	// Key are the raw key bytes
+	Key []byte `json:"key"`
+}
+
+type accountSource interface {
+	GetAccount(ctx context.Context, addr sdk.AccAddress) sdk.AccountI
+}
+
+// AuthQuerier handles AuthQuery custom queries with the account keeper. Any other custom query is passed to the
+// next custom querier.
+// Accounts that do not exist return a not found error to the contract.
+func AuthQuerier(accounts accountSource, params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			Auth *AuthQuery `json:"auth,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.Auth == nil {
+			return next(ctx, request)
+		}
+		if slices.Contains(params.GetCachedParams(ctx).DisabledCapabilities, AuthQueryCapability) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "auth queries are disabled on this chain"}
+		}
+		if msg.Auth.AccountInfo == nil {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "unknown AuthQuery variant"}
+		}
+		addr, err := sdk.AccAddressFromBech32(msg.Auth.AccountInfo.Address)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "address")
+		}
+		acc := accounts.GetAccount(ctx, addr)
+		if acc == nil {
+			return nil, types.MarkErrorDeterministic(errorsmod.Wrapf(types.ErrNotFound, "account %s", addr))
+		}
+		res := AccountInfoResponse{
+			AccountNumber: acc.GetAccountNumber(),
+			Sequence:      acc.GetSequence(),
+		}
+		if pk := acc.GetPubKey(); pk != nil {
+			res.PubKey = &AccountPubKey{TypeURL: sdk.MsgTypeURL(pk), Key: pk.Bytes()}
+		}
+		return json.Marshal(res)
+	}
+}
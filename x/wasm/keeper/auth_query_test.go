@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAuthQuerier(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ak := keepers.AccountKeeper
+
+	pubKey := secp256k1.GenPrivKey().PubKey()
+	myAddr := sdk.AccAddress(pubKey.Address())
+	myAcc := ak.NewAccountWithAddress(ctx, myAddr)
+	require.NoError(t, myAcc.SetPubKey(pubKey))
+	require.NoError(t, myAcc.SetSequence(3))
+	ak.SetAccount(ctx, myAcc)
+	noPubKeyAddr := RandomAccountAddress(t)
+	noPubKeyAcc := ak.NewAccountWithAddress(ctx, noPubKeyAddr)
+	ak.SetAccount(ctx, noPubKeyAcc)
+	moduleAcc := ak.GetModuleAccount(ctx, authtypes.FeeCollectorName)
+
+	query := func(addr string) json.RawMessage {
+		return json.RawMessage(`{"auth":{"account_info":{"address":"` + addr + `"}}}`)
+	}
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	specs := map[string]struct {
+		src            json.RawMessage
+		disabled       bool
+		exp            *AccountInfoResponse
+		expResult      []byte
+		expErr         bool
+		expNotFound    bool
+		expUnsupported bool
+	}{
+		"existing account": {
+			src: query(myAddr.String()),
+			exp: &AccountInfoResponse{
+				AccountNumber: myAcc.GetAccountNumber(),
+				Sequence:      3,
+				PubKey:        &AccountPubKey{TypeURL: "/cosmos.crypto.secp256k1.PubKey", Key: pubKey.Bytes()},
+			},
+		},
+		"account without pub key": {
+			src: query(noPubKeyAddr.String()),
+			exp: &AccountInfoResponse{AccountNumber: noPubKeyAcc.GetAccountNumber()},
+		},
+		"module account": {
+			src: query(moduleAcc.GetAddress().String()),
+			exp: &AccountInfoResponse{AccountNumber: moduleAcc.GetAccountNumber()},
+		},
+		"never seen address": {
+			src:         query(RandomAccountAddress(t).String()),
+			expNotFound: true,
+		},
+		"invalid address": {
+			src:    query("invalid"),
+			expErr: true,
+		},
+		"unknown auth query": {
+			src:            []byte(`{"auth":{"foo":{}}}`),
+			expUnsupported: true,
+		},
+		"other custom query": {
+			src:       []byte(`{"foo":{}}`),
+			expResult: []byte("next"),
+		},
+		"disabled capability": {
+			src:            query(myAddr.String()),
+			disabled:       true,
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			params := types.DefaultParams()
+			if spec.disabled {
+				params.DisabledCapabilities = []string{AuthQueryCapability}
+			}
+			q := AuthQuerier(ak, mockParamsSource(params), next)
+
+			// when
+			gotResult, gotErr := q(ctx, spec.src)
+
+			// then
+			switch {
+			case spec.expUnsupported:
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			case spec.expNotFound:
+				assert.ErrorIs(t, gotErr, types.ErrNotFound)
+				return
+			case spec.expErr:
+				assert.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			if spec.expResult != nil {
+				assert.Equal(t, spec.expResult, gotResult)
+				return
+			}
+			var got AccountInfoResponse
+			require.NoError(t, json.Unmarshal(gotResult, &got))
+			assert.Equal(t, *spec.exp, got)
+		})
+	}
+}
+
+func TestAuthQuerierNotFoundIsNotRedacted(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	addr := RandomAccountAddress(t)
+	q := AuthQuerier(keepers.AccountKeeper, mockParamsSource(types.DefaultParams()), NoCustomQuerier)
+	plugins := QueryPlugins{Custom: q}
+
+	_, err := plugins.HandleQuery(ctx, nil, wasmvmtypes.QueryRequest{
+		Custom: json.RawMessage(`{"auth":{"account_info":{"address":"` + addr.String() + `"}}}`),
+	})
+	require.Error(t, err)
+	assert.Equal(t, "account "+addr.String()+": not found", redactError(err).Error())
+}
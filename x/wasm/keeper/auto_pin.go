@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"slices"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// autoPinCode pins the code when its checksum is in the auto pin code hashes param and the code is not pinned yet
+func (k Keeper) autoPinCode(ctx context.Context, codeID uint64, checksum []byte) error {
+	if !slices.Contains(k.GetParams(ctx).AutoPinCodeHashes, hex.EncodeToString(checksum)) || k.IsPinnedCode(ctx, codeID) {
+		return nil
+	}
+	return k.pinAutoCode(ctx, codeID)
+}
+
+// pinAutoCode pins the code and records it as auto pinned, so that only this pin is removed with the checksum from
+// the auto pin code hashes param. Pins by governance are never removed by the param.
+func (k Keeper) pinAutoCode(ctx context.Context, codeID uint64) error {
+	if err := k.pinCode(ctx, codeID); err != nil {
+		return err
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetAutoPinnedCodeIndexKey(codeID), []byte{1})
+}
+
+// IsAutoPinnedCode returns true when the code was pinned by the auto pin code hashes param
+func (k Keeper) IsAutoPinnedCode(ctx context.Context, codeID uint64) bool {
+	ok, err := k.storeService.OpenKVStore(ctx).Has(types.GetAutoPinnedCodeIndexKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+// applyAutoPinCodeHashes pins all stored codes that are not pinned yet with a checksum that was added to the auto
+// pin code hashes and unpins all auto pinned codes with a checksum that was removed.
+func (k Keeper) applyAutoPinCodeHashes(ctx context.Context, oldHashes, newHashes []string) error {
+	var pin, unpin [][]byte
+	for _, h := range newHashes {
+		if !slices.Contains(oldHashes, h) {
+			bz, err := hex.DecodeString(h)
+			if err != nil {
+				return err
+			}
+			pin = append(pin, bz)
+		}
+	}
+	for _, h := range oldHashes {
+		if !slices.Contains(newHashes, h) {
+			bz, err := hex.DecodeString(h)
+			if err != nil {
+				return err
+			}
+			unpin = append(unpin, bz)
+		}
+	}
+	if len(pin) == 0 && len(unpin) == 0 {
+		return nil
+	}
+	containsHash := func(hashes [][]byte, h []byte) bool {
+		return slices.ContainsFunc(hashes, func(o []byte) bool { return bytes.Equal(o, h) })
+	}
+	var err error
+	k.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		switch {
+		case containsHash(pin, info.CodeHash) && !k.IsPinnedCode(ctx, codeID):
+			err = k.pinAutoCode(ctx, codeID)
+		case containsHash(unpin, info.CodeHash) && k.IsAutoPinnedCode(ctx, codeID):
+			err = k.unpinCode(ctx, codeID)
+		}
+		return err != nil
+	})
+	return err
+}
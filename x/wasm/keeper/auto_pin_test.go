@@ -0,0 +1,169 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAutoPinCodeOnStore(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := RandomAccountAddress(t)
+	hackatomChecksum := testdata.ChecksumHackatom
+
+	specs := map[string]struct {
+		autoPinHashes []string
+		expPinned     bool
+	}{
+		"checksum in list": {
+			autoPinHashes: []string{hackatomChecksum},
+			expPinned:     true,
+		},
+		"checksum not in list": {
+			autoPinHashes: []string{strings.Repeat("a", 64)},
+		},
+		"empty list": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			params := k.GetParams(ctx)
+			params.AutoPinCodeHashes = spec.autoPinHashes
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when
+			codeID, _, err := keepers.ContractKeeper.Create(ctx, creator, testdata.HackatomContractWasm(), nil)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expPinned, k.IsPinnedCode(ctx, codeID))
+			assert.Equal(t, spec.expPinned, hasEvent(em.Events(), types.EventTypePinCode))
+		})
+	}
+}
+
+func TestApplyAutoPinCodeHashes(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	hackatomChecksum := hex.EncodeToString(example.Checksum)
+	otherChecksum := strings.Repeat("a", 64)
+
+	specs := map[string]struct {
+		pinned    bool
+		autoPin   bool
+		oldHashes []string
+		newHashes []string
+		expPinned bool
+		expAuto   bool
+		expEvent  string
+	}{
+		"hash added": {
+			newHashes: []string{hackatomChecksum},
+			expPinned: true,
+			expAuto:   true,
+			expEvent:  types.EventTypePinCode,
+		},
+		"hash added for pinned code": {
+			pinned:    true,
+			newHashes: []string{hackatomChecksum},
+			expPinned: true,
+		},
+		"hash removed": {
+			pinned:    true,
+			autoPin:   true,
+			oldHashes: []string{hackatomChecksum},
+			expEvent:  types.EventTypeUnpinCode,
+		},
+		"hash removed for code pinned by gov": {
+			pinned:    true,
+			oldHashes: []string{hackatomChecksum},
+			expPinned: true,
+		},
+		"hash kept": {
+			pinned:    true,
+			autoPin:   true,
+			oldHashes: []string{hackatomChecksum},
+			newHashes: []string{otherChecksum, hackatomChecksum},
+			expPinned: true,
+			expAuto:   true,
+		},
+		"other hash removed": {
+			pinned:    true,
+			oldHashes: []string{otherChecksum},
+			expPinned: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			switch {
+			case spec.pinned && spec.autoPin:
+				require.NoError(t, k.pinAutoCode(ctx, example.CodeID))
+			case spec.pinned:
+				require.NoError(t, k.pinCode(ctx, example.CodeID))
+			}
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			// when
+			gotErr := k.applyAutoPinCodeHashes(ctx, spec.oldHashes, spec.newHashes)
+
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expPinned, k.IsPinnedCode(ctx, example.CodeID))
+			assert.Equal(t, spec.expAuto, k.IsAutoPinnedCode(ctx, example.CodeID))
+			if spec.expEvent == "" {
+				assert.Empty(t, em.Events())
+				return
+			}
+			assert.True(t, hasEvent(em.Events(), spec.expEvent))
+		})
+	}
+}
+
+func hasEvent(events sdk.Events, eventType string) bool {
+	for _, e := range events {
+		if e.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExportGenesisSkipsAutoPins(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	params := k.GetParams(ctx)
+	params.AutoPinCodeHashes = []string{hex.EncodeToString(example.Checksum)}
+	require.NoError(t, k.SetParams(ctx, params))
+	require.NoError(t, k.applyAutoPinCodeHashes(ctx, nil, params.AutoPinCodeHashes))
+	require.True(t, k.IsAutoPinnedCode(ctx, example.CodeID))
+
+	// when
+	genState := ExportGenesis(ctx, k)
+
+	// then
+	require.Len(t, genState.Codes, 1)
+	assert.False(t, genState.Codes[0].Pinned)
+
+	// and when a gov pin takes over
+	require.NoError(t, k.pinCode(ctx, example.CodeID))
+	genState = ExportGenesis(ctx, k)
+
+	// then
+	assert.False(t, k.IsAutoPinnedCode(ctx, example.CodeID))
+	assert.True(t, genState.Codes[0].Pinned)
+}
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// BlockBeaconQueryCapability is the capability that contracts declare with `requires_block_beacon_query`
+// to use the block beacon query. It is added to the available capabilities by the WithBlockBeaconQueries
+// option and can be disabled by governance with the disabled capabilities param.
+const BlockBeaconQueryCapability = "block_beacon_query"
+
+// blockBeaconDomain separates the block beacon from other hashes of the block hash
+var blockBeaconDomain = []byte("wasm/block_beacon")
+
+// BlockBeaconQuery is a custom query for a per block value that all nodes agree on.
+// It is sent by contracts as `{"block_beacon":{"salt":<optional base64 salt>}}`. Different salts
+// can be used to derive multiple values in the same block.
+//
+// The beacon is derived from the hash of the current block. It is deterministic but it is NOT
+// unpredictable or unbiasable: the block proposer knows it before anybody else and can influence it
+// by choosing the transactions of the block. It must not be used as a source of randomness for
+// anything of value.
+type BlockBeaconQuery struct {
+	Salt []byte `json:"salt,omitempty"`
+}
+
+// BlockBeaconResponse is the response to the BlockBeaconQuery
+type BlockBeaconResponse struct {
+	Height uint64 `json:"height"`
+	// Beacon is a 32 byte value, base64 encoded in JSON
+	Beacon []byte `json:"beacon"`
+}
+
+// BlockBeacon returns the sha256 hash of the domain, block height, block hash and salt.
+// The block hash is only set when the block is executed, so there is no beacon in CheckTx or
+// queries outside of a block. Simulations have no block hash either. They use a zero hash as a
+// placeholder so that the gas of a transaction with the query can be estimated. The beacon of a
+// simulation is not the one of the block that includes the transaction.
+func BlockBeacon(ctx sdk.Context, salt []byte) ([]byte, error) {
+	blockHash := ctx.HeaderHash()
+	if len(blockHash) == 0 {
+		if ctx.ExecMode() != sdk.ExecModeSimulate {
+			return nil, errorsmod.Wrap(types.ErrNotFound, "block hash")
+		}
+		blockHash = make([]byte, sha256.Size)
+	}
+	h := sha256.New()
+	h.Write(blockBeaconDomain)
+	h.Write(binary.BigEndian.AppendUint64(nil, uint64(ctx.BlockHeight())))
+	h.Write(blockHash)
+	h.Write(salt)
+	return h.Sum(nil), nil
+}
+
+// BlockBeaconQuerier handles BlockBeaconQuery custom queries. Any other custom query is passed to the next custom querier.
+func BlockBeaconQuerier(params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			BlockBeacon *BlockBeaconQuery `json:"block_beacon,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.BlockBeacon == nil {
+			return next(ctx, request)
+		}
+		if slices.Contains(params.GetCachedParams(ctx).DisabledCapabilities, BlockBeaconQueryCapability) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "block beacon queries are disabled on this chain"}
+		}
+		beacon, err := BlockBeacon(ctx, msg.BlockBeacon.Salt)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(BlockBeaconResponse{Height: uint64(ctx.BlockHeight()), Beacon: beacon})
+	}
+}
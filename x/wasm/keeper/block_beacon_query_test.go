@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestBlockBeacon(t *testing.T) {
+	blockHash := []byte("myBlockHash")
+	// two nodes executing the same block
+	nodeA, _ := CreateTestInput(t, false, AvailableCapabilities)
+	nodeB, _ := CreateTestInput(t, false, AvailableCapabilities)
+	nodeA = nodeA.WithBlockHeight(100).WithHeaderHash(blockHash)
+	nodeB = nodeB.WithBlockHeight(100).WithHeaderHash(blockHash)
+
+	gotA, err := BlockBeacon(nodeA, []byte("salt"))
+	require.NoError(t, err)
+	gotB, err := BlockBeacon(nodeB, []byte("salt"))
+	require.NoError(t, err)
+	assert.Equal(t, gotA, gotB)
+	assert.Len(t, gotA, 32)
+
+	// and the value changes with the salt, height and block hash
+	got, err := BlockBeacon(nodeA, []byte("other"))
+	require.NoError(t, err)
+	assert.NotEqual(t, gotA, got)
+	got, err = BlockBeacon(nodeA.WithBlockHeight(101), []byte("salt"))
+	require.NoError(t, err)
+	assert.NotEqual(t, gotA, got)
+	got, err = BlockBeacon(nodeA.WithHeaderHash([]byte("otherBlockHash")), []byte("salt"))
+	require.NoError(t, err)
+	assert.NotEqual(t, gotA, got)
+
+	// and there is no beacon without a block hash
+	_, err = BlockBeacon(nodeA.WithHeaderHash(nil), []byte("salt"))
+	assert.ErrorIs(t, err, types.ErrNotFound)
+	_, err = BlockBeacon(nodeA.WithHeaderHash(nil).WithExecMode(sdk.ExecModeCheck), []byte("salt"))
+	assert.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestBlockBeaconInSimulation(t *testing.T) {
+	ctx, _ := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithBlockHeight(100).WithHeaderHash(nil).WithExecMode(sdk.ExecModeSimulate)
+
+	// when
+	got, err := BlockBeacon(ctx, []byte("salt"))
+
+	// then the beacon is derived from a zero block hash
+	require.NoError(t, err)
+	exp, err := BlockBeacon(ctx.WithExecMode(sdk.ExecModeFinalize).WithHeaderHash(make([]byte, 32)), []byte("salt"))
+	require.NoError(t, err)
+	assert.Equal(t, exp, got)
+
+	// and the block hash is used when it is set
+	got, err = BlockBeacon(ctx.WithHeaderHash([]byte("myBlockHash")), []byte("salt"))
+	require.NoError(t, err)
+	assert.NotEqual(t, exp, got)
+}
+
+func TestBlockBeaconQuery(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	var (
+		gotResult []byte
+		gotErr    error
+	)
+	// the calling contract sends the execute msg as custom query
+	m.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, msg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		gotResult, gotErr = querier.Query(wasmvmtypes.QueryRequest{Custom: msg}, gasLimit)
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithBlockBeaconQueries())
+	k := keepers.WasmKeeper
+	caller := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	parentCtx = parentCtx.WithBlockHeight(100).WithHeaderHash([]byte("myBlockHash"))
+
+	expBeacon := func(salt []byte) []byte {
+		bz, err := BlockBeacon(parentCtx, salt)
+		require.NoError(t, err)
+		return bz
+	}
+	specs := map[string]struct {
+		src            []byte
+		noBlockHash    bool
+		disabled       bool
+		exp            []byte
+		expErr         bool
+		expUnsupported bool
+	}{
+		"with salt": {
+			src: []byte(`{"block_beacon":{"salt":"YWxpY2U="}}`), // base64 of "alice"
+			exp: expBeacon([]byte("alice")),
+		},
+		"without salt": {
+			src: []byte(`{"block_beacon":{}}`),
+			exp: expBeacon(nil),
+		},
+		"no block hash": {
+			src:         []byte(`{"block_beacon":{}}`),
+			noBlockHash: true,
+			expErr:      true,
+		},
+		"disabled capability": {
+			src:            []byte(`{"block_beacon":{}}`),
+			disabled:       true,
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.noBlockHash {
+				ctx = ctx.WithHeaderHash(nil)
+			}
+			if spec.disabled {
+				params := k.GetParams(ctx)
+				params.DisabledCapabilities = []string{BlockBeaconQueryCapability}
+				require.NoError(t, k.SetParams(ctx, params))
+			}
+			gotResult, gotErr = nil, nil
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, caller.Contract, caller.CreatorAddr, spec.src, nil)
+			require.NoError(t, err)
+
+			// then
+			switch {
+			case spec.expUnsupported:
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			case spec.expErr:
+				assert.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got BlockBeaconResponse
+			require.NoError(t, json.Unmarshal(gotResult, &got))
+			assert.Equal(t, BlockBeaconResponse{Height: 100, Beacon: spec.exp}, got)
+		})
+	}
+}
+
+func TestBlockBeaconQuerierPassesOtherQueries(t *testing.T) {
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	q := BlockBeaconQuerier(mockParamsSource(types.DefaultParams()), next)
+
+	gotResult, gotErr := q(sdk.Context{}, []byte(`{"foo":{}}`))
+
+	require.NoError(t, gotErr)
+	assert.Equal(t, []byte("next"), gotResult)
+}
@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// countBlockInstantiate increments the instantiate counter of the current block and fails when the
+// max instantiates per block param is exceeded. The counter is kept in the transient store, so that it
+// starts from zero in every new block and is never persisted.
+// Instantiations dispatched by contracts are only counted when the count sub-message instantiates param is set.
+func (k Keeper) countBlockInstantiate(ctx context.Context) error {
+	params := k.GetCachedParams(ctx)
+	if params.MaxInstantiatesPerBlock == 0 {
+		return nil
+	}
+	if _, ok := types.CallDepth(ctx); ok && !params.CountSubmsgInstantiates {
+		return nil
+	}
+	count := k.GetBlockInstantiateCount(ctx)
+	if uint64(count) >= params.MaxInstantiatesPerBlock {
+		return errorsmod.Wrapf(types.ErrExceedMaxInstantiatesPerBlock, "max %d", params.MaxInstantiatesPerBlock)
+	}
+	return k.transientStoreService.OpenTransientStore(ctx).Set(
+		types.GetBlockInstantiateCounterKey(sdk.UnwrapSDKContext(ctx).BlockHeight()),
+		binary.BigEndian.AppendUint32(nil, count+1),
+	)
+}
+
+// GetBlockInstantiateCount returns the number of counted contract instantiations in the current block
+func (k Keeper) GetBlockInstantiateCount(ctx context.Context) uint32 {
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(
+		types.GetBlockInstantiateCounterKey(sdk.UnwrapSDKContext(ctx).BlockHeight()),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(bz)
+}
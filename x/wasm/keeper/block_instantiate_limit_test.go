@@ -0,0 +1,93 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMaxInstantiatesPerBlock(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	initMsgBz, err := json.Marshal(HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		maxPerBlock   uint64
+		countSubMsgs  bool
+		fromContract  bool
+		instantiates  int
+		newBlockAfter int
+		expSuccess    int
+	}{
+		"unlimited by default": {
+			instantiates: 3,
+			expSuccess:   3,
+		},
+		"within limit": {
+			maxPerBlock:  2,
+			instantiates: 2,
+			expSuccess:   2,
+		},
+		"limit exceeded": {
+			maxPerBlock:  2,
+			instantiates: 3,
+			expSuccess:   2,
+		},
+		"counter reset in new block": {
+			maxPerBlock:   2,
+			instantiates:  4,
+			newBlockAfter: 2,
+			expSuccess:    4,
+		},
+		"sub-message instantiates not counted": {
+			maxPerBlock:  1,
+			fromContract: true,
+			instantiates: 3,
+			expSuccess:   3,
+		},
+		"sub-message instantiates counted": {
+			maxPerBlock:  1,
+			countSubMsgs: true,
+			fromContract: true,
+			instantiates: 3,
+			expSuccess:   1,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := keepers.WasmKeeper.GetParams(ctx)
+			params.MaxInstantiatesPerBlock = spec.maxPerBlock
+			params.CountSubmsgInstantiates = spec.countSubMsgs
+			require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+			if spec.fromContract {
+				ctx = types.WithCallDepth(ctx, 1)
+			}
+
+			// when
+			var success int
+			for i := 0; i < spec.instantiates; i++ {
+				if spec.newBlockAfter != 0 && i == spec.newBlockAfter {
+					ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+				}
+				_, _, gotErr := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, initMsgBz, "label", nil)
+				if gotErr != nil {
+					require.ErrorIs(t, gotErr, types.ErrExceedMaxInstantiatesPerBlock)
+					continue
+				}
+				success++
+			}
+
+			// then
+			assert.Equal(t, spec.expSuccess, success)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package keeper
+
+import (
+	"context"
+	"slices"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// RegisterBeginBlockSudo registers the contract to be sudo called with the given message in each BeginBlock.
+func (k Keeper) RegisterBeginBlockSudo(ctx context.Context, contractAddr sdk.AccAddress, msg []byte) error {
+	return k.RegisterBlockSudoHook(ctx, types.BlockSudoPhaseBeginBlock, contractAddr, msg)
+}
+
+// RegisterEndBlockSudo registers the contract to be sudo called with the given message in each EndBlock.
+func (k Keeper) RegisterEndBlockSudo(ctx context.Context, contractAddr sdk.AccAddress, msg []byte) error {
+	return k.RegisterBlockSudoHook(ctx, types.BlockSudoPhaseEndBlock, contractAddr, msg)
+}
+
+// RegisterBlockSudoHook appends the contract to the ordered list of hooks for the block phase.
+// A contract can be registered only once per phase and the number of hooks per phase is limited by the
+// MaxBlockSudoHooks param.
+func (k Keeper) RegisterBlockSudoHook(ctx context.Context, phase types.BlockSudoPhase, contractAddr sdk.AccAddress, msg []byte) error {
+	hook := types.BlockSudoHook{Contract: contractAddr.String(), Msg: msg}
+	if err := hook.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := phase.ValidateBasic(); err != nil {
+		return err
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	hooks := k.GetBlockSudoHooks(ctx, phase)
+	if slices.ContainsFunc(hooks, func(h types.BlockSudoHook) bool { return h.Contract == hook.Contract }) {
+		return errorsmod.Wrapf(types.ErrDuplicate, "contract %s already registered", hook.Contract)
+	}
+	if maxHooks := k.GetParams(ctx).BlockSudoHooksLimit(); len(hooks) >= maxHooks {
+		return errorsmod.Wrapf(types.ErrLimit, "max %d block sudo hooks per phase", maxHooks)
+	}
+	if err := k.setBlockSudoHooks(ctx, phase, append(hooks, hook)); err != nil {
+		return err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRegisterBlockSudoHook,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, hook.Contract),
+		sdk.NewAttribute(types.AttributeKeyBlockSudoPhase, phase.String()),
+	))
+	return nil
+}
+
+// RemoveBlockSudoHook removes the contract from the hooks of the block phase.
+func (k Keeper) RemoveBlockSudoHook(ctx context.Context, phase types.BlockSudoPhase, contractAddr sdk.AccAddress) error {
+	if err := phase.ValidateBasic(); err != nil {
+		return err
+	}
+	hooks := k.GetBlockSudoHooks(ctx, phase)
+	pos := slices.IndexFunc(hooks, func(h types.BlockSudoHook) bool { return h.Contract == contractAddr.String() })
+	if pos < 0 {
+		return errorsmod.Wrapf(types.ErrNotFound, "contract %s not registered", contractAddr.String())
+	}
+	if err := k.setBlockSudoHooks(ctx, phase, slices.Delete(hooks, pos, pos+1)); err != nil {
+		return err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRemoveBlockSudoHook,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyBlockSudoPhase, phase.String()),
+	))
+	return nil
+}
+
+// GetBlockSudoHooks returns the hooks of the block phase in execution order
+func (k Keeper) GetBlockSudoHooks(ctx context.Context, phase types.BlockSudoPhase) []types.BlockSudoHook {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(types.GetBlockSudoHooksKey(phase))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return nil
+	}
+	var hooks types.BlockSudoHooks
+	k.cdc.MustUnmarshal(bz, &hooks)
+	return hooks.Hooks
+}
+
+// setBlockSudoHooks stores the hooks of the block phase. An empty list is not persisted.
+func (k Keeper) setBlockSudoHooks(ctx context.Context, phase types.BlockSudoPhase, hooks []types.BlockSudoHook) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetBlockSudoHooksKey(phase)
+	if len(hooks) == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, k.cdc.MustMarshal(&types.BlockSudoHooks{Hooks: hooks}))
+}
+
+// BeginBlocker logs changed wasmvm cache params, samples the wasmvm metrics and sudo calls all contracts registered
+// for the BeginBlock phase
+func (k Keeper) BeginBlocker(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	k.logChangedVMCacheParams(sdkCtx)
+	k.sampleVMMetrics(sdkCtx)
+	k.runBlockSudoHooks(sdkCtx, types.BlockSudoPhaseBeginBlock)
+	return nil
+}
+
+// EndBlocker sudo calls all contracts registered for the EndBlock phase and removes the bytecode of pruned codes
+func (k Keeper) EndBlocker(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	k.runBlockSudoHooks(sdkCtx, types.BlockSudoPhaseEndBlock)
+	k.removePendingCodes(sdkCtx)
+	return nil
+}
+
+// runBlockSudoHooks executes the hooks in order, up to the MaxBlockSudoHooks param so that the work per block is
+// bounded. Failures are logged and emitted as events but do not abort the block.
+func (k Keeper) runBlockSudoHooks(ctx sdk.Context, phase types.BlockSudoPhase) {
+	hooks := k.GetBlockSudoHooks(ctx, phase)
+	if maxHooks := k.GetCachedParams(ctx).BlockSudoHooksLimit(); len(hooks) > maxHooks {
+		hooks = hooks[:maxHooks]
+	}
+	for _, hook := range hooks {
+		if err := k.runBlockSudoHook(ctx, hook); err != nil {
+			k.Logger(ctx).Error("block sudo hook failed", "phase", phase.String(), "contract", hook.Contract, "error", err)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeBlockSudoFailed,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, hook.Contract),
+				sdk.NewAttribute(types.AttributeKeyBlockSudoPhase, phase.String()),
+				sdk.NewAttribute(types.AttributeKeyBlockSudoError, err.Error()),
+			))
+		}
+	}
+}
+
+// runBlockSudoHook sudo calls the contract in a sandbox with the block sudo gas limit applied.
+// State changes are only committed on success.
+func (k Keeper) runBlockSudoHook(ctx sdk.Context, hook types.BlockSudoHook) (err error) {
+	contractAddr, err := sdk.AccAddressFromBech32(hook.Contract)
+	if err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	cacheCtx, commit := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(storetypes.NewGasMeter(k.blockSudoGasLimit))
+
+	// catch out of gas panic so that a single contract can not halt the chain
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(storetypes.ErrorOutOfGas); !ok {
+				moduleLogger(ctx).Info("block sudo rethrowing panic: %#v", r)
+				panic(r)
+			}
+			err = errorsmod.Wrap(sdkerrors.ErrOutOfGas, "block sudo hit gas limit")
+		}
+	}()
+	if _, err = k.Sudo(cacheCtx, contractAddr, hook.Msg); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
@@ -0,0 +1,231 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRegisterBlockSudoHook(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	otherExample := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	require.NoError(t, k.RegisterBeginBlockSudo(parentCtx, otherExample.Contract, []byte(`{}`)))
+
+	specs := map[string]struct {
+		phase    types.BlockSudoPhase
+		contract sdk.AccAddress
+		msg      []byte
+		maxHooks uint32
+		expErr   bool
+		expHooks []sdk.AccAddress
+	}{
+		"appended to begin block": {
+			phase:    types.BlockSudoPhaseBeginBlock,
+			contract: example.Contract,
+			msg:      []byte(`{}`),
+			expHooks: []sdk.AccAddress{otherExample.Contract, example.Contract},
+		},
+		"end block": {
+			phase:    types.BlockSudoPhaseEndBlock,
+			contract: otherExample.Contract,
+			msg:      []byte(`{}`),
+			expHooks: []sdk.AccAddress{otherExample.Contract},
+		},
+		"duplicate": {
+			phase:    types.BlockSudoPhaseBeginBlock,
+			contract: otherExample.Contract,
+			msg:      []byte(`{}`),
+			expErr:   true,
+		},
+		"unknown contract": {
+			phase:    types.BlockSudoPhaseBeginBlock,
+			contract: RandomAccountAddress(t),
+			msg:      []byte(`{}`),
+			expErr:   true,
+		},
+		"unspecified phase": {
+			phase:    types.BlockSudoPhaseUnspecified,
+			contract: example.Contract,
+			msg:      []byte(`{}`),
+			expErr:   true,
+		},
+		"invalid msg": {
+			phase:    types.BlockSudoPhaseBeginBlock,
+			contract: example.Contract,
+			msg:      []byte(`not json`),
+			expErr:   true,
+		},
+		"max hooks reached": {
+			phase:    types.BlockSudoPhaseBeginBlock,
+			contract: example.Contract,
+			msg:      []byte(`{}`),
+			maxHooks: 1,
+			expErr:   true,
+		},
+		"max hooks of other phase": {
+			phase:    types.BlockSudoPhaseEndBlock,
+			contract: example.Contract,
+			msg:      []byte(`{}`),
+			maxHooks: 1,
+			expHooks: []sdk.AccAddress{example.Contract},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxBlockSudoHooks = spec.maxHooks
+			require.NoError(t, k.SetParams(ctx, params))
+			gotErr := k.RegisterBlockSudoHook(ctx, spec.phase, spec.contract, spec.msg)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			hooks := k.GetBlockSudoHooks(ctx, spec.phase)
+			require.Len(t, hooks, len(spec.expHooks))
+			for i, addr := range spec.expHooks {
+				assert.Equal(t, addr.String(), hooks[i].Contract)
+			}
+		})
+	}
+}
+
+func TestRemoveBlockSudoHook(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	otherExample := InstantiateHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, k.RegisterEndBlockSudo(ctx, example.Contract, []byte(`{}`)))
+	require.NoError(t, k.RegisterEndBlockSudo(ctx, otherExample.Contract, []byte(`{}`)))
+
+	// when
+	require.NoError(t, k.RemoveBlockSudoHook(ctx, types.BlockSudoPhaseEndBlock, example.Contract))
+
+	// then
+	hooks := k.GetBlockSudoHooks(ctx, types.BlockSudoPhaseEndBlock)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, otherExample.Contract.String(), hooks[0].Contract)
+	// and not registered hooks can not be removed
+	require.ErrorIs(t, k.RemoveBlockSudoHook(ctx, types.BlockSudoPhaseEndBlock, example.Contract), types.ErrNotFound)
+	require.ErrorIs(t, k.RemoveBlockSudoHook(ctx, types.BlockSudoPhaseBeginBlock, otherExample.Contract), types.ErrNotFound)
+
+	// and the store entry is removed with the last hook
+	require.NoError(t, k.RemoveBlockSudoHook(ctx, types.BlockSudoPhaseEndBlock, otherExample.Contract))
+	ok, err := k.storeService.OpenKVStore(ctx).Has(types.GetBlockSudoHooksKey(types.BlockSudoPhaseEndBlock))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRunBlockSudoHooks(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	failing := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	succeeding := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	community := RandomAccountAddress(t)
+	stealFunds := mustMarshal(t, sudoMsg{StealFunds: stealFundsMsg{
+		Recipient: community.String(),
+		Amount:    wasmvmtypes.Array[wasmvmtypes.Coin]{wasmvmtypes.NewCoin(1, "denom")},
+	}})
+	require.NoError(t, k.RegisterEndBlockSudo(parentCtx, failing.Contract, []byte(`{"unknown":{}}`)))
+	require.NoError(t, k.RegisterEndBlockSudo(parentCtx, succeeding.Contract, stealFunds))
+
+	specs := map[string]struct {
+		gasLimit  uint64
+		expFailed []sdk.AccAddress
+		expPaid   bool
+	}{
+		"failing hook does not abort the others": {
+			gasLimit:  types.DefaultBlockSudoGasLimit,
+			expFailed: []sdk.AccAddress{failing.Contract},
+			expPaid:   true,
+		},
+		"out of gas": {
+			gasLimit:  1,
+			expFailed: []sdk.AccAddress{failing.Contract, succeeding.Contract},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			k.blockSudoGasLimit = spec.gasLimit
+			t.Cleanup(func() { k.blockSudoGasLimit = types.DefaultBlockSudoGasLimit })
+
+			// when
+			require.NoError(t, k.EndBlocker(ctx))
+
+			// then
+			var gotFailed []sdk.AccAddress
+			for _, e := range em.Events() {
+				if e.Type != types.EventTypeBlockSudoFailed {
+					continue
+				}
+				attr, ok := e.GetAttribute(types.AttributeKeyContractAddr)
+				require.True(t, ok)
+				gotFailed = append(gotFailed, sdk.MustAccAddressFromBech32(attr.Value))
+			}
+			assert.Equal(t, spec.expFailed, gotFailed)
+			balance := keepers.BankKeeper.GetBalance(ctx, community, "denom")
+			assert.Equal(t, spec.expPaid, balance.IsPositive())
+		})
+	}
+}
+
+func TestRunBlockSudoHooksLimit(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	first := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	second := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	require.NoError(t, k.RegisterEndBlockSudo(parentCtx, first.Contract, []byte(`{"unknown":{}}`)))
+	require.NoError(t, k.RegisterEndBlockSudo(parentCtx, second.Contract, []byte(`{"unknown":{}}`)))
+
+	specs := map[string]struct {
+		maxHooks uint32
+		expRun   []sdk.AccAddress
+	}{
+		"default": {
+			expRun: []sdk.AccAddress{first.Contract, second.Contract},
+		},
+		"lowered below the registered hooks": {
+			maxHooks: 1,
+			expRun:   []sdk.AccAddress{first.Contract},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxBlockSudoHooks = spec.maxHooks
+			require.NoError(t, k.SetParams(ctx, params))
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			// when
+			require.NoError(t, k.EndBlocker(ctx))
+
+			// then all hooks fail so that the failure events show which were run
+			var gotRun []sdk.AccAddress
+			for _, e := range em.Events() {
+				if e.Type != types.EventTypeBlockSudoFailed {
+					continue
+				}
+				attr, ok := e.GetAttribute(types.AttributeKeyContractAddr)
+				require.True(t, ok)
+				gotRun = append(gotRun, sdk.MustAccAddressFromBech32(attr.Value))
+			}
+			assert.Equal(t, spec.expRun, gotRun)
+			// and the registered hooks are kept
+			assert.Len(t, k.GetBlockSudoHooks(ctx, types.BlockSudoPhaseEndBlock), 2)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// BondedValidatorsQueryCapability is the capability that contracts declare with `requires_bonded_validators_query`
+// to use the bonded validators query. It is added to the available capabilities by the WithBondedValidatorsQueries
+// option and can be disabled by governance with the disabled capabilities param.
+const BondedValidatorsQueryCapability = "bonded_validators_query"
+
+const (
+	// defaultBondedValidatorsLimit is the number of validators returned when the query has no limit
+	defaultBondedValidatorsLimit = 30
+	// maxBondedValidatorsLimit is the max number of validators returned by a single query
+	maxBondedValidatorsLimit = 100
+)
+
+// BondedValidatorsQuery is a custom query to read a page of the active validator set with the voting power.
+// The `StakingQuery::AllValidators` query defined by wasmvm has no tokens or power and the wasmvm type can not be
+// extended, so that this is a custom query.
+// It is sent by contracts as `{"bonded_validators":{"offset":<number>,"limit":<number>}}`.
+type BondedValidatorsQuery struct {
+	// Offset is the number of validators to skip
+	Offset uint32 `json:"offset,omitempty"`
+	// Limit is the max number of validators returned. Defaults to 30 and must not exceed 100.
+	Limit uint32 `json:"limit,omitempty"`
+}
+
+// BondedValidatorsResponse is the response to the BondedValidatorsQuery
+type BondedValidatorsResponse struct {
+	// Validators are sorted by voting power, highest first. Validators with the same power are sorted by address.
+	Validators []BondedValidator `json:"validators"`
+	// Total is the number of validators in the active set
+	Total uint32 `json:"total"`
+}
+
+// BondedValidator is a validator of the active set
+type BondedValidator struct {
+	// Address is the bech32 operator address
+	Address string `json:"address"`
+	// Tokens are the bonded tokens of the validator in the bond denom
+	Tokens string `json:"tokens"`
+	// VotingPower is the consensus power of the validator
+	VotingPower uint64 `json:"voting_power"`
+}
+
+// BondedValidatorsSource provides the active validator set
+type BondedValidatorsSource interface {
+	// GetBondedValidatorsByPower returns the bonded validators sorted by power-rank
+	GetBondedValidatorsByPower(ctx context.Context) ([]stakingtypes.Validator, error)
+	PowerReduction(ctx context.Context) sdkmath.Int
+}
+
+// BondedValidatorsQuerier handles BondedValidatorsQuery custom queries. Any other custom query is passed to the next
+// custom querier.
+func BondedValidatorsQuerier(source BondedValidatorsSource, params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			BondedValidators *BondedValidatorsQuery `json:"bonded_validators,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.BondedValidators == nil {
+			return next(ctx, request)
+		}
+		if slices.Contains(params.GetCachedParams(ctx).DisabledCapabilities, BondedValidatorsQueryCapability) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "bonded validators queries are disabled on this chain"}
+		}
+		limit := msg.BondedValidators.Limit
+		switch {
+		case limit == 0:
+			limit = defaultBondedValidatorsLimit
+		case limit > maxBondedValidatorsLimit:
+			return nil, errorsmod.Wrapf(types.ErrInvalid, "limit must not exceed %d", maxBondedValidatorsLimit)
+		}
+		validators, err := source.GetBondedValidatorsByPower(ctx)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "bonded validators")
+		}
+		powerReduction := source.PowerReduction(ctx)
+		total := uint64(len(validators))
+		start := min(uint64(msg.BondedValidators.Offset), total)
+		end := min(start+uint64(limit), total)
+		res := BondedValidatorsResponse{
+			Validators: make([]BondedValidator, 0, end-start),
+			Total:      uint32(total),
+		}
+		for _, v := range validators[start:end] {
+			res.Validators = append(res.Validators, BondedValidator{
+				Address:     v.OperatorAddress,
+				Tokens:      v.Tokens.String(),
+				VotingPower: uint64(v.ConsensusPower(powerReduction)),
+			})
+		}
+		return json.Marshal(res)
+	}
+}
@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestBondedValidatorsQuery(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	stakingKeeper := keepers.StakingKeeper
+	val1 := addValidator(t, ctx, stakingKeeper, keepers.Faucet, sdk.NewInt64Coin("stake", 3_000_000))
+	val2 := addValidator(t, ctx, stakingKeeper, keepers.Faucet, sdk.NewInt64Coin("stake", 1_000_000))
+	val3 := addValidator(t, ctx, stakingKeeper, keepers.Faucet, sdk.NewInt64Coin("stake", 2_000_000))
+	ctx = nextBlock(ctx, stakingKeeper)
+	// an unbonded validator is not part of the active set
+	addValidator(t, ctx, stakingKeeper, keepers.Faucet, sdk.NewInt64Coin("stake", 4_000_000))
+
+	validator := func(addr sdk.ValAddress, tokens string, power uint64) BondedValidator {
+		return BondedValidator{Address: addr.String(), Tokens: tokens, VotingPower: power}
+	}
+	allValidators := []BondedValidator{
+		validator(val1, "3000000", 3),
+		validator(val3, "2000000", 2),
+		validator(val2, "1000000", 1),
+	}
+	specs := map[string]struct {
+		src      string
+		disabled bool
+		exp      *BondedValidatorsResponse
+		expErr   bool
+	}{
+		"all validators": {
+			src: `{"bonded_validators":{}}`,
+			exp: &BondedValidatorsResponse{Validators: allValidators, Total: 3},
+		},
+		"with limit": {
+			src: `{"bonded_validators":{"limit":2}}`,
+			exp: &BondedValidatorsResponse{Validators: allValidators[:2], Total: 3},
+		},
+		"with offset": {
+			src: `{"bonded_validators":{"offset":2,"limit":2}}`,
+			exp: &BondedValidatorsResponse{Validators: allValidators[2:], Total: 3},
+		},
+		"offset exceeds total": {
+			src: `{"bonded_validators":{"offset":5}}`,
+			exp: &BondedValidatorsResponse{Validators: []BondedValidator{}, Total: 3},
+		},
+		"limit exceeds max": {
+			src:    `{"bonded_validators":{"limit":101}}`,
+			expErr: true,
+		},
+		"disabled capability": {
+			src:      `{"bonded_validators":{}}`,
+			disabled: true,
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			params := types.DefaultParams()
+			if spec.disabled {
+				params.DisabledCapabilities = []string{BondedValidatorsQueryCapability}
+			}
+			q := BondedValidatorsQuerier(stakingKeeper, mockParamsSource(params), nil)
+
+			// when
+			gotResult, gotErr := q(ctx, []byte(spec.src))
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				if spec.disabled {
+					var unsupported wasmvmtypes.UnsupportedRequest
+					assert.ErrorAs(t, gotErr, &unsupported)
+				}
+				return
+			}
+			require.NoError(t, gotErr)
+			var got BondedValidatorsResponse
+			require.NoError(t, json.Unmarshal(gotResult, &got))
+			assert.Equal(t, *spec.exp, got)
+		})
+	}
+}
+
+func TestBondedValidatorsQuerierPassesOtherQueries(t *testing.T) {
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	q := BondedValidatorsQuerier(nil, mockParamsSource(types.DefaultParams()), next)
+
+	gotResult, gotErr := q(sdk.Context{}, []byte(`{"foo":{}}`))
+
+	require.NoError(t, gotErr)
+	assert.Equal(t, []byte("next"), gotResult)
+}
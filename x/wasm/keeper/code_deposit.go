@@ -0,0 +1,96 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetCodeDeposit returns the storage deposit escrowed for the code or nil when none exists
+func (k Keeper) GetCodeDeposit(ctx context.Context, codeID uint64) *types.CodeDeposit {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetCodeDepositKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return nil
+	}
+	var deposit types.CodeDeposit
+	k.cdc.MustUnmarshal(bz, &deposit)
+	return &deposit
+}
+
+// IterateCodeDeposits iterates all code deposits ordered by code id
+func (k Keeper) IterateCodeDeposits(ctx context.Context, cb func(types.CodeDeposit) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.CodeDepositPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var deposit types.CodeDeposit
+		k.cdc.MustUnmarshal(iter.Value(), &deposit)
+		if cb(deposit) {
+			return
+		}
+	}
+}
+
+func (k Keeper) setCodeDeposit(ctx context.Context, deposit types.CodeDeposit) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeDepositKey(deposit.CodeID), k.cdc.MustMarshal(&deposit))
+}
+
+// escrowCodeDeposit moves the CodeStorageDeposit param amount from the depositor to the module account
+// and records it for the code. Nothing is escrowed when the param is empty or the depositor is the authority.
+func (k Keeper) escrowCodeDeposit(ctx context.Context, codeID uint64, depositor sdk.AccAddress) error {
+	amount := k.GetCachedParams(ctx).CodeStorageDeposit
+	if amount.IsZero() || depositor.String() == k.GetAuthority() {
+		return nil
+	}
+	if err := k.balances.SendCoinsFromAccountToModule(ctx, depositor, types.ModuleName, amount); err != nil {
+		return errorsmod.Wrap(err, "code storage deposit")
+	}
+	return k.setCodeDeposit(ctx, types.CodeDeposit{CodeID: codeID, Depositor: depositor.String(), Amount: amount})
+}
+
+// refundCodeDeposit returns the escrowed deposit of the code to the depositor
+func (k Keeper) refundCodeDeposit(ctx context.Context, codeID uint64) error {
+	deposit := k.GetCodeDeposit(ctx, codeID)
+	if deposit == nil {
+		return nil
+	}
+	depositor, err := sdk.AccAddressFromBech32(deposit.Depositor)
+	if err != nil {
+		return errorsmod.Wrap(err, "depositor")
+	}
+	if err := k.balances.SendCoinsFromModuleToAccount(ctx, types.ModuleName, depositor, deposit.Amount); err != nil {
+		return errorsmod.Wrap(err, "refund code storage deposit")
+	}
+	return k.storeService.OpenKVStore(ctx).Delete(types.GetCodeDepositKey(codeID))
+}
+
+// ForfeitCodeDeposit burns the escrowed storage deposit of the code. The code is not removed.
+func (k Keeper) ForfeitCodeDeposit(ctx context.Context, codeID uint64) error {
+	deposit := k.GetCodeDeposit(ctx, codeID)
+	if deposit == nil {
+		return errorsmod.Wrapf(types.ErrNotFound, "deposit for code id %d", codeID)
+	}
+	if err := k.balances.BurnCoins(ctx, types.ModuleName, deposit.Amount); err != nil {
+		return errorsmod.Wrap(err, "burn code storage deposit")
+	}
+	if err := k.storeService.OpenKVStore(ctx).Delete(types.GetCodeDepositKey(codeID)); err != nil {
+		return err
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeForfeitDeposit,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyAmount, deposit.Amount.String()),
+	))
+	return nil
+}
@@ -0,0 +1,185 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestStoreCodeDeposit(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	myDeposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+
+	specs := map[string]struct {
+		deposit     sdk.Coins
+		balance     sdk.Coins
+		authority   bool
+		expErr      error
+		expEscrowed sdk.Coins
+	}{
+		"deposit escrowed": {
+			deposit:     myDeposit,
+			balance:     sdk.NewCoins(sdk.NewInt64Coin("denom", 150)),
+			expEscrowed: myDeposit,
+		},
+		"insufficient balance": {
+			deposit: myDeposit,
+			balance: sdk.NewCoins(sdk.NewInt64Coin("denom", 50)),
+			expErr:  sdkerrors.ErrInsufficientFunds,
+		},
+		"no deposit param": {
+			balance: sdk.NewCoins(sdk.NewInt64Coin("denom", 150)),
+		},
+		"authority exempt": {
+			deposit:   myDeposit,
+			authority: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.CodeStorageDeposit = spec.deposit
+			require.NoError(t, k.SetParams(ctx, params))
+			sender := sdk.MustAccAddressFromBech32(k.GetAuthority())
+			if !spec.authority {
+				sender = keepers.Faucet.NewFundedRandomAccount(ctx, spec.balance...)
+			}
+			moduleBalance := keepers.BankKeeper.GetAllBalances(ctx, moduleAddr)
+
+			// when
+			rsp, gotErr := NewMsgServerImpl(k).StoreCode(ctx, &types.MsgStoreCode{
+				Sender:       sender.String(),
+				WASMByteCode: hackatomWasm,
+			})
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.balance.Sub(spec.expEscrowed...).String(), keepers.BankKeeper.GetAllBalances(ctx, sender).String())
+			assert.Equal(t, moduleBalance.Add(spec.expEscrowed...).String(), keepers.BankKeeper.GetAllBalances(ctx, moduleAddr).String())
+			gotDeposit := k.GetCodeDeposit(ctx, rsp.CodeID)
+			if spec.expEscrowed.IsZero() {
+				assert.Nil(t, gotDeposit)
+				return
+			}
+			require.NotNil(t, gotDeposit)
+			assert.Equal(t, types.CodeDeposit{CodeID: rsp.CodeID, Depositor: sender.String(), Amount: spec.expEscrowed}, *gotDeposit)
+		})
+	}
+}
+
+func TestCodeDepositRefundAndForfeit(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	myDeposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	params := k.GetParams(parentCtx)
+	params.CodeStorageDeposit = myDeposit
+	require.NoError(t, k.SetParams(parentCtx, params))
+	sender := keepers.Faucet.NewFundedRandomAccount(parentCtx, myDeposit...)
+	rsp, err := NewMsgServerImpl(k).StoreCode(parentCtx, &types.MsgStoreCode{
+		Sender:       sender.String(),
+		WASMByteCode: hackatomWasm,
+	})
+	require.NoError(t, err)
+	require.True(t, keepers.BankKeeper.GetAllBalances(parentCtx, sender).IsZero())
+
+	t.Run("refund on prune", func(t *testing.T) {
+		ctx, _ := parentCtx.CacheContext()
+		gotPruned, err := k.PruneUnusedCodes(ctx, 0)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{rsp.CodeID}, gotPruned)
+		assert.Equal(t, myDeposit, keepers.BankKeeper.GetAllBalances(ctx, sender))
+		assert.Nil(t, k.GetCodeDeposit(ctx, rsp.CodeID))
+	})
+	t.Run("forfeit", func(t *testing.T) {
+		ctx, _ := parentCtx.CacheContext()
+		supplyBefore := keepers.BankKeeper.GetSupply(ctx, "denom")
+		_, err := NewMsgServerImpl(k).ForfeitCodeDeposit(ctx, &types.MsgForfeitCodeDeposit{Authority: k.GetAuthority(), CodeID: rsp.CodeID})
+		require.NoError(t, err)
+		assert.Nil(t, k.GetCodeDeposit(ctx, rsp.CodeID))
+		assert.Equal(t, supplyBefore.Sub(myDeposit[0]).String(), keepers.BankKeeper.GetSupply(ctx, "denom").String())
+		assert.NotNil(t, k.GetCodeInfo(ctx, rsp.CodeID))
+		// and no refund on prune
+		_, err = k.PruneUnusedCodes(ctx, 0)
+		require.NoError(t, err)
+		assert.True(t, keepers.BankKeeper.GetAllBalances(ctx, sender).IsZero())
+	})
+	t.Run("forfeit unknown deposit", func(t *testing.T) {
+		ctx, _ := parentCtx.CacheContext()
+		err := k.ForfeitCodeDeposit(ctx, rsp.CodeID+1)
+		require.ErrorIs(t, err, types.ErrNotFound)
+	})
+	t.Run("forfeit by non authority", func(t *testing.T) {
+		ctx, _ := parentCtx.CacheContext()
+		_, err := NewMsgServerImpl(k).ForfeitCodeDeposit(ctx, &types.MsgForfeitCodeDeposit{Authority: sender.String(), CodeID: rsp.CodeID})
+		require.Error(t, err)
+		assert.NotNil(t, k.GetCodeDeposit(ctx, rsp.CodeID))
+	})
+}
+
+func TestInitGenesisCodeDeposits(t *testing.T) {
+	srcCtx, srcKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	myDeposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+	params := srcKeepers.WasmKeeper.GetParams(srcCtx)
+	params.CodeStorageDeposit = myDeposit
+	require.NoError(t, srcKeepers.WasmKeeper.SetParams(srcCtx, params))
+	sender := srcKeepers.Faucet.NewFundedRandomAccount(srcCtx, myDeposit...)
+	_, err := NewMsgServerImpl(srcKeepers.WasmKeeper).StoreCode(srcCtx, &types.MsgStoreCode{
+		Sender:       sender.String(),
+		WASMByteCode: hackatomWasm,
+	})
+	require.NoError(t, err)
+	genesis := ExportGenesis(srcCtx, srcKeepers.WasmKeeper)
+	require.Len(t, genesis.CodeDeposits, 1)
+
+	specs := map[string]struct {
+		moduleBalance sdk.Coins
+		expErr        error
+	}{
+		"deposits held by the module": {
+			moduleBalance: myDeposit,
+		},
+		"module balance exceeds deposits": {
+			moduleBalance: myDeposit.Add(myDeposit...),
+		},
+		"module balance too low": {
+			moduleBalance: sdk.NewCoins(sdk.NewInt64Coin("denom", 99)),
+			expErr:        types.ErrInvalidGenesis,
+		},
+		"no module balance": {
+			expErr: types.ErrInvalidGenesis,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			if !spec.moduleBalance.IsZero() {
+				keepers.Faucet.Fund(ctx, authtypes.NewModuleAddress(types.ModuleName), spec.moduleBalance...)
+			}
+
+			// when
+			_, gotErr := InitGenesis(ctx, keepers.WasmKeeper, *genesis)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, genesis.CodeDeposits, ExportGenesis(ctx, keepers.WasmKeeper).CodeDeposits)
+		})
+	}
+}
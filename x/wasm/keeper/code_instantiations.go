@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// recordCodeInstantiation appends the contract to the instantiation log of the code when the
+// RecordCodeInstantiations param is set. The log is kept when the contract is migrated to another code and
+// removed when the code is pruned.
+func (k Keeper) recordCodeInstantiation(ctx context.Context, codeID, height uint64, contractAddr, creator sdk.AccAddress) error {
+	if !k.GetCachedParams(ctx).RecordCodeInstantiations {
+		return nil
+	}
+	return k.setCodeInstantiation(ctx, codeID, height, contractAddr, creator)
+}
+
+// recordImportedCodeInstantiation rebuilds the instantiation log entry of a contract from its initial code
+// history entry on genesis import. The log is not exported, so it is always rebuilt, independent of the
+// RecordCodeInstantiations param. Contracts without an init entry, for example with a pruned history, are
+// not recorded.
+func (k Keeper) recordImportedCodeInstantiation(ctx context.Context, contractAddr, creator sdk.AccAddress, first types.ContractCodeHistoryEntry) error {
+	if first.Operation != types.ContractCodeHistoryOperationTypeInit || first.Updated == nil {
+		return nil
+	}
+	return k.setCodeInstantiation(ctx, first.CodeID, first.Updated.BlockHeight, contractAddr, creator)
+}
+
+func (k Keeper) setCodeInstantiation(ctx context.Context, codeID, height uint64, contractAddr, creator sdk.AccAddress) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeInstantiationKey(codeID, height, contractAddr), creator)
+}
+
+// deleteCodeInstantiations removes the instantiation log of the code
+func (k Keeper) deleteCodeInstantiations(ctx context.Context, codeID uint64) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodeInstantiationPrefix(codeID))
+	iter := prefixStore.Iterator(nil, nil)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		prefixStore.Delete(key)
+	}
+}
+
+// IterateCodeInstantiations iterates the instantiation log of the code ordered by height.
+// The callback returns true to stop the iteration.
+func (k Keeper) IterateCodeInstantiations(ctx context.Context, codeID uint64, cb func(types.CodeInstantiation) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodeInstantiationPrefix(codeID))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(parseCodeInstantiation(iter.Key(), iter.Value())) {
+			return
+		}
+	}
+}
+
+// parseCodeInstantiation converts a `<height><contractAddr>` key and creator value of the instantiation log
+func parseCodeInstantiation(key, value []byte) types.CodeInstantiation {
+	return types.CodeInstantiation{
+		Height:   sdk.BigEndianToUint64(key[:8]),
+		Contract: sdk.AccAddress(key[8:]).String(),
+		Creator:  sdk.AccAddress(value).String(),
+	}
+}
@@ -0,0 +1,188 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRecordCodeInstantiations(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	example1 := StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	instantiate := func(height int64) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx.WithBlockHeight(height), example1.CodeID, creator, creator, initMsgBz, "label", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	list := func(codeID uint64) []types.CodeInstantiation {
+		var r []types.CodeInstantiation
+		k.IterateCodeInstantiations(ctx, codeID, func(i types.CodeInstantiation) bool {
+			r = append(r, i)
+			return false
+		})
+		return r
+	}
+
+	// nothing is recorded by default
+	instantiate(1)
+	assert.Empty(t, list(example1.CodeID))
+
+	// when enabled
+	params := k.GetParams(ctx)
+	params.RecordCodeInstantiations = true
+	require.NoError(t, k.SetParams(ctx, params))
+	contract1 := instantiate(3)
+	contract2 := instantiate(2)
+	exp := []types.CodeInstantiation{
+		{Height: 2, Contract: contract2.String(), Creator: creator.String()},
+		{Height: 3, Contract: contract1.String(), Creator: creator.String()},
+	}
+	assert.Equal(t, exp, list(example1.CodeID))
+
+	// and the log write is charged
+	gasCtx, _ := ctx.CacheContext()
+	gasCtx = gasCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	require.NoError(t, k.setCodeInstantiation(gasCtx, example1.CodeID, 4, contract1, creator))
+	assert.GreaterOrEqual(t, gasCtx.GasMeter().GasConsumed(), storetypes.KVGasConfig().WriteCostFlat)
+
+	// and the log is kept when the contract is migrated to another code
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.AccAddress `json:"verifier"`
+	}{Verifier: RandomAccountAddress(t)})
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contract1, creator, example2.CodeID, migMsgBz)
+	require.NoError(t, err)
+	assert.Equal(t, exp, list(example1.CodeID))
+	assert.Empty(t, list(example2.CodeID))
+
+	// and rebuilt from the initial history entry when the contract is imported
+	contractInfo := k.GetContractInfo(ctx, contract1)
+	history := k.GetContractHistory(ctx, contract1)
+	require.NoError(t, k.removeContract(ctx, contract1))
+	require.NoError(t, k.storeService.OpenKVStore(ctx).Delete(types.GetCodeInstantiationKey(example1.CodeID, 3, contract1)))
+	require.Equal(t, exp[:1], list(example1.CodeID))
+	require.NoError(t, k.importContract(ctx, contract1, contractInfo, nil, history))
+	assert.Equal(t, exp, list(example1.CodeID))
+
+	// and rebuilt on import when the param is not set
+	params.RecordCodeInstantiations = false
+	require.NoError(t, k.SetParams(ctx, params))
+	require.NoError(t, k.removeContract(ctx, contract1))
+	require.NoError(t, k.storeService.OpenKVStore(ctx).Delete(types.GetCodeInstantiationKey(example1.CodeID, 3, contract1)))
+	require.NoError(t, k.importContract(ctx, contract1, contractInfo, nil, history))
+	assert.Equal(t, exp, list(example1.CodeID))
+}
+
+func TestPruneCodeInstantiations(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := k.GetParams(ctx)
+	params.RecordCodeInstantiations = true
+	require.NoError(t, k.SetParams(ctx, params))
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	otherCode := StoreHackatomExampleContract(t, ctx, keepers)
+	// the contract is migrated away so that the code can be pruned
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.AccAddress `json:"verifier"`
+	}{Verifier: RandomAccountAddress(t)})
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, otherCode.CodeID, migMsgBz)
+	require.NoError(t, err)
+	var got int
+	k.IterateCodeInstantiations(ctx, example.CodeID, func(types.CodeInstantiation) bool {
+		got++
+		return false
+	})
+	require.Equal(t, 1, got)
+
+	// when
+	gotPruned, err := k.PruneUnusedCodes(ctx, 0)
+
+	// then
+	require.NoError(t, err)
+	require.Equal(t, []uint64{example.CodeID}, gotPruned)
+	got = 0
+	k.IterateCodeInstantiations(ctx, example.CodeID, func(types.CodeInstantiation) bool {
+		got++
+		return false
+	})
+	assert.Zero(t, got)
+}
+
+func TestQueryCodeInstantiations(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := k.GetParams(ctx)
+	params.RecordCodeInstantiations = true
+	require.NoError(t, k.SetParams(ctx, params))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	var exp []types.CodeInstantiation
+	for height := int64(1); height <= 3; height++ {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx.WithBlockHeight(height), example.CodeID, creator, nil, initMsgBz, "label", nil)
+		require.NoError(t, err)
+		exp = append(exp, types.CodeInstantiation{Height: uint64(height), Contract: addr.String(), Creator: creator.String()})
+	}
+	q := Querier(k)
+
+	specs := map[string]struct {
+		req    *types.QueryCodeInstantiationsRequest
+		exp    []types.CodeInstantiation
+		expErr bool
+	}{
+		"all": {
+			req: &types.QueryCodeInstantiationsRequest{CodeId: example.CodeID},
+			exp: exp,
+		},
+		"with pagination": {
+			req: &types.QueryCodeInstantiationsRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Limit: 2}},
+			exp: exp[:2],
+		},
+		"reverse": {
+			req: &types.QueryCodeInstantiationsRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Limit: 2, Reverse: true}},
+			exp: []types.CodeInstantiation{exp[2], exp[1]},
+		},
+		"unknown code": {
+			req: &types.QueryCodeInstantiationsRequest{CodeId: example.CodeID + 1},
+			exp: []types.CodeInstantiation{},
+		},
+		"empty code id": {
+			req:    &types.QueryCodeInstantiationsRequest{},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.CodeInstantiations(ctx, spec.req)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got.Instantiations)
+		})
+	}
+}
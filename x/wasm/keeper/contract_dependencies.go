@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// recordContractDependency stores the code id that a contract instantiated or migrated another contract to.
+// Nothing is recorded when the caller is not a contract or the record contract dependencies param is disabled.
+func (k Keeper) recordContractDependency(ctx context.Context, caller sdk.AccAddress, codeID uint64) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if !k.GetCachedParams(sdkCtx).RecordContractDependencies {
+		return nil
+	}
+	if !k.HasContractInfo(ctx, caller) {
+		return nil
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractDependencyKey(caller, codeID), []byte{1})
+}
+
+// importContractDependency stores a dependency of a contract from genesis
+func (k Keeper) importContractDependency(ctx context.Context, contractAddr sdk.AccAddress, codeID uint64) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return errorsmod.Wrapf(types.ErrNotFound, "contract %s", contractAddr)
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractDependencyKey(contractAddr, codeID), []byte{1})
+}
+
+// IterateContractDependencies iterates over the code ids that the contract instantiated or migrated other
+// contracts to in ascending order.
+func (k Keeper) IterateContractDependencies(ctx context.Context, contractAddr sdk.AccAddress, cb func(codeID uint64) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractDependencyPrefix(contractAddr))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(sdk.BigEndianToUint64(iter.Key())) {
+			return
+		}
+	}
+}
+
+// PruneContractDependencies deletes all recorded dependencies of the contract
+func (k Keeper) PruneContractDependencies(ctx context.Context, contractAddr sdk.AccAddress) error {
+	var codeIDs []uint64
+	k.IterateContractDependencies(ctx, contractAddr, func(codeID uint64) bool {
+		codeIDs = append(codeIDs, codeID)
+		return false
+	})
+	store := k.storeService.OpenKVStore(ctx)
+	for _, codeID := range codeIDs {
+		if err := store.Delete(types.GetContractDependencyKey(contractAddr, codeID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRecordContractDependencies(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	reflect := InstantiateReflectExampleContract(t, parentCtx, keepers)
+	hackatom := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	otherHackatom := StoreHackatomExampleContract(t, parentCtx, keepers)
+	// the reflect contract becomes admin of the hackatom contract
+	require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(parentCtx, hackatom.Contract, hackatom.CreatorAddr, reflect.Contract))
+
+	reflectMsg := func(msg wasmvmtypes.WasmMsg) []byte {
+		return mustMarshal(t, testdata.ReflectHandleMsg{
+			Reflect: &testdata.ReflectPayload{Msgs: []wasmvmtypes.CosmosMsg{{Wasm: &msg}}},
+		})
+	}
+	instantiateMsg := reflectMsg(wasmvmtypes.WasmMsg{Instantiate: &wasmvmtypes.InstantiateMsg{
+		CodeID: hackatom.CodeID,
+		Msg:    HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)}.GetBytes(t),
+		Label:  "child",
+	}})
+	migrateMsg := reflectMsg(wasmvmtypes.WasmMsg{Migrate: &wasmvmtypes.MigrateMsg{
+		ContractAddr: hackatom.Contract.String(),
+		NewCodeID:    otherHackatom.CodeID,
+		Msg:          mustMarshal(t, map[string]string{"verifier": RandomAccountAddress(t).String()}),
+	}})
+
+	specs := map[string]struct {
+		enabled    bool
+		msgs       [][]byte
+		expCodeIDs []uint64
+	}{
+		"instantiate": {
+			enabled:    true,
+			msgs:       [][]byte{instantiateMsg},
+			expCodeIDs: []uint64{hackatom.CodeID},
+		},
+		"migrate": {
+			enabled:    true,
+			msgs:       [][]byte{migrateMsg},
+			expCodeIDs: []uint64{otherHackatom.CodeID},
+		},
+		"instantiate and migrate": {
+			enabled:    true,
+			msgs:       [][]byte{instantiateMsg, instantiateMsg, migrateMsg},
+			expCodeIDs: []uint64{hackatom.CodeID, otherHackatom.CodeID},
+		},
+		"disabled": {
+			msgs: [][]byte{instantiateMsg, migrateMsg},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.RecordContractDependencies = spec.enabled
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when
+			for _, msg := range spec.msgs {
+				_, err := keepers.ContractKeeper.Execute(ctx, reflect.Contract, reflect.CreatorAddr, msg, nil)
+				require.NoError(t, err)
+			}
+
+			// then
+			assert.Equal(t, spec.expCodeIDs, contractDependencies(ctx, k, reflect.Contract))
+			// and nothing recorded for accounts
+			assert.Empty(t, contractDependencies(ctx, k, reflect.CreatorAddr))
+
+			// and pruned
+			require.NoError(t, k.PruneContractDependencies(ctx, reflect.Contract))
+			assert.Empty(t, contractDependencies(ctx, k, reflect.Contract))
+		})
+	}
+}
+
+func TestPruneContractDependenciesMsg(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	require.NoError(t, k.importContractDependency(parentCtx, example.Contract, example.CodeID))
+
+	specs := map[string]struct {
+		authority string
+		expErr    bool
+	}{
+		"gov authority": {
+			authority: k.GetAuthority(),
+		},
+		"other authority": {
+			authority: example.CreatorAddr.String(),
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			_, gotErr := NewMsgServerImpl(k).PruneContractDependencies(ctx, &types.MsgPruneContractDependencies{
+				Authority: spec.authority,
+				Contract:  example.Contract.String(),
+			})
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Equal(t, []uint64{example.CodeID}, contractDependencies(ctx, k, example.Contract))
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Empty(t, contractDependencies(ctx, k, example.Contract))
+		})
+	}
+}
+
+func contractDependencies(ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) []uint64 {
+	var r []uint64
+	k.IterateContractDependencies(ctx, contractAddr, func(codeID uint64) bool {
+		r = append(r, codeID)
+		return false
+	})
+	return r
+}
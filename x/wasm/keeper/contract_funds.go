@@ -0,0 +1,13 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetContractFunds returns the bank balances of the contract. The TotalContractFunds query sums them up for a page
+// of contracts, so that coins sent to contracts by other modules are included and no total is kept in state.
+func (k Keeper) GetContractFunds(ctx context.Context, contractAddr sdk.AccAddress) sdk.Coins {
+	return k.balances.GetAllBalances(ctx, contractAddr)
+}
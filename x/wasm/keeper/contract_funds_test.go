@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestTotalContractFunds(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	q := Querier(keepers.WasmKeeper)
+	example1 := InstantiateHackatomExampleContract(t, ctx, keepers)
+	example2 := InstantiateHackatomExampleContract(t, ctx, keepers)
+	// coins sent outside the wasm module are included
+	extra := sdk.NewCoins(sdk.NewInt64Coin("denom", 5))
+	require.NoError(t, keepers.BankKeeper.SendCoins(ctx, example1.VerifierAddr, example1.Contract, extra))
+	expTotal := example1.Deposit.Add(example2.Deposit...).Add(extra...)
+
+	// when all contracts are in the page
+	got, err := q.TotalContractFunds(ctx, &types.QueryTotalContractFundsRequest{})
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, expTotal.String(), got.Funds.String())
+	assert.Nil(t, got.Pagination.NextKey)
+
+	// when the contracts are paginated
+	var total sdk.Coins
+	var nextKey []byte
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 2)
+		got, err = q.TotalContractFunds(ctx, &types.QueryTotalContractFundsRequest{Pagination: &query.PageRequest{Key: nextKey, Limit: 1}})
+		require.NoError(t, err)
+		total = total.Add(got.Funds...)
+		if nextKey = got.Pagination.NextKey; nextKey == nil {
+			break
+		}
+	}
+
+	// then the sum of the pages is the total
+	assert.Equal(t, expTotal.String(), total.String())
+}
@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// ImportContractState replaces the state of an existing contract with the models. With StateImportModeOverwrite the
+// existing state is removed first, with StateImportModeMerge the models are stored on top of it, replacing the
+// values of existing keys. This is meant for chain surgery by the authority and must not be exposed to contracts.
+func (k Keeper) ImportContractState(ctx context.Context, contractAddr sdk.AccAddress, models []types.Model, mode types.StateImportMode) error {
+	if err := mode.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := types.ValidateModels(models); err != nil {
+		return errorsmod.Wrap(err, "models")
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	contractStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(contractAddr))
+	if mode == types.StateImportModeOverwrite {
+		deleteAll(contractStore)
+	}
+	for _, model := range models {
+		if model.Value == nil {
+			model.Value = []byte{}
+		}
+		contractStore.Set(model.Key, model.Value)
+	}
+	if quota := k.GetContractStorageQuota(ctx, contractAddr); quota != 0 {
+		if err := k.refreshContractStorageUsage(ctx, contractAddr, quota); err != nil {
+			return err
+		}
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeReplaceContractState,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyStateImportMode, mode.String()),
+	))
+	return nil
+}
+
+// removeContract deletes the contract info, history, indexes, state and checkpoints of the contract
+func (k Keeper) removeContract(ctx context.Context, contractAddr sdk.AccAddress) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	history := k.GetContractHistory(ctx, contractAddr)
+	if len(history) != 0 {
+		if err := k.removeFromContractCodeSecondaryIndex(ctx, contractAddr, history[len(history)-1]); err != nil {
+			return err
+		}
+	}
+	if err := k.removeFromContractLabelIndex(ctx, contractAddr, contractInfo.CodeID, contractInfo.Label); err != nil {
+		return err
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	if creator, err := sdk.AccAddressFromBech32(contractInfo.Creator); err == nil && contractInfo.Created != nil {
+		if err := store.Delete(types.GetContractByCreatorSecondaryIndexKey(creator, contractInfo.Created.Bytes(), contractAddr)); err != nil {
+			return err
+		}
+	}
+	for _, c := range k.GetMigrationCheckpoints(ctx, contractAddr) {
+		if err := k.deleteMigrationCheckpoint(ctx, contractAddr, c.ID); err != nil {
+			return err
+		}
+	}
+	kvStore := runtime.KVStoreAdapter(store)
+	deleteAll(prefix.NewStore(kvStore, types.GetContractCodeHistoryElementPrefix(contractAddr)))
+	deleteAll(prefix.NewStore(kvStore, types.GetContractStorePrefix(contractAddr)))
+	if err := store.Delete(types.GetContractGasMultiplierKey(contractAddr)); err != nil {
+		return err
+	}
+	if err := k.deleteCachedContractGasMultiplier(ctx, contractAddr); err != nil {
+		return err
+	}
+	if err := k.deleteContractStorageUsage(ctx, contractAddr); err != nil {
+		return err
+	}
+	if err := store.Delete(types.GetContractAddressKey(contractAddr)); err != nil {
+		return err
+	}
+	return k.decrementModuleStat(ctx, types.KeyStatsContractCount)
+}
@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestImportContractState(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	existing := contractState(parentCtx, k, example.Contract)
+	require.Len(t, existing, 1)
+	configKey := existing[0].Key
+
+	specs := map[string]struct {
+		contract sdk.AccAddress
+		models   []types.Model
+		mode     types.StateImportMode
+		expState []types.Model
+		expErr   error
+	}{
+		"overwrite": {
+			contract: example.Contract,
+			models:   []types.Model{{Key: []byte("foo"), Value: []byte("bar")}},
+			mode:     types.StateImportModeOverwrite,
+			expState: []types.Model{{Key: []byte("foo"), Value: []byte("bar")}},
+		},
+		"overwrite with empty models": {
+			contract: example.Contract,
+			mode:     types.StateImportModeOverwrite,
+		},
+		"merge": {
+			contract: example.Contract,
+			models:   []types.Model{{Key: []byte("foo"), Value: []byte("bar")}},
+			mode:     types.StateImportModeMerge,
+			expState: []types.Model{existing[0], {Key: []byte("foo"), Value: []byte("bar")}},
+		},
+		"merge replaces existing key": {
+			contract: example.Contract,
+			models:   []types.Model{{Key: configKey, Value: []byte("{}")}},
+			mode:     types.StateImportModeMerge,
+			expState: []types.Model{{Key: configKey, Value: []byte("{}")}},
+		},
+		"unspecified mode": {
+			contract: example.Contract,
+			mode:     types.StateImportModeUnspecified,
+			expErr:   types.ErrEmpty,
+		},
+		"duplicate keys": {
+			contract: example.Contract,
+			models:   []types.Model{{Key: []byte("foo")}, {Key: []byte("foo")}},
+			mode:     types.StateImportModeMerge,
+			expErr:   types.ErrDuplicate,
+		},
+		"unknown contract": {
+			contract: RandomAccountAddress(t),
+			mode:     types.StateImportModeOverwrite,
+			expErr:   types.ErrNoSuchContractFn(""),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+			// when
+			gotErr := k.ImportContractState(ctx, spec.contract, spec.models, spec.mode)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Equal(t, existing, contractState(ctx, k, example.Contract))
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expState, contractState(ctx, k, example.Contract))
+			expEvents := sdk.Events{sdk.NewEvent(
+				types.EventTypeReplaceContractState,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+				sdk.NewAttribute(types.AttributeKeyStateImportMode, spec.mode.String()),
+			)}
+			assert.Equal(t, expEvents, ctx.EventManager().Events())
+		})
+	}
+}
@@ -12,7 +12,7 @@ var _ types.ContractOpsKeeper = PermissionedKeeper{}
 
 // decoratedKeeper contains a subset of the wasm keeper that are already or can be guarded by an authorization policy in the future
 type decoratedKeeper interface {
-	create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error)
+	create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy, opts ...codeInfoOption) (codeID uint64, checksum []byte, err error)
 
 	instantiate(
 		ctx context.Context,
@@ -25,15 +25,16 @@ type decoratedKeeper interface {
 		authZ types.AuthorizationPolicy,
 	) (sdk.AccAddress, []byte, error)
 
-	migrate(ctx context.Context, contractAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ types.AuthorizationPolicy) ([]byte, error)
+	migrate(ctx context.Context, contractAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, deposit sdk.Coins, authZ types.AuthorizationPolicy) ([]byte, error)
 	setContractAdmin(ctx context.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ types.AuthorizationPolicy) error
 	pinCode(ctx context.Context, codeID uint64) error
 	unpinCode(ctx context.Context, codeID uint64) error
 	execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error)
 	Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
-	setContractInfoExtension(ctx context.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
+	SetContractInfoExtension(ctx context.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
 	setAccessConfig(ctx context.Context, codeID uint64, caller sdk.AccAddress, newConfig types.AccessConfig, authz types.AuthorizationPolicy) error
 	ClassicAddressGenerator() AddressGenerator
+	PredictableAddressGenerator(creator sdk.AccAddress, salt, msg []byte, fixMsg bool) AddressGenerator
 }
 
 type PermissionedKeeper struct {
@@ -88,7 +89,7 @@ func (p PermissionedKeeper) Instantiate2(
 		initMsg,
 		label,
 		deposit,
-		PredictableAddressGenerator(creator, salt, initMsg, fixMsg),
+		p.nested.PredictableAddressGenerator(creator, salt, initMsg, fixMsg),
 		p.authZPolicy,
 	)
 }
@@ -98,7 +99,7 @@ func (p PermissionedKeeper) Execute(ctx sdk.Context, contractAddress, caller sdk
 }
 
 func (p PermissionedKeeper) Migrate(ctx sdk.Context, contractAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error) {
-	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
+	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, nil, p.authZPolicy)
 }
 
 func (p PermissionedKeeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error) {
@@ -123,7 +124,7 @@ func (p PermissionedKeeper) UnpinCode(ctx sdk.Context, codeID uint64) error {
 
 // SetContractInfoExtension updates the extra attributes that can be stored with the contract info
 func (p PermissionedKeeper) SetContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error {
-	return p.nested.setContractInfoExtension(ctx, contract, extra)
+	return p.nested.SetContractInfoExtension(ctx, contract, extra)
 }
 
 // SetAccessConfig updates the access config of a code id.
@@ -136,7 +136,7 @@ func TestInstantiate2(t *testing.T) {
 			sender:  example.CreatorAddr,
 			salt:    []byte(mySalt),
 			initMsg: mustMarshal(t, HackatomExampleInitMsg{Verifier: otherAddr, Beneficiary: beneficiaryAddr}),
-			expErr:  types.ErrDuplicate,
+			expErr:  types.ErrContractAddressExists,
 		},
 		"fix msg - long msg": {
 			setup:   exampleWithFixMsg,
@@ -172,6 +172,79 @@ func TestInstantiate2(t *testing.T) {
 	}
 }
 
+func TestInstantiate2Idempotent(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	parentCtx = parentCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	k := keepers.WasmKeeper
+
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	sameChecksum := StoreHackatomExampleContract(t, parentCtx, keepers)
+	mock := &wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(mock)
+	k.wasmVM = mock // set mock to not fail on contract init message
+
+	const mySalt = "my salt"
+	newMsg := func(codeID uint64, salt string, idempotent bool) *types.MsgInstantiateContract2 {
+		return &types.MsgInstantiateContract2{
+			Sender:     example.CreatorAddr.String(),
+			CodeID:     codeID,
+			Label:      "my label",
+			Msg:        []byte(`{}`),
+			Salt:       []byte(salt),
+			Idempotent: idempotent,
+		}
+	}
+	rsp, err := NewMsgServerImpl(k).InstantiateContract2(parentCtx, newMsg(example.CodeID, mySalt, false))
+	require.NoError(t, err)
+	existing := rsp.Address
+
+	specs := map[string]struct {
+		msg         *types.MsgInstantiateContract2
+		expExisting bool
+		expErr      error
+	}{
+		"idempotent - same code, creator and salt": {
+			msg:         newMsg(example.CodeID, mySalt, true),
+			expExisting: true,
+		},
+		"idempotent - new address": {
+			msg: newMsg(example.CodeID, "other salt", true),
+		},
+		"idempotent - other code with same checksum": {
+			msg:    newMsg(sameChecksum.CodeID, mySalt, true),
+			expErr: types.ErrContractAddressExists,
+		},
+		"not idempotent": {
+			msg:    newMsg(example.CodeID, mySalt, false),
+			expErr: types.ErrContractAddressExists,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+			// when
+			gotRsp, gotErr := NewMsgServerImpl(k).InstantiateContract2(ctx, spec.msg)
+
+			// then
+			if spec.expErr != nil {
+				assert.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			if spec.expExisting {
+				assert.Equal(t, existing, gotRsp.Address)
+				assert.Nil(t, gotRsp.Data)
+				assert.Empty(t, ctx.EventManager().Events())
+				return
+			}
+			assert.NotEqual(t, existing, gotRsp.Address)
+			assert.True(t, k.HasContractInfo(ctx, sdk.MustAccAddressFromBech32(gotRsp.Address)))
+		})
+	}
+}
+
 func TestQuerierError(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	parentCtx = parentCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetContractsByCodeAndLabel returns the addresses of all contracts of the code with the given label.
+// Labels are not unique, so that multiple contracts can be returned. The addresses are sorted by their bytes.
+func (k Keeper) GetContractsByCodeAndLabel(ctx context.Context, codeID uint64, label string) []sdk.AccAddress {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractLabelIndexPrefix(codeID, label))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	var r []sdk.AccAddress
+	for ; iter.Valid(); iter.Next() {
+		r = append(r, iter.Key())
+	}
+	return r
+}
+
+// checkLabelUniqueness returns an error when the EnforceLabelUniquenessPerCode param is set and the label is
+// already used by another contract of the code. Contracts can not be deleted, so that a label is only released when the
+// contract that uses it changes its label or is migrated to another code. The lookup is charged and stops at the first
+// contract other than the given one.
+func (k Keeper) checkLabelUniqueness(ctx context.Context, codeID uint64, label string, contractAddr sdk.AccAddress) error {
+	if !k.GetCachedParams(ctx).EnforceLabelUniquenessPerCode {
+		return nil
+	}
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractLabelIndexPrefix(codeID, label))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if addr := sdk.AccAddress(iter.Key()); !addr.Equals(contractAddr) {
+			return errorsmod.Wrapf(types.ErrDuplicateLabel, "used by contract %s of code %d", addr.String(), codeID)
+		}
+	}
+	return nil
+}
+
+// addToContractLabelIndex adds the contract to the (code id, label) index
+func (k Keeper) addToContractLabelIndex(ctx context.Context, contractAddr sdk.AccAddress, codeID uint64, label string) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractLabelIndexKey(codeID, label, contractAddr), []byte{})
+}
+
+// removeFromContractLabelIndex removes the contract from the (code id, label) index
+func (k Keeper) removeFromContractLabelIndex(ctx context.Context, contractAddr sdk.AccAddress, codeID uint64, label string) error {
+	return k.storeService.OpenKVStore(ctx).Delete(types.GetContractLabelIndexKey(codeID, label, contractAddr))
+}
@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestGetContractsByCodeAndLabel(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	example1 := StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	instantiate := func(codeID uint64, label string) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, creator, initMsgBz, label, nil)
+		require.NoError(t, err)
+		return addr
+	}
+	sorted := func(addrs ...sdk.AccAddress) []sdk.AccAddress {
+		sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i], addrs[j]) < 0 })
+		return addrs
+	}
+
+	// two contracts of the same code share a label
+	contract1 := instantiate(example1.CodeID, "my label")
+	contract2 := instantiate(example1.CodeID, "my label")
+	contract3 := instantiate(example1.CodeID, "other label")
+	contract4 := instantiate(example2.CodeID, "my label")
+
+	assert.Equal(t, sorted(contract1, contract2), k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "my label"))
+	assert.Equal(t, []sdk.AccAddress{contract3}, k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "other label"))
+	assert.Equal(t, []sdk.AccAddress{contract4}, k.GetContractsByCodeAndLabel(ctx, example2.CodeID, "my label"))
+	assert.Empty(t, k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "unknown label"))
+	assert.Empty(t, k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "my"))
+
+	// and when the label is updated
+	require.NoError(t, k.setContractLabel(ctx, contract2, creator, "other label", DefaultAuthorizationPolicy{}))
+	assert.Equal(t, []sdk.AccAddress{contract1}, k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "my label"))
+	assert.Equal(t, sorted(contract2, contract3), k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "other label"))
+
+	// and when the contract is migrated to another code
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.AccAddress `json:"verifier"`
+	}{Verifier: RandomAccountAddress(t)})
+	require.NoError(t, err)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contract1, creator, example2.CodeID, migMsgBz)
+	require.NoError(t, err)
+	assert.Empty(t, k.GetContractsByCodeAndLabel(ctx, example1.CodeID, "my label"))
+	assert.Equal(t, sorted(contract1, contract4), k.GetContractsByCodeAndLabel(ctx, example2.CodeID, "my label"))
+
+	// and when the contract is removed
+	require.NoError(t, k.removeContract(ctx, contract4))
+	assert.Equal(t, []sdk.AccAddress{contract1}, k.GetContractsByCodeAndLabel(ctx, example2.CodeID, "my label"))
+
+	// and when the contract is imported
+	contractInfo := k.GetContractInfo(ctx, contract1)
+	history := k.GetContractHistory(ctx, contract1)
+	require.NoError(t, k.removeContract(ctx, contract1))
+	require.Empty(t, k.GetContractsByCodeAndLabel(ctx, example2.CodeID, "my label"))
+	require.NoError(t, k.importContract(ctx, contract1, contractInfo, nil, history))
+	assert.Equal(t, []sdk.AccAddress{contract1}, k.GetContractsByCodeAndLabel(ctx, example2.CodeID, "my label"))
+}
+
+func TestLabelUniquenessPerCode(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := keepers.Faucet.NewFundedRandomAccount(parentCtx, sdk.NewInt64Coin("denom", 1000))
+	example1 := StoreHackatomExampleContract(t, parentCtx, keepers)
+	example2 := StoreHackatomExampleContract(t, parentCtx, keepers)
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.AccAddress `json:"verifier"`
+	}{Verifier: RandomAccountAddress(t)})
+	require.NoError(t, err)
+
+	for _, enforced := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enforced: %v", enforced), func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.EnforceLabelUniquenessPerCode = enforced
+			require.NoError(t, k.SetParams(ctx, params))
+			instantiate := func(codeID uint64, label string) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, creator, initMsgBz, label, nil)
+				return addr, err
+			}
+			assertErr := func(t *testing.T, err error) {
+				t.Helper()
+				if enforced {
+					assert.ErrorIs(t, err, types.ErrDuplicateLabel)
+					return
+				}
+				assert.NoError(t, err)
+			}
+			contract1, err := instantiate(example1.CodeID, "my label")
+			require.NoError(t, err)
+
+			// when the label is used by a contract of the same code
+			_, err = instantiate(example1.CodeID, "my label")
+			assertErr(t, err)
+
+			// and when the label is used by a contract of another code
+			contract2, err := instantiate(example2.CodeID, "my label")
+			require.NoError(t, err)
+
+			// and when a contract is migrated to the code of a contract with the same label
+			_, err = keepers.ContractKeeper.Migrate(ctx, contract2, creator, example1.CodeID, migMsgBz)
+			assertErr(t, err)
+
+			// and when the label is updated to a used label
+			contract3, err := instantiate(example1.CodeID, "other label")
+			require.NoError(t, err)
+			assertErr(t, k.setContractLabel(ctx, contract3, creator, "my label", DefaultAuthorizationPolicy{}))
+
+			// and when the contract keeps its own label
+			require.NoError(t, k.setContractLabel(ctx, contract1, creator, "my label", DefaultAuthorizationPolicy{}))
+
+			// and when the label was released by the other contract
+			require.NoError(t, k.setContractLabel(ctx, contract1, creator, "new label", DefaultAuthorizationPolicy{}))
+			_, err = instantiate(example1.CodeID, "my label")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckLabelUniquenessGas(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	// labels are used multiple times before the uniqueness is enforced
+	for _, label := range []string{"single", "shared", "shared", "shared"} {
+		_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, label, nil)
+		require.NoError(t, err)
+	}
+	params := k.GetParams(ctx)
+	params.EnforceLabelUniquenessPerCode = true
+	require.NoError(t, k.SetParams(ctx, params))
+	checkGas := func(label string) uint64 {
+		gasCtx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		require.ErrorIs(t, k.checkLabelUniqueness(gasCtx, example.CodeID, label, RandomAccountAddress(t)), types.ErrDuplicateLabel)
+		return gasCtx.GasMeter().GasConsumed()
+	}
+
+	// when
+	gasSingle, gasShared := checkGas("single"), checkGas("shared")
+
+	// then the lookup is charged and stops at the first contract
+	assert.NotZero(t, gasSingle)
+	assert.Equal(t, gasSingle, gasShared)
+}
+
+func TestQueryContractsByLabel(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 1000))
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	var myContracts []string
+	for _, label := range []string{"my label", "my label", "my label", "other label"} {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, label, nil)
+		require.NoError(t, err)
+		if label == "my label" {
+			myContracts = append(myContracts, addr.String())
+		}
+	}
+	sort.Slice(myContracts, func(i, j int) bool {
+		return bytes.Compare(sdk.MustAccAddressFromBech32(myContracts[i]), sdk.MustAccAddressFromBech32(myContracts[j])) < 0
+	})
+	q := Querier(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		req          *types.QueryContractsByLabelRequest
+		expContracts []string
+		expErr       bool
+	}{
+		"all contracts with label": {
+			req:          &types.QueryContractsByLabelRequest{CodeId: example.CodeID, Label: "my label"},
+			expContracts: myContracts,
+		},
+		"with pagination": {
+			req:          &types.QueryContractsByLabelRequest{CodeId: example.CodeID, Label: "my label", Pagination: &query.PageRequest{Limit: 2}},
+			expContracts: myContracts[:2],
+		},
+		"unknown label": {
+			req:          &types.QueryContractsByLabelRequest{CodeId: example.CodeID, Label: "unknown"},
+			expContracts: []string{},
+		},
+		"unknown code": {
+			req:          &types.QueryContractsByLabelRequest{CodeId: example.CodeID + 1, Label: "my label"},
+			expContracts: []string{},
+		},
+		"empty code id": {
+			req:    &types.QueryContractsByLabelRequest{Label: "my label"},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractsByLabel(ctx, spec.req)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expContracts, got.Contracts)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// SetContractLock locks or unlocks the contract. All execute, migrate, sudo, reply and IBC calls to a locked
+// contract are rejected with ErrContractLocked. Smart queries are rejected only when the
+// RejectLockedContractQueries param is set. The lock is stored in the contract info.
+func (k Keeper) SetContractLock(ctx context.Context, contractAddr sdk.AccAddress, locked bool) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	contractInfo.Locked = locked
+	k.mustStoreContractInfo(ctx, contractAddr, contractInfo)
+
+	eventType := types.EventTypeUnlockContract
+	if locked {
+		eventType = types.EventTypeLockContract
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		eventType,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+	))
+	return nil
+}
+
+// IsContractLocked returns true when the contract is locked
+func (k Keeper) IsContractLocked(ctx context.Context, contractAddr sdk.AccAddress) bool {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	return contractInfo != nil && contractInfo.Locked
+}
+
+// checkContractLock returns an error when the contract is locked
+func checkContractLock(contractInfo types.ContractInfo, contractAddr sdk.AccAddress) error {
+	if contractInfo.Locked {
+		return types.ErrContractLocked.Wrapf("address %s", contractAddr.String())
+	}
+	return nil
+}
+
+// checkContractQueryLock returns an error when the contract is locked and the RejectLockedContractQueries
+// param is set
+func (k Keeper) checkContractQueryLock(ctx context.Context, contractInfo types.ContractInfo, contractAddr sdk.AccAddress) error {
+	if !contractInfo.Locked || !k.GetCachedParams(ctx).RejectLockedContractQueries {
+		return nil
+	}
+	return types.ErrContractLocked.Wrapf("address %s", contractAddr.String())
+}
@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractLock(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeIBCInstantiable(&m)
+	okResult := func(wasmvm.Checksum, wasmvmtypes.Env, []byte, wasmvm.KVStore, wasmvm.GoAPI, wasmvm.Querier, wasmvm.GasMeter, uint64, wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	m.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, msg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return okResult(codeID, env, msg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+	}
+	m.MigrateFn = okResult
+	m.MigrateWithInfoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg []byte, _ wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return okResult(codeID, env, msg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+	}
+	m.SudoFn = okResult
+	m.QueryFn = func(wasmvm.Checksum, wasmvmtypes.Env, []byte, wasmvm.KVStore, wasmvm.GoAPI, wasmvm.Querier, wasmvm.GasMeter, uint64, wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		return &wasmvmtypes.QueryResult{Ok: []byte(`{}`)}, 0, nil
+	}
+	m.IBCChannelOpenFn = func(wasmvm.Checksum, wasmvmtypes.Env, wasmvmtypes.IBCChannelOpenMsg, wasmvm.KVStore, wasmvm.GoAPI, wasmvm.Querier, wasmvm.GasMeter, uint64, wasmvmtypes.UFraction) (*wasmvmtypes.IBCChannelOpenResult, uint64, error) {
+		return &wasmvmtypes.IBCChannelOpenResult{Ok: &wasmvmtypes.IBC3ChannelOpenResponse{}}, 0, nil
+	}
+	m.IBCPacketTimeoutFn = func(wasmvm.Checksum, wasmvmtypes.Env, wasmvmtypes.IBCPacketTimeoutMsg, wasmvm.KVStore, wasmvm.GoAPI, wasmvm.Querier, wasmvm.GasMeter, uint64, wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+		return &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}}, 0, nil
+	}
+
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+
+	specs := map[string]struct {
+		call                  func(ctx sdk.Context) error
+		rejectQueries         bool
+		expRejectedWhenLocked bool
+	}{
+		"execute": {
+			call: func(ctx sdk.Context) error {
+				_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+				return err
+			},
+			expRejectedWhenLocked: true,
+		},
+		"migrate": {
+			call: func(ctx sdk.Context) error {
+				_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`))
+				return err
+			},
+			expRejectedWhenLocked: true,
+		},
+		"sudo": {
+			call: func(ctx sdk.Context) error {
+				_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+				return err
+			},
+			expRejectedWhenLocked: true,
+		},
+		"ibc open channel": {
+			call: func(ctx sdk.Context) error {
+				_, err := k.OnOpenChannel(ctx, example.Contract, wasmvmtypes.IBCChannelOpenMsg{OpenInit: &wasmvmtypes.IBCOpenInit{}})
+				return err
+			},
+			expRejectedWhenLocked: true,
+		},
+		"ibc timeout packet": {
+			call: func(ctx sdk.Context) error {
+				return k.OnTimeoutPacket(ctx, example.Contract, wasmvmtypes.IBCPacketTimeoutMsg{})
+			},
+			expRejectedWhenLocked: true,
+		},
+		"smart query": {
+			call: func(ctx sdk.Context) error {
+				_, err := k.QuerySmart(ctx, example.Contract, []byte(`{}`))
+				return err
+			},
+		},
+		"smart query rejected by param": {
+			call: func(ctx sdk.Context) error {
+				_, err := k.QuerySmart(ctx, example.Contract, []byte(`{}`))
+				return err
+			},
+			rejectQueries:         true,
+			expRejectedWhenLocked: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.RejectLockedContractQueries = spec.rejectQueries
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when locked
+			lockCtx, _ := ctx.CacheContext()
+			require.NoError(t, k.SetContractLock(lockCtx, example.Contract, true))
+			require.True(t, k.IsContractLocked(lockCtx, example.Contract))
+			gotErr := spec.call(lockCtx)
+
+			// then
+			if spec.expRejectedWhenLocked {
+				require.ErrorIs(t, gotErr, types.ErrContractLocked)
+			} else {
+				require.NoError(t, gotErr)
+			}
+
+			// and when unlocked again
+			require.NoError(t, k.SetContractLock(lockCtx, example.Contract, false))
+			assert.False(t, k.IsContractLocked(lockCtx, example.Contract))
+			assert.NoError(t, spec.call(lockCtx))
+		})
+	}
+}
+
+func TestSetContractLock(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		contract     sdk.AccAddress
+		locked       bool
+		expErr       error
+		expEventType string
+	}{
+		"lock": {
+			contract:     example.Contract,
+			locked:       true,
+			expEventType: types.EventTypeLockContract,
+		},
+		"unlock": {
+			contract:     example.Contract,
+			expEventType: types.EventTypeUnlockContract,
+		},
+		"unknown contract": {
+			contract: RandomAccountAddress(t),
+			locked:   true,
+			expErr:   types.ErrNoSuchContractFn(""),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+			// when
+			gotErr := k.SetContractLock(ctx, spec.contract, spec.locked)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Empty(t, ctx.EventManager().Events())
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.locked, k.IsContractLocked(ctx, spec.contract))
+			require.Len(t, ctx.EventManager().Events(), 1)
+			assert.Equal(t, spec.expEventType, ctx.EventManager().Events()[0].Type)
+		})
+	}
+}
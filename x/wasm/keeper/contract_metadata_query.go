@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// ContractMetadataQueryCapability is the capability that contracts declare with `requires_contract_metadata_query`
+// to use the contract metadata query. It is added to the available capabilities by the WithContractMetadataQueries
+// option and can be disabled by governance with the disabled capabilities param.
+const ContractMetadataQueryCapability = "contract_metadata_query"
+
+// ContractMetadataQuery is a custom query to read the metadata of another contract, including the label that is
+// not part of the `WasmQuery::ContractInfo` response defined by wasmvm.
+// It is sent by contracts as `{"contract_metadata":{"address":<bech32 address>}}`.
+type ContractMetadataQuery struct {
+	Address string `json:"address"`
+}
+
+// ContractMetadataResponse is the response to the ContractMetadataQuery
+type ContractMetadataResponse struct {
+	CodeID  uint64 `json:"code_id"`
+	Creator string `json:"creator"`
+	// Admin is set when the contract can be migrated
+	Admin  string `json:"admin,omitempty"`
+	Label  string `json:"label"`
+	Pinned bool   `json:"pinned"`
+}
+
+type contractMetadataSource interface {
+	contractMetaDataSource
+	IsPinnedCode(ctx context.Context, codeID uint64) bool
+}
+
+// ContractMetadataQuerier handles ContractMetadataQuery custom queries with the committed contract state. Any other
+// custom query is passed to the next custom querier.
+// Addresses without a contract return a no such contract error to the contract.
+func ContractMetadataQuerier(contracts contractMetadataSource, params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			ContractMetadata *ContractMetadataQuery `json:"contract_metadata,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.ContractMetadata == nil {
+			return next(ctx, request)
+		}
+		if slices.Contains(params.GetCachedParams(ctx).DisabledCapabilities, ContractMetadataQueryCapability) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "contract metadata queries are disabled on this chain"}
+		}
+		contractAddr := msg.ContractMetadata.Address
+		addr, err := sdk.AccAddressFromBech32(contractAddr)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "address")
+		}
+		info := contracts.GetContractInfo(ctx, addr)
+		if info == nil {
+			return nil, types.ErrNoSuchContractFn(contractAddr).Wrapf("address %s", contractAddr)
+		}
+		return json.Marshal(ContractMetadataResponse{
+			CodeID:  info.CodeID,
+			Creator: info.Creator,
+			Admin:   info.Admin,
+			Label:   info.Label,
+			Pinned:  contracts.IsPinnedCode(ctx, info.CodeID),
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractMetadataQuery(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	m.PinFn = func(wasmvm.Checksum) error { return nil }
+	var (
+		gotResult []byte
+		gotErr    error
+	)
+	// the calling contract sends the execute msg as custom query
+	m.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, msg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		gotResult, gotErr = querier.Query(wasmvmtypes.QueryRequest{Custom: msg}, gasLimit)
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithContractMetadataQueries())
+	k := keepers.WasmKeeper
+
+	caller := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	otherCode := StoreRandomContract(t, parentCtx, keepers, &m)
+	require.NoError(t, k.pinCode(parentCtx, otherCode.CodeID))
+	otherContract, _, err := keepers.ContractKeeper.Instantiate(parentCtx, otherCode.CodeID, otherCode.CreatorAddr, caller.Contract, []byte(`{}`), "other", nil)
+	require.NoError(t, err)
+
+	query := func(addr string) []byte {
+		return []byte(`{"contract_metadata":{"address":"` + addr + `"}}`)
+	}
+	specs := map[string]struct {
+		src            []byte
+		disabled       bool
+		exp            *ContractMetadataResponse
+		expNoSuch      bool
+		expErr         bool
+		expUnsupported bool
+	}{
+		"other contract": {
+			src: query(otherContract.String()),
+			exp: &ContractMetadataResponse{
+				CodeID:  otherCode.CodeID,
+				Creator: otherCode.CreatorAddr.String(),
+				Admin:   caller.Contract.String(),
+				Label:   "other",
+				Pinned:  true,
+			},
+		},
+		"own contract": {
+			src: query(caller.Contract.String()),
+			exp: &ContractMetadataResponse{
+				CodeID:  caller.CodeID,
+				Creator: caller.CreatorAddr.String(),
+				Admin:   caller.CreatorAddr.String(),
+			},
+		},
+		"non existing address": {
+			src:       query(RandomBech32AccountAddress(t)),
+			expNoSuch: true,
+		},
+		"invalid address": {
+			src:    query("invalid"),
+			expErr: true,
+		},
+		"disabled capability": {
+			src:            query(otherContract.String()),
+			disabled:       true,
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.disabled {
+				params := k.GetParams(ctx)
+				params.DisabledCapabilities = []string{ContractMetadataQueryCapability}
+				require.NoError(t, k.SetParams(ctx, params))
+			}
+			gotResult, gotErr = nil, nil
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, caller.Contract, caller.CreatorAddr, spec.src, nil)
+			require.NoError(t, err)
+
+			// then
+			switch {
+			case spec.expNoSuch:
+				var noSuch wasmvmtypes.NoSuchContract
+				assert.ErrorAs(t, gotErr, &noSuch)
+				return
+			case spec.expUnsupported:
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			case spec.expErr:
+				assert.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got ContractMetadataResponse
+			require.NoError(t, json.Unmarshal(gotResult, &got))
+			assert.Equal(t, *spec.exp, got)
+		})
+	}
+}
+
+func TestContractMetadataQuerierPassesOtherQueries(t *testing.T) {
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	q := ContractMetadataQuerier(nil, mockParamsSource(types.DefaultParams()), next)
+
+	gotResult, gotErr := q(sdk.Context{}, []byte(`{"foo":{}}`))
+
+	require.NoError(t, gotErr)
+	assert.Equal(t, []byte("next"), gotResult)
+}
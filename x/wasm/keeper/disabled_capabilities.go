@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// checkRequiredCapabilities returns an error when any of the required capabilities of a code is disabled by the
+// disabled capabilities param.
+func (k Keeper) checkRequiredCapabilities(ctx sdk.Context, requiredCapabilities []string) error {
+	disabled := k.GetCachedParams(ctx).DisabledCapabilities
+	for _, c := range requiredCapabilities {
+		if slices.Contains(disabled, c) {
+			return errorsmod.Wrapf(types.ErrUnsupportedForContract, "required capability %q is disabled", c)
+		}
+	}
+	return nil
+}
+
+// checkCodeCapabilities returns an error when any of the required capabilities of the stored code is disabled. The
+// capabilities are only read when a capability is disabled.
+func (k Keeper) checkCodeCapabilities(ctx sdk.Context, codeID uint64) error {
+	if len(k.GetCachedParams(ctx).DisabledCapabilities) == 0 {
+		return nil
+	}
+	return k.checkRequiredCapabilities(ctx, k.GetCodeCapabilities(ctx, codeID))
+}
+
+// parseCapabilities returns the capabilities of the comma separated list of a wasmvm analysis report
+func parseCapabilities(s string) []string {
+	var r []string
+	for _, c := range strings.Split(s, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			r = append(r, c)
+		}
+	}
+	return r
+}
+
+// AnalyzeCodeCapabilities returns the capabilities required by the code. A capability is available when wasmvm was
+// configured with it and it is not disabled by the disabled capabilities param.
+func (k Keeper) AnalyzeCodeCapabilities(ctx context.Context, codeID uint64) ([]types.CodeCapability, error) {
+	if !k.containsCodeInfo(ctx, codeID) {
+		return nil, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	disabled := k.GetParams(ctx).DisabledCapabilities
+	var r []types.CodeCapability
+	for _, c := range k.GetCodeCapabilities(ctx, codeID) {
+		r = append(r, types.CodeCapability{
+			Name:      c,
+			Available: slices.Contains(k.availableCapabilities, c) && !slices.Contains(disabled, c),
+		})
+	}
+	return r, nil
+}
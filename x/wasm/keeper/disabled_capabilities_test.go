@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDisabledCapabilities(t *testing.T) {
+	mock := wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.AnalyzeCodeFn = func(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+		return &wasmvmtypes.AnalysisReport{RequiredCapabilities: "iterator, stargate"}, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := StoreRandomContract(t, parentCtx, keepers, &mock)
+	require.Equal(t, []string{"iterator", "stargate"}, k.GetCodeCapabilities(parentCtx, example.CodeID))
+
+	specs := map[string]struct {
+		disabled []string
+		expErr   bool
+	}{
+		"none disabled": {},
+		"other disabled": {
+			disabled: []string{"staking"},
+		},
+		"required disabled": {
+			disabled: []string{"staking", "stargate"},
+			expErr:   true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.DisabledCapabilities = spec.disabled
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when
+			_, _, gotStoreErr := keepers.ContractKeeper.Create(ctx, example.CreatorAddr, append(wasmIdent, []byte("other")...), nil)
+			_, _, gotInstErr := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "label", nil)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, gotStoreErr, types.ErrCreateFailed)
+				require.ErrorIs(t, gotInstErr, types.ErrUnsupportedForContract)
+				return
+			}
+			assert.NoError(t, gotStoreErr)
+			assert.NoError(t, gotInstErr)
+		})
+	}
+}
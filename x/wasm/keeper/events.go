@@ -1,7 +1,9 @@
 package keeper
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
@@ -44,6 +46,55 @@ func newCustomEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk
 	return events, nil
 }
 
+// maxRawContractEventAttributesSize is the max size of the json encoded attributes of a raw contract event.
+// Larger attributes are left out and the event is marked as truncated.
+const maxRawContractEventAttributesSize = 8 * 1024
+
+// newRawContractEvents creates an event for every custom contract event that carries the original event type
+// and the json encoded attributes as returned by the contract
+func newRawContractEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk.AccAddress) (sdk.Events, error) {
+	events := make(sdk.Events, 0, len(evts))
+	for _, e := range evts {
+		attrsBz, err := json.Marshal(e.Attributes)
+		if err != nil {
+			return nil, errorsmod.Wrap(types.ErrInvalidEvent, err.Error())
+		}
+		truncated := len(attrsBz) > maxRawContractEventAttributesSize
+		if truncated {
+			attrsBz = []byte("[]")
+		}
+		events = append(events, sdk.NewEvent(
+			types.EventTypeRawContractEvent,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyRawEventType, e.Type),
+			sdk.NewAttribute(types.AttributeKeyRawEventAttributes, string(attrsBz)),
+			sdk.NewAttribute(types.AttributeKeyRawEventTruncated, strconv.FormatBool(truncated)),
+		))
+	}
+	return events, nil
+}
+
+// checkMaxEvents returns an error when the events that handleContractResponse emits for the attributes and custom
+// events of a contract response exceed the max events per call param: one wasm event for all attributes, one event
+// per custom event and one raw event per custom event when raw events are enabled.
+func checkMaxEvents(params types.Params, attrs []wasmvmtypes.EventAttribute, evts wasmvmtypes.Array[wasmvmtypes.Event]) error {
+	if params.MaxEventsPerCall == 0 {
+		return nil
+	}
+	var n int
+	if len(attrs) != 0 {
+		n++
+	}
+	n += len(evts)
+	if params.EmitRawContractEvents {
+		n += len(evts)
+	}
+	if n > int(params.MaxEventsPerCall) {
+		return errorsmod.Wrapf(types.ErrExceedMaxEvents, "got %d, max %d", n, params.MaxEventsPerCall)
+	}
+	return nil
+}
+
 // convert and add contract address issuing this event
 func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress) ([]sdk.Attribute, error) {
 	attrs := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String())}
@@ -2,13 +2,18 @@ package keeper
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -205,6 +210,163 @@ func TestNewCustomEvents(t *testing.T) {
 	}
 }
 
+func TestNewRawContractEvents(t *testing.T) {
+	myContract := RandomAccountAddress(t)
+	specs := map[string]struct {
+		src wasmvmtypes.Array[wasmvmtypes.Event]
+		exp sdk.Events
+	}{
+		"verbatim": {
+			src: wasmvmtypes.Array[wasmvmtypes.Event]{{
+				Type:       " foo",
+				Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: " myVal"}},
+			}},
+			exp: sdk.Events{sdk.NewEvent("raw_contract_event",
+				sdk.NewAttribute("_contract_address", myContract.String()),
+				sdk.NewAttribute("event_type", " foo"),
+				sdk.NewAttribute("attributes", `[{"key":"myKey","value":" myVal"}]`),
+				sdk.NewAttribute("truncated", "false"))},
+		},
+		"attributes too large": {
+			src: wasmvmtypes.Array[wasmvmtypes.Event]{{
+				Type:       "foo",
+				Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: strings.Repeat("a", maxRawContractEventAttributesSize)}},
+			}},
+			exp: sdk.Events{sdk.NewEvent("raw_contract_event",
+				sdk.NewAttribute("_contract_address", myContract.String()),
+				sdk.NewAttribute("event_type", "foo"),
+				sdk.NewAttribute("attributes", `[]`),
+				sdk.NewAttribute("truncated", "true"))},
+		},
+		"nil": {
+			exp: sdk.Events{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotEvents, err := newRawContractEvents(spec.src, myContract)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, gotEvents)
+		})
+	}
+}
+
+func TestEmitRawContractEvents(t *testing.T) {
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Events: []wasmvmtypes.Event{{
+				Type:       "foo",
+				Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myVal"}},
+			}}}}, 0, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled: %t", enabled), func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.EmitRawContractEvents = enabled
+			require.NoError(t, k.SetParams(ctx, params))
+			em := sdk.NewEventManager()
+
+			// when
+			_, err := k.execute(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			require.NoError(t, err)
+			var gotTypes []string
+			for _, e := range em.Events() {
+				gotTypes = append(gotTypes, e.Type)
+			}
+			assert.Contains(t, gotTypes, "wasm-foo")
+			if enabled {
+				assert.Contains(t, gotTypes, types.EventTypeRawContractEvent)
+			} else {
+				assert.NotContains(t, gotTypes, types.EventTypeRawContractEvent)
+			}
+		})
+	}
+}
+
+func TestMaxEventsPerCall(t *testing.T) {
+	// the contract emits one wasm event for the attributes and 3 custom events
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+				Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myVal"}},
+				Events: []wasmvmtypes.Event{
+					{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myVal"}}},
+					{Type: "bar", Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myVal"}}},
+					{Type: "baz", Attributes: []wasmvmtypes.EventAttribute{{Key: "myKey", Value: "myVal"}}},
+				},
+			}}, 0, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	specs := map[string]struct {
+		maxEvents uint32
+		rawEvents bool
+		expErr    bool
+	}{
+		"unlimited": {},
+		"at max": {
+			maxEvents: 4,
+		},
+		"above max": {
+			maxEvents: 3,
+			expErr:    true,
+		},
+		"at max with raw events": {
+			maxEvents: 7,
+			rawEvents: true,
+		},
+		"above max with raw events": {
+			maxEvents: 6,
+			rawEvents: true,
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxEventsPerCall = spec.maxEvents
+			params.EmitRawContractEvents = spec.rawEvents
+			require.NoError(t, k.SetParams(ctx, params))
+			em := sdk.NewEventManager()
+
+			// when
+			_, err := k.execute(ctx.WithEventManager(em), example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, err, types.ErrExceedMaxEvents)
+				return
+			}
+			require.NoError(t, err)
+			if spec.maxEvents == 0 {
+				return
+			}
+			// and the count matches the emitted contract events
+			var gotContractEvents uint32
+			for _, e := range em.Events() {
+				if e.Type != types.EventTypeExecute {
+					gotContractEvents++
+				}
+			}
+			assert.Equal(t, spec.maxEvents, gotContractEvents)
+		})
+	}
+}
+
 func TestNewWasmModuleEvent(t *testing.T) {
 	myContract := RandomAccountAddress(t)
 	specs := map[string]struct {
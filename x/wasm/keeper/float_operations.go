@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// checkFloatOperations rejects uncompressed wasm code that uses floats when the reject float operations param is set.
+// Without the param, floats are handled by wasmvm like any other instruction.
+func (k Keeper) checkFloatOperations(ctx sdk.Context, wasmCode []byte) error {
+	if !k.GetCachedParams(ctx).RejectFloatOperations {
+		return nil
+	}
+	found, err := ioutils.FindFloatUsage(wasmCode)
+	if err != nil {
+		return errorsmod.Wrap(types.ErrCreateFailed, errorsmod.Wrap(err, "float check").Error())
+	}
+	if found != "" {
+		return errorsmod.Wrapf(types.ErrCreateFailed, "float operations are rejected: %s", found)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// floatWasm is a minimal contract with a function that uses an f32.const instruction
+//
+//go:embed testdata/float.wasm
+var floatWasm []byte
+
+func TestRejectFloatOperations(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		reject     bool
+		src        []byte
+		expErrMsg  string
+		simulation bool
+	}{
+		"floats accepted": {
+			src: floatWasm,
+		},
+		"floats rejected": {
+			reject:    true,
+			src:       floatWasm,
+			expErrMsg: "float operations are rejected: f32.const in function 3",
+		},
+		"floats rejected in simulation": {
+			reject:     true,
+			src:        floatWasm,
+			simulation: true,
+			expErrMsg:  "float operations are rejected: f32.const in function 3",
+		},
+		"no floats": {
+			reject: true,
+			src:    hackatomWasm,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.RejectFloatOperations = spec.reject
+			require.NoError(t, k.SetParams(ctx, params))
+			if spec.simulation {
+				ctx = ctx.WithExecMode(sdk.ExecModeSimulate)
+			}
+
+			// when
+			codeID, _, gotErr := keepers.ContractKeeper.Create(ctx, creator, spec.src, nil)
+
+			// then
+			if spec.expErrMsg != "" {
+				require.ErrorIs(t, gotErr, types.ErrCreateFailed)
+				assert.Contains(t, gotErr.Error(), spec.expErrMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.NotNil(t, k.GetCodeInfo(ctx, codeID))
+		})
+	}
+}
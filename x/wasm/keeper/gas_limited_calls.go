@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// ExecuteWithGasLimit executes the contract with a gas limit that is independent of the remaining gas of the
+// context. See runWithGasLimit for the gas and state handling.
+func (k Keeper) ExecuteWithGasLimit(ctx sdk.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, gasLimit storetypes.Gas) ([]byte, storetypes.Gas, error) {
+	return k.runWithGasLimit(ctx, gasLimit, func(ctx sdk.Context) ([]byte, error) {
+		return k.execute(ctx, contractAddress, caller, msg, coins)
+	})
+}
+
+// SudoWithGasLimit sudo calls the contract with a gas limit that is independent of the remaining gas of the
+// context. See runWithGasLimit for the gas and state handling.
+func (k Keeper) SudoWithGasLimit(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte, gasLimit storetypes.Gas) ([]byte, storetypes.Gas, error) {
+	return k.runWithGasLimit(ctx, gasLimit, func(ctx sdk.Context) ([]byte, error) {
+		return k.Sudo(ctx, contractAddress, msg)
+	})
+}
+
+// runWithGasLimit runs the contract call in a sandbox with its own gas meter capped at the gas limit.
+// State changes are only committed on success. The gas used is returned and charged to the parent context.
+// Running out of gas within the call is returned as an error instead of aborting the parent.
+func (k Keeper) runWithGasLimit(ctx sdk.Context, gasLimit storetypes.Gas, call func(ctx sdk.Context) ([]byte, error)) (data []byte, gasUsed storetypes.Gas, err error) {
+	cacheCtx, commit := ctx.CacheContext()
+	limitedMeter := storetypes.NewGasMeter(gasLimit)
+	cacheCtx = cacheCtx.WithGasMeter(limitedMeter)
+
+	// make sure we charge the parent what was spent, also on out of gas. This sets the gas used on return.
+	defer func() {
+		gasUsed = limitedMeter.GasConsumedToLimit()
+		ctx.GasMeter().ConsumeGas(gasUsed, types.GasDescLimitedCall)
+	}()
+	// catch out of gas panic so that the parent can continue
+	defer func() {
+		if r := recover(); r != nil {
+			// if it's not an OutOfGas error, raise it again
+			if _, ok := r.(storetypes.ErrorOutOfGas); !ok {
+				moduleLogger(ctx).Info("gas limited call rethrowing panic: %#v", r)
+				panic(r)
+			}
+			data, err = nil, errorsmod.Wrap(sdkerrors.ErrOutOfGas, "contract call hit gas limit")
+		}
+	}()
+	if data, err = call(cacheCtx); err != nil {
+		return nil, gasUsed, err
+	}
+	commit()
+	return data, gasUsed, nil
+}
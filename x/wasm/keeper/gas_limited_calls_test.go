@@ -0,0 +1,98 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCallsWithGasLimit(t *testing.T) {
+	// the contract writes a key and uses the wasm gas from the msg
+	var wasmGasUsed uint64
+	var contractErr string
+	call := func(store wasmvm.KVStore) (*wasmvmtypes.ContractResult, uint64, error) {
+		store.Set([]byte("foo"), []byte("bar"))
+		if contractErr != "" {
+			return &wasmvmtypes.ContractResult{Err: contractErr}, wasmGasUsed, nil
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: []byte("ok")}}, wasmGasUsed, nil
+	}
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return call(store)
+		},
+		SudoFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return call(store)
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	const gasLimit storetypes.Gas = 200_000
+	calls := map[string]func(ctx sdk.Context) ([]byte, storetypes.Gas, error){
+		"execute": func(ctx sdk.Context) ([]byte, storetypes.Gas, error) {
+			return k.ExecuteWithGasLimit(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil, gasLimit)
+		},
+		"sudo": func(ctx sdk.Context) ([]byte, storetypes.Gas, error) {
+			return k.SudoWithGasLimit(ctx, example.Contract, []byte(`{}`), gasLimit)
+		},
+	}
+	specs := map[string]struct {
+		wasmGasUsed uint64
+		contractErr string
+		expErr      error
+	}{
+		"within limit": {
+			wasmGasUsed: 1,
+		},
+		"exceeds limit": {
+			wasmGasUsed: k.gasRegister.ToWasmVMGas(gasLimit) + 1,
+			expErr:      sdkerrors.ErrOutOfGas,
+		},
+		"contract error": {
+			wasmGasUsed: 1,
+			contractErr: "my error",
+			expErr:      types.ErrExecuteFailed,
+		},
+	}
+	for callName, call := range calls {
+		for name, spec := range specs {
+			t.Run(callName+" - "+name, func(t *testing.T) {
+				ctx, _ := parentCtx.CacheContext()
+				ctx = ctx.WithGasMeter(storetypes.NewGasMeter(10 * gasLimit))
+				wasmGasUsed, contractErr = spec.wasmGasUsed, spec.contractErr
+
+				// when
+				gotData, gotGasUsed, gotErr := call(ctx)
+
+				// then
+				assert.Equal(t, gotGasUsed, ctx.GasMeter().GasConsumed())
+				assert.LessOrEqual(t, gotGasUsed, gasLimit)
+				if spec.expErr != nil {
+					require.ErrorIs(t, gotErr, spec.expErr)
+					assert.Nil(t, gotData)
+					// and the state is not modified
+					assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("foo")))
+					return
+				}
+				require.NoError(t, gotErr)
+				assert.Equal(t, []byte("ok"), gotData)
+				assert.NotZero(t, gotGasUsed)
+				assert.Equal(t, []byte("bar"), k.QueryRaw(ctx, example.Contract, []byte("foo")))
+			})
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GasPriceQueryCapability is the capability that contracts declare with `requires_gas_price_query` to use the
+// gas price query. It is added to the available capabilities by the WithGasPriceQueries option and can be disabled
+// by governance with the disabled capabilities param.
+const GasPriceQueryCapability = "gas_price_query"
+
+// GasPriceQuery is a custom query to read the min gas prices of the chain.
+// It is sent by contracts as `{"gas_price":{}}`.
+type GasPriceQuery struct{}
+
+// GasPriceResponse is the response to the GasPriceQuery
+type GasPriceResponse struct {
+	MinGasPrices []wasmvmtypes.DecCoin `json:"min_gas_prices"`
+}
+
+// GasPriceSource provides min gas prices that are part of the consensus state, for example from a fee market module.
+type GasPriceSource interface {
+	GetMinGasPrices(ctx context.Context) sdk.DecCoins
+}
+
+// GasPriceQuerier handles GasPriceQuery custom queries. Any other custom query is passed to the next custom querier.
+//
+// The min gas prices configured in the node's app.toml are node local and not part of the consensus. When a
+// consensus source is given, its prices are returned in all execution modes. Without a source, the node local
+// prices of the context are returned only in check tx, simulation and gRPC queries. In any other mode, like
+// finalize block, the query is rejected with the same error on all nodes so that the result stays deterministic.
+func GasPriceQuerier(source GasPriceSource, params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			GasPrice *GasPriceQuery `json:"gas_price,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.GasPrice == nil {
+			return next(ctx, request)
+		}
+		if slices.Contains(params.GetCachedParams(ctx).DisabledCapabilities, GasPriceQueryCapability) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "gas price queries are disabled on this chain"}
+		}
+		var prices sdk.DecCoins
+		switch {
+		case source != nil:
+			prices = source.GetMinGasPrices(ctx)
+		case isNodeLocalExecMode(ctx.ExecMode()):
+			prices = ctx.MinGasPrices()
+		default:
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "gas price query is not deterministic in this execution mode"}
+		}
+		return json.Marshal(GasPriceResponse{MinGasPrices: ConvertSDKDecCoinsToWasmDecCoins(prices)})
+	}
+}
+
+// isNodeLocalExecMode returns true for the execution modes that do not contribute to the consensus state
+func isNodeLocalExecMode(mode sdk.ExecMode) bool {
+	switch mode {
+	case sdk.ExecModeCheck, sdk.ExecModeReCheck, sdk.ExecModeSimulate:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+type mockGasPriceSource sdk.DecCoins
+
+func (m mockGasPriceSource) GetMinGasPrices(context.Context) sdk.DecCoins {
+	return sdk.DecCoins(m)
+}
+
+func TestGasPriceQuerier(t *testing.T) {
+	nodeLocalPrices := sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", sdkmath.LegacyMustNewDecFromStr("0.025")))
+	consensusPrices := mockGasPriceSource(sdk.NewDecCoins(sdk.NewDecCoinFromDec("ustake", sdkmath.LegacyMustNewDecFromStr("0.5"))))
+	query := json.RawMessage(`{"gas_price":{}}`)
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	specs := map[string]struct {
+		src            json.RawMessage
+		source         GasPriceSource
+		execMode       sdk.ExecMode
+		disabled       bool
+		exp            []wasmvmtypes.DecCoin
+		expResult      []byte
+		expUnsupported bool
+	}{
+		"check tx": {
+			src:      query,
+			execMode: sdk.ExecModeCheck,
+			exp:      []wasmvmtypes.DecCoin{{Denom: "stake", Amount: "0.025000000000000000"}},
+		},
+		"simulate": {
+			src:      query,
+			execMode: sdk.ExecModeSimulate,
+			exp:      []wasmvmtypes.DecCoin{{Denom: "stake", Amount: "0.025000000000000000"}},
+		},
+		"finalize block without consensus source": {
+			src:            query,
+			execMode:       sdk.ExecModeFinalize,
+			expUnsupported: true,
+		},
+		"process proposal without consensus source": {
+			src:            query,
+			execMode:       sdk.ExecModeProcessProposal,
+			expUnsupported: true,
+		},
+		"finalize block with consensus source": {
+			src:      query,
+			source:   consensusPrices,
+			execMode: sdk.ExecModeFinalize,
+			exp:      []wasmvmtypes.DecCoin{{Denom: "ustake", Amount: "0.500000000000000000"}},
+		},
+		"check tx with consensus source": {
+			src:      query,
+			source:   consensusPrices,
+			execMode: sdk.ExecModeCheck,
+			exp:      []wasmvmtypes.DecCoin{{Denom: "ustake", Amount: "0.500000000000000000"}},
+		},
+		"other custom query": {
+			src:       []byte(`{"foo":{}}`),
+			execMode:  sdk.ExecModeFinalize,
+			expResult: []byte("next"),
+		},
+		"disabled capability": {
+			src:            query,
+			source:         consensusPrices,
+			disabled:       true,
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithMinGasPrices(nodeLocalPrices).WithExecMode(spec.execMode)
+			params := types.DefaultParams()
+			if spec.disabled {
+				params.DisabledCapabilities = []string{GasPriceQueryCapability}
+			}
+			q := GasPriceQuerier(spec.source, mockParamsSource(params), next)
+
+			// when
+			gotResult, gotErr := q(ctx, spec.src)
+
+			// then
+			if spec.expUnsupported {
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			}
+			require.NoError(t, gotErr)
+			if spec.expResult != nil {
+				assert.Equal(t, spec.expResult, gotResult)
+				return
+			}
+			var got GasPriceResponse
+			require.NoError(t, json.Unmarshal(gotResult, &got))
+			assert.Equal(t, spec.exp, got.MinGasPrices)
+		})
+	}
+}
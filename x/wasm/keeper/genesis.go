@@ -8,6 +8,7 @@ import (
 	errorsmod "cosmossdk.io/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -26,6 +27,10 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 	if err != nil {
 		return nil, errorsmod.Wrapf(err, "set params")
 	}
+	// the vm is recreated before the codes are imported and pinned
+	if err := keeper.applyVMCacheParams(ctx); err != nil {
+		return nil, err
+	}
 
 	var maxCodeID uint64
 	for i, code := range data.Codes {
@@ -36,10 +41,17 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if code.CodeID > maxCodeID {
 			maxCodeID = code.CodeID
 		}
+		if code.StoredHeight != 0 {
+			if err := keeper.setCodeStoredHeight(ctx, code.CodeID, code.StoredHeight); err != nil {
+				return nil, errorsmod.Wrapf(err, "stored height of code %d with id: %d", i, code.CodeID)
+			}
+		}
 		if code.Pinned {
 			if err := contractKeeper.PinCode(ctx, code.CodeID); err != nil {
 				return nil, errorsmod.Wrapf(err, "contract number %d", i)
 			}
+		} else if err := keeper.autoPinCode(ctx, code.CodeID, code.CodeInfo.CodeHash); err != nil {
+			return nil, errorsmod.Wrapf(err, "auto pin code %d with id: %d", i, code.CodeID)
 		}
 	}
 
@@ -52,6 +64,21 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "contract number %d", i)
 		}
+		if contract.GasMultiplier != nil {
+			if err := keeper.SetContractGasMultiplier(ctx, contractAddr, *contract.GasMultiplier); err != nil {
+				return nil, errorsmod.Wrapf(err, "gas multiplier in contract number %d", i)
+			}
+		}
+		for j, c := range contract.MigrationCheckpoints {
+			if err := keeper.importMigrationCheckpoint(ctx, contractAddr, c.Checkpoint, c.State); err != nil {
+				return nil, errorsmod.Wrapf(err, "migration checkpoint %d in contract number %d", j, i)
+			}
+		}
+		for j, codeID := range contract.DependencyCodeIDs {
+			if err := keeper.importContractDependency(ctx, contractAddr, codeID); err != nil {
+				return nil, errorsmod.Wrapf(err, "dependency %d in contract number %d", j, i)
+			}
+		}
 	}
 
 	for i, seq := range data.Sequences {
@@ -61,6 +88,48 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		}
 	}
 
+	for i, c := range data.InstantiateCounts {
+		if keeper.GetCodeInfo(ctx, c.CodeID) == nil {
+			return nil, types.ErrNoSuchCodeFn(c.CodeID).Wrapf("instantiate count number %d", i)
+		}
+		addr, err := sdk.AccAddressFromBech32(c.Address)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "address in instantiate count number %d", i)
+		}
+		if err := keeper.setInstantiateCount(ctx, c.CodeID, addr, c.Count); err != nil {
+			return nil, errorsmod.Wrapf(err, "instantiate count number %d", i)
+		}
+	}
+
+	if err := importBlockSudoHooks(ctx, keeper, types.BlockSudoPhaseBeginBlock, data.BeginBlockSudoHooks); err != nil {
+		return nil, err
+	}
+	if err := importBlockSudoHooks(ctx, keeper, types.BlockSudoPhaseEndBlock, data.EndBlockSudoHooks); err != nil {
+		return nil, err
+	}
+	if len(data.StargateAllowlist) != 0 {
+		if err := keeper.UpdateStargateAllowlist(ctx, data.StargateAllowlist, nil); err != nil {
+			return nil, errorsmod.Wrap(err, "stargate allowlist")
+		}
+	}
+	var totalDeposits sdk.Coins
+	for i, d := range data.CodeDeposits {
+		if keeper.GetCodeInfo(ctx, d.CodeID) == nil {
+			return nil, types.ErrNoSuchCodeFn(d.CodeID).Wrapf("code deposit number %d", i)
+		}
+		if err := keeper.setCodeDeposit(ctx, d); err != nil {
+			return nil, errorsmod.Wrapf(err, "code deposit number %d", i)
+		}
+		totalDeposits = totalDeposits.Add(d.Amount...)
+	}
+	// the escrowed deposits must be held by the module account. The bank balances are imported before.
+	if !totalDeposits.IsZero() {
+		moduleBalance := keeper.balances.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName))
+		if !moduleBalance.IsAllGTE(totalDeposits) {
+			return nil, errorsmod.Wrapf(types.ErrInvalidGenesis, "code deposits %s exceed the module balance %s", totalDeposits, moduleBalance)
+		}
+	}
+
 	// sanity check seq values
 	seqVal, err := keeper.PeekAutoIncrementID(ctx, types.KeySequenceCodeID)
 	if err != nil {
@@ -82,6 +151,20 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 	return nil, nil
 }
 
+// importBlockSudoHooks registers the hooks for the block phase in the given order
+func importBlockSudoHooks(ctx sdk.Context, keeper *Keeper, phase types.BlockSudoPhase, hooks []types.BlockSudoHook) error {
+	for i, h := range hooks {
+		contractAddr, err := sdk.AccAddressFromBech32(h.Contract)
+		if err != nil {
+			return errorsmod.Wrapf(err, "contract in %s hook number %d", phase, i)
+		}
+		if err := keeper.RegisterBlockSudoHook(ctx, phase, contractAddr, h.Msg); err != nil {
+			return errorsmod.Wrapf(err, "%s hook number %d", phase, i)
+		}
+	}
+	return nil
+}
+
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 	var genState types.GenesisState
@@ -97,7 +180,9 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			CodeID:    codeID,
 			CodeInfo:  info,
 			CodeBytes: bytecode,
-			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			// auto pins are restored from the params on import
+			Pinned:       keeper.IsPinnedCode(ctx, codeID) && !keeper.IsAutoPinnedCode(ctx, codeID),
+			StoredHeight: keeper.GetCodeStoredHeight(ctx, codeID),
 		})
 		return false
 	})
@@ -111,15 +196,56 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 
 		contractCodeHistory := keeper.GetContractHistory(ctx, addr)
 
+		var gasMultiplier *types.GasMultiplier
+		if m := keeper.GetContractGasMultiplier(ctx, addr); !m.IsDefault() {
+			gasMultiplier = &m
+		}
+
+		var checkpoints []types.MigrationCheckpointState
+		for _, c := range keeper.GetMigrationCheckpoints(ctx, addr) {
+			var checkpointState []types.Model
+			keeper.IterateMigrationCheckpointState(ctx, addr, c.ID, func(key, value []byte) bool {
+				checkpointState = append(checkpointState, types.Model{Key: key, Value: value})
+				return false
+			})
+			checkpoints = append(checkpoints, types.MigrationCheckpointState{Checkpoint: c, State: checkpointState})
+		}
+
+		var dependencies []uint64
+		keeper.IterateContractDependencies(ctx, addr, func(codeID uint64) bool {
+			dependencies = append(dependencies, codeID)
+			return false
+		})
+
 		genState.Contracts = append(genState.Contracts, types.Contract{
-			ContractAddress:     addr.String(),
-			ContractInfo:        contract,
-			ContractState:       state,
-			ContractCodeHistory: contractCodeHistory,
+			ContractAddress:      addr.String(),
+			ContractInfo:         contract,
+			ContractState:        state,
+			ContractCodeHistory:  contractCodeHistory,
+			GasMultiplier:        gasMultiplier,
+			MigrationCheckpoints: checkpoints,
+			DependencyCodeIDs:    dependencies,
+		})
+		return false
+	})
+
+	keeper.IterateInstantiateCounts(ctx, func(codeID uint64, addr sdk.AccAddress, count uint64) bool {
+		genState.InstantiateCounts = append(genState.InstantiateCounts, types.InstantiateCount{
+			CodeID:  codeID,
+			Address: addr.String(),
+			Count:   count,
 		})
 		return false
 	})
 
+	genState.BeginBlockSudoHooks = keeper.GetBlockSudoHooks(ctx, types.BlockSudoPhaseBeginBlock)
+	genState.EndBlockSudoHooks = keeper.GetBlockSudoHooks(ctx, types.BlockSudoPhaseEndBlock)
+	genState.StargateAllowlist = keeper.GetStargateAllowlist(ctx)
+	keeper.IterateCodeDeposits(ctx, func(d types.CodeDeposit) bool {
+		genState.CodeDeposits = append(genState.CodeDeposits, d)
+		return false
+	})
+
 	for _, k := range [][]byte{types.KeySequenceCodeID, types.KeySequenceInstanceID} {
 		id, err := keeper.PeekAutoIncrementID(ctx, k)
 		if err != nil {
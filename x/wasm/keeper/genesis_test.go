@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"testing"
@@ -45,7 +46,10 @@ func TestGenesisExportImport(t *testing.T) {
 	// store some test data
 	f := fuzz.New().Funcs(ModelFuzzers...)
 
-	err = wasmKeeper.SetParams(srcCtx, types.DefaultParams())
+	params := types.DefaultParams()
+	// up to one block sudo hook per contract
+	params.MaxBlockSudoHooks = 25
+	err = wasmKeeper.SetParams(srcCtx, params)
 	require.NoError(t, err)
 
 	for i := 0; i < 25; i++ {
@@ -56,6 +60,13 @@ func TestGenesisExportImport(t *testing.T) {
 			history           []types.ContractCodeHistoryEntry
 			pinned            bool
 			contractExtension bool
+			gasMultiplier     bool
+			storageQuota      bool
+			locked            bool
+			instantiateCount  bool
+			blockSudoHook     bool
+			dependency        bool
+			checkpointState   []types.Model
 		)
 		f.Fuzz(&codeInfo)
 		f.Fuzz(&contract)
@@ -63,6 +74,13 @@ func TestGenesisExportImport(t *testing.T) {
 		f.NilChance(0).Fuzz(&history)
 		f.Fuzz(&pinned)
 		f.Fuzz(&contractExtension)
+		f.Fuzz(&gasMultiplier)
+		f.Fuzz(&storageQuota)
+		f.Fuzz(&locked)
+		f.Fuzz(&instantiateCount)
+		f.Fuzz(&blockSudoHook)
+		f.Fuzz(&dependency)
+		f.Fuzz(&checkpointState)
 
 		creatorAddr, err := sdk.AccAddressFromBech32(codeInfo.Creator)
 		require.NoError(t, err)
@@ -88,7 +106,37 @@ func TestGenesisExportImport(t *testing.T) {
 		require.NoError(t, wasmKeeper.appendToContractHistory(srcCtx, contractAddr, history...))
 		err = wasmKeeper.importContractState(srcCtx, contractAddr, stateModels)
 		require.NoError(t, err)
+		if gasMultiplier {
+			err = wasmKeeper.SetContractGasMultiplier(srcCtx, contractAddr, types.GasMultiplier{Numerator: 1, Denominator: 2})
+			require.NoError(t, err)
+		}
+		if storageQuota {
+			err = wasmKeeper.SetContractStorageQuota(srcCtx, contractAddr, math.MaxUint32)
+			require.NoError(t, err)
+		}
+		if locked {
+			err = wasmKeeper.SetContractLock(srcCtx, contractAddr, true)
+			require.NoError(t, err)
+		}
+		if instantiateCount {
+			err = wasmKeeper.setInstantiateCount(srcCtx, codeID, creatorAddr, 3)
+			require.NoError(t, err)
+		}
+		if blockSudoHook {
+			err = wasmKeeper.RegisterEndBlockSudo(srcCtx, contractAddr, []byte(`{}`))
+			require.NoError(t, err)
+		}
+		if dependency {
+			err = wasmKeeper.importContractDependency(srcCtx, contractAddr, codeID)
+			require.NoError(t, err)
+		}
+		if len(checkpointState) != 0 {
+			checkpoint := types.MigrationCheckpoint{ID: 1, CodeID: codeID, Created: types.NewAbsoluteTxPosition(srcCtx)}
+			err = wasmKeeper.importMigrationCheckpoint(srcCtx, contractAddr, checkpoint, checkpointState)
+			require.NoError(t, err)
+		}
 	}
+	require.NoError(t, wasmKeeper.UpdateStargateAllowlist(srcCtx, []string{"/cosmos.bank.v1beta1.Query/Balance"}, nil))
 	var wasmParams types.Params
 	f.NilChance(0).Fuzz(&wasmParams)
 	err = wasmKeeper.SetParams(srcCtx, wasmParams)
@@ -121,6 +169,12 @@ func TestGenesisExportImport(t *testing.T) {
 		require.NoError(t, err)
 		err = wasmKeeper.addToContractCreatorSecondaryIndex(srcCtx, creatorAddress, history[0].Updated, address)
 		require.NoError(t, err)
+		err = wasmKeeper.addToContractLabelIndex(srcCtx, address, info.CodeID, info.Label)
+		require.NoError(t, err)
+		err = wasmKeeper.recordImportedCodeInstantiation(srcCtx, address, creatorAddress, history[0])
+		require.NoError(t, err)
+		err = wasmKeeper.incrementModuleStat(srcCtx, types.KeyStatsContractCount)
+		require.NoError(t, err)
 		return false
 	})
 
@@ -444,6 +498,41 @@ func TestGenesisInit(t *testing.T) {
 				Params: types.DefaultParams(),
 			},
 		},
+		"happy path: instantiate count for existing code": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:    1,
+					CodeInfo:  myCodeInfo,
+					CodeBytes: wasmCode,
+				}},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceCodeID, Value: 2},
+					{IDKey: types.KeySequenceInstanceID, Value: 1},
+				},
+				InstantiateCounts: []types.InstantiateCount{
+					{CodeID: 1, Address: RandomBech32AccountAddress(t), Count: 1},
+				},
+				Params: types.DefaultParams(),
+			},
+			expSuccess: true,
+		},
+		"prevent instantiate count for non existing code": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:    1,
+					CodeInfo:  myCodeInfo,
+					CodeBytes: wasmCode,
+				}},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceCodeID, Value: 2},
+					{IDKey: types.KeySequenceInstanceID, Value: 1},
+				},
+				InstantiateCounts: []types.InstantiateCount{
+					{CodeID: 2, Address: RandomBech32AccountAddress(t), Count: 1},
+				},
+				Params: types.DefaultParams(),
+			},
+		},
 		"prevent code id seq init value == max codeID used": {
 			src: types.GenesisState{
 				Codes: []types.Code{{
@@ -607,6 +696,10 @@ func TestImportContractWithCodeHistoryPreserved(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expCodeInfo, *gotCodeInfo)
+	assert.Equal(t, uint64(len(wasmCode)), keeper.GetCodeSize(ctx, 1))
+	gotExports, err := keeper.CodeExports(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"execute", "instantiate", "migrate", "query", "sudo"}, gotExports)
 
 	// verify contract
 	contractAddr, _ := sdk.AccAddressFromBech32("cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr")
@@ -658,10 +751,12 @@ func setupKeeper(t *testing.T) (*Keeper, sdk.Context) {
 	tempDir := t.TempDir()
 
 	keyWasm := storetypes.NewKVStoreKey(types.StoreKey)
+	tKeyWasm := storetypes.NewTransientStoreKey(types.TStoreKey)
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db, log.NewTestLogger(t), storemetrics.NewNoOpMetrics())
 	ms.MountStoreWithDB(keyWasm, storetypes.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tKeyWasm, storetypes.StoreTypeTransient, db)
 	require.NoError(t, ms.LoadLatestVersion())
 
 	ctx := sdk.NewContext(ms, cmtproto.Header{
@@ -683,6 +778,7 @@ func setupKeeper(t *testing.T) (*Keeper, sdk.Context) {
 	srcKeeper := NewKeeper(
 		encodingConfig.Codec,
 		runtime.NewKVStoreService(keyWasm),
+		runtime.NewTransientStoreService(tKeyWasm),
 		authkeeper.AccountKeeper{},
 		&bankkeeper.BaseKeeper{},
 		stakingkeeper.Keeper{},
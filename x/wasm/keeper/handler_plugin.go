@@ -3,6 +3,7 @@ package keeper
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
 	channeltypesv2 "github.com/cosmos/ibc-go/v10/modules/core/04-channel/v2/types"
@@ -52,6 +53,7 @@ func NewDefaultMessageHandler(
 		encoders = encoders.Merge(e)
 	}
 	return NewMessageHandlerChain(
+		NewIBCSenderAllowlistHandler(keeper),
 		NewSDKMessageHandler(cdc, router, encoders),
 		NewIBCRawPacketHandler(ics4Wrapper, keeper),
 		NewIBC2RawPacketHandler(channelKeeperV2),
@@ -124,13 +126,16 @@ func (h SDKMessageHandler) handleSdkMessage(ctx sdk.Context, contractAddr sdk.Ad
 	return nil, errorsmod.Wrapf(sdkerrors.ErrUnknownRequest, "can't route message %+v", msg)
 }
 
+// callDepthMessageHandler limits the number of nested messages dispatched by contracts.
+// The max call depth param can lower MaxCallDepth of the node when set.
 type callDepthMessageHandler struct {
 	Messenger
 	MaxCallDepth uint32
+	params       paramsSource
 }
 
 func (h callDepthMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
-	ctx, err = checkAndIncreaseCallDepth(ctx, h.MaxCallDepth)
+	ctx, err = checkAndIncreaseCallDepth(ctx, h.maxCallDepth(ctx))
 	if err != nil {
 		return nil, nil, nil, errorsmod.Wrap(err, "dispatch")
 	}
@@ -138,6 +143,16 @@ func (h callDepthMessageHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.A
 	return h.Messenger.DispatchMsg(ctx, contractAddr, contractIBCPortID, msg)
 }
 
+func (h callDepthMessageHandler) maxCallDepth(ctx sdk.Context) uint32 {
+	if h.params == nil {
+		return h.MaxCallDepth
+	}
+	if depth := h.params.GetCachedParams(ctx).MaxCallDepth; depth != 0 {
+		return min(depth, h.MaxCallDepth)
+	}
+	return h.MaxCallDepth
+}
+
 // MessageHandlerChain defines a chain of handlers that are called one by one until it can be handled.
 type MessageHandlerChain struct {
 	handlers []Messenger
@@ -172,6 +187,30 @@ func (m MessageHandlerChain) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAd
 	return nil, nil, nil, errorsmod.Wrap(types.ErrUnknownMsg, "no handler found")
 }
 
+// IBCSenderAllowlistHandler rejects IBC.SendPacket and IBC.Transfer messages of contracts that are not on the
+// IBC sender allowlist param. All other messages are passed to the next handler.
+type IBCSenderAllowlistHandler struct {
+	params paramsSource
+}
+
+// NewIBCSenderAllowlistHandler constructor
+func NewIBCSenderAllowlistHandler(params paramsSource) IBCSenderAllowlistHandler {
+	return IBCSenderAllowlistHandler{params: params}
+}
+
+// DispatchMsg returns an error for IBC packets and transfers of contracts that are not allowed to send them,
+// before any packet is built
+func (h IBCSenderAllowlistHandler) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) ([]sdk.Event, [][]byte, [][]*codectypes.Any, error) {
+	if msg.IBC == nil || (msg.IBC.SendPacket == nil && msg.IBC.Transfer == nil) {
+		return nil, nil, nil, types.ErrUnknownMsg
+	}
+	allowlist := h.params.GetCachedParams(ctx).IBCSenderAllowlist
+	if len(allowlist) == 0 || slices.Contains(allowlist, contractAddr.String()) {
+		return nil, nil, nil, types.ErrUnknownMsg
+	}
+	return nil, nil, nil, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "contract %s is not allowed to send ibc packets", contractAddr)
+}
+
 // IBCRawPacketHandler handles IBC.SendPacket messages which are published to an IBC channel.
 type IBCRawPacketHandler struct {
 	ics4Wrapper types.ICS4Wrapper
@@ -329,7 +368,8 @@ func (m MessageHandlerFunc) DispatchMsg(ctx sdk.Context, contractAddr sdk.AccAdd
 	return m(ctx, contractAddr, contractIBCPortID, msg)
 }
 
-// NewBurnCoinMessageHandler handles wasmvm.BurnMsg messages
+// NewBurnCoinMessageHandler handles wasmvm.BurnMsg messages.
+// Contracts can only burn coins from their own balance.
 func NewBurnCoinMessageHandler(burner types.Burner) MessageHandlerFunc {
 	return func(ctx sdk.Context, contractAddr sdk.AccAddress, _ string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
 		if msg.Bank != nil && msg.Bank.Burn != nil {
@@ -347,7 +387,12 @@ func NewBurnCoinMessageHandler(burner types.Burner) MessageHandlerFunc {
 				return nil, nil, nil, errorsmod.Wrap(err, "burn coins")
 			}
 			moduleLogger(ctx).Info("Burned", "amount", coins)
-			return nil, nil, nil, nil
+			events = []sdk.Event{sdk.NewEvent(
+				types.EventTypeContractBurn,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, coins.String()),
+			)}
+			return events, nil, nil, nil
 		}
 		return nil, nil, nil, types.ErrUnknownMsg
 	}
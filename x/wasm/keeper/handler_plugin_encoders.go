@@ -147,6 +147,9 @@ func NoCustomMsg(_ sdk.AccAddress, _ json.RawMessage) ([]sdk.Msg, error) {
 	return nil, errorsmod.Wrap(types.ErrUnknownMsg, "custom variant not supported")
 }
 
+// EncodeDistributionMsg encodes the distribution messages with the contract as delegator or depositor. They are
+// routed to the distribution module which emits the events. wasmd does not restrict them, the variants available
+// to a contract depend on the cosmwasm-std features it was built with (`FundCommunityPool` needs `cosmwasm_1_3`).
 func EncodeDistributionMsg(sender sdk.AccAddress, msg *wasmvmtypes.DistributionMsg) ([]sdk.Msg, error) {
 	switch {
 	case msg.SetWithdrawAddress != nil:
@@ -291,6 +294,9 @@ func EncodeWasmMsg(sender sdk.AccAddress, msg *wasmvmtypes.WasmMsg) ([]sdk.Msg,
 			Contract: msg.Migrate.ContractAddr,
 			CodeID:   msg.Migrate.NewCodeID,
 			Msg:      msg.Migrate.Msg,
+			// the downgrade check is a guardrail for operators. The wasmvm migrate message has no field
+			// for the override, so contracts as admin can always migrate to any code id.
+			AllowDowngrade: true,
 		}
 		return []sdk.Msg{&sdkMsg}, nil
 	case msg.ClearAdmin != nil:
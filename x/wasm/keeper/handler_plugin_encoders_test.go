@@ -228,10 +228,11 @@ func TestEncoding(t *testing.T) {
 			},
 			output: []sdk.Msg{
 				&types.MsgMigrateContract{
-					Sender:   addr2.String(),
-					Contract: addr1.String(),
-					CodeID:   12,
-					Msg:      jsonMsg,
+					Sender:         addr2.String(),
+					Contract:       addr1.String(),
+					CodeID:         12,
+					Msg:            jsonMsg,
+					AllowDowngrade: true,
 				},
 			},
 		},
@@ -361,6 +361,80 @@ func TestIBCRawPacketHandler(t *testing.T) {
 	}
 }
 
+func TestIBCSenderAllowlistHandler(t *testing.T) {
+	allowedContract, otherContract := RandomAccountAddress(t), RandomAccountAddress(t)
+	sendPacketMsg := wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{SendPacket: &wasmvmtypes.SendPacketMsg{ChannelID: "channel-1"}}}
+	transferMsg := wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{Transfer: &wasmvmtypes.TransferMsg{ChannelID: "channel-1"}}}
+	closeChannelMsg := wasmvmtypes.CosmosMsg{IBC: &wasmvmtypes.IBCMsg{CloseChannel: &wasmvmtypes.CloseChannelMsg{ChannelID: "channel-1"}}}
+
+	specs := map[string]struct {
+		allowlist   []string
+		sender      sdk.AccAddress
+		msg         wasmvmtypes.CosmosMsg
+		expErr      *errorsmod.Error
+		expDispatch bool
+	}{
+		"empty allowlist allows all": {
+			sender:      otherContract,
+			msg:         sendPacketMsg,
+			expDispatch: true,
+		},
+		"allowlisted contract sends packet": {
+			allowlist:   []string{allowedContract.String()},
+			sender:      allowedContract,
+			msg:         sendPacketMsg,
+			expDispatch: true,
+		},
+		"allowlisted contract transfers": {
+			allowlist:   []string{allowedContract.String()},
+			sender:      allowedContract,
+			msg:         transferMsg,
+			expDispatch: true,
+		},
+		"other contract sends packet": {
+			allowlist: []string{allowedContract.String()},
+			sender:    otherContract,
+			msg:       sendPacketMsg,
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+		"other contract transfers": {
+			allowlist: []string{allowedContract.String()},
+			sender:    otherContract,
+			msg:       transferMsg,
+			expErr:    sdkerrors.ErrUnauthorized,
+		},
+		"other contract closes channel": {
+			allowlist:   []string{allowedContract.String()},
+			sender:      otherContract,
+			msg:         closeChannelMsg,
+			expDispatch: true,
+		},
+		"other contract sends non ibc msg": {
+			allowlist:   []string{allowedContract.String()},
+			sender:      otherContract,
+			msg:         wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{}},
+			expDispatch: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			capturingHandler, gotMsgs := wasmtesting.NewCapturingMessageHandler()
+			h := NewMessageHandlerChain(NewIBCSenderAllowlistHandler(mockParamsSource{IBCSenderAllowlist: spec.allowlist}), capturingHandler)
+
+			// when
+			_, _, _, gotErr := h.DispatchMsg(sdk.Context{}, spec.sender, "", spec.msg)
+
+			// then
+			require.True(t, spec.expErr.Is(gotErr), "exp %v but got %#+v", spec.expErr, gotErr)
+			if spec.expDispatch {
+				assert.Equal(t, []wasmvmtypes.CosmosMsg{spec.msg}, *gotMsgs)
+			} else {
+				assert.Empty(t, *gotMsgs)
+			}
+		})
+	}
+}
+
 func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 	// testing via full keeper setup so that we are confident the
 	// module permissions are set correct and no other handler
@@ -376,8 +450,9 @@ func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 	require.NoError(t, err)
 
 	specs := map[string]struct {
-		msg    wasmvmtypes.BurnMsg
-		expErr bool
+		msg       wasmvmtypes.BurnMsg
+		expBurned sdk.Coins
+		expErr    bool
 	}{
 		"all good": {
 			msg: wasmvmtypes.BurnMsg{
@@ -386,6 +461,16 @@ func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 					Amount: "100",
 				}},
 			},
+			expBurned: sdk.NewCoins(sdk.NewCoin("denom", sdkmath.NewInt(100))),
+		},
+		"partial balance": {
+			msg: wasmvmtypes.BurnMsg{
+				Amount: wasmvmtypes.Array[wasmvmtypes.Coin]{{
+					Denom:  "denom",
+					Amount: "40",
+				}},
+			},
+			expBurned: sdk.NewCoins(sdk.NewCoin("denom", sdkmath.NewInt(40))),
 		},
 		"not enough funds in contract": {
 			msg: wasmvmtypes.BurnMsg{
@@ -429,6 +514,9 @@ func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 				}, 0, nil
 			}}
 
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
 			// when
 			_, err = k.execute(ctx, example.Contract, example.CreatorAddr, nil, nil)
 
@@ -443,10 +531,95 @@ func TestBurnCoinMessageHandlerIntegration(t *testing.T) {
 			after, err := keepers.BankKeeper.TotalSupply(ctx, &banktypes.QueryTotalSupplyRequest{})
 			require.NoError(t, err)
 			diff := before.Supply.Sub(after.Supply...)
-			assert.Equal(t, sdk.NewCoins(sdk.NewCoin("denom", sdkmath.NewInt(100))), diff)
+			assert.Equal(t, spec.expBurned, diff)
+			// and the remaining contract balance
+			expBalance := sdk.NewCoins(sdk.NewCoin("denom", sdkmath.NewInt(100))).Sub(spec.expBurned...)
+			assert.Equal(t, expBalance, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+			// and a burn event emitted
+			exp := sdk.NewEvent(types.EventTypeContractBurn,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, spec.expBurned.String()),
+			)
+			assert.Contains(t, em.Events(), exp)
 		})
 	}
+}
+
+func TestMaxCallDepth(t *testing.T) {
+	// the contract executes itself via submessage until the recursion count is reached
+	var recursions, executions int
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			executions++
+			if executions > recursions {
+				return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+			}
+			selfCall := wasmvmtypes.SubMsg{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{
+					Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: env.Contract.Address, Msg: []byte(`{}`)},
+				}},
+			}
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Messages: []wasmvmtypes.SubMsg{selfCall}}}, 0, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	const nodeMaxCallDepth = 5
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock), WithMaxCallDepth(nodeMaxCallDepth))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	specs := map[string]struct {
+		maxCallDepth  uint32
+		recursions    int
+		expExecutions int
+		expErr        error
+	}{
+		"within max call depth": {
+			maxCallDepth:  3,
+			recursions:    3,
+			expExecutions: 4,
+		},
+		"exceeds max call depth": {
+			maxCallDepth:  3,
+			recursions:    4,
+			expExecutions: 4,
+			expErr:        types.ErrExceedMaxCallDepth,
+		},
+		"param can not exceed node max": {
+			maxCallDepth:  nodeMaxCallDepth + 2,
+			recursions:    nodeMaxCallDepth + 1,
+			expExecutions: nodeMaxCallDepth + 1,
+			expErr:        types.ErrExceedMaxCallDepth,
+		},
+		"zero falls back to node max - within": {
+			recursions:    nodeMaxCallDepth,
+			expExecutions: nodeMaxCallDepth + 1,
+		},
+		"zero falls back to node max - exceeded": {
+			recursions:    nodeMaxCallDepth + 1,
+			expExecutions: nodeMaxCallDepth + 1,
+			expErr:        types.ErrExceedMaxCallDepth,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxCallDepth = spec.maxCallDepth
+			require.NoError(t, k.SetParams(ctx, params))
+			recursions, executions = spec.recursions, 0
+
+			// when
+			_, gotErr := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
 
-	// test cases:
-	// not enough money to burn
+			// then
+			assert.Equal(t, spec.expExecutions, executions)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
 }
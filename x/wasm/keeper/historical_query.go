@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	errorsmod "cosmossdk.io/errors"
+	pruningtypes "cosmossdk.io/store/pruning/types"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// QueryAtHeight is a custom query to run a bank or staking query against the committed state of a past block height.
+// It is sent by contracts as `{"query_at_height":{"height":<height>,"request":<query request>}}`.
+type QueryAtHeight struct {
+	Height  int64                    `json:"height"`
+	Request wasmvmtypes.QueryRequest `json:"request"`
+}
+
+// HistoricalStateSource provides read access to the committed state of past block heights.
+// This is implemented by the app's CommitMultiStore.
+type HistoricalStateSource interface {
+	CacheMultiStoreWithVersion(version int64) (storetypes.CacheMultiStore, error)
+	GetPruning() pruningtypes.PruningOptions
+}
+
+// paramsSource provides the wasm params to the contract call paths. This is implemented by the Keeper.
+type paramsSource interface {
+	GetCachedParams(ctx context.Context) types.Params
+}
+
+// HistoricalQuerier handles QueryAtHeight custom queries with the bank and staking handlers. Any other custom
+// query is passed to the next custom querier.
+// Which heights are available depends on the pruning settings of the node, so the query is only supported in
+// smart queries and never within a transaction or block execution, where all nodes must return the same result.
+// The height must be within the historical query depth param and the pruning retention of the node. The query is
+// executed on a branch of the committed multistore that is never written.
+func HistoricalQuerier(
+	source HistoricalStateSource,
+	params paramsSource,
+	bank func(ctx sdk.Context, request *wasmvmtypes.BankQuery) ([]byte, error),
+	staking func(ctx sdk.Context, request *wasmvmtypes.StakingQuery) ([]byte, error),
+	next CustomQuerier,
+) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			QueryAtHeight *QueryAtHeight `json:"query_at_height,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.QueryAtHeight == nil {
+			return next(ctx, request)
+		}
+		req := msg.QueryAtHeight
+		if req.Request.Bank == nil && req.Request.Staking == nil {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "only bank and staking queries are supported at height"}
+		}
+		if !isQueryContext(ctx) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "historical queries are only supported in smart queries"}
+		}
+		if err := validateHistoricalHeight(ctx, source, params.GetCachedParams(ctx).HistoricalQueryDepth, req.Height); err != nil {
+			return nil, err
+		}
+		ms, err := source.CacheMultiStoreWithVersion(req.Height)
+		if err != nil {
+			return nil, errorsmod.Wrapf(types.ErrNotFound, "state at height %d", req.Height)
+		}
+		// the branched store is discarded so that no writes can be persisted
+		historicalCtx := ctx.WithMultiStore(ms).WithBlockHeight(req.Height)
+		if req.Request.Bank != nil {
+			return bank(historicalCtx, req.Request.Bank)
+		}
+		return staking(historicalCtx, req.Request.Staking)
+	}
+}
+
+// isQueryContext returns true when the context is not used for the execution of a transaction or block. Transactions
+// get a tx counter assigned by the CountTXDecorator, except for simulations.
+func isQueryContext(ctx sdk.Context) bool {
+	if _, ok := types.TXCounter(ctx); ok {
+		return false
+	}
+	return ctx.ExecMode() == sdk.ExecModeCheck
+}
+
+// validateHistoricalHeight ensures the height is in the past and within the max depth and the pruning retention
+func validateHistoricalHeight(ctx sdk.Context, source HistoricalStateSource, maxDepth uint64, height int64) error {
+	if maxDepth == 0 {
+		return wasmvmtypes.UnsupportedRequest{Kind: "historical queries are disabled on this chain"}
+	}
+	if height <= 0 || height >= ctx.BlockHeight() {
+		return errorsmod.Wrapf(types.ErrInvalid, "height %d must be before current height %d", height, ctx.BlockHeight())
+	}
+	depth := uint64(ctx.BlockHeight() - height)
+	if depth > maxDepth {
+		return errorsmod.Wrapf(types.ErrInvalid, "height %d exceeds max depth %d", height, maxDepth)
+	}
+	if pruning := source.GetPruning(); pruning.Strategy != pruningtypes.PruningNothing && depth > pruning.KeepRecent {
+		return errorsmod.Wrapf(types.ErrInvalid, "height %d is beyond pruning retention", height)
+	}
+	return nil
+}
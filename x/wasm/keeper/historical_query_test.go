@@ -0,0 +1,192 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store"
+	storemetrics "cosmossdk.io/store/metrics"
+	pruningtypes "cosmossdk.io/store/pruning/types"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestHistoricalQuerier(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey("test")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db, log.NewNopLogger(), storemetrics.NewNoOpMetrics())
+	ms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	// commit a value per height: 1 => a, 2 => b, 3 => c
+	for _, v := range []string{"a", "b", "c"} {
+		ms.GetKVStore(storeKey).Set([]byte("key"), []byte(v))
+		ms.Commit()
+	}
+	parentCtx := sdk.NewContext(ms.CacheMultiStore(), tmproto.Header{Height: 4}, false, log.NewNopLogger())
+
+	// the handlers return the stored value and try to modify it
+	readAndWrite := func(ctx sdk.Context) []byte {
+		bz := ctx.KVStore(storeKey).Get([]byte("key"))
+		ctx.KVStore(storeKey).Set([]byte("key"), []byte("modified"))
+		return bz
+	}
+	bank := func(ctx sdk.Context, _ *wasmvmtypes.BankQuery) ([]byte, error) {
+		return readAndWrite(ctx), nil
+	}
+	staking := func(ctx sdk.Context, _ *wasmvmtypes.StakingQuery) ([]byte, error) {
+		return readAndWrite(ctx), nil
+	}
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	queryAtHeight := func(height int64, req wasmvmtypes.QueryRequest) json.RawMessage {
+		bz, err := json.Marshal(map[string]QueryAtHeight{"query_at_height": {Height: height, Request: req}})
+		require.NoError(t, err)
+		return bz
+	}
+	bankReq := wasmvmtypes.QueryRequest{Bank: &wasmvmtypes.BankQuery{Balance: &wasmvmtypes.BalanceQuery{}}}
+
+	specs := map[string]struct {
+		src            json.RawMessage
+		depth          uint64
+		keepRecent     uint64
+		inTx           bool
+		execMode       sdk.ExecMode
+		expResult      []byte
+		expErr         error
+		expUnsupported bool
+	}{
+		"bank query at height": {
+			src:       queryAtHeight(2, bankReq),
+			depth:     10,
+			expResult: []byte("b"),
+		},
+		"staking query at height": {
+			src:       queryAtHeight(1, wasmvmtypes.QueryRequest{Staking: &wasmvmtypes.StakingQuery{BondedDenom: &struct{}{}}}),
+			depth:     10,
+			expResult: []byte("a"),
+		},
+		"last committed height": {
+			src:       queryAtHeight(3, bankReq),
+			depth:     1,
+			expResult: []byte("c"),
+		},
+		"within pruning retention": {
+			src:        queryAtHeight(2, bankReq),
+			depth:      10,
+			keepRecent: 2,
+			expResult:  []byte("b"),
+		},
+		"other custom query": {
+			src:       []byte(`{"foo":{}}`),
+			expResult: []byte("next"),
+		},
+		"current height": {
+			src:    queryAtHeight(4, bankReq),
+			depth:  10,
+			expErr: types.ErrInvalid,
+		},
+		"future height": {
+			src:    queryAtHeight(5, bankReq),
+			depth:  10,
+			expErr: types.ErrInvalid,
+		},
+		"zero height": {
+			src:    queryAtHeight(0, bankReq),
+			depth:  10,
+			expErr: types.ErrInvalid,
+		},
+		"exceeds max depth": {
+			src:    queryAtHeight(1, bankReq),
+			depth:  2,
+			expErr: types.ErrInvalid,
+		},
+		"beyond pruning retention": {
+			src:        queryAtHeight(1, bankReq),
+			depth:      10,
+			keepRecent: 2,
+			expErr:     types.ErrInvalid,
+		},
+		"disabled": {
+			src:            queryAtHeight(2, bankReq),
+			expUnsupported: true,
+		},
+		"in a transaction": {
+			src:            queryAtHeight(2, bankReq),
+			depth:          10,
+			inTx:           true,
+			expUnsupported: true,
+		},
+		"in a block": {
+			src:            queryAtHeight(2, bankReq),
+			depth:          10,
+			execMode:       sdk.ExecModeFinalize,
+			expUnsupported: true,
+		},
+		"in a simulation": {
+			src:            queryAtHeight(2, bankReq),
+			depth:          10,
+			execMode:       sdk.ExecModeSimulate,
+			expUnsupported: true,
+		},
+		"unsupported request": {
+			src:            queryAtHeight(2, wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{}}),
+			depth:          10,
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithExecMode(spec.execMode)
+			if spec.inTx {
+				ctx = types.WithTXCounter(ctx, 1)
+			}
+			ms.SetPruning(pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+			if spec.keepRecent != 0 {
+				ms.SetPruning(pruningtypes.NewCustomPruningOptions(spec.keepRecent, 100))
+			}
+			q := HistoricalQuerier(ms, mockParamsSource{HistoricalQueryDepth: spec.depth}, bank, staking, next)
+
+			// when
+			gotResult, gotErr := q(ctx, spec.src)
+
+			// then
+			if spec.expUnsupported {
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			}
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expResult, gotResult)
+			// and no state was modified
+			for h, exp := range map[int64]string{1: "a", 2: "b", 3: "c"} {
+				cms, err := ms.CacheMultiStoreWithVersion(h)
+				require.NoError(t, err)
+				assert.Equal(t, []byte(exp), cms.GetKVStore(storeKey).Get([]byte("key")))
+			}
+			assert.Equal(t, []byte("c"), ctx.KVStore(storeKey).Get([]byte("key")))
+		})
+	}
+}
+
+type mockParamsSource types.Params
+
+func (m mockParamsSource) GetCachedParams(context.Context) types.Params {
+	return types.Params(m)
+}
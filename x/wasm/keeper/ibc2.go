@@ -150,9 +150,9 @@ func (k Keeper) OnAckIBC2Packet(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBC2PacketAck(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -161,7 +161,7 @@ func (k Keeper) OnAckIBC2Packet(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBC2PortID, res.Ok)
@@ -187,9 +187,9 @@ func (k Keeper) OnRecvIBC2Packet(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBC2PacketReceive(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		panic(execErr) // let the contract fully abort an IBC packet receive.
 		// Throwing a panic here instead of an error ack will revert
@@ -205,9 +205,13 @@ func (k Keeper) OnRecvIBC2Packet(
 	}
 	if res.Err != "" {
 		// return error ACK with non-redacted contract message, state will be reverted
+		ack := []byte(res.Err)
+		if typedAck, ok := parseContractErrorAck(res.Err); ok {
+			ack = typedAck.Acknowledgement()
+		}
 		return channeltypesv2.RecvPacketResult{
 			Status:          channeltypesv2.PacketStatus_Failure,
-			Acknowledgement: []byte(res.Err),
+			Acknowledgement: ack,
 		}
 	}
 
@@ -236,7 +240,9 @@ func (k Keeper) OnRecvIBC2Packet(
 
 // OnTimeoutIBC2Packet calls the contract to let it know the packet was never received
 // on the destination chain within the timeout boundaries.
-// The contract should handle this on the application level and undo the original operation
+// The contract should handle this on the application level and undo the original operation.
+// The message contains the original payload so that the contract can send it again. IBC v2 has no channels
+// that are closed on timeout, so the packet can be sent again over the same source client.
 func (k Keeper) OnTimeoutIBC2Packet(
 	ctx sdk.Context,
 	contractAddr sdk.AccAddress,
@@ -252,9 +258,9 @@ func (k Keeper) OnTimeoutIBC2Packet(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBC2PacketTimeout(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -263,7 +269,7 @@ func (k Keeper) OnTimeoutIBC2Packet(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBC2PortID, res.Ok)
@@ -287,9 +293,9 @@ func (k Keeper) OnSendIBC2Packet(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBC2PacketSend(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -298,7 +304,7 @@ func (k Keeper) OnSendIBC2Packet(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
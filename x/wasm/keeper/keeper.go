@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
@@ -29,6 +30,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
 
@@ -36,8 +38,8 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
-// contractMemoryLimit is the memory limit of each contract execution (in MiB)
-// constant value so all nodes run with the same limit.
+// contractMemoryLimit is the default memory limit of each contract execution (in MiB)
+// so all nodes run with the same limit. It can be changed with the contract memory limit param.
 const contractMemoryLimit = 32
 
 // Option is an extension point to instantiate keeper with non default values
@@ -77,6 +79,21 @@ type WasmVMResponseHandler interface {
 	) ([]byte, error)
 }
 
+// ContractInstantiateListener is an extension point to get notified about new contract instances.
+type ContractInstantiateListener interface {
+	// OnContractInstantiated is called after a contract was instantiated successfully, within the same tx.
+	// The admin is nil when not set. Returning an error aborts the instantiation.
+	OnContractInstantiated(ctx context.Context, codeID uint64, contractAddr, creator, admin sdk.AccAddress) error
+}
+
+// OutOfGasListener is an extension point to get notified when a contract call runs out of gas.
+type OutOfGasListener interface {
+	// OnContractOutOfGas is called when the gas meter is exhausted by the wasm execution of the contract, before
+	// the out of gas panic is raised. It is informational only: state changes are discarded, gas is not charged
+	// and panics are recovered.
+	OnContractOutOfGas(ctx context.Context, contractAddr sdk.AccAddress)
+}
+
 // list of account types that are accepted for wasm contracts. Chains importing wasmd
 // can overwrite this list with the WithAcceptedAccountTypesOnContractInstantiation option.
 var defaultAcceptedAccountTypes = map[reflect.Type]struct{}{
@@ -86,22 +103,34 @@ var defaultAcceptedAccountTypes = map[reflect.Type]struct{}{
 // Keeper will have a reference to Wasm Engine with it's own data directory.
 type Keeper struct {
 	// The (unexposed) keys used to access the stores from the Context.
-	storeService          corestoretypes.KVStoreService
+	storeService corestoretypes.KVStoreService
+	// transientStoreService is for state that is reset after every block
+	transientStoreService corestoretypes.TransientStoreService
 	cdc                   codec.Codec
 	accountKeeper         types.AccountKeeper
 	bank                  CoinTransferrer
-	wasmVM                types.WasmEngine
+	// balances is the bank keeper for balance reads and the code storage deposits of the module account
+	balances types.BankKeeper
+	wasmVM   types.WasmEngine
+	// vmReloader recreates the wasmvm created by the keeper, nil when the engine was set with an option
+	vmReloader            *vmReloader
 	wasmVMQueryHandler    WasmVMQueryHandler
 	wasmVMResponseHandler WasmVMResponseHandler
 	messenger             Messenger
 	// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
-	queryGasLimit        uint64
-	gasRegister          types.GasRegister
-	maxQueryStackSize    uint32
-	maxCallDepth         uint32
+	queryGasLimit     uint64
+	gasRegister       types.GasRegister
+	maxQueryStackSize uint32
+	maxCallDepth      uint32
+	// blockSudoGasLimit is the max gas a single block sudo hook can consume
+	blockSudoGasLimit    uint64
 	acceptedAccountTypes map[reflect.Type]struct{}
 	accountPruner        AccountPruner
-	params               collections.Item[types.Params]
+	// contractAddrGenerator derives the addresses of new contract instances
+	contractAddrGenerator ContractAddrGenerator
+	instantiateListeners  []ContractInstantiateListener
+	outOfGasListeners     []OutOfGasListener
+	params                collections.Item[types.Params]
 	// propagate gov authZ to sub-messages
 	propagateGovAuthorization map[types.AuthorizationPolicyAction]struct{}
 
@@ -111,6 +140,12 @@ type Keeper struct {
 
 	// wasmLimits contains the limits sent to wasmvm on init
 	wasmLimits wasmvmtypes.WasmLimits
+	// availableCapabilities are the capabilities sent to wasmvm on init
+	availableCapabilities []string
+	// metricsSampleInterval is the number of blocks between two wasmvm metrics samples. 0 disables sampling
+	metricsSampleInterval uint64
+	// warmList are the recently used checksums that are pinned on the next start, nil when disabled
+	warmList *warmList
 
 	ibcRouterV2 *ibcapi.Router
 }
@@ -140,9 +175,37 @@ func (k Keeper) GetParams(ctx context.Context) types.Params {
 	return p
 }
 
+// GetCachedParams returns the wasm parameters for the contract call paths. The first lookup in a block reads the
+// params store without charging gas and caches the params in the transient store. Further lookups in the block
+// are memory reads that are not charged either, so that a feature gated by a param does not add gas costs when
+// it is disabled. The cache is dropped by SetParams.
+func (k Keeper) GetCachedParams(ctx context.Context) types.Params {
+	unchargedCtx := sdk.UnwrapSDKContext(ctx).WithGasMeter(storetypes.NewInfiniteGasMeter())
+	store := k.transientStoreService.OpenTransientStore(unchargedCtx)
+	key := types.GetParamsCacheKey(unchargedCtx.BlockHeight())
+	bz, err := store.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	var params types.Params
+	if bz != nil {
+		k.cdc.MustUnmarshal(bz, &params)
+		return params
+	}
+	params = k.GetParams(unchargedCtx)
+	if err := store.Set(key, k.cdc.MustMarshal(&params)); err != nil {
+		panic(err)
+	}
+	return params
+}
+
 // SetParams sets all wasm parameters.
 func (k Keeper) SetParams(ctx context.Context, ps types.Params) error {
-	return k.params.Set(ctx, ps)
+	if err := k.params.Set(ctx, ps); err != nil {
+		return err
+	}
+	unchargedCtx := sdk.UnwrapSDKContext(ctx).WithGasMeter(storetypes.NewInfiniteGasMeter())
+	return k.transientStoreService.OpenTransientStore(unchargedCtx).Delete(types.GetParamsCacheKey(unchargedCtx.BlockHeight()))
 }
 
 // GetAuthority returns the x/wasm module's authority.
@@ -155,34 +218,79 @@ func (k Keeper) GetGasRegister() types.GasRegister {
 	return k.gasRegister
 }
 
-func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error) {
+// codeInfoOption sets optional fields of the code info before it is stored
+type codeInfoOption func(info *types.CodeInfo)
+
+// withCodeProvenance sets the reproducible build metadata of the code
+func withCodeProvenance(source, builder string) codeInfoOption {
+	return func(info *types.CodeInfo) {
+		info.Source = source
+		info.Builder = builder
+	}
+}
+
+func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy, opts ...codeInfoOption) (codeID uint64, checksum []byte, err error) {
+	instantiateAccess, err = k.authorizeCodeUpload(ctx, creator, instantiateAccess, authZ)
+	if err != nil {
+		return 0, checksum, err
+	}
+	return k.storeCode(ctx, creator, wasmCode, instantiateAccess, opts...)
+}
+
+// createCodes stores multiple codes with the same instantiate access. The upload access is checked once for all codes.
+func (k Keeper) createCodes(ctx context.Context, creator sdk.AccAddress, wasmCodes [][]byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (codeIDs []uint64, checksums [][]byte, err error) {
+	instantiateAccess, err = k.authorizeCodeUpload(ctx, creator, instantiateAccess, authZ)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, wasmCode := range wasmCodes {
+		codeID, checksum, err := k.storeCode(ctx, creator, wasmCode, instantiateAccess)
+		if err != nil {
+			return nil, nil, errorsmod.Wrapf(err, "code %d", i)
+		}
+		codeIDs = append(codeIDs, codeID)
+		checksums = append(checksums, checksum)
+	}
+	return codeIDs, checksums, nil
+}
+
+// authorizeCodeUpload ensures that the creator can upload code with the instantiate access. The chain's default
+// instantiate access for the creator is returned when none is set.
+func (k Keeper) authorizeCodeUpload(ctx context.Context, creator sdk.AccAddress, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (*types.AccessConfig, error) {
 	if creator == nil {
-		return 0, checksum, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
 	}
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	// figure out proper instantiate access
-	defaultAccessConfig := k.getInstantiateAccessConfig(sdkCtx).With(creator)
+	defaultAccessConfig := k.getInstantiateAccessConfig(ctx).With(creator)
 	if instantiateAccess == nil {
 		instantiateAccess = &defaultAccessConfig
 	}
 	chainConfigs := types.ChainAccessConfigs{
 		Instantiate: defaultAccessConfig,
-		Upload:      k.getUploadAccessConfig(sdkCtx),
+		Upload:      k.getUploadAccessConfig(ctx),
 	}
 
 	if !authZ.CanCreateCode(chainConfigs, creator, *instantiateAccess) {
-		return 0, checksum, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
+		return nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not create code")
 	}
+	return instantiateAccess, nil
+}
 
-	if ioutils.IsGzip(wasmCode) {
-		sdkCtx.GasMeter().ConsumeGas(k.gasRegister.UncompressCosts(len(wasmCode)), "Uncompress gzip bytecode")
-		wasmCode, err = ioutils.Uncompress(wasmCode, int64(types.MaxWasmSize))
-		if err != nil {
-			return 0, checksum, types.ErrCreateFailed.Wrap(errorsmod.Wrap(err, "uncompress wasm archive").Error())
-		}
+func (k Keeper) storeCode(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, opts ...codeInfoOption) (codeID uint64, checksum []byte, err error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if ioutils.IsCompressed(wasmCode) {
+		sdkCtx.GasMeter().ConsumeGas(k.gasRegister.UncompressCosts(len(wasmCode)), "Uncompress bytecode")
+	}
+	// the size limit applies to the decompressed payload
+	wasmCode, err = ioutils.Decompress(wasmCode, int64(types.MaxWasmSize))
+	if err != nil {
+		return 0, checksum, types.ErrCreateFailed.Wrap(errorsmod.Wrap(err, "uncompress wasm archive").Error())
+	}
+	if err := k.checkFloatOperations(sdkCtx, wasmCode); err != nil {
+		return 0, checksum, err
 	}
 
-	gasLeft := k.runtimeGasForContract(sdkCtx)
+	gasLeft := k.runtimeGasForContract(sdkCtx, nil)
 	var gasUsed uint64
 	isSimulation := sdkCtx.ExecMode() == sdk.ExecModeSimulate
 	if isSimulation {
@@ -191,23 +299,53 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	} else {
 		checksum, gasUsed, err = k.wasmVM.StoreCode(wasmCode, gasLeft)
 	}
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	k.consumeRuntimeGas(sdkCtx, nil, gasUsed)
 	if err != nil {
 		return 0, checksum, errorsmod.Wrap(types.ErrCreateFailed, err.Error())
 	}
 	// simulation gets default value for capabilities
-	var requiredCapabilities string
+	var (
+		requiredCapabilities string
+		entrypoints          []string
+	)
 	if !isSimulation {
 		report, err := k.wasmVM.AnalyzeCode(checksum)
 		if err != nil {
 			return 0, checksum, errorsmod.Wrap(types.ErrCreateFailed, err.Error())
 		}
-		requiredCapabilities = report.RequiredCapabilities
+		requiredCapabilities, entrypoints = report.RequiredCapabilities, report.Entrypoints
+		if err := k.checkRequiredCapabilities(sdkCtx, parseCapabilities(requiredCapabilities)); err != nil {
+			return 0, checksum, errorsmod.Wrap(types.ErrCreateFailed, err.Error())
+		}
 	}
 	codeID = k.mustAutoIncrementID(sdkCtx, types.KeySequenceCodeID)
 	k.Logger(sdkCtx).Debug("storing new contract", "capabilities", requiredCapabilities, "code_id", codeID)
 	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
+	for _, opt := range opts {
+		opt(&codeInfo)
+	}
 	k.mustStoreCodeInfo(sdkCtx, codeID, codeInfo)
+	if err := k.setCodeSize(sdkCtx, codeID, uint64(len(wasmCode))); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.setCodeCapabilities(sdkCtx, codeID, parseCapabilities(requiredCapabilities)); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.setCodeEntrypoints(sdkCtx, codeID, entrypoints); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.incrementModuleStat(sdkCtx, types.KeyStatsCodeCount); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.incrementChecksumCodeCount(sdkCtx, checksum); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.setCodeStoredHeight(sdkCtx, codeID, uint64(sdkCtx.BlockHeight())); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.escrowCodeDeposit(sdkCtx, codeID, creator); err != nil {
+		return 0, checksum, err
+	}
 
 	evt := sdk.NewEvent(
 		types.EventTypeStoreCode,
@@ -218,6 +356,12 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 		evt.AppendAttributes(sdk.NewAttribute(types.AttributeKeyRequiredCapability, strings.TrimSpace(f)))
 	}
 	sdkCtx.EventManager().EmitEvent(evt)
+	// simulations have no code stored that could be pinned
+	if !isSimulation {
+		if err := k.autoPinCode(sdkCtx, codeID, checksum); err != nil {
+			return 0, checksum, err
+		}
+	}
 
 	return codeID, checksum, nil
 }
@@ -231,10 +375,81 @@ func (k Keeper) mustStoreCodeInfo(ctx context.Context, codeID uint64, codeInfo t
 	}
 }
 
+// analyzeCode returns the required capabilities and the exported entrypoints of the stored code from the wasmvm
+// analysis.
+func (k Keeper) analyzeCode(checksum []byte) (requiredCapabilities, entrypoints []string, err error) {
+	report, err := k.wasmVM.AnalyzeCode(checksum)
+	if err != nil {
+		return nil, nil, errorsmod.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	return parseCapabilities(report.RequiredCapabilities), report.Entrypoints, nil
+}
+
+// GetCodeCapabilities returns the capabilities required by the code, as analyzed by wasmvm when the code was stored.
+// They are kept out of the code info so that only instantiations read them.
+func (k Keeper) GetCodeCapabilities(ctx context.Context, codeID uint64) []string {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetCodeCapabilitiesKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	return parseCapabilities(string(bz))
+}
+
+// setCodeCapabilities stores the required capabilities of the code as comma separated list
+func (k Keeper) setCodeCapabilities(ctx context.Context, codeID uint64, capabilities []string) error {
+	if len(capabilities) == 0 {
+		return nil
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeCapabilitiesKey(codeID), []byte(strings.Join(capabilities, ",")))
+}
+
+// setCodeEntrypoints stores the entrypoints exported by the code as comma separated list. They are kept out of
+// the code info so that contract calls do not read them.
+func (k Keeper) setCodeEntrypoints(ctx context.Context, codeID uint64, entrypoints []string) error {
+	if len(entrypoints) == 0 {
+		return nil
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeEntrypointsKey(codeID), []byte(strings.Join(entrypoints, ",")))
+}
+
+// GetCodeSize returns the byte length of the uncompressed wasm code. The size is kept out of the code info so that
+// contract calls do not read it.
+func (k Keeper) GetCodeSize(ctx context.Context, codeID uint64) uint64 {
+	return k.getUint64(ctx, types.GetCodeSizeKey(codeID))
+}
+
+func (k Keeper) setCodeSize(ctx context.Context, codeID, size uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeSizeKey(codeID), sdk.Uint64ToBigEndian(size))
+}
+
+// backfillCodeInfo stores the code info, the analysis of the stored code and the code size and counts the code for
+// its checksum. It is used by the store migration for the codes that were stored before this data was kept.
+func (k Keeper) backfillCodeInfo(ctx context.Context, codeID uint64, codeInfo types.CodeInfo) error {
+	code, err := k.wasmVM.GetCode(codeInfo.CodeHash)
+	if err != nil {
+		return errorsmod.Wrapf(err, "loading wasm code %d", codeID)
+	}
+	capabilities, entrypoints, err := k.analyzeCode(codeInfo.CodeHash)
+	if err != nil {
+		return err
+	}
+	k.mustStoreCodeInfo(ctx, codeID, codeInfo)
+	if err := k.setCodeSize(ctx, codeID, uint64(len(code))); err != nil {
+		return err
+	}
+	if err := k.setCodeCapabilities(ctx, codeID, capabilities); err != nil {
+		return err
+	}
+	if err := k.setCodeEntrypoints(ctx, codeID, entrypoints); err != nil {
+		return err
+	}
+	return k.incrementChecksumCodeCount(ctx, codeInfo.CodeHash)
+}
+
 func (k Keeper) importCode(ctx context.Context, codeID uint64, codeInfo types.CodeInfo, wasmCode []byte) error {
-	if ioutils.IsGzip(wasmCode) {
+	if ioutils.IsCompressed(wasmCode) {
 		var err error
-		wasmCode, err = ioutils.Uncompress(wasmCode, math.MaxInt64)
+		wasmCode, err = ioutils.Decompress(wasmCode, math.MaxInt64)
 		if err != nil {
 			return types.ErrCreateFailed.Wrap(errorsmod.Wrap(err, "uncompress wasm archive").Error())
 		}
@@ -246,6 +461,10 @@ func (k Keeper) importCode(ctx context.Context, codeID uint64, codeInfo types.Co
 	if !bytes.Equal(codeInfo.CodeHash, newCodeHash) {
 		return errorsmod.Wrap(types.ErrInvalid, "code hashes not same")
 	}
+	capabilities, entrypoints, err := k.analyzeCode(codeInfo.CodeHash)
+	if err != nil {
+		return err
+	}
 
 	store := k.storeService.OpenKVStore(ctx)
 	key := types.GetCodeKey(codeID)
@@ -257,7 +476,22 @@ func (k Keeper) importCode(ctx context.Context, codeID uint64, codeInfo types.Co
 		return errorsmod.Wrapf(types.ErrDuplicate, "duplicate code: %d", codeID)
 	}
 	// 0x01 | codeID (uint64) -> ContractInfo
-	return store.Set(key, k.cdc.MustMarshal(&codeInfo))
+	if err := store.Set(key, k.cdc.MustMarshal(&codeInfo)); err != nil {
+		return err
+	}
+	if err := k.setCodeSize(ctx, codeID, uint64(len(wasmCode))); err != nil {
+		return err
+	}
+	if err := k.setCodeCapabilities(ctx, codeID, capabilities); err != nil {
+		return err
+	}
+	if err := k.setCodeEntrypoints(ctx, codeID, entrypoints); err != nil {
+		return err
+	}
+	if err := k.incrementChecksumCodeCount(ctx, codeInfo.CodeHash); err != nil {
+		return err
+	}
+	return k.incrementModuleStat(ctx, types.KeyStatsCodeCount)
 }
 
 func (k Keeper) instantiate(
@@ -285,17 +519,35 @@ func (k Keeper) instantiate(
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(sdkCtx, codeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(initMsg))
 
-	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: instantiate")
+	sdkCtx.GasMeter().ConsumeGas(setupCost, types.GasDescSetupPrefix+"instantiate")
 
 	if !authPolicy.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
 		return nil, nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
 	}
+	// the max instances limit applies to the addresses the permission was granted to
+	limitInstances := codeInfo.InstantiateConfig.MaxInstancesPerAddress != 0 && codeInfo.InstantiateConfig.Allowed(creator)
+	var instances uint64
+	if limitInstances {
+		instances = k.GetInstantiateCount(sdkCtx, codeID, creator)
+		if !codeInfo.InstantiateConfig.AllowedInstances(creator, instances) {
+			return nil, nil, errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "max instances per address reached: %d", instances)
+		}
+	}
+	if err := k.checkCodeCapabilities(sdkCtx, codeID); err != nil {
+		return nil, nil, err
+	}
+	if err := k.countBlockInstantiate(sdkCtx); err != nil {
+		return nil, nil, err
+	}
 	contractAddress := addressGenerator(ctx, codeID, codeInfo.CodeHash)
 	if k.HasContractInfo(ctx, contractAddress) {
 		// This case must only happen for instantiate2 because instantiate is based on a counter in state.
 		// So we create an instantiate2 specific error message here even though technically this function
 		// is used for both cases.
-		return nil, nil, types.ErrDuplicate.Wrap("contract address already exists, try a different combination of creator, checksum and salt")
+		return nil, nil, types.ErrContractAddressExists.Wrap("try a different combination of creator, checksum and salt")
+	}
+	if err := k.checkLabelUniqueness(sdkCtx, codeID, label, contractAddress); err != nil {
+		return nil, nil, err
 	}
 
 	// check account
@@ -350,18 +602,18 @@ func (k Keeper) instantiate(
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
 
 	// instantiate wasm contract
-	gasLeft := k.runtimeGasForContract(sdkCtx)
-	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, vmStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasLeft := k.runtimeGasForContract(sdkCtx, contractAddress)
+	res, gasUsed, err := k.wasmVM.Instantiate(codeInfo.CodeHash, env, info, initMsg, vmStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx, contractAddress), gasLeft, costJSONDeserialization)
+	k.consumeRuntimeGas(sdkCtx, contractAddress, gasUsed)
 	if err != nil {
-		return nil, nil, errorsmod.Wrap(types.ErrVMError, err.Error())
+		return nil, nil, k.contractVMError(sdkCtx, contractAddress, gasLeft, err)
 	}
 	if res == nil {
 		// If this gets executed, that's a bug in wasmvm
 		return nil, nil, errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return nil, nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrInstantiateFailed, res.Err))
+		return nil, nil, types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrInstantiateFailed, res.Err))
 	}
 
 	// persist instance first
@@ -371,7 +623,7 @@ func (k Keeper) instantiate(
 	// check for IBC flag
 	report, err := k.wasmVM.AnalyzeCode(codeInfo.CodeHash)
 	if err != nil {
-		return nil, nil, errorsmod.Wrap(types.ErrVMError, err.Error())
+		return nil, nil, vmError(err)
 	}
 	if report.HasIBCEntryPoints {
 		// register IBC port
@@ -397,17 +649,38 @@ func (k Keeper) instantiate(
 	if err != nil {
 		return nil, nil, err
 	}
+	err = k.addToContractLabelIndex(sdkCtx, contractAddress, codeID, label)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = k.recordCodeInstantiation(sdkCtx, codeID, uint64(sdkCtx.BlockHeight()), contractAddress, creator)
+	if err != nil {
+		return nil, nil, err
+	}
 	err = k.appendToContractHistory(sdkCtx, contractAddress, historyEntry)
 	if err != nil {
 		return nil, nil, err
 	}
+	if limitInstances {
+		if err := k.setInstantiateCount(sdkCtx, codeID, creator, instances+1); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	k.mustStoreContractInfo(sdkCtx, contractAddress, &contractInfo)
+	if err := k.incrementModuleStat(sdkCtx, types.KeyStatsContractCount); err != nil {
+		return nil, nil, err
+	}
+	if err := k.recordContractDependency(sdkCtx, creator, codeID); err != nil {
+		return nil, nil, err
+	}
 
+	sdkCtx, traceID := withTraceID(sdkCtx, contractAddress)
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeInstantiate,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyTraceID, traceID),
 	))
 
 	sdkCtx = types.WithSubMsgAuthzPolicy(sdkCtx, authPolicy.SubMessageAuthorizationPolicy(types.AuthZActionInstantiate))
@@ -416,6 +689,12 @@ func (k Keeper) instantiate(
 		return nil, nil, errorsmod.Wrap(err, "dispatch")
 	}
 
+	for _, l := range k.instantiateListeners {
+		if err := l.OnContractInstantiated(sdkCtx, codeID, contractAddress, creator, admin); err != nil {
+			return nil, nil, errorsmod.Wrap(err, "instantiate listener")
+		}
+	}
+
 	return contractAddress, data, nil
 }
 
@@ -427,11 +706,12 @@ func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddr
 	if err != nil {
 		return nil, err
 	}
+	prefixStore, cleanupTracker := k.withStateCleanupTracking(sdkCtx, prefixStore)
 
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
 
-	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: execute")
+	sdkCtx.GasMeter().ConsumeGas(setupCost, types.GasDescSetupPrefix+"execute")
 
 	// add more funds
 	if !coins.IsZero() {
@@ -445,39 +725,86 @@ func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddr
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
-	gasLeft := k.runtimeGasForContract(sdkCtx)
-	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasLeft := k.runtimeGasForContract(sdkCtx, contractAddress)
+	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, k.withStateChangeEvents(sdkCtx, contractAddress, prefixStore), cosmwasmAPI, querier, k.gasMeter(sdkCtx, contractAddress), gasLeft, costJSONDeserialization)
+	k.consumeRuntimeGas(sdkCtx, contractAddress, gasUsed)
 	if execErr != nil {
-		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
+		return nil, k.contractCallError(sdkCtx, contractAddress, contractInfo.StorageQuota, gasLeft, execErr)
 	}
 	if res == nil {
 		// If this gets executed, that's a bug in wasmvm
 		return nil, errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return nil, types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
+	sdkCtx, traceID := withTraceID(sdkCtx, contractAddress)
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeExecute,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyTraceID, traceID),
 	))
 
 	data, err := k.handleContractResponse(sdkCtx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, err
 	}
+	k.refundStateCleanupGas(sdkCtx, contractAddress, cleanupTracker)
 
 	return data, nil
 }
 
+// ExecuteDetailed executes the contract instance like execute and returns the response data together with
+// all events emitted and the gas consumed. The events are emitted to the event manager of the context as well.
+func (k Keeper) ExecuteDetailed(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (*types.ExecuteResult, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	em := sdk.NewEventManager()
+	gasBefore := sdkCtx.GasMeter().GasConsumed()
+	data, err := k.execute(sdkCtx.WithEventManager(em), contractAddress, caller, msg, coins)
+	if err != nil {
+		return nil, err
+	}
+	sdkCtx.EventManager().EmitEvents(em.Events())
+	return &types.ExecuteResult{
+		Data:    data,
+		Events:  em.Events(),
+		GasUsed: sdkCtx.GasMeter().GasConsumed() - gasBefore,
+	}, nil
+}
+
+// SimulateExecute executes the contract instance like execute on a branch of the state that is always discarded.
+// It returns the response data and the events that the execution would emit.
+func (k Keeper) SimulateExecute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, sdk.Events, error) {
+	cacheCtx, _ := sdk.UnwrapSDKContext(ctx).CacheContext()
+	em := sdk.NewEventManager()
+	data, err := k.execute(cacheCtx.WithEventManager(em), contractAddress, caller, msg, coins)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, em.Events(), nil
+}
+
+// SimulateExecuteWithBalances is the same as SimulateExecute and returns the balances of the contract before and
+// after the call in addition. The balance after the call is read from the discarded branch of the state.
+func (k Keeper) SimulateExecuteWithBalances(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (data []byte, events sdk.Events, before, after sdk.Coins, err error) {
+	cacheCtx, _ := sdk.UnwrapSDKContext(ctx).CacheContext()
+	before = k.balances.GetAllBalances(cacheCtx, contractAddress)
+	em := sdk.NewEventManager()
+	data, err = k.execute(cacheCtx.WithEventManager(em), contractAddress, caller, msg, coins)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return data, em.Events(), before, k.balances.GetAllBalances(cacheCtx, contractAddress), nil
+}
+
 func (k Keeper) migrate(
 	ctx context.Context,
 	contractAddress sdk.AccAddress,
 	caller sdk.AccAddress,
 	newCodeID uint64,
 	msg []byte,
+	deposit sdk.Coins,
 	authZ types.AuthorizationPolicy,
 ) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "migrate")
@@ -491,6 +818,9 @@ func (k Keeper) migrate(
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not migrate")
 	}
+	if err := checkContractLock(*contractInfo, contractAddress); err != nil {
+		return nil, err
+	}
 
 	newCodeInfo := k.GetCodeInfo(ctx, newCodeID)
 	if newCodeInfo == nil {
@@ -500,12 +830,15 @@ func (k Keeper) migrate(
 	if !authZ.CanInstantiateContract(newCodeInfo.InstantiateConfig, caller) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "to use new code")
 	}
+	if err := k.checkLabelUniqueness(sdkCtx, newCodeID, contractInfo.Label, contractAddress); err != nil {
+		return nil, err
+	}
 
 	// check for IBC flag
 	report, err := k.wasmVM.AnalyzeCode(newCodeInfo.CodeHash)
 	switch {
 	case err != nil:
-		return nil, errorsmod.Wrap(types.ErrVMError, err.Error())
+		return nil, vmError(err)
 	case !report.HasIBCEntryPoints && contractInfo.IBCPortID != "":
 		// prevent update to non ibc contract
 		return nil, errorsmod.Wrap(types.ErrMigrationFailed, "requires ibc callbacks")
@@ -518,20 +851,27 @@ func (k Keeper) migrate(
 	ibc2Port := PortIDForContractV2(contractAddress)
 	contractInfo.IBC2PortID = ibc2Port
 
+	// deposit funds so that the migrate entrypoint sees the new balance
+	if !deposit.IsZero() {
+		if err := k.bank.TransferCoins(sdkCtx, caller, contractAddress, deposit); err != nil {
+			return nil, err
+		}
+	}
+
 	var response *wasmvmtypes.Response
 
 	// check for migrate version
 	oldCodeInfo := k.GetCodeInfo(ctx, contractInfo.CodeID)
 	oldReport, err := k.wasmVM.AnalyzeCode(oldCodeInfo.CodeHash)
 	if err != nil {
-		return nil, errorsmod.Wrap(types.ErrVMError, err.Error())
+		return nil, vmError(err)
 	}
 
 	// call migrate entrypoint, except if both migrate versions are set and the same value
 	if report.ContractMigrateVersion == nil ||
 		oldReport.ContractMigrateVersion == nil ||
 		*report.ContractMigrateVersion != *oldReport.ContractMigrateVersion {
-		response, err = k.callMigrateEntrypoint(sdkCtx, contractAddress, wasmvmtypes.Checksum(newCodeInfo.CodeHash), msg, newCodeID, caller, oldReport.ContractMigrateVersion)
+		response, err = k.callMigrateEntrypoint(sdkCtx, contractAddress, contractInfo.StorageQuota, wasmvmtypes.Checksum(newCodeInfo.CodeHash), msg, newCodeID, caller, oldReport.ContractMigrateVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -542,6 +882,10 @@ func (k Keeper) migrate(
 	if err != nil {
 		return nil, err
 	}
+	err = k.removeFromContractLabelIndex(ctx, contractAddress, contractInfo.CodeID, contractInfo.Label)
+	if err != nil {
+		return nil, err
+	}
 	// persist migration updates
 	historyEntry := contractInfo.AddMigration(sdkCtx, newCodeID, msg)
 	err = k.appendToContractHistory(ctx, contractAddress, historyEntry)
@@ -552,12 +896,21 @@ func (k Keeper) migrate(
 	if err != nil {
 		return nil, err
 	}
+	err = k.addToContractLabelIndex(ctx, contractAddress, newCodeID, contractInfo.Label)
+	if err != nil {
+		return nil, err
+	}
 	k.mustStoreContractInfo(ctx, contractAddress, contractInfo)
+	if err := k.recordContractDependency(ctx, caller, newCodeID); err != nil {
+		return nil, err
+	}
 
+	sdkCtx, traceID := withTraceID(sdkCtx, contractAddress)
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeMigrate,
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyTraceID, traceID),
 	))
 
 	var data []byte
@@ -586,6 +939,7 @@ func (k Keeper) migrate(
 func (k Keeper) callMigrateEntrypoint(
 	sdkCtx sdk.Context,
 	contractAddress sdk.AccAddress,
+	storageQuota uint64,
 	newChecksum wasmvmtypes.Checksum,
 	msg []byte,
 	newCodeID uint64,
@@ -594,7 +948,7 @@ func (k Keeper) callMigrateEntrypoint(
 ) (*wasmvmtypes.Response, error) {
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, newChecksum, k.IsPinnedCode(sdkCtx, newCodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
-	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: migrate")
+	sdkCtx.GasMeter().ConsumeGas(setupCost, types.GasDescSetupPrefix+"migrate")
 
 	env := types.NewEnv(sdkCtx, contractAddress)
 
@@ -602,26 +956,28 @@ func (k Keeper) callMigrateEntrypoint(
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
 
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
-	vmStore := types.NewStoreAdapter(prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(sdkCtx)), prefixStoreKey))
-	gasLeft := k.runtimeGasForContract(sdkCtx)
+	vmStore, cleanupTracker := k.withStateCleanupTracking(sdkCtx, k.withStorageQuota(sdkCtx, contractAddress, storageQuota, types.NewStoreAdapter(prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(sdkCtx)), prefixStoreKey))))
+	vmStore = k.withStateChangeEvents(sdkCtx, contractAddress, vmStore)
+	gasLeft := k.runtimeGasForContract(sdkCtx, contractAddress)
 
 	migrateInfo := wasmvmtypes.MigrateInfo{
 		Sender:            senderAddress.String(),
 		OldMigrateVersion: oldMigrateVersion,
 	}
-	res, gasUsed, err := k.wasmVM.MigrateWithInfo(newChecksum, env, msg, migrateInfo, vmStore, cosmwasmAPI, &querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
+	res, gasUsed, err := k.wasmVM.MigrateWithInfo(newChecksum, env, msg, migrateInfo, vmStore, cosmwasmAPI, &querier, k.gasMeter(sdkCtx, contractAddress), gasLeft, costJSONDeserialization)
 
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	k.consumeRuntimeGas(sdkCtx, contractAddress, gasUsed)
 	if err != nil {
-		return nil, errorsmod.Wrap(types.ErrVMError, err.Error())
+		return nil, k.contractCallError(sdkCtx, contractAddress, storageQuota, gasLeft, err)
 	}
 	if res == nil {
 		// If this gets executed, that's a bug in wasmvm
 		return nil, errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrMigrationFailed, res.Err))
+		return nil, types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrMigrationFailed, res.Err))
 	}
+	k.refundStateCleanupGas(sdkCtx, contractAddress, cleanupTracker)
 	return res.Ok, nil
 }
 
@@ -641,32 +997,35 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 		return nil, err
 	}
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	prefixStore, cleanupTracker := k.withStateCleanupTracking(sdkCtx, prefixStore)
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
 
-	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: sudo")
+	sdkCtx.GasMeter().ConsumeGas(setupCost, types.GasDescSetupPrefix+"sudo")
 
 	env := types.NewEnv(sdkCtx, contractAddress)
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddress)
-	gasLeft := k.runtimeGasForContract(sdkCtx)
-	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasLeft := k.runtimeGasForContract(sdkCtx, contractAddress)
+	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, k.withStateChangeEvents(sdkCtx, contractAddress, prefixStore), cosmwasmAPI, querier, k.gasMeter(sdkCtx, contractAddress), gasLeft, costJSONDeserialization)
+	k.consumeRuntimeGas(sdkCtx, contractAddress, gasUsed)
 	if execErr != nil {
-		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
+		return nil, k.contractCallError(sdkCtx, contractAddress, contractInfo.StorageQuota, gasLeft, execErr)
 	}
 	if res == nil {
 		// If this gets executed, that's a bug in wasmvm
 		return nil, errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return nil, types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
+	sdkCtx, traceID := withTraceID(sdkCtx, contractAddress)
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeSudo,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyTraceID, traceID),
 	))
 
 	// sudo submessages are executed with the default authorization policy
@@ -674,6 +1033,7 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "dispatch")
 	}
+	k.refundStateCleanupGas(sdkCtx, contractAddress, cleanupTracker)
 
 	return data, nil
 }
@@ -684,51 +1044,164 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 	if err != nil {
 		return nil, err
 	}
+	prefixStore, cleanupTracker := k.withStateCleanupTracking(ctx, prefixStore)
 
 	replyCosts := k.gasRegister.ReplyCosts(true, reply)
-	ctx.GasMeter().ConsumeGas(replyCosts, "Loading CosmWasm module: reply")
+	ctx.GasMeter().ConsumeGas(replyCosts, types.GasDescSetupPrefix+"reply")
 
 	env := types.NewEnv(ctx, contractAddress)
 
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddress)
 
-	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx, contractAddress), gasLeft, costJSONDeserialization)
+	k.consumeRuntimeGas(ctx, contractAddress, gasUsed)
 	if execErr != nil {
-		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
+		return nil, k.contractCallError(ctx, contractAddress, contractInfo.StorageQuota, gasLeft, execErr)
 	}
 	if res == nil {
 		// If this gets executed, that's a bug in wasmvm
 		return nil, errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return nil, types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
+	ctx, traceID := withTraceID(ctx, contractAddress)
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeReply,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyTraceID, traceID),
 	))
 
 	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "dispatch")
 	}
+	k.refundStateCleanupGas(ctx, contractAddress, cleanupTracker)
 
 	return data, nil
 }
 
 // addToContractCodeSecondaryIndex adds element to the index for contracts-by-codeid queries
+// and increments the contract counter of the code
 func (k Keeper) addToContractCodeSecondaryIndex(ctx context.Context, contractAddress sdk.AccAddress, entry types.ContractCodeHistoryEntry) error {
 	store := k.storeService.OpenKVStore(ctx)
-	return store.Set(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry), []byte{})
+	key := types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry)
+	exists, err := store.Has(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	if err := store.Set(key, []byte{}); err != nil {
+		return err
+	}
+	return k.setContractCountByCode(ctx, entry.CodeID, k.GetContractCountByCode(ctx, entry.CodeID)+1)
 }
 
 // removeFromContractCodeSecondaryIndex removes element to the index for contracts-by-codeid queries
+// and decrements the contract counter of the code
 func (k Keeper) removeFromContractCodeSecondaryIndex(ctx context.Context, contractAddress sdk.AccAddress, entry types.ContractCodeHistoryEntry) error {
-	return k.storeService.OpenKVStore(ctx).Delete(types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry))
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetContractByCreatedSecondaryIndexKey(contractAddress, entry)
+	exists, err := store.Has(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	if err := store.Delete(key); err != nil {
+		return err
+	}
+	count := k.GetContractCountByCode(ctx, entry.CodeID)
+	if count == 0 {
+		return errorsmod.Wrapf(types.ErrInvalid, "contract count underflow for code id %d", entry.CodeID)
+	}
+	return k.setContractCountByCode(ctx, entry.CodeID, count-1)
+}
+
+// GetContractCountByCode returns the number of contract instances that currently run the given code
+func (k Keeper) GetContractCountByCode(ctx context.Context, codeID uint64) uint64 {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(types.GetContractCountByCodeIDKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// setContractCountByCode stores the number of contract instances for the given code.
+// A zero count is not persisted.
+func (k Keeper) setContractCountByCode(ctx context.Context, codeID, count uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetContractCountByCodeIDKey(codeID)
+	if count == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, sdk.Uint64ToBigEndian(count))
+}
+
+// GetInstantiateCount returns the number of contracts the address has instantiated from the given code.
+// Instances are only counted while the code's instantiate config has a max instances per address limit.
+func (k Keeper) GetInstantiateCount(ctx context.Context, codeID uint64, addr sdk.AccAddress) uint64 {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(types.GetInstantiateCountKey(codeID, addr))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// CanInstantiate returns true when the actor is allowed to instantiate a new contract from the given code
+// by its instantiate config, including the max instances per address limit.
+func (k Keeper) CanInstantiate(ctx context.Context, codeID uint64, actor sdk.AccAddress) bool {
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil || actor == nil {
+		return false
+	}
+	config := codeInfo.InstantiateConfig
+	if !config.Allowed(actor) {
+		return false
+	}
+	if config.MaxInstancesPerAddress == 0 {
+		return true
+	}
+	return config.AllowedInstances(actor, k.GetInstantiateCount(ctx, codeID, actor))
+}
+
+// setInstantiateCount stores the number of contracts the address has instantiated from the given code.
+// A zero count is not persisted.
+func (k Keeper) setInstantiateCount(ctx context.Context, codeID uint64, addr sdk.AccAddress, count uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetInstantiateCountKey(codeID, addr)
+	if count == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, sdk.Uint64ToBigEndian(count))
+}
+
+// IterateInstantiateCounts iterates over all stored instantiate counts
+func (k Keeper) IterateInstantiateCounts(ctx context.Context, cb func(codeID uint64, addr sdk.AccAddress, count uint64) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.InstantiateCountPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if cb(binary.BigEndian.Uint64(key[:8]), key[8:], binary.BigEndian.Uint64(iter.Value())) {
+			return
+		}
+	}
 }
 
 // addToContractCreatorSecondaryIndex adds element to the index for contracts-by-creator queries
@@ -792,6 +1265,15 @@ func (k Keeper) setContractLabel(ctx context.Context, contractAddress, caller sd
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
 	}
+	if err := k.checkLabelUniqueness(sdkCtx, contractInfo.CodeID, newLabel, contractAddress); err != nil {
+		return err
+	}
+	if err := k.removeFromContractLabelIndex(sdkCtx, contractAddress, contractInfo.CodeID, contractInfo.Label); err != nil {
+		return err
+	}
+	if err := k.addToContractLabelIndex(sdkCtx, contractAddress, contractInfo.CodeID, newLabel); err != nil {
+		return err
+	}
 	contractInfo.Label = newLabel
 	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
@@ -846,6 +1328,29 @@ func (k Keeper) GetContractHistory(ctx context.Context, contractAddr sdk.AccAddr
 	return r
 }
 
+// GetContractHistoryPaginated returns a page of the contract's code history in chronological order
+func (k Keeper) GetContractHistoryPaginated(ctx context.Context, contractAddr sdk.AccAddress, pageReq *query.PageRequest) ([]types.ContractCodeHistoryEntry, *query.PageResponse, error) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractCodeHistoryElementPrefix(contractAddr))
+	r := make([]types.ContractCodeHistoryEntry, 0)
+	pageRes, err := query.FilteredPaginate(prefixStore, pageReq, func(key, value []byte, accumulate bool) (bool, error) {
+		if len(key) != 8 { // add extra safety in a mixed contract length environment
+			return false, nil
+		}
+		if accumulate {
+			var e types.ContractCodeHistoryEntry
+			if err := k.cdc.Unmarshal(value, &e); err != nil {
+				return false, err
+			}
+			r = append(r, e)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, pageRes, nil
+}
+
 // mustGetLastContractHistoryEntry returns the last element from history. To be used internally only as it panics when none exists
 func (k Keeper) mustGetLastContractHistoryEntry(ctx context.Context, contractAddr sdk.AccAddress) types.ContractCodeHistoryEntry {
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractCodeHistoryElementPrefix(contractAddr))
@@ -868,28 +1373,32 @@ func (k Keeper) QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-smart")
 
 	// checks and increase query stack size
-	sdkCtx, err := checkAndIncreaseQueryStackSize(sdk.UnwrapSDKContext(ctx), k.maxQueryStackSize)
+	sdkCtx, err := k.checkAndIncreaseQueryStackSize(sdk.UnwrapSDKContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 
-	contractInfo, codeInfo, prefixStore, err := k.contractInstance(sdkCtx, contractAddr)
+	contractInfo, codeInfo, prefixStore, err := k.loadContractInstance(sdkCtx, contractAddr)
 	if err != nil {
 		return nil, err
 	}
+	if err := k.checkContractQueryLock(sdkCtx, contractInfo, contractAddr); err != nil {
+		return nil, err
+	}
 
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(req))
-	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: query")
+	sdkCtx.GasMeter().ConsumeGas(setupCost, types.GasDescSetupPrefix+"query")
 
 	// prepare querier
 	querier := k.newQueryHandler(sdkCtx, contractAddr)
 
 	env := types.NewEnv(sdkCtx, contractAddr)
-	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), k.runtimeGasForContract(sdkCtx), costJSONDeserialization)
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	gasLeft := k.runtimeGasForContract(sdkCtx, contractAddr)
+	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx, contractAddr), gasLeft, costJSONDeserialization)
+	k.consumeRuntimeGas(sdkCtx, contractAddr, gasUsed)
 	if qErr != nil {
-		return nil, errorsmod.Wrap(types.ErrVMError, qErr.Error())
+		return nil, k.contractVMError(sdkCtx, contractAddr, gasLeft, qErr)
 	}
 	if queryResult.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrQueryFailed, queryResult.Err))
@@ -897,7 +1406,9 @@ func (k Keeper) QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req
 	return queryResult.Ok, nil
 }
 
-func checkAndIncreaseQueryStackSize(ctx context.Context, maxQueryStackSize uint32) (sdk.Context, error) {
+// checkAndIncreaseQueryStackSize increases the number of nested smart queries in the context and fails when
+// the MaxQueryRecursionDepth param or the keeper limit is exceeded. The param can only lower the keeper limit.
+func (k Keeper) checkAndIncreaseQueryStackSize(ctx sdk.Context) (sdk.Context, error) {
 	var queryStackSize uint32 = 0
 	if size, ok := types.QueryStackSize(ctx); ok {
 		queryStackSize = size
@@ -907,12 +1418,15 @@ func checkAndIncreaseQueryStackSize(ctx context.Context, maxQueryStackSize uint3
 	queryStackSize++
 
 	// did we go too far?
-	if queryStackSize > maxQueryStackSize {
+	if queryStackSize > k.maxQueryStackSize {
 		return sdk.Context{}, types.ErrExceedMaxQueryStackSize
 	}
+	if maxDepth := k.GetCachedParams(ctx).MaxQueryRecursionDepth; maxDepth != 0 && uint64(queryStackSize) > maxDepth {
+		return sdk.Context{}, errorsmod.Wrapf(types.ErrExceedMaxQueryRecursionDepth, "max %d", maxDepth)
+	}
 
 	// set updated stack size
-	return types.WithQueryStackSize(sdk.UnwrapSDKContext(ctx), queryStackSize), nil
+	return types.WithQueryStackSize(ctx, queryStackSize), nil
 }
 
 func checkAndIncreaseCallDepth(ctx context.Context, maxCallDepth uint32) (sdk.Context, error) {
@@ -926,7 +1440,7 @@ func checkAndIncreaseCallDepth(ctx context.Context, maxCallDepth uint32) (sdk.Co
 
 	// did we go too far?
 	if callDepth > maxCallDepth {
-		return sdk.Context{}, types.ErrExceedMaxCallDepth
+		return sdk.Context{}, errorsmod.Wrapf(types.ErrExceedMaxCallDepth, "max %d", maxCallDepth)
 	}
 
 	// set updated stack size
@@ -944,8 +1458,42 @@ func (k Keeper) QueryRaw(ctx context.Context, contractAddress sdk.AccAddress, ke
 	return prefixStore.Get(key)
 }
 
+// QueryRawPrefix returns a page of the contract's state for all keys starting with the given prefix.
+// The returned model keys are the full keys, including the prefix. An empty prefix iterates the
+// whole contract state. The models are in ascending key byte order, or descending when the page request is reversed.
+func (k Keeper) QueryRawPrefix(ctx context.Context, contractAddress sdk.AccAddress, keyPrefix []byte, pageReq *query.PageRequest) ([]types.Model, *query.PageResponse, error) {
+	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-raw-prefix")
+	prefixStoreKey := append(types.GetContractStorePrefix(contractAddress), keyPrefix...)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
+
+	r := make([]types.Model, 0)
+	pageRes, err := query.Paginate(prefixStore, pageReq, func(key, value []byte) error {
+		r = append(r, types.Model{
+			Key:   append(bytes.Clone(keyPrefix), key...),
+			Value: value,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, pageRes, nil
+}
+
 // internal helper function
+// contractInstance loads the contract for a call that is rejected when the contract is locked
 func (k Keeper) contractInstance(ctx context.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, wasmvm.KVStore, error) {
+	contractInfo, codeInfo, prefixStore, err := k.loadContractInstance(ctx, contractAddress)
+	if err != nil {
+		return types.ContractInfo{}, types.CodeInfo{}, nil, err
+	}
+	if err := checkContractLock(contractInfo, contractAddress); err != nil {
+		return types.ContractInfo{}, types.CodeInfo{}, nil, err
+	}
+	return contractInfo, codeInfo, prefixStore, nil
+}
+
+func (k Keeper) loadContractInstance(ctx context.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, wasmvm.KVStore, error) {
 	store := k.storeService.OpenKVStore(ctx)
 
 	contractBz, err := store.Get(types.GetContractAddressKey(contractAddress))
@@ -972,7 +1520,7 @@ func (k Keeper) contractInstance(ctx context.Context, contractAddress sdk.AccAdd
 	k.cdc.MustUnmarshal(codeInfoBz, &codeInfo)
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
-	return contractInfo, codeInfo, types.NewStoreAdapter(prefixStore), nil
+	return contractInfo, codeInfo, k.withStorageQuota(sdk.UnwrapSDKContext(ctx), contractAddress, contractInfo.StorageQuota, types.NewStoreAdapter(prefixStore)), nil
 }
 
 func (k Keeper) LoadAsyncAckPacket(ctx context.Context, portID, channelID string, sequence uint64) (channeltypes.Packet, error) {
@@ -1066,6 +1614,7 @@ func (k Keeper) IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress,
 
 // IterateContractState iterates through all elements of the key value store for the given contract address and passes
 // them to the provided callback function. The callback method can return true to abort early.
+// The elements are passed in ascending key byte order. Migrations can rely on this order.
 func (k Keeper) IterateContractState(ctx context.Context, contractAddress sdk.AccAddress, cb func(key, value []byte) bool) {
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
@@ -1156,12 +1705,24 @@ func (k Keeper) pinCode(ctx context.Context, codeID uint64) error {
 	if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
 		return errorsmod.Wrap(types.ErrPinContractFailed, err.Error())
 	}
+	if k.warmList != nil {
+		k.warmList.release(codeInfo.CodeHash)
+	}
+	if !k.IsPinnedCode(ctx, codeID) {
+		if err := k.incrementModuleStat(ctx, types.KeyStatsPinnedCodeCount); err != nil {
+			return err
+		}
+	}
 	store := k.storeService.OpenKVStore(ctx)
 	// store 1 byte to not run into `nil` debugging issues
 	err := store.Set(types.GetPinnedCodeIndexPrefix(codeID), []byte{1})
 	if err != nil {
 		return err
 	}
+	// an explicit pin takes over an auto pin
+	if err := store.Delete(types.GetAutoPinnedCodeIndexKey(codeID)); err != nil {
+		return err
+	}
 
 	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypePinCode,
@@ -1179,12 +1740,20 @@ func (k Keeper) unpinCode(ctx context.Context, codeID uint64) error {
 	if err := k.wasmVM.Unpin(codeInfo.CodeHash); err != nil {
 		return errorsmod.Wrap(types.ErrUnpinContractFailed, err.Error())
 	}
+	if k.IsPinnedCode(ctx, codeID) {
+		if err := k.decrementModuleStat(ctx, types.KeyStatsPinnedCodeCount); err != nil {
+			return err
+		}
+	}
 
 	store := k.storeService.OpenKVStore(ctx)
 	err := store.Delete(types.GetPinnedCodeIndexPrefix(codeID))
 	if err != nil {
 		return err
 	}
+	if err := store.Delete(types.GetAutoPinnedCodeIndexKey(codeID)); err != nil {
+		return err
+	}
 
 	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeUnpinCode,
@@ -1219,27 +1788,67 @@ func (k Keeper) checkDiscountEligibility(ctx sdk.Context, checksum []byte, isPin
 	return types.WithTxContracts(ctx, txContracts), false
 }
 
-// InitializePinnedCodes updates wasmvm to pin to cache all contracts marked as pinned
-func (k Keeper) InitializePinnedCodes(ctx context.Context) error {
-	store := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.PinnedCodeIndexPrefix)
-	iter := store.Iterator(nil, nil)
+// IteratePinnedCodes iterates over all code ids that are marked as pinned in the store in ascending order.
+// The store is the source of truth and the wasmvm cache is initialized from it on startup.
+func (k Keeper) IteratePinnedCodes(ctx context.Context, cb func(codeID uint64) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.PinnedCodeIndexPrefix)
+	iter := prefixStore.Iterator(nil, nil)
 	defer iter.Close()
 
 	for ; iter.Valid(); iter.Next() {
-		codeID := types.ParsePinnedCodeIndex(iter.Key())
+		if cb(types.ParsePinnedCodeIndex(iter.Key())) {
+			return
+		}
+	}
+}
+
+// InitializePinnedCodes updates wasmvm to pin to cache all contracts marked as pinned.
+// The wasmvm is recreated before when the cache params differ from the settings of the vm.
+// The codes pinned by the warm cache are pinned again.
+func (k Keeper) InitializePinnedCodes(ctx context.Context) error {
+	if err := k.applyVMCacheParams(ctx); err != nil {
+		return err
+	}
+	var err error
+	k.IteratePinnedCodes(ctx, func(codeID uint64) bool {
 		codeInfo := k.GetCodeInfo(ctx, codeID)
 		if codeInfo == nil {
-			return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+			err = types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+			return true
 		}
-		if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
-			return errorsmod.Wrap(types.ErrPinContractFailed, err.Error())
+		if pinErr := k.wasmVM.Pin(codeInfo.CodeHash); pinErr != nil {
+			err = errorsmod.Wrap(types.ErrPinContractFailed, pinErr.Error())
+			return true
 		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	// the codes of the warm cache are pinned again as the wasmvm may have been recreated
+	if k.warmList != nil {
+		k.warmList.repin()
 	}
 	return nil
 }
 
-// setContractInfoExtension updates the extension point data that is stored with the contract info
-func (k Keeper) setContractInfoExtension(ctx context.Context, contractAddr sdk.AccAddress, ext types.ContractInfoExtension) error {
+// GetContractInfoExtension copies the extension data that is stored with the contract info to the pointer passed as
+// argument. An error is returned when the contract has no extension or the extension is of a different type.
+func (k Keeper) GetContractInfoExtension(ctx context.Context, contractAddr sdk.AccAddress, out types.ContractInfoExtension) error {
+	info := k.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	if info.Extension == nil {
+		return errorsmod.Wrapf(types.ErrNotFound, "extension for contract %s", contractAddr.String())
+	}
+	return info.ReadExtension(out)
+}
+
+// SetContractInfoExtension updates the extension point data that is stored with the contract info.
+// A nil extension removes the existing data.
+func (k Keeper) SetContractInfoExtension(ctx context.Context, contractAddr sdk.AccAddress, ext types.ContractInfoExtension) error {
 	info := k.GetContractInfo(ctx, contractAddr)
 	if info == nil {
 		return types.ErrNoSuchContractFn(contractAddr.String()).
@@ -1278,6 +1887,115 @@ func (k Keeper) setAccessConfig(ctx context.Context, codeID uint64, caller sdk.A
 	return nil
 }
 
+// GetCodeProvenance returns the source url and builder image that were stored with the code.
+// Both are empty when the code was uploaded without provenance metadata.
+func (k Keeper) GetCodeProvenance(ctx context.Context, codeID uint64) (source, builder string, err error) {
+	info := k.GetCodeInfo(ctx, codeID)
+	if info == nil {
+		return "", "", types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	return info.Source, info.Builder, nil
+}
+
+// CodeExports returns the names of the entrypoints that are exported by the code, e.g. `migrate` or `sudo`.
+// The entrypoints are recorded when the code is stored and by the store migration for the codes stored before,
+// so that the code is not analyzed at query time.
+func (k Keeper) CodeExports(ctx context.Context, codeID uint64) ([]string, error) {
+	if !k.containsCodeInfo(ctx, codeID) {
+		return nil, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetCodeEntrypointsKey(codeID))
+	if err != nil || bz == nil {
+		return nil, err
+	}
+	return strings.Split(string(bz), ","), nil
+}
+
+// SetContractGasMultiplier overrides the multiplier that scales the SDK gas charged for
+// the wasm execution of the given contract. Setting the default multiplier removes the override.
+func (k Keeper) SetContractGasMultiplier(ctx context.Context, contractAddr sdk.AccAddress, multiplier types.GasMultiplier) error {
+	if err := multiplier.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "gas multiplier")
+	}
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetContractGasMultiplierKey(contractAddr)
+	var err error
+	if multiplier.IsDefault() {
+		err = store.Delete(key)
+	} else {
+		err = store.Set(key, k.cdc.MustMarshal(&multiplier))
+	}
+	if err != nil {
+		return err
+	}
+	if err := k.deleteCachedContractGasMultiplier(ctx, contractAddr); err != nil {
+		return err
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateGasMultiplier,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyGasMultiplier, fmt.Sprintf("%d/%d", multiplier.Numerator, multiplier.Denominator)),
+	))
+	return nil
+}
+
+// GetContractGasMultiplier returns the gas multiplier for the given contract.
+// The default multiplier is returned when no override is stored.
+func (k Keeper) GetContractGasMultiplier(ctx context.Context, contractAddr sdk.AccAddress) types.GasMultiplier {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(types.GetContractGasMultiplierKey(contractAddr))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return types.DefaultContractGasMultiplier()
+	}
+	var multiplier types.GasMultiplier
+	k.cdc.MustUnmarshal(bz, &multiplier)
+	return multiplier
+}
+
+// getCachedContractGasMultiplier returns the gas multiplier of the contract. The multiplier is cached in the transient
+// store for the block, so that it is read from the store only once per block and not for every gas conversion of a
+// contract call.
+func (k Keeper) getCachedContractGasMultiplier(ctx sdk.Context, contractAddr sdk.AccAddress) types.GasMultiplier {
+	unchargedCtx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	store := k.transientStoreService.OpenTransientStore(unchargedCtx)
+	key := types.GetContractGasMultiplierCacheKey(unchargedCtx.BlockHeight(), contractAddr)
+	bz, err := store.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	var multiplier types.GasMultiplier
+	if bz != nil {
+		k.cdc.MustUnmarshal(bz, &multiplier)
+		return multiplier
+	}
+	multiplier = k.GetContractGasMultiplier(unchargedCtx, contractAddr)
+	if err := store.Set(key, k.cdc.MustMarshal(&multiplier)); err != nil {
+		panic(err)
+	}
+	return multiplier
+}
+
+// deleteCachedContractGasMultiplier removes the gas multiplier of the contract from the cache of the block
+func (k Keeper) deleteCachedContractGasMultiplier(ctx context.Context, contractAddr sdk.AccAddress) error {
+	unchargedCtx := sdk.UnwrapSDKContext(ctx).WithGasMeter(storetypes.NewInfiniteGasMeter())
+	return k.transientStoreService.OpenTransientStore(unchargedCtx).Delete(types.GetContractGasMultiplierCacheKey(unchargedCtx.BlockHeight(), contractAddr))
+}
+
+// contractGasRegister returns the gas register with the gas multiplier override of the contract applied
+func (k Keeper) contractGasRegister(ctx sdk.Context, contractAddr sdk.AccAddress) types.GasRegister {
+	if len(contractAddr) == 0 {
+		return k.gasRegister
+	}
+	return types.NewContractGasRegister(k.gasRegister, k.getCachedContractGasMultiplier(ctx, contractAddr))
+}
+
 // handleContractResponse processes the contract response data by emitting events and sending sub-/messages.
 func (k *Keeper) handleContractResponse(
 	ctx sdk.Context,
@@ -1288,8 +2006,14 @@ func (k *Keeper) handleContractResponse(
 	data []byte,
 	evts wasmvmtypes.Array[wasmvmtypes.Event],
 ) ([]byte, error) {
+	// submessages share the trace id of the calling contract, also for entry points without an own event like IBC
+	ctx, _ = withTraceID(ctx, contractAddr)
 	attributeGasCost := k.gasRegister.EventCosts(attrs, evts)
-	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
+	ctx.GasMeter().ConsumeGas(attributeGasCost, types.GasDescEventAttributes)
+	params := k.GetCachedParams(ctx)
+	if err := checkMaxEvents(params, attrs, evts); err != nil {
+		return nil, err
+	}
 	// emit all events from this contract itself
 	if len(attrs) != 0 {
 		wasmEvents, err := newWasmModuleEvent(attrs, contractAddr)
@@ -1304,30 +2028,81 @@ func (k *Keeper) handleContractResponse(
 			return nil, err
 		}
 		ctx.EventManager().EmitEvents(customEvents)
+		if params.EmitRawContractEvents {
+			rawEvents, err := newRawContractEvents(evts, contractAddr)
+			if err != nil {
+				return nil, err
+			}
+			ctx.EventManager().EmitEvents(rawEvents)
+		}
 	}
 	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, msgs, data)
 }
 
-func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
+func (k Keeper) runtimeGasForContract(ctx sdk.Context, contractAddr sdk.AccAddress) uint64 {
 	meter := ctx.GasMeter()
 	if meter.IsOutOfGas() {
 		return 0
 	}
-	if meter.Limit() == math.MaxUint64 { // infinite gas meter and not out of gas
-		return math.MaxUint64
+	gasLeft := uint64(math.MaxUint64) // infinite gas meter and not out of gas
+	if meter.Limit() != math.MaxUint64 {
+		gasLeft = k.contractGasRegister(ctx, contractAddr).ToWasmVMGas(meter.Limit() - meter.GasConsumedToLimit())
+	}
+	if maxGas, ok := k.maxRuntimeGasPerCall(ctx, contractAddr); ok {
+		return min(gasLeft, maxGas)
 	}
-	return k.gasRegister.ToWasmVMGas(meter.Limit() - meter.GasConsumedToLimit())
+	return gasLeft
 }
 
-func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
-	consumed := k.gasRegister.FromWasmVMGas(gas)
-	ctx.GasMeter().ConsumeGas(consumed, "wasm contract")
+func (k Keeper) consumeRuntimeGas(ctx sdk.Context, contractAddr sdk.AccAddress, gas uint64) {
+	if len(contractAddr) != 0 && len(k.outOfGasListeners) != 0 {
+		defer func() {
+			if r := recover(); r != nil {
+				if _, ok := r.(storetypes.ErrorOutOfGas); ok {
+					k.notifyOutOfGas(ctx, contractAddr)
+				}
+				panic(r)
+			}
+		}()
+	}
+	consumed := k.contractGasRegister(ctx, contractAddr).FromWasmVMGas(gas)
+	ctx.GasMeter().ConsumeGas(consumed, types.GasDescWasmExecution)
 	// throw OutOfGas error if we ran out (got exactly to zero due to better limit enforcing)
 	if ctx.GasMeter().IsOutOfGas() {
 		panic(storetypes.ErrorOutOfGas{Descriptor: "Wasm engine function execution"})
 	}
 }
 
+// notifyOutOfGas calls the out of gas listeners with a branched context that is never committed.
+// A panicking listener is logged and does not affect the out of gas panic that is raised again by the caller.
+func (k Keeper) notifyOutOfGas(ctx sdk.Context, contractAddr sdk.AccAddress) {
+	for _, l := range k.outOfGasListeners {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					k.Logger(ctx).Error("out of gas listener panicked", "contract", contractAddr.String(), "panic", r)
+				}
+			}()
+			listenerCtx, _ := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()).WithEventManager(sdk.NewEventManager()).CacheContext()
+			l.OnContractOutOfGas(listenerCtx, contractAddr)
+		}()
+	}
+}
+
+// maxRuntimeGasPerCall returns the VM gas ceiling of a contract call that is set by the max wasm instructions
+// per call param. Code uploads are not limited. The lookup is not charged so that the gas cost of existing
+// calls is not affected.
+func (k Keeper) maxRuntimeGasPerCall(ctx sdk.Context, contractAddr sdk.AccAddress) (uint64, bool) {
+	if len(contractAddr) == 0 {
+		return 0, false
+	}
+	maxInstructions := k.GetCachedParams(ctx).MaxWasmInstructionsPerCall
+	if maxInstructions == 0 {
+		return 0, false
+	}
+	return types.WasmInstructionsToVMGas(maxInstructions), true
+}
+
 func (k Keeper) mustAutoIncrementID(ctx context.Context, sequenceKey []byte) uint64 {
 	store := k.storeService.OpenKVStore(ctx)
 	bz, err := store.Get(sequenceKey)
@@ -1394,6 +2169,9 @@ func (k Keeper) importContract(ctx context.Context, contractAddr sdk.AccAddress,
 		return err
 	}
 	k.mustStoreContractInfo(ctx, contractAddr, c)
+	if err := k.incrementModuleStat(ctx, types.KeyStatsContractCount); err != nil {
+		return err
+	}
 	err = k.addToContractCodeSecondaryIndex(ctx, contractAddr, historyEntries[len(historyEntries)-1])
 	if err != nil {
 		return err
@@ -1402,11 +2180,54 @@ func (k Keeper) importContract(ctx context.Context, contractAddr sdk.AccAddress,
 	if err != nil {
 		return err
 	}
-	return k.importContractState(ctx, contractAddr, state)
+	err = k.addToContractLabelIndex(ctx, contractAddr, c.CodeID, c.Label)
+	if err != nil {
+		return err
+	}
+	err = k.recordImportedCodeInstantiation(ctx, contractAddr, creatorAddress, historyEntries[0])
+	if err != nil {
+		return err
+	}
+	if err := k.importContractState(ctx, contractAddr, state); err != nil {
+		return err
+	}
+	if c.StorageQuota != 0 {
+		return k.refreshContractStorageUsage(ctx, contractAddr, c.StorageQuota)
+	}
+	return nil
+}
+
+// contractCallError returns the storage quota error when the contract call was aborted by a write that exceeds the
+// storage quota of the contract and the contract VM error otherwise.
+func (k Keeper) contractCallError(ctx sdk.Context, contractAddr sdk.AccAddress, storageQuota, gasLimit uint64, err error) error {
+	if quotaErr := k.checkStorageQuota(ctx, contractAddr, storageQuota); quotaErr != nil {
+		return errorsmod.Wrap(quotaErr, err.Error())
+	}
+	return k.contractVMError(ctx, contractAddr, gasLimit, err)
+}
+
+// contractVMError returns ErrExceedMaxWasmInstructions when the VM ran out of gas with the instruction ceiling of
+// the call as gas limit and the wrapped wasmvm error otherwise. Running out of the tx gas limit panics before in
+// consumeRuntimeGas. A call that uses up to the ceiling succeeds.
+func (k Keeper) contractVMError(ctx sdk.Context, contractAddr sdk.AccAddress, gasLimit uint64, err error) error {
+	if maxGas, ok := k.maxRuntimeGasPerCall(ctx, contractAddr); ok && gasLimit == maxGas && errors.As(err, &wasmvmtypes.OutOfGasError{}) {
+		return errorsmod.Wrapf(types.ErrExceedMaxWasmInstructions, "contract %s: %s", contractAddr, err)
+	}
+	return vmError(err)
+}
+
+// vmError wraps an error of the wasmvm. Out of gas errors are returned as ErrOutOfGas.
+func vmError(err error) error {
+	if errors.As(err, &wasmvmtypes.OutOfGasError{}) {
+		return errorsmod.Wrap(types.ErrOutOfGas, err.Error())
+	}
+	return errorsmod.Wrap(types.ErrVMError, err.Error())
 }
 
 func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
-	return NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	h := NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	h.rejectSelfQueries = k.GetCachedParams(ctx).RejectSelfQueries
+	return h
 }
 
 // MultipliedGasMeter wraps the GasMeter from context and multiplies all reads by out defined multiplier
@@ -1425,8 +2246,8 @@ func (m MultipliedGasMeter) GasConsumed() storetypes.Gas {
 	return m.GasRegister.ToWasmVMGas(m.originalMeter.GasConsumed())
 }
 
-func (k Keeper) gasMeter(ctx sdk.Context) MultipliedGasMeter {
-	return NewMultipliedGasMeter(ctx.GasMeter(), k.gasRegister)
+func (k Keeper) gasMeter(ctx sdk.Context, contractAddr sdk.AccAddress) MultipliedGasMeter {
+	return NewMultipliedGasMeter(ctx.GasMeter(), k.contractGasRegister(ctx, contractAddr))
 }
 
 // Logger returns a module-specific logger.
@@ -22,6 +22,7 @@ import (
 func NewKeeper(
 	cdc codec.Codec,
 	storeService corestoretypes.KVStoreService,
+	transientStoreService corestoretypes.TransientStoreService,
 	accountKeeper types.AccountKeeper,
 	bankKeeper types.BankKeeper,
 	stakingKeeper types.StakingKeeper,
@@ -42,24 +43,30 @@ func NewKeeper(
 ) Keeper {
 	sb := collections.NewSchemaBuilder(storeService)
 	keeper := &Keeper{
-		storeService:         storeService,
-		cdc:                  cdc,
-		wasmVM:               nil,
-		accountKeeper:        accountKeeper,
-		bank:                 NewBankCoinTransferrer(bankKeeper),
-		accountPruner:        NewVestingCoinBurner(bankKeeper),
-		queryGasLimit:        nodeConfig.SmartQueryGasLimit,
-		gasRegister:          types.NewDefaultWasmGasRegister(),
-		maxQueryStackSize:    types.DefaultMaxQueryStackSize,
-		maxCallDepth:         types.DefaultMaxCallDepth,
-		acceptedAccountTypes: defaultAcceptedAccountTypes,
-		params:               collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+		storeService:          storeService,
+		transientStoreService: transientStoreService,
+		cdc:                   cdc,
+		wasmVM:                nil,
+		accountKeeper:         accountKeeper,
+		bank:                  NewBankCoinTransferrer(bankKeeper),
+		balances:              bankKeeper,
+		accountPruner:         NewVestingCoinBurner(bankKeeper),
+		contractAddrGenerator: DefaultContractAddrGenerator{},
+		queryGasLimit:         nodeConfig.SmartQueryGasLimit,
+		gasRegister:           types.NewDefaultWasmGasRegister(),
+		maxQueryStackSize:     types.DefaultMaxQueryStackSize,
+		maxCallDepth:          types.DefaultMaxCallDepth,
+		blockSudoGasLimit:     types.DefaultBlockSudoGasLimit,
+		acceptedAccountTypes:  defaultAcceptedAccountTypes,
+		params:                collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
 		propagateGovAuthorization: map[types.AuthorizationPolicyAction]struct{}{
 			types.AuthZActionInstantiate: {},
 		},
-		authority:   authority,
-		wasmLimits:  vmConfig.WasmLimits,
-		ibcRouterV2: ibcRouterV2,
+		authority:             authority,
+		wasmLimits:            vmConfig.WasmLimits,
+		availableCapabilities: availableCapabilities,
+		metricsSampleInterval: nodeConfig.MetricsSampleInterval,
+		ibcRouterV2:           ibcRouterV2,
 	}
 	keeper.messenger = NewDefaultMessageHandler(keeper, router, ics4Wrapper, channelKeeperV2, bankKeeper, cdc, portSource)
 	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distrKeeper, channelKeeper, keeper)
@@ -68,29 +75,38 @@ func NewKeeper(
 		o.apply(keeper)
 	}
 	// always wrap the messenger, even if it was replaced by an option
-	keeper.messenger = callDepthMessageHandler{keeper.messenger, keeper.maxCallDepth}
+	keeper.messenger = callDepthMessageHandler{keeper.messenger, keeper.maxCallDepth, keeper}
 	// only set the wasmvm if no one set this in the options
 	// NewVM does a lot, so better not to create it and silently drop it.
 	if keeper.wasmVM == nil {
-		var err error
-		keeper.wasmVM, err = wasmvm.NewVMWithConfig(wasmvmtypes.VMConfig{
-			Cache: wasmvmtypes.CacheOptions{
-				BaseDir:                  filepath.Join(homeDir, "wasm"),
-				AvailableCapabilities:    availableCapabilities,
-				MemoryCacheSizeBytes:     wasmvmtypes.NewSizeMebi(nodeConfig.MemoryCacheSize),
-				InstanceMemoryLimitBytes: wasmvmtypes.NewSizeMebi(contractMemoryLimit),
-			},
-			WasmLimits: vmConfig.WasmLimits,
-		}, nodeConfig.ContractDebugMode)
+		newVM := func(c vmCacheConfig) (*wasmvm.VM, error) {
+			return wasmvm.NewVMWithConfig(wasmvmtypes.VMConfig{
+				Cache: wasmvmtypes.CacheOptions{
+					BaseDir:                  filepath.Join(homeDir, "wasm"),
+					AvailableCapabilities:    keeper.availableCapabilities,
+					MemoryCacheSizeBytes:     wasmvmtypes.NewSizeMebi(c.MemoryCacheSize),
+					InstanceMemoryLimitBytes: wasmvmtypes.NewSizeMebi(c.ContractMemoryLimit),
+				},
+				WasmLimits: vmConfig.WasmLimits,
+			}, nodeConfig.ContractDebugMode)
+		}
+		defaults := vmCacheConfig{MemoryCacheSize: nodeConfig.MemoryCacheSize, ContractMemoryLimit: contractMemoryLimit}
+		vm, err := newVM(defaults)
 		if err != nil {
 			panic(err)
 		}
+		keeper.wasmVM = vm
+		keeper.vmReloader = &vmReloader{vm: vm, config: defaults, defaultMemoryCacheSize: nodeConfig.MemoryCacheSize, newVM: newVM}
+	}
+	if nodeConfig.WarmCacheSize != 0 {
+		keeper.warmList = newWarmList(warmListPath(homeDir), nodeConfig.WarmCacheSize, keeper.wasmVM)
+		keeper.wasmVM = warmListWasmEngine{WasmEngine: keeper.wasmVM, warmList: keeper.warmList}
 	}
 
 	for _, o := range postOpts {
 		o.apply(keeper)
 	}
 	// not updatable, yet
-	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
+	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper, keeper))
 	return *keeper
 }
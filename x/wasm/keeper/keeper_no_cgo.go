@@ -15,6 +15,7 @@ import (
 func NewKeeper(
 	cdc codec.Codec,
 	storeService corestoretypes.KVStoreService,
+	transientStoreService corestoretypes.TransientStoreService,
 	accountKeeper types.AccountKeeper,
 	bankKeeper types.BankKeeper,
 	stakingKeeper types.StakingKeeper,
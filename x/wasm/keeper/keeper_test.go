@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"encoding/json"
 	"errors"
@@ -22,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	corestoretypes "cosmossdk.io/core/store"
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	sdkmath "cosmossdk.io/math"
@@ -39,7 +41,9 @@ import (
 	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -61,6 +65,33 @@ func TestNewKeeper(t *testing.T) {
 	require.NotNil(t, keepers.ContractKeeper)
 }
 
+func TestGetCachedParams(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	ctx, _ := parentCtx.CacheContext()
+	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(1_000_000))
+
+	// when
+	got := k.GetCachedParams(ctx)
+	// then the params are returned without gas costs
+	assert.Equal(t, k.GetParams(parentCtx), got)
+	assert.Zero(t, ctx.GasMeter().GasConsumed())
+
+	// and the cache is used within the block
+	params := types.DefaultParams()
+	params.MaxCallDepth = 10
+	require.NoError(t, k.params.Set(ctx, params))
+	assert.Equal(t, got, k.GetCachedParams(ctx))
+
+	// and dropped when the params are set
+	require.NoError(t, k.SetParams(ctx, params))
+	assert.Equal(t, params, k.GetCachedParams(ctx))
+
+	// and not used in another block
+	require.NoError(t, k.params.Set(ctx, types.DefaultParams()))
+	assert.Equal(t, types.DefaultParams(), k.GetCachedParams(ctx.WithBlockHeight(ctx.BlockHeight()+1)))
+}
+
 func TestCreateSuccess(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.ContractKeeper
@@ -371,6 +402,81 @@ func TestCreateWithGzippedPayload(t *testing.T) {
 	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm.gzip")
 	require.NoError(t, err, "reading gzipped WASM code")
 
+	contractID, _, err := keeper.Create(ctx, creator, wasmCode, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), contractID)
+	// and verify content
+	storedCode, err := keepers.WasmKeeper.GetByteCode(ctx, contractID)
+	require.NoError(t, err)
+	require.Equal(t, hackatomWasm, storedCode)
+	// and the uncompressed size is recorded
+	assert.Equal(t, uint64(len(hackatomWasm)), keepers.WasmKeeper.GetCodeSize(ctx, contractID))
+}
+
+func TestCodeExports(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	withEntrypoints := func(entrypoints ...string) func(wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+		return func(wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+			return &wasmvmtypes.AnalysisReport{Entrypoints: entrypoints}, nil
+		}
+	}
+	m.AnalyzeCodeFn = withEntrypoints("instantiate", "execute", "migrate")
+	migratable := StoreRandomContract(t, ctx, keepers, &m)
+	m.AnalyzeCodeFn = withEntrypoints("instantiate", "execute")
+	nonMigratable := StoreRandomContract(t, ctx, keepers, &m)
+	// the code is not analyzed at query time
+	var analyzed []wasmvm.Checksum
+	m.AnalyzeCodeFn = func(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error) {
+		analyzed = append(analyzed, checksum)
+		return &wasmvmtypes.AnalysisReport{}, nil
+	}
+
+	specs := map[string]struct {
+		codeID uint64
+		exp    []string
+		expErr bool
+	}{
+		"exports migrate": {
+			codeID: migratable.CodeID,
+			exp:    []string{"instantiate", "execute", "migrate"},
+		},
+		"does not export migrate": {
+			codeID: nonMigratable.CodeID,
+			exp:    []string{"instantiate", "execute"},
+		},
+		"unknown code": {
+			codeID: 100,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			analyzed = nil
+			got, gotErr := k.CodeExports(ctx, spec.codeID)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+			assert.Empty(t, analyzed)
+		})
+	}
+}
+
+func TestCreateWithZstdPayload(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.ContractKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, deposit...)
+
+	wasmCode, err := ioutils.ZstdIt(hackatomWasm)
+	require.NoError(t, err)
+
 	contractID, _, err := keeper.Create(ctx, creator, wasmCode, nil)
 	require.NoError(t, err)
 	require.Equal(t, uint64(1), contractID)
@@ -380,6 +486,20 @@ func TestCreateWithGzippedPayload(t *testing.T) {
 	require.Equal(t, hackatomWasm, storedCode)
 }
 
+func TestCreateWithUnknownCompression(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.ContractKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, deposit...)
+
+	codeID, checksum, err := keeper.Create(ctx, creator, []byte("BZh91AY&SY"), nil)
+	require.ErrorIs(t, err, types.ErrCreateFailed)
+	assert.Contains(t, err.Error(), "unknown compression")
+	assert.Empty(t, codeID)
+	assert.Empty(t, checksum)
+}
+
 func TestCreateWithBrokenGzippedPayload(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.ContractKeeper
@@ -423,7 +543,7 @@ func TestInstantiate(t *testing.T) {
 
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x1c473), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x1f500), gasAfter-gasBefore)
 	}
 
 	// ensure it is stored properly
@@ -444,7 +564,8 @@ func TestInstantiate(t *testing.T) {
 	// and events emitted
 	expEvt := sdk.Events{
 		sdk.NewEvent("instantiate",
-			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1")),
+			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1"),
+			sdk.NewAttribute("trace_id", types.NewTraceID(ctx.BlockHeight(), 0, 0, gotContractAddr))),
 		sdk.NewEvent("wasm",
 			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("Let the", "hacking begin")),
 	}
@@ -562,6 +683,262 @@ func TestInstantiateWithPermissions(t *testing.T) {
 	}
 }
 
+func TestInstantiateWithMaxInstancesPerAddress(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	var (
+		myAddr    = keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+		otherAddr = keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+		anyAddr   = RandomAccountAddress(t)
+	)
+	initMsgBz := mustMarshal(t, HackatomExampleInitMsg{Verifier: anyAddr, Beneficiary: anyAddr})
+
+	permission := types.AccessTypeAnyOfAddresses.With(myAddr, otherAddr)
+	permission.MaxInstancesPerAddress = 2
+	codeID, _, err := keepers.ContractKeeper.Create(ctx, myAddr, hackatomWasm, &permission)
+	require.NoError(t, err)
+
+	// when instantiated up to the limit
+	for i := 0; i < 2; i++ {
+		_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, myAddr, nil, initMsgBz, fmt.Sprintf("contract %d", i), nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, uint64(2), keepers.WasmKeeper.GetInstantiateCount(ctx, codeID, myAddr))
+
+	// then the next instantiation fails
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, myAddr, nil, initMsgBz, "one too many", nil)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	assert.Equal(t, uint64(2), keepers.WasmKeeper.GetInstantiateCount(ctx, codeID, myAddr))
+
+	// and other addresses have their own limit
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, otherAddr, nil, initMsgBz, "other contract", nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), keepers.WasmKeeper.GetInstantiateCount(ctx, codeID, otherAddr))
+
+	// and instantiations from codes without limit are not counted
+	otherCodeID, _, err := keepers.ContractKeeper.Create(ctx, myAddr, hackatomWasm, nil)
+	require.NoError(t, err)
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, otherCodeID, myAddr, nil, initMsgBz, "unlimited", nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), keepers.WasmKeeper.GetInstantiateCount(ctx, otherCodeID, myAddr))
+}
+
+func TestCanInstantiate(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	var (
+		myAddr    = keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+		otherAddr = RandomAccountAddress(t)
+		anyAddr   = RandomAccountAddress(t)
+	)
+	initMsgBz := mustMarshal(t, HackatomExampleInitMsg{Verifier: anyAddr, Beneficiary: anyAddr})
+
+	permission := types.AccessTypeAnyOfAddresses.With(myAddr)
+	permission.MaxInstancesPerAddress = 1
+	codeID, _, err := keepers.ContractKeeper.Create(ctx, myAddr, hackatomWasm, &permission)
+	require.NoError(t, err)
+
+	assert.True(t, keepers.WasmKeeper.CanInstantiate(ctx, codeID, myAddr))
+	assert.False(t, keepers.WasmKeeper.CanInstantiate(ctx, codeID, otherAddr))
+	assert.False(t, keepers.WasmKeeper.CanInstantiate(ctx, codeID+1, myAddr))
+
+	// when the instances limit is reached
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, myAddr, nil, initMsgBz, "my contract", nil)
+	require.NoError(t, err)
+	assert.False(t, keepers.WasmKeeper.CanInstantiate(ctx, codeID, myAddr))
+
+	// when the config is updated
+	require.NoError(t, keepers.ContractKeeper.SetAccessConfig(ctx, codeID, myAddr, types.AllowEverybody))
+	assert.True(t, keepers.WasmKeeper.CanInstantiate(ctx, codeID, myAddr))
+	assert.True(t, keepers.WasmKeeper.CanInstantiate(ctx, codeID, otherAddr))
+}
+
+func TestInstantiateListeners(t *testing.T) {
+	var (
+		listener      = &capturingInstantiateListener{}
+		otherListener = &capturingInstantiateListener{}
+	)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithInstantiateListeners(listener, otherListener))
+	var (
+		myAddr  = keepers.Faucet.NewFundedRandomAccount(parentCtx, sdk.NewInt64Coin("denom", 100000))
+		myAdmin = RandomAccountAddress(t)
+		anyAddr = RandomAccountAddress(t)
+	)
+	initMsgBz := mustMarshal(t, HackatomExampleInitMsg{Verifier: anyAddr, Beneficiary: anyAddr})
+	codeID, _, err := keepers.ContractKeeper.Create(parentCtx, myAddr, hackatomWasm, nil)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		admin       sdk.AccAddress
+		instantiate func(ctx sdk.Context, admin sdk.AccAddress) (sdk.AccAddress, error)
+		listenerErr error
+	}{
+		"instantiate": {
+			admin: myAdmin,
+			instantiate: func(ctx sdk.Context, admin sdk.AccAddress) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, myAddr, admin, initMsgBz, "test", nil)
+				return addr, err
+			},
+		},
+		"instantiate without admin": {
+			instantiate: func(ctx sdk.Context, admin sdk.AccAddress) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, myAddr, admin, initMsgBz, "test", nil)
+				return addr, err
+			},
+		},
+		"instantiate2": {
+			admin: myAdmin,
+			instantiate: func(ctx sdk.Context, admin sdk.AccAddress) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate2(ctx, codeID, myAddr, admin, initMsgBz, "test", nil, []byte("my salt"), false)
+				return addr, err
+			},
+		},
+		"listener error aborts": {
+			instantiate: func(ctx sdk.Context, admin sdk.AccAddress) (sdk.AccAddress, error) {
+				addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, myAddr, admin, initMsgBz, "test", nil)
+				return addr, err
+			},
+			listenerErr: errors.New("testing"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			*listener = capturingInstantiateListener{err: spec.listenerErr}
+			*otherListener = capturingInstantiateListener{}
+
+			// when
+			gotAddr, gotErr := spec.instantiate(ctx, spec.admin)
+
+			// then
+			if spec.listenerErr != nil {
+				require.ErrorIs(t, gotErr, spec.listenerErr)
+				assert.Empty(t, otherListener.captured)
+				return
+			}
+			require.NoError(t, gotErr)
+			exp := []capturedInstantiation{{CodeID: codeID, Contract: gotAddr, Creator: myAddr, Admin: spec.admin}}
+			assert.Equal(t, exp, listener.captured)
+			assert.Equal(t, exp, otherListener.captured)
+		})
+	}
+}
+
+type capturedInstantiation struct {
+	CodeID                   uint64
+	Contract, Creator, Admin sdk.AccAddress
+}
+
+type capturingInstantiateListener struct {
+	captured []capturedInstantiation
+	err      error
+}
+
+func (l *capturingInstantiateListener) OnContractInstantiated(_ context.Context, codeID uint64, contractAddr, creator, admin sdk.AccAddress) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.captured = append(l.captured, capturedInstantiation{CodeID: codeID, Contract: contractAddr, Creator: creator, Admin: admin})
+	return nil
+}
+
+func TestOutOfGasListeners(t *testing.T) {
+	var (
+		listener      = &capturingOutOfGasListener{}
+		otherListener = &capturingOutOfGasListener{}
+		wasmGasUsed   uint64
+	)
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, wasmGasUsed, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock), WithOutOfGasListeners(listener, otherListener))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+	const gasLimit storetypes.Gas = 200_000
+
+	specs := map[string]struct {
+		wasmGasUsed      uint64
+		withGasLimit     bool
+		listenerPanics   bool
+		expOutOfGasPanic bool
+		expErr           error
+	}{
+		"within gas limit": {
+			wasmGasUsed: 1,
+		},
+		"out of gas": {
+			wasmGasUsed:      k.gasRegister.ToWasmVMGas(gasLimit) + 1,
+			expOutOfGasPanic: true,
+		},
+		"out of gas with gas limited call": {
+			wasmGasUsed:  k.gasRegister.ToWasmVMGas(gasLimit) + 1,
+			withGasLimit: true,
+			expErr:       sdkerrors.ErrOutOfGas,
+		},
+		"panicking listener": {
+			wasmGasUsed:      k.gasRegister.ToWasmVMGas(gasLimit) + 1,
+			listenerPanics:   true,
+			expOutOfGasPanic: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			*listener = capturingOutOfGasListener{panics: spec.listenerPanics, store: keepers.WasmKeeper.storeService}
+			*otherListener = capturingOutOfGasListener{store: keepers.WasmKeeper.storeService}
+			wasmGasUsed = spec.wasmGasUsed
+
+			// when
+			var gotErr error
+			call := func() {
+				if spec.withGasLimit {
+					_, _, gotErr = k.ExecuteWithGasLimit(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil, gasLimit)
+					return
+				}
+				_, gotErr = k.execute(ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit)), example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+			}
+
+			// then
+			if spec.expOutOfGasPanic {
+				require.PanicsWithValue(t, storetypes.ErrorOutOfGas{Descriptor: types.GasDescWasmExecution}, call)
+			} else {
+				call()
+			}
+			require.ErrorIs(t, gotErr, spec.expErr)
+			if spec.wasmGasUsed <= k.gasRegister.ToWasmVMGas(gasLimit) {
+				assert.Empty(t, listener.captured)
+				assert.Empty(t, otherListener.captured)
+				return
+			}
+			if !spec.listenerPanics {
+				assert.Equal(t, []sdk.AccAddress{example.Contract}, listener.captured)
+			}
+			assert.Equal(t, []sdk.AccAddress{example.Contract}, otherListener.captured)
+			// and state changes of the listeners are discarded
+			has, err := keepers.WasmKeeper.storeService.OpenKVStore(ctx).Has([]byte("listener"))
+			require.NoError(t, err)
+			assert.False(t, has)
+		})
+	}
+}
+
+type capturingOutOfGasListener struct {
+	captured []sdk.AccAddress
+	panics   bool
+	store    corestoretypes.KVStoreService
+}
+
+func (l *capturingOutOfGasListener) OnContractOutOfGas(ctx context.Context, contractAddr sdk.AccAddress) {
+	if err := l.store.OpenKVStore(ctx).Set([]byte("listener"), []byte("was here")); err != nil {
+		panic(err)
+	}
+	if l.panics {
+		panic("testing")
+	}
+	l.captured = append(l.captured, contractAddr)
+}
+
 func TestInstantiateWithAccounts(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	example := StoreHackatomExampleContract(t, parentCtx, keepers)
@@ -850,7 +1227,7 @@ func TestInstantiateWithContractFactoryChildQueriesParent(t *testing.T) {
 	types.RegisterMsgServer(router, NewMsgServerImpl(keeper))
 	keeper.messenger = NewDefaultMessageHandler(nil, router, nil, nil, nil, keepers.EncodingConfig.Codec, nil)
 	// overwrite wasmvm in response handler
-	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper))
+	keeper.wasmVMResponseHandler = NewDefaultWasmVMContractResponseHandler(NewMessageDispatcher(keeper.messenger, keeper, keeper))
 
 	example := StoreRandomContract(t, ctx, keepers, wasmEngineMock)
 	// factory contract
@@ -947,6 +1324,10 @@ func TestExecute(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, errors.Is(err, types.ErrExecuteFailed))
 	require.Equal(t, "Unauthorized: execute wasm contract failed", err.Error())
+	var contractErr types.ErrContractExecutionFailed
+	require.ErrorAs(t, err, &contractErr)
+	assert.Equal(t, "Unauthorized", contractErr.Msg)
+	assert.Equal(t, types.ErrExecuteFailed.ABCICode(), contractErr.Code)
 
 	// verifier can execute, and get proper gas amount
 	start := time.Now()
@@ -977,7 +1358,8 @@ func TestExecute(t *testing.T) {
 	// and events emitted
 	require.Len(t, em.Events(), 9)
 	expEvt := sdk.NewEvent("execute",
-		sdk.NewAttribute("_contract_address", addr.String()))
+		sdk.NewAttribute("_contract_address", addr.String()),
+		sdk.NewAttribute("trace_id", types.NewTraceID(ctx.BlockHeight(), 0, 0, addr)))
 	assert.Equal(t, expEvt, em.Events()[3], prettyEvents(t, em.Events()))
 
 	t.Logf("Duration: %v (%d gas)\n", diff, gasAfter-gasBefore)
@@ -1162,6 +1544,158 @@ func TestExecuteWithCpuLoop(t *testing.T) {
 	require.True(t, false, "We must panic before this line")
 }
 
+func TestExecuteWithMaxWasmInstructions(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		maxInstructions uint64
+		msg             string
+		expErr          *errorsmod.Error
+		expPanic        bool
+	}{
+		"within limit": {
+			maxInstructions: 1_000_000_000,
+			msg:             `{"release":{}}`,
+		},
+		"exceeds limit": {
+			maxInstructions: 1_000_000,
+			msg:             `{"cpu_loop":{}}`,
+			expErr:          types.ErrExceedMaxWasmInstructions,
+		},
+		"disabled": {
+			msg:      `{"cpu_loop":{}}`,
+			expPanic: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxWasmInstructionsPerCall = spec.maxInstructions
+			require.NoError(t, k.SetParams(ctx, params))
+			ctx = ctx.WithGasMeter(storetypes.NewGasMeter(2_000_000))
+
+			if spec.expPanic {
+				defer func() {
+					r := recover()
+					require.NotNil(t, r)
+					_, ok := r.(storetypes.ErrorOutOfGas)
+					require.True(t, ok, "%v", r)
+				}()
+			}
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(spec.msg), nil)
+
+			// then
+			require.False(t, spec.expPanic, "must panic before this line")
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				_, code, _ := errorsmod.ABCIInfo(err, false)
+				assert.Equal(t, spec.expErr.ABCICode(), code)
+				assert.NotEqual(t, sdkerrors.ErrOutOfGas.ABCICode(), code)
+				// the tx gas limit is not reached
+				assert.False(t, ctx.GasMeter().IsOutOfGas())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMaxWasmInstructionsCeilingIsInclusive(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	ctx, _ := parentCtx.CacheContext()
+	params := k.GetParams(ctx)
+	params.MaxWasmInstructionsPerCall = 1_000_000
+	require.NoError(t, k.SetParams(ctx, params))
+	maxGas := types.WasmInstructionsToVMGas(params.MaxWasmInstructionsPerCall)
+
+	specs := map[string]struct {
+		vmErr  error
+		expErr *errorsmod.Error
+	}{
+		"uses the ceiling": {},
+		"out of gas at the ceiling": {
+			vmErr:  wasmvmtypes.OutOfGasError{},
+			expErr: types.ErrExceedMaxWasmInstructions,
+		},
+		"other error at the ceiling": {
+			vmErr:  errors.New("testing"),
+			expErr: types.ErrVMError,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			k.wasmVM = &wasmtesting.MockWasmEngine{
+				ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+					require.Equal(t, maxGas, gasLimit)
+					if spec.vmErr != nil {
+						return nil, gasLimit, spec.vmErr
+					}
+					return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, gasLimit, nil
+				},
+			}
+
+			// when
+			_, gotErr := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestExecuteWithSelfQuery(t *testing.T) {
+	// the contract writes a key and then queries itself for it
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			store.Set([]byte("foo"), []byte("bar"))
+			res, err := querier.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{
+				ContractAddr: env.Contract.Address,
+				Msg:          []byte(`{}`),
+			}}}, gasLimit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{Data: res}}, 0, nil
+		},
+		QueryFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+			// writes in queries are discarded
+			store.Set([]byte("query"), []byte("value"))
+			return &wasmvmtypes.QueryResult{Ok: store.Get([]byte("foo"))}, 0, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	// when
+	gotData, err := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+	// then the query result contains the pending write of the execution
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bar"), gotData)
+	assert.Equal(t, []byte("bar"), k.QueryRaw(ctx, example.Contract, []byte("foo")))
+	assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("query")))
+
+	// and when self queries are rejected
+	params := k.GetParams(ctx)
+	params.RejectSelfQueries = true
+	require.NoError(t, k.SetParams(ctx, params))
+	_, err = k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.ErrorContains(t, err, "code: 41")
+}
+
 func TestExecuteWithStorageLoop(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 
@@ -1453,6 +1987,45 @@ func TestMigrate(t *testing.T) {
 	}
 }
 
+func TestMigrateWithFunds(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	migrateMsg := []byte(fmt.Sprintf(`{"verifier":%q}`, RandomAccountAddress(t).String()))
+
+	specs := map[string]struct {
+		funds  sdk.Coins
+		expErr error
+	}{
+		"with funds": {
+			funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 200)),
+		},
+		"without funds": {},
+		"insufficient funds": {
+			funds:  sdk.NewCoins(sdk.NewInt64Coin("denom", 1_000_000)),
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			senderBefore := keepers.BankKeeper.GetAllBalances(ctx, example.CreatorAddr)
+
+			// when
+			_, gotErr := k.migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, migrateMsg, spec.funds, DefaultAuthorizationPolicy{})
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, example.Deposit.Add(spec.funds...), keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+			assert.Equal(t, senderBefore.Sub(spec.funds...), keepers.BankKeeper.GetAllBalances(ctx, example.CreatorAddr))
+		})
+	}
+}
+
 func TestMigrateReplacesTheSecondIndex(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	example := InstantiateHackatomExampleContract(t, ctx, keepers)
@@ -1468,6 +2041,7 @@ func TestMigrateReplacesTheSecondIndex(t *testing.T) {
 	exists, err := store.Has(types.GetContractByCreatedSecondaryIndexKey(example.Contract, createHistoryEntry))
 	require.NoError(t, err)
 	require.True(t, exists)
+	require.Equal(t, uint64(1), keepers.WasmKeeper.GetContractCountByCode(ctx, example.CodeID))
 
 	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1) // increment for different block
 	// when do migrate
@@ -1488,6 +2062,13 @@ func TestMigrateReplacesTheSecondIndex(t *testing.T) {
 	exists, err = store.Has(types.GetContractByCreatedSecondaryIndexKey(example.Contract, createHistoryEntry))
 	require.NoError(t, err)
 	require.False(t, exists)
+	// and the contract counters were updated
+	assert.Equal(t, uint64(0), keepers.WasmKeeper.GetContractCountByCode(ctx, example.CodeID))
+	assert.Equal(t, uint64(1), keepers.WasmKeeper.GetContractCountByCode(ctx, newCodeExample.CodeID))
+	stats, err := keepers.WasmKeeper.GetModuleStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), stats.CodeCount)
+	assert.Equal(t, uint64(1), stats.ContractCount)
 }
 
 func TestMigrateWithDispatchedMessage(t *testing.T) {
@@ -1530,6 +2111,7 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 			"Attr": []dict{
 				{"code_id": "2"},
 				{"_contract_address": contractAddr},
+				{"trace_id": types.NewTraceID(ctx.BlockHeight(), 0, 0, contractAddr)},
 			},
 		},
 		{
@@ -1721,7 +2303,8 @@ func TestSudo(t *testing.T) {
 	// and events emitted
 	require.Len(t, em.Events(), 4, prettyEvents(t, em.Events()))
 	expEvt := sdk.NewEvent("sudo",
-		sdk.NewAttribute("_contract_address", addr.String()))
+		sdk.NewAttribute("_contract_address", addr.String()),
+		sdk.NewAttribute("trace_id", types.NewTraceID(ctx.BlockHeight(), 0, 0, addr)))
 	assert.Equal(t, expEvt, em.Events()[0])
 }
 
@@ -1977,6 +2560,41 @@ func TestInitializePinnedCodes(t *testing.T) {
 	}
 }
 
+func TestIteratePinnedCodes(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	mock := wasmtesting.MockWasmEngine{PinFn: func(checksum wasmvm.Checksum) error { return nil }}
+	wasmtesting.MakeInstantiable(&mock)
+
+	var expCodeIDs []uint64
+	for i := 0; i < 4; i++ {
+		codeID := StoreRandomContract(t, ctx, keepers, &mock).CodeID
+		if i%2 == 0 {
+			continue
+		}
+		require.NoError(t, k.pinCode(ctx, codeID))
+		expCodeIDs = append(expCodeIDs, codeID)
+	}
+
+	// when
+	var gotCodeIDs []uint64
+	k.IteratePinnedCodes(ctx, func(codeID uint64) bool {
+		gotCodeIDs = append(gotCodeIDs, codeID)
+		return false
+	})
+
+	// then
+	assert.Equal(t, expCodeIDs, gotCodeIDs)
+	// and iteration can be stopped
+	var count int
+	k.IteratePinnedCodes(ctx, func(codeID uint64) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 1, count)
+}
+
 func TestPinnedContractLoops(t *testing.T) {
 	var capturedChecksums []wasmvm.Checksum
 	mock := wasmtesting.MockWasmEngine{PinFn: func(checksum wasmvm.Checksum) error {
@@ -2110,6 +2728,7 @@ func TestReply(t *testing.T) {
 	var mock wasmtesting.MockWasmEngine
 	wasmtesting.MakeInstantiable(&mock)
 	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	traceID := types.NewTraceID(ctx.BlockHeight(), 0, 0, example.Contract)
 
 	specs := map[string]struct {
 		replyFn func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error)
@@ -2122,7 +2741,7 @@ func TestReply(t *testing.T) {
 				return &wasmvmtypes.Response{Data: []byte("foo")}, 1, nil
 			},
 			expData: []byte("foo"),
-			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()))},
+			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("trace_id", traceID))},
 		},
 		"with query": {
 			replyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -2138,7 +2757,7 @@ func TestReply(t *testing.T) {
 				return &wasmvmtypes.Response{Data: []byte("foo")}, 1, nil
 			},
 			expData: []byte("foo"),
-			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()))},
+			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("trace_id", traceID))},
 		},
 		"with query error handled": {
 			replyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -2148,7 +2767,7 @@ func TestReply(t *testing.T) {
 				return &wasmvmtypes.Response{Data: []byte("foo")}, 1, nil
 			},
 			expData: []byte("foo"),
-			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()))},
+			expEvt:  sdk.Events{sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", example.Contract.String()), sdk.NewAttribute("trace_id", traceID))},
 		},
 		"error": {
 			replyFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
@@ -2381,6 +3000,75 @@ func TestQueryIsolation(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+func TestContractInfoExtension(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	// register an example extension. must be protobuf
+	keepers.EncodingConfig.InterfaceRegistry.RegisterImplementations(
+		(*types.ContractInfoExtension)(nil),
+		&govv1beta1.Proposal{},
+	)
+	govv1beta1.RegisterInterfaces(keepers.EncodingConfig.InterfaceRegistry)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	anyDate := time.Now().UTC()
+	// abuse gov proposal as a random protobuf extension with an Any type
+	myExt, err := govv1beta1.NewProposal(&govv1beta1.TextProposal{Title: "foo", Description: "bar"}, 1, anyDate, anyDate)
+	require.NoError(t, err)
+	myExt.TotalDeposit = nil
+
+	specs := map[string]struct {
+		contract sdk.AccAddress
+		ext      types.ContractInfoExtension
+		out      types.ContractInfoExtension
+		expErr   error
+	}{
+		"set and get": {
+			contract: example.Contract,
+			ext:      &myExt,
+			out:      &govv1beta1.Proposal{},
+		},
+		"type mismatch": {
+			contract: example.Contract,
+			ext:      &myExt,
+			out:      &govv1beta1.TextProposal{},
+			expErr:   sdkerrors.ErrInvalidType,
+		},
+		"removed with nil": {
+			contract: example.Contract,
+			out:      &govv1beta1.Proposal{},
+			expErr:   types.ErrNotFound,
+		},
+		"unknown contract": {
+			contract: RandomAccountAddress(t),
+			ext:      &myExt,
+			out:      &govv1beta1.Proposal{},
+			expErr:   types.ErrNoSuchContractFn(""),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			setErr := k.SetContractInfoExtension(ctx, example.Contract, &myExt)
+			require.NoError(t, setErr)
+
+			// when
+			gotErr := k.SetContractInfoExtension(ctx, spec.contract, spec.ext)
+			if gotErr == nil {
+				gotErr = k.GetContractInfoExtension(ctx, spec.contract, spec.out)
+			}
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.ext, spec.out)
+		})
+	}
+}
+
 func TestSetAccessConfig(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	k := keepers.WasmKeeper
@@ -2636,6 +3324,8 @@ func TestIteratorContractByCreator(t *testing.T) {
 	mockAddress1 := keepers.Faucet.NewFundedRandomAccount(parentCtx, topUp...)
 	mockAddress2 := keepers.Faucet.NewFundedRandomAccount(parentCtx, topUp...)
 	mockAddress3 := keepers.Faucet.NewFundedRandomAccount(parentCtx, topUp...)
+	mockAddress4 := keepers.Faucet.NewFundedRandomAccount(parentCtx, topUp...)
+	govAddress := sdk.MustAccAddressFromBech32(keepers.WasmKeeper.GetAuthority())
 
 	contract1ID, _, err := keeper.Create(parentCtx, creator, hackatomWasm, nil)
 	require.NoError(t, err)
@@ -2659,6 +3349,12 @@ func TestIteratorContractByCreator(t *testing.T) {
 	gotAddr4, _, _ := keepers.ContractKeeper.Instantiate(ctx, contract2ID, mockAddress2, nil, initMsgBz, "label", depositContract)
 	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
 	gotAddr5, _, _ := keepers.ContractKeeper.Instantiate(ctx, contract2ID, mockAddress2, nil, initMsgBz, "label", depositContract)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	gotAddr6, _, err := keepers.ContractKeeper.Instantiate2(ctx, contract1ID, mockAddress4, nil, initMsgBz, "label", depositContract, []byte("salt"), false)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	gotAddr7, _, err := keepers.ContractKeeper.Instantiate(ctx, contract2ID, govAddress, nil, initMsgBz, "label", nil)
+	require.NoError(t, err)
 
 	specs := map[string]struct {
 		creatorAddr   sdk.AccAddress
@@ -2676,6 +3372,14 @@ func TestIteratorContractByCreator(t *testing.T) {
 			creatorAddr:   gotAddr1,
 			contractsAddr: []string{gotAddr3.String()},
 		},
+		"instantiate2": {
+			creatorAddr:   mockAddress4,
+			contractsAddr: []string{gotAddr6.String()},
+		},
+		"gov authority": {
+			creatorAddr:   govAddress,
+			contractsAddr: []string{gotAddr7.String()},
+		},
 		"no contracts- unknown": {
 			creatorAddr:   mockAddress3,
 			contractsAddr: nil,
@@ -2818,6 +3522,198 @@ func TestGasConsumed(t *testing.T) {
 	}
 }
 
+func TestSetContractGasMultiplier(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	half := types.GasMultiplier{Numerator: 1, Denominator: 2}
+
+	specs := map[string]struct {
+		addr       sdk.AccAddress
+		multiplier types.GasMultiplier
+		expErr     bool
+		expStored  types.GasMultiplier
+	}{
+		"override": {
+			addr:       example.Contract,
+			multiplier: half,
+			expStored:  half,
+		},
+		"default removes override": {
+			addr:       example.Contract,
+			multiplier: types.DefaultContractGasMultiplier(),
+			expStored:  types.DefaultContractGasMultiplier(),
+		},
+		"unknown contract": {
+			addr:       RandomAccountAddress(t),
+			multiplier: half,
+			expErr:     true,
+		},
+		"invalid multiplier": {
+			addr:       example.Contract,
+			multiplier: types.GasMultiplier{Numerator: 1},
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			require.NoError(t, k.SetContractGasMultiplier(ctx, example.Contract, half))
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			// when
+			gotErr := k.SetContractGasMultiplier(ctx, spec.addr, spec.multiplier)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Empty(t, em.Events())
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expStored, k.GetContractGasMultiplier(ctx, spec.addr))
+			exp := sdk.Events{sdk.NewEvent(
+				"update_contract_gas_multiplier",
+				sdk.NewAttribute("_contract_address", spec.addr.String()),
+				sdk.NewAttribute("gas_multiplier", fmt.Sprintf("%d/%d", spec.multiplier.Numerator, spec.multiplier.Denominator)),
+			)}
+			assert.Equal(t, exp, em.Events())
+		})
+	}
+}
+
+func TestExecuteWithContractGasMultiplier(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	// runtime gas conversions are scaled
+	ctx, _ := parentCtx.CacheContext()
+	require.NoError(t, k.SetContractGasMultiplier(ctx, example.Contract, types.GasMultiplier{Numerator: 1, Denominator: 2}))
+	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(100))
+	assert.Equal(t, 200*types.DefaultGasMultiplier, k.runtimeGasForContract(ctx, example.Contract))
+	assert.Equal(t, 100*types.DefaultGasMultiplier, k.runtimeGasForContract(ctx, example.VerifierAddr))
+	k.consumeRuntimeGas(ctx, example.Contract, 10*types.DefaultGasMultiplier)
+	assert.Equal(t, storetypes.Gas(5), ctx.GasMeter().GasConsumed())
+
+	// and an update within the same block replaces the cached multiplier
+	require.NoError(t, k.SetContractGasMultiplier(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()), example.Contract, types.GasMultiplier{Numerator: 1, Denominator: 4}))
+	assert.Equal(t, 4*95*types.DefaultGasMultiplier, k.runtimeGasForContract(ctx, example.Contract))
+
+	// and contract execution is cheaper
+	execute := func(multiplier types.GasMultiplier) storetypes.Gas {
+		ctx, _ := parentCtx.CacheContext()
+		require.NoError(t, k.SetContractGasMultiplier(ctx, example.Contract, multiplier))
+		ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed()
+	}
+	defaultGas := execute(types.DefaultContractGasMultiplier())
+	reducedGas := execute(types.GasMultiplier{Numerator: 1, Denominator: 10})
+	assert.Less(t, reducedGas, defaultGas)
+}
+
+func TestExecuteGasTrace(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	msgServer := NewMsgServerImpl(k)
+	execMsg := func() *types.MsgExecuteContract {
+		return &types.MsgExecuteContract{
+			Sender:   example.VerifierAddr.String(),
+			Contract: example.Contract.String(),
+			Msg:      []byte(`{"release":{}}`),
+		}
+	}
+
+	// when executed in a delivered tx
+	ctx, _ := parentCtx.CacheContext()
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()).WithExecMode(sdk.ExecModeFinalize)
+	expRsp, err := msgServer.ExecuteContract(ctx, execMsg())
+	require.NoError(t, err)
+	require.Empty(t, expRsp.GasTrace)
+	expGas := ctx.GasMeter().GasConsumed()
+
+	// and in a simulation
+	ctx, _ = parentCtx.CacheContext()
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()).WithExecMode(sdk.ExecModeSimulate)
+	gotRsp, err := msgServer.ExecuteContract(ctx, execMsg())
+	require.NoError(t, err)
+
+	// then the same gas is charged
+	assert.Equal(t, expRsp.Data, gotRsp.Data)
+	assert.Equal(t, expGas, ctx.GasMeter().GasConsumed())
+	var trace types.GasTrace
+	require.NoError(t, json.Unmarshal(gotRsp.GasTrace, &trace))
+	assert.Equal(t, expGas, trace.Total)
+	// and the gas consumed is broken down
+	gotCategories := make(map[string]uint64, len(trace.Categories))
+	var sum uint64
+	for _, c := range trace.Categories {
+		gotCategories[c.Name] = c.Gas
+		sum += c.Gas
+	}
+	assert.Equal(t, trace.Total, sum)
+	for _, c := range []string{types.GasCategorySetup, types.GasCategoryWasmExecution, types.GasCategoryDBRead, types.GasCategoryDBWrite} {
+		assert.NotZero(t, gotCategories[c], c)
+	}
+	assert.Equal(t, k.gasRegister.SetupContractCost(false, len(`{"release":{}}`)), gotCategories[types.GasCategorySetup])
+
+	// and nested calls in a simulation do not return a trace
+	ctx, _ = parentCtx.CacheContext()
+	ctx = types.WithCallDepth(ctx.WithExecMode(sdk.ExecModeSimulate), 1)
+	gotRsp, err = msgServer.ExecuteContract(ctx, execMsg())
+	require.NoError(t, err)
+	assert.Empty(t, gotRsp.GasTrace)
+}
+
+func TestExecuteDetailed(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	fundAccounts(t, parentCtx, keepers.AccountKeeper, keepers.BankKeeper, example.Contract, sdk.NewCoins(sdk.NewInt64Coin("denom", 100)))
+	recipient := RandomAccountAddress(t)
+	m.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+			Data:       []byte("my data"),
+			Attributes: []wasmvmtypes.EventAttribute{{Key: "action", Value: "release"}},
+			Events:     []wasmvmtypes.Event{{Type: "released", Attributes: []wasmvmtypes.EventAttribute{{Key: "amount", Value: "1"}}}},
+			Messages: []wasmvmtypes.SubMsg{{
+				ReplyOn: wasmvmtypes.ReplyNever,
+				Msg:     wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: recipient.String(), Amount: wasmvmtypes.Array[wasmvmtypes.Coin]{{Denom: "denom", Amount: "1"}}}}},
+			}},
+		}}, 0, nil
+	}
+
+	ctx, _ := parentCtx.CacheContext()
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	gasBefore := ctx.GasMeter().GasConsumed()
+
+	// when
+	got, err := k.ExecuteDetailed(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []byte("my data"), got.Data)
+	assert.Equal(t, ctx.GasMeter().GasConsumed()-gasBefore, got.GasUsed)
+	assert.NotZero(t, got.GasUsed)
+	gotTypes := make(map[string]bool, len(got.Events))
+	for _, e := range got.Events {
+		gotTypes[e.Type] = true
+	}
+	for _, exp := range []string{types.EventTypeExecute, types.WasmModuleEventType, types.CustomContractEventPrefix + "released", banktypes.EventTypeTransfer} {
+		assert.True(t, gotTypes[exp], exp)
+	}
+	// and the events are emitted to the caller as well
+	assert.Equal(t, got.Events, ctx.EventManager().Events())
+	// and the submessage was dispatched
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("denom", 1)), keepers.BankKeeper.GetAllBalances(ctx, recipient))
+}
+
 func TestSetContractLabel(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	k := keepers.WasmKeeper
@@ -2985,3 +3881,109 @@ func TestCheckDiscountEligibility(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryRecursionDepthParam(t *testing.T) {
+	specs := map[string]struct {
+		keeperLimit uint32
+		paramDepth  uint64
+		expCalls    int
+		expErr      *errorsmod.Error
+	}{
+		"param lowers keeper limit": {
+			keeperLimit: 5,
+			paramDepth:  2,
+			expCalls:    2,
+			expErr:      types.ErrExceedMaxQueryRecursionDepth,
+		},
+		"param above keeper limit": {
+			keeperLimit: 2,
+			paramDepth:  5,
+			expCalls:    2,
+			expErr:      types.ErrExceedMaxQueryStackSize,
+		},
+		"param not set": {
+			keeperLimit: 3,
+			expCalls:    3,
+			expErr:      types.ErrExceedMaxQueryStackSize,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var m wasmtesting.MockWasmEngine
+			wasmtesting.MakeInstantiable(&m)
+			var (
+				calls     int
+				nestedErr error
+			)
+			// the contract queries itself until the query fails
+			m.QueryFn = func(_ wasmvm.Checksum, env wasmvmtypes.Env, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+				calls++
+				_, err := querier.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{
+					ContractAddr: env.Contract.Address,
+					Msg:          []byte(`{}`),
+				}}}, gasLimit)
+				if err != nil {
+					nestedErr = err
+				}
+				return &wasmvmtypes.QueryResult{Ok: []byte(`{}`)}, 0, nil
+			}
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&m), WithMaxQueryStackSize(spec.keeperLimit))
+			k := keepers.WasmKeeper
+			params := k.GetParams(ctx)
+			params.MaxQueryRecursionDepth = spec.paramDepth
+			require.NoError(t, k.SetParams(ctx, params))
+			example := SeedNewContractInstance(t, ctx, keepers, &m)
+
+			// when
+			_, err := k.QuerySmart(ctx, example.Contract, []byte(`{}`))
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.expCalls, calls)
+			require.Error(t, nestedErr)
+			_, code, _ := errorsmod.ABCIInfo(spec.expErr, false)
+			assert.Contains(t, nestedErr.Error(), fmt.Sprintf("code: %d", code))
+		})
+	}
+}
+
+func TestQueryRecursionLimit(t *testing.T) {
+	const limit = 3
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	var (
+		calls     int
+		nestedErr error
+	)
+	// the contract queries itself until the query fails
+	m.QueryFn = func(_ wasmvm.Checksum, env wasmvmtypes.Env, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+		calls++
+		_, err := querier.Query(wasmvmtypes.QueryRequest{Wasm: &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{
+			ContractAddr: env.Contract.Address,
+			Msg:          []byte(`{}`),
+		}}}, gasLimit)
+		if err != nil {
+			nestedErr = err
+		}
+		return &wasmvmtypes.QueryResult{Ok: []byte(`{}`)}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&m), WithMaxQueryStackSize(limit))
+	k := keepers.WasmKeeper
+	// the keeper limit is below the default MaxQueryRecursionDepth param
+	require.Less(t, uint64(limit), k.GetParams(parentCtx).MaxQueryRecursionDepth)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+
+	// when
+	_, err := k.QuerySmart(parentCtx, example.Contract, []byte(`{}`))
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, limit, calls)
+	require.Error(t, nestedErr)
+	_, code, _ := errorsmod.ABCIInfo(types.ErrExceedMaxQueryStackSize, false)
+	assert.Contains(t, nestedErr.Error(), fmt.Sprintf("code: %d", code))
+
+	// and a query beyond the limit fails with the specific error
+	_, err = k.QuerySmart(types.WithQueryStackSize(parentCtx, limit), example.Contract, []byte(`{}`))
+	assert.ErrorIs(t, err, types.ErrExceedMaxQueryStackSize)
+}
@@ -3,6 +3,9 @@ package keeper
 import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
@@ -86,3 +89,28 @@ func (p *WasmVMMetricsCollector) Collect(c chan<- prometheus.Metric) {
 	// We had to either scan the whole directory of potentially thousands of files or track the values when files are added or removed.
 	// Such a tracking would need to be on disk such that the values are not cleared when the node is restarted.
 }
+
+// sampleVMMetrics reports the wasmvm cache metrics via telemetry every metricsSampleInterval blocks.
+// The metrics are node local and not written to state so that consensus is not affected.
+// Nothing is read from wasmvm when telemetry is disabled.
+func (k Keeper) sampleVMMetrics(ctx sdk.Context) {
+	if !telemetry.IsTelemetryEnabled() || k.metricsSampleInterval == 0 || ctx.BlockHeight()%int64(k.metricsSampleInterval) != 0 {
+		return
+	}
+	m, err := k.wasmVM.GetMetrics()
+	if err != nil {
+		k.Logger(ctx).Debug("failed to read wasmvm metrics", "error", err)
+		return
+	}
+	setGauge := func(val uint64, keys ...string) {
+		telemetry.SetGauge(float32(val), append([]string{"wasm", "vm", "cache"}, keys...)...)
+	}
+	setGauge(uint64(m.HitsPinnedMemoryCache), "hits", labelPinned)
+	setGauge(uint64(m.HitsMemoryCache), "hits", labelMemory)
+	setGauge(uint64(m.HitsFsCache), "hits", labelFs)
+	setGauge(uint64(m.Misses), "misses")
+	setGauge(m.ElementsPinnedMemoryCache, "elements", labelPinned)
+	setGauge(m.ElementsMemoryCache, "elements", labelMemory)
+	setGauge(m.SizePinnedMemoryCache, "size_bytes", labelPinned)
+	setGauge(m.SizeMemoryCache, "size_bytes", labelMemory)
+}
@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+)
+
+func TestSampleVMMetrics(t *testing.T) {
+	_, err := telemetry.New(telemetry.Config{Enabled: true, ServiceName: "test"})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		interval uint64
+		expCalls int
+	}{
+		"every block": {
+			interval: 1,
+			expCalls: 20,
+		},
+		"every 10 blocks": {
+			interval: 10,
+			expCalls: 2,
+		},
+		"disabled": {
+			interval: 0,
+			expCalls: 0,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			k := Keeper{
+				wasmVM: &wasmtesting.MockWasmEngine{GetMetricsFn: func() (*wasmvmtypes.Metrics, error) {
+					calls++
+					return &wasmvmtypes.Metrics{}, nil
+				}},
+				metricsSampleInterval: spec.interval,
+			}
+
+			// when
+			for h := int64(1); h <= 20; h++ {
+				k.sampleVMMetrics(sdk.NewContext(nil, tmproto.Header{Height: h}, false, log.NewNopLogger()))
+			}
+
+			// then
+			assert.Equal(t, spec.expCalls, calls)
+		})
+	}
+}
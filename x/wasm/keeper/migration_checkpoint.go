@@ -0,0 +1,173 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// createMigrationCheckpoint copies the current contract state into a backup and records a checkpoint for it.
+// The oldest checkpoints of the contract are pruned when the max migration checkpoints param is exceeded.
+func (k Keeper) createMigrationCheckpoint(ctx context.Context, contractAddr sdk.AccAddress) (uint64, error) {
+	maxCheckpoints := k.GetParams(ctx).MaxMigrationCheckpoints
+	if maxCheckpoints == 0 {
+		return 0, errorsmod.Wrap(types.ErrInvalid, "migration checkpoints disabled")
+	}
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return 0, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	checkpoints := k.GetMigrationCheckpoints(ctx, contractAddr)
+	var id uint64 = 1
+	if len(checkpoints) != 0 {
+		id = checkpoints[len(checkpoints)-1].ID + 1
+	}
+	checkpoint := types.MigrationCheckpoint{
+		ID:      id,
+		CodeID:  contractInfo.CodeID,
+		Created: types.NewAbsoluteTxPosition(sdk.UnwrapSDKContext(ctx)),
+	}
+	var state []types.Model
+	k.IterateContractState(ctx, contractAddr, func(key, value []byte) bool {
+		state = append(state, types.Model{Key: key, Value: value})
+		return false
+	})
+	if err := k.importMigrationCheckpoint(ctx, contractAddr, checkpoint, state); err != nil {
+		return 0, err
+	}
+	for i := 0; uint64(len(checkpoints)+1-i) > maxCheckpoints; i++ {
+		if err := k.deleteMigrationCheckpoint(ctx, contractAddr, checkpoints[i].ID); err != nil {
+			return 0, err
+		}
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeMigrationCheckpoint,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyCheckpointID, strconv.FormatUint(id, 10)),
+	))
+	return id, nil
+}
+
+// RestoreContractState replaces the contract state with the state backup of the migration checkpoint.
+// The contract code is not changed.
+func (k Keeper) RestoreContractState(ctx context.Context, contractAddr sdk.AccAddress, checkpointID uint64) error {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	ok, err := store.Has(types.GetMigrationCheckpointKey(contractAddr, checkpointID))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errorsmod.Wrapf(types.ErrNotFound, "checkpoint %d", checkpointID)
+	}
+	contractStore := prefix.NewStore(runtime.KVStoreAdapter(store), types.GetContractStorePrefix(contractAddr))
+	deleteAll(contractStore)
+
+	backupStore := prefix.NewStore(runtime.KVStoreAdapter(store), types.GetMigrationCheckpointStatePrefix(contractAddr, checkpointID))
+	iter := backupStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		contractStore.Set(iter.Key(), iter.Value())
+	}
+	if quota := k.GetContractStorageQuota(ctx, contractAddr); quota != 0 {
+		if err := k.refreshContractStorageUsage(ctx, contractAddr, quota); err != nil {
+			return err
+		}
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRestoreContractState,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyCheckpointID, strconv.FormatUint(checkpointID, 10)),
+	))
+	return nil
+}
+
+// GetMigrationCheckpoints returns the migration checkpoints of the contract ordered by id
+func (k Keeper) GetMigrationCheckpoints(ctx context.Context, contractAddr sdk.AccAddress) []types.MigrationCheckpoint {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetMigrationCheckpointPrefix(contractAddr))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	var r []types.MigrationCheckpoint
+	for ; iter.Valid(); iter.Next() {
+		var checkpoint types.MigrationCheckpoint
+		k.cdc.MustUnmarshal(iter.Value(), &checkpoint)
+		r = append(r, checkpoint)
+	}
+	return r
+}
+
+// IterateMigrationCheckpointState iterates through the state backup of the migration checkpoint.
+// The callback method can return true to abort early.
+func (k Keeper) IterateMigrationCheckpointState(ctx context.Context, contractAddr sdk.AccAddress, checkpointID uint64, cb func(key, value []byte) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetMigrationCheckpointStatePrefix(contractAddr, checkpointID))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+}
+
+// importMigrationCheckpoint stores the checkpoint with its state backup
+func (k Keeper) importMigrationCheckpoint(ctx context.Context, contractAddr sdk.AccAddress, checkpoint types.MigrationCheckpoint, state []types.Model) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetMigrationCheckpointKey(contractAddr, checkpoint.ID)
+	ok, err := store.Has(key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return errorsmod.Wrapf(types.ErrDuplicate, "checkpoint %d", checkpoint.ID)
+	}
+	if err := store.Set(key, k.cdc.MustMarshal(&checkpoint)); err != nil {
+		return err
+	}
+	backupStore := prefix.NewStore(runtime.KVStoreAdapter(store), types.GetMigrationCheckpointStatePrefix(contractAddr, checkpoint.ID))
+	for _, model := range state {
+		if model.Value == nil {
+			model.Value = []byte{}
+		}
+		if backupStore.Has(model.Key) {
+			return errorsmod.Wrapf(types.ErrDuplicate, "duplicate key: %x", model.Key)
+		}
+		backupStore.Set(model.Key, model.Value)
+	}
+	return nil
+}
+
+// deleteMigrationCheckpoint removes the checkpoint and its state backup
+func (k Keeper) deleteMigrationCheckpoint(ctx context.Context, contractAddr sdk.AccAddress, checkpointID uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	if err := store.Delete(types.GetMigrationCheckpointKey(contractAddr, checkpointID)); err != nil {
+		return err
+	}
+	deleteAll(prefix.NewStore(runtime.KVStoreAdapter(store), types.GetMigrationCheckpointStatePrefix(contractAddr, checkpointID)))
+	return nil
+}
+
+// deleteAll removes all entries of the store
+func deleteAll(store storetypes.KVStore) {
+	iter := store.Iterator(nil, nil)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
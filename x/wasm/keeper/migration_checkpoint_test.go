@@ -0,0 +1,162 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCreateMigrationCheckpoint(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		maxCheckpoints uint64
+		runs           int
+		expErr         bool
+		expIDs         []uint64
+	}{
+		"single": {
+			maxCheckpoints: 2,
+			runs:           1,
+			expIDs:         []uint64{1},
+		},
+		"within limit": {
+			maxCheckpoints: 2,
+			runs:           2,
+			expIDs:         []uint64{1, 2},
+		},
+		"oldest pruned": {
+			maxCheckpoints: 2,
+			runs:           4,
+			expIDs:         []uint64{3, 4},
+		},
+		"disabled": {
+			maxCheckpoints: 0,
+			runs:           1,
+			expErr:         true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.MaxMigrationCheckpoints = spec.maxCheckpoints
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when
+			var gotErr error
+			for i := 0; i < spec.runs && gotErr == nil; i++ {
+				_, gotErr = k.createMigrationCheckpoint(ctx, example.Contract)
+			}
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Empty(t, k.GetMigrationCheckpoints(ctx, example.Contract))
+				return
+			}
+			require.NoError(t, gotErr)
+			checkpoints := k.GetMigrationCheckpoints(ctx, example.Contract)
+			require.Len(t, checkpoints, len(spec.expIDs))
+			for i, id := range spec.expIDs {
+				assert.Equal(t, id, checkpoints[i].ID)
+				assert.Equal(t, example.CodeID, checkpoints[i].CodeID)
+			}
+			// and the state of pruned checkpoints is removed
+			for id := uint64(1); id < spec.expIDs[0]; id++ {
+				k.IterateMigrationCheckpointState(ctx, example.Contract, id, func(key, value []byte) bool {
+					t.Fatalf("unexpected state for checkpoint %d", id)
+					return true
+				})
+			}
+		})
+	}
+}
+
+func TestRestoreContractState(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	params := k.GetParams(parentCtx)
+	params.MaxMigrationCheckpoints = 1
+	require.NoError(t, k.SetParams(parentCtx, params))
+
+	expState := contractState(parentCtx, k, example.Contract)
+	require.NotEmpty(t, expState)
+	checkpointID, err := k.createMigrationCheckpoint(parentCtx, example.Contract)
+	require.NoError(t, err)
+	// and the live state modified
+	require.NoError(t, k.importContractState(parentCtx, example.Contract, []types.Model{{Key: []byte("foo"), Value: []byte("bar")}}))
+
+	specs := map[string]struct {
+		contract     sdk.AccAddress
+		checkpointID uint64
+		storageQuota uint64
+		expErr       error
+	}{
+		"restored": {
+			contract:     example.Contract,
+			checkpointID: checkpointID,
+		},
+		"restored with storage quota": {
+			contract:     example.Contract,
+			checkpointID: checkpointID,
+			storageQuota: 1 << 20,
+		},
+		"unknown checkpoint": {
+			contract:     example.Contract,
+			checkpointID: checkpointID + 1,
+			expErr:       types.ErrNotFound,
+		},
+		"unknown contract": {
+			contract:     RandomAccountAddress(t),
+			checkpointID: checkpointID,
+			expErr:       types.ErrNoSuchContractFn(""),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.storageQuota != 0 {
+				require.NoError(t, k.SetContractStorageQuota(ctx, example.Contract, spec.storageQuota))
+			}
+
+			// when
+			gotErr := k.RestoreContractState(ctx, spec.contract, spec.checkpointID)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, expState, contractState(ctx, k, example.Contract))
+			if spec.storageQuota != 0 {
+				// and the storage usage is recalculated
+				var expUsage uint64
+				for _, m := range expState {
+					expUsage += uint64(len(m.Key) + len(m.Value))
+				}
+				assert.Equal(t, expUsage, k.GetContractStorageUsage(ctx, example.Contract))
+			}
+			// and the checkpoint can be restored again
+			assert.Len(t, k.GetMigrationCheckpoints(ctx, example.Contract), 1)
+		})
+	}
+}
+
+func contractState(ctx sdk.Context, k *Keeper, contractAddr sdk.AccAddress) []types.Model {
+	var r []types.Model
+	k.IterateContractState(ctx, contractAddr, func(key, value []byte) bool {
+		r = append(r, types.Model{Key: key, Value: value})
+		return false
+	})
+	return r
+}
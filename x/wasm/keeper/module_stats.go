@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetModuleStats returns the code, contract and pinned code counters and the last assigned ids.
+// The counters are maintained on each change so that no store scan is needed.
+func (k Keeper) GetModuleStats(ctx context.Context) (*types.QueryModuleStatsResponse, error) {
+	nextCodeID, err := k.PeekAutoIncrementID(ctx, types.KeySequenceCodeID)
+	if err != nil {
+		return nil, err
+	}
+	nextInstanceID, err := k.PeekAutoIncrementID(ctx, types.KeySequenceInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryModuleStatsResponse{
+		CodeCount:       k.getModuleStat(ctx, types.KeyStatsCodeCount),
+		ContractCount:   k.getModuleStat(ctx, types.KeyStatsContractCount),
+		PinnedCodeCount: k.getModuleStat(ctx, types.KeyStatsPinnedCodeCount),
+		LastCodeID:      nextCodeID - 1,
+		LastInstanceID:  nextInstanceID - 1,
+	}, nil
+}
+
+func (k Keeper) getModuleStat(ctx context.Context, key []byte) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(key)
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// setModuleStat stores the counter. A zero count is not persisted.
+func (k Keeper) setModuleStat(ctx context.Context, key []byte, count uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	if count == 0 {
+		return store.Delete(key)
+	}
+	return store.Set(key, sdk.Uint64ToBigEndian(count))
+}
+
+func (k Keeper) incrementModuleStat(ctx context.Context, key []byte) error {
+	return k.setModuleStat(ctx, key, k.getModuleStat(ctx, key)+1)
+}
+
+func (k Keeper) decrementModuleStat(ctx context.Context, key []byte) error {
+	count := k.getModuleStat(ctx, key)
+	if count == 0 {
+		return errorsmod.Wrapf(types.ErrInvalid, "counter underflow for %s", string(key[len(types.ModuleStatsPrefix):]))
+	}
+	return k.setModuleStat(ctx, key, count-1)
+}
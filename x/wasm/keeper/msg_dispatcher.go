@@ -40,11 +40,14 @@ type replyer interface {
 type MessageDispatcher struct {
 	messenger Messenger
 	keeper    replyer
+	// optional, to enforce the max submessages per call param
+	params paramsSource
 }
 
-// NewMessageDispatcher constructor
-func NewMessageDispatcher(messenger Messenger, keeper replyer) *MessageDispatcher {
-	return &MessageDispatcher{messenger: messenger, keeper: keeper}
+// NewMessageDispatcher constructor. The params source is optional, without it the submessages per call are
+// not limited.
+func NewMessageDispatcher(messenger Messenger, keeper replyer, params paramsSource) *MessageDispatcher {
+	return &MessageDispatcher{messenger: messenger, keeper: keeper, params: params}
 }
 
 // DispatchMessages sends all messages.
@@ -82,7 +85,7 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 
 	// make sure we charge the parent what was spent
 	spent := subCtx.GasMeter().GasConsumed()
-	ctx.GasMeter().ConsumeGas(spent, "From limited Sub-Message")
+	ctx.GasMeter().ConsumeGas(spent, types.GasDescLimitedSubMsg)
 
 	return events, data, msgResponses, err
 }
@@ -90,6 +93,9 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 // DispatchSubmessages builds a sandbox to execute these messages and returns the execution result to the contract
 // that dispatched them, both on success as well as failure
 func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.SubMsg) ([]byte, error) {
+	if maxMsgs := d.maxSubMessagesPerCall(ctx); maxMsgs != 0 && len(msgs) > int(maxMsgs) {
+		return nil, errorsmod.Wrapf(types.ErrExceedMaxSubMessages, "got %d, max %d", len(msgs), maxMsgs)
+	}
 	var rsp []byte
 	for _, msg := range msgs {
 		switch msg.ReplyOn {
@@ -225,6 +231,14 @@ func redactError(err error) error {
 	return fmt.Errorf("codespace: %s, code: %d", codespace, code)
 }
 
+// maxSubMessagesPerCall returns the limit for the submessages of a single contract call. 0 means unlimited.
+func (d MessageDispatcher) maxSubMessagesPerCall(ctx sdk.Context) uint32 {
+	if d.params == nil {
+		return 0
+	}
+	return d.params.GetCachedParams(ctx).MaxSubMessagesPerCall
+}
+
 func filterEvents(events []sdk.Event) []sdk.Event {
 	// pre-allocate space for efficiency
 	res := make([]sdk.Event, 0, len(events))
@@ -239,9 +253,34 @@ func filterEvents(events []sdk.Event) []sdk.Event {
 func sdkEventsToWasmVMEvents(events []sdk.Event) []wasmvmtypes.Event {
 	res := make([]wasmvmtypes.Event, len(events))
 	for i, ev := range events {
+		attrs := ev.Attributes
+		if tracedEventTypes[ev.Type] {
+			attrs = withoutTraceID(attrs)
+		}
 		res[i] = wasmvmtypes.Event{
 			Type:       ev.Type,
-			Attributes: sdkAttributesToWasmVMAttributes(ev.Attributes),
+			Attributes: sdkAttributesToWasmVMAttributes(attrs),
+		}
+	}
+	return res
+}
+
+// tracedEventTypes are the event types that carry the trace id attribute
+var tracedEventTypes = map[string]bool{
+	types.EventTypeInstantiate: true,
+	types.EventTypeExecute:     true,
+	types.EventTypeMigrate:     true,
+	types.EventTypeSudo:        true,
+	types.EventTypeReply:       true,
+}
+
+// withoutTraceID removes the trace id attribute so that the events passed to a reply, and their gas costs,
+// do not depend on the observational trace id.
+func withoutTraceID(attrs []abci.EventAttribute) []abci.EventAttribute {
+	res := make([]abci.EventAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr.Key != types.AttributeKeyTraceID {
+			res = append(res, attr)
 		}
 	}
 	return res
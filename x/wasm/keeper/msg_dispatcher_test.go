@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -18,6 +19,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestDispatchSubmessages(t *testing.T) {
@@ -423,7 +425,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			ctx := sdk.Context{}.WithMultiStore(&mockStore).
 				WithGasMeter(storetypes.NewGasMeter(100)).
 				WithEventManager(em).WithLogger(log.NewTestLogger(t))
-			d := NewMessageDispatcher(spec.msgHandler, spec.replyer)
+			d := NewMessageDispatcher(spec.msgHandler, spec.replyer, nil)
 
 			// run the test
 			gotData, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", spec.msgs)
@@ -448,6 +450,122 @@ func TestDispatchSubmessages(t *testing.T) {
 	}
 }
 
+func TestDispatchSubmessagesNestedPayloads(t *testing.T) {
+	var (
+		d           *MessageDispatcher
+		gotPayloads []string
+		depth       int
+	)
+	replyer := &mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			gotPayloads = append(gotPayloads, fmt.Sprintf("%d:%s", reply.ID, reply.Payload))
+			return nil, nil
+		},
+	}
+	msgHandler := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+			depth++
+			// the called contract sends a sub-message with its own payload
+			if depth < 3 {
+				id := uint64(depth + 1)
+				subMsg := wasmvmtypes.SubMsg{ID: id, ReplyOn: wasmvmtypes.ReplyAlways, Payload: []byte(fmt.Sprintf("payload-%d", id)), Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{}}}
+				if _, err := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "", []wasmvmtypes.SubMsg{subMsg}); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+			return nil, nil, [][]*codectypes.Any{}, nil
+		},
+	}
+	d = NewMessageDispatcher(msgHandler, replyer, nil)
+	var mockStore wasmtesting.MockCommitMultiStore
+	ctx := sdk.Context{}.WithMultiStore(&mockStore).
+		WithGasMeter(storetypes.NewGasMeter(100)).
+		WithEventManager(sdk.NewEventManager()).WithLogger(log.NewTestLogger(t))
+	msgs := []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyAlways, Payload: []byte("payload-1"), Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{}}}}
+
+	// when
+	_, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", msgs)
+
+	// then each level receives the payload it attached, innermost first
+	require.NoError(t, gotErr)
+	assert.Equal(t, []string{"3:payload-3", "2:payload-2", "1:payload-1"}, gotPayloads)
+}
+
+func TestDispatchSubmessagesMaxSubMessagesPerCall(t *testing.T) {
+	newMsgs := func(n int) []wasmvmtypes.SubMsg {
+		msgs := make([]wasmvmtypes.SubMsg, n)
+		for i := range msgs {
+			msgs[i] = wasmvmtypes.SubMsg{ID: uint64(i), ReplyOn: wasmvmtypes.ReplyNever, Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{}}}
+		}
+		return msgs
+	}
+	specs := map[string]struct {
+		maxMsgs       uint32
+		msgs          int
+		nested        int
+		expDispatched int
+		expErr        error
+	}{
+		"unlimited": {
+			msgs:          3,
+			expDispatched: 3,
+		},
+		"within limit": {
+			maxMsgs:       3,
+			msgs:          3,
+			expDispatched: 3,
+		},
+		"limit exceeded": {
+			maxMsgs: 2,
+			msgs:    3,
+			expErr:  types.ErrExceedMaxSubMessages,
+		},
+		"nested submessages are counted per call": {
+			maxMsgs:       2,
+			msgs:          2,
+			nested:        2,
+			expDispatched: 6,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var (
+				d          *MessageDispatcher
+				dispatched int
+			)
+			msgHandler := &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					dispatched++
+					// top level messages dispatch the nested submessages of the called contract
+					if _, ok := types.CallDepth(ctx); !ok && spec.nested != 0 {
+						if _, err := d.DispatchSubmessages(types.WithCallDepth(ctx, 1), contractAddr, "", newMsgs(spec.nested)); err != nil {
+							return nil, nil, nil, err
+						}
+					}
+					return nil, nil, [][]*codectypes.Any{}, nil
+				},
+			}
+			d = NewMessageDispatcher(msgHandler, mockReplyer{}, mockParamsSource{MaxSubMessagesPerCall: spec.maxMsgs})
+			var mockStore wasmtesting.MockCommitMultiStore
+			ctx := sdk.Context{}.WithContext(context.Background()).WithMultiStore(&mockStore).
+				WithGasMeter(storetypes.NewGasMeter(100)).
+				WithEventManager(sdk.NewEventManager()).WithLogger(log.NewTestLogger(t))
+
+			// when
+			_, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", newMsgs(spec.msgs))
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Zero(t, dispatched)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expDispatched, dispatched)
+		})
+	}
+}
+
 type mockReplyer struct {
 	replyFn func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
 }
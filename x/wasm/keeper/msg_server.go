@@ -2,11 +2,16 @@ package keeper
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"slices"
+	"strconv"
+	"strings"
 
 	errorsmod "cosmossdk.io/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -35,7 +40,7 @@ func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*typ
 
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
 
-	codeID, checksum, err := m.keeper.create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission, policy)
+	codeID, checksum, err := m.keeper.create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission, policy, withCodeProvenance(msg.Source, msg.Builder))
 	if err != nil {
 		return nil, err
 	}
@@ -46,11 +51,49 @@ func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*typ
 	}, nil
 }
 
+// StoreCodes stores multiple codes with the same instantiate permission. Either all codes are stored or none.
+func (m msgServer) StoreCodes(goCtx context.Context, msg *types.MsgStoreCodes) (*types.MsgStoreCodesResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+	cacheCtx, commit := ctx.CacheContext()
+	codeIDs, checksums, err := m.keeper.createCodes(cacheCtx, senderAddr, msg.WASMByteCodes, msg.InstantiatePermission, policy)
+	if err != nil {
+		return nil, err
+	}
+	commit()
+
+	ids := make([]string, len(codeIDs))
+	for i, id := range codeIDs {
+		ids[i] = strconv.FormatUint(id, 10)
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeStoreCodes,
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+		sdk.NewAttribute(types.AttributeKeyCodeIDs, strings.Join(ids, ",")),
+	))
+
+	return &types.MsgStoreCodesResponse{
+		CodeIDs:   codeIDs,
+		Checksums: checksums,
+	}, nil
+}
+
 // InstantiateContract instantiate a new contract with classic sequence based address generation
 func (m msgServer) InstantiateContract(ctx context.Context, msg *types.MsgInstantiateContract) (*types.MsgInstantiateContractResponse, error) {
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
+	if err := m.validateContractMsgSize(ctx, msg.Msg, msg.Funds); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
 
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
@@ -81,6 +124,9 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
+	if err := m.validateContractMsgSize(ctx, msg.Msg, msg.Funds); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
 
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
@@ -93,9 +139,15 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 		}
 	}
 
+	if msg.Idempotent {
+		if contractAddr, ok := m.idempotentInstance(ctx, senderAddr, msg); ok {
+			return &types.MsgInstantiateContract2Response{Address: contractAddr.String()}, nil
+		}
+	}
+
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
 
-	addrGenerator := PredictableAddressGenerator(senderAddr, msg.Salt, msg.Msg, msg.FixMsg)
+	addrGenerator := m.keeper.PredictableAddressGenerator(senderAddr, msg.Salt, msg.Msg, msg.FixMsg)
 
 	contractAddr, data, err := m.keeper.instantiate(ctx, msg.CodeID, senderAddr, adminAddr, msg.Msg, msg.Label, msg.Funds, addrGenerator, policy)
 	if err != nil {
@@ -121,10 +173,33 @@ func (m msgServer) ExecuteContract(ctx context.Context, msg *types.MsgExecuteCon
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "contract")
 	}
+	if err := m.validateContractMsgSize(ctx, msg.Msg, msg.Funds); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
+
+	// the gas trace is only collected in simulations to never affect consensus. The trace meter only observes the
+	// gas consumed so that the execution path and gas are the same as in a delivered tx. Nested calls are part of the
+	// top level trace and do not return their own.
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	var traceMeter *types.GasTraceMeter
+	if _, nested := types.CallDepth(ctx); !nested && sdkCtx.ExecMode() == sdk.ExecModeSimulate {
+		traceMeter = types.NewGasTraceMeter(sdkCtx.GasMeter())
+		ctx = sdkCtx.WithGasMeter(traceMeter)
+	}
 
 	data, err := m.keeper.execute(ctx, contractAddr, senderAddr, msg.Msg, msg.Funds)
 	if err != nil {
-		return nil, err
+		return nil, toSDKError(err)
+	}
+	if traceMeter != nil {
+		traceBz, err := json.Marshal(traceMeter.Trace())
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "gas trace")
+		}
+		return &types.MsgExecuteContractResponse{
+			Data:     data,
+			GasTrace: traceBz,
+		}, nil
 	}
 
 	return &types.MsgExecuteContractResponse{
@@ -132,10 +207,55 @@ func (m msgServer) ExecuteContract(ctx context.Context, msg *types.MsgExecuteCon
 	}, nil
 }
 
+// ExecuteContracts executes a batch of contract calls in order. The calls share one cache
+// context that is only committed when all of them succeed. Each call emits the regular
+// execute event, followed by a summary event for the batch.
+func (m msgServer) ExecuteContracts(goCtx context.Context, msg *types.MsgExecuteContracts) (*types.MsgExecuteContractsResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	cacheCtx, commit := ctx.CacheContext()
+	data := make([][]byte, len(msg.Executions))
+	for i, e := range msg.Executions {
+		contractAddr, err := sdk.AccAddressFromBech32(e.Contract)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "contract of execution %d", i)
+		}
+		if err := m.validateContractMsgSize(ctx, e.Msg, e.Funds); err != nil {
+			return nil, errorsmod.Wrapf(err, "payload msg of execution %d", i)
+		}
+		data[i], err = m.keeper.execute(cacheCtx, contractAddr, senderAddr, e.Msg, e.Funds)
+		if err != nil {
+			return nil, errorsmod.Wrapf(toSDKError(err), "execution %d", i)
+		}
+	}
+	commit()
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExecuteBatch,
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+		sdk.NewAttribute(types.AttributeKeyExecutionCount, strconv.Itoa(len(msg.Executions))),
+	))
+
+	return &types.MsgExecuteContractsResponse{
+		Data: data,
+	}, nil
+}
+
 func (m msgServer) MigrateContract(ctx context.Context, msg *types.MsgMigrateContract) (*types.MsgMigrateContractResponse, error) {
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
+	if err := m.validateContractMsgSize(ctx, msg.Msg, msg.Funds); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
 
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
@@ -146,15 +266,72 @@ func (m msgServer) MigrateContract(ctx context.Context, msg *types.MsgMigrateCon
 		return nil, errorsmod.Wrap(err, "contract")
 	}
 
+	if err := m.checkDowngrade(ctx, contractAddr, msg.CodeID, msg.AllowDowngrade); err != nil {
+		return nil, err
+	}
+
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
 
-	data, err := m.keeper.migrate(ctx, contractAddr, senderAddr, msg.CodeID, msg.Msg, policy)
+	var checkpointID uint64
+	if msg.WithBackup {
+		if checkpointID, err = m.keeper.createMigrationCheckpoint(ctx, contractAddr); err != nil {
+			return nil, errorsmod.Wrap(err, "migration checkpoint")
+		}
+	}
+
+	data, err := m.keeper.migrate(ctx, contractAddr, senderAddr, msg.CodeID, msg.Msg, msg.Funds, policy)
 	if err != nil {
-		return nil, err
+		return nil, toSDKError(err)
 	}
 
 	return &types.MsgMigrateContractResponse{
-		Data: data,
+		Data:         data,
+		CheckpointID: checkpointID,
+	}, nil
+}
+
+// MigrateContractAndExecute migrates the contract and executes it in a cache context, so that both are rolled back
+// when any fails. The migrate and execute events are emitted as with separate messages.
+func (m msgServer) MigrateContractAndExecute(goCtx context.Context, msg *types.MsgMigrateContractAndExecute) (*types.MsgMigrateContractAndExecuteResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+	if err := m.validateContractMsgSize(goCtx, msg.MigrateMsg, nil); err != nil {
+		return nil, errorsmod.Wrap(err, "migrate msg")
+	}
+	if err := m.validateContractMsgSize(goCtx, msg.ExecuteMsg, msg.Funds); err != nil {
+		return nil, errorsmod.Wrap(err, "execute msg")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := m.checkDowngrade(ctx, contractAddr, msg.NewCodeID, msg.AllowDowngrade); err != nil {
+		return nil, err
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+	cacheCtx, commit := ctx.CacheContext()
+	migrateData, err := m.keeper.migrate(cacheCtx, contractAddr, senderAddr, msg.NewCodeID, msg.MigrateMsg, nil, policy)
+	if err != nil {
+		return nil, errorsmod.Wrap(toSDKError(err), "migrate")
+	}
+	executeData, err := m.keeper.execute(cacheCtx, contractAddr, senderAddr, msg.ExecuteMsg, msg.Funds)
+	if err != nil {
+		return nil, errorsmod.Wrap(toSDKError(err), "execute")
+	}
+	commit()
+
+	return &types.MsgMigrateContractAndExecuteResponse{
+		MigrateData: migrateData,
+		ExecuteData: executeData,
 	}, nil
 }
 
@@ -208,6 +385,97 @@ func (m msgServer) ClearAdmin(ctx context.Context, msg *types.MsgClearAdmin) (*t
 	return &types.MsgClearAdminResponse{}, nil
 }
 
+// ClearAdmins clears the admin of a list of contracts in order. The changes are only committed when
+// all contracts succeed. In skip mode, contracts that the sender can not modify are skipped instead.
+// Each contract emits the regular admin update event, followed by a summary event for the batch.
+func (m msgServer) ClearAdmins(goCtx context.Context, msg *types.MsgClearAdmins) (*types.MsgClearAdminsResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+	cacheCtx, commit := ctx.CacheContext()
+	var rsp types.MsgClearAdminsResponse
+	for _, c := range msg.Contracts {
+		contractAddr, err := sdk.AccAddressFromBech32(c)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "contract")
+		}
+		switch err := m.keeper.setContractAdmin(cacheCtx, contractAddr, senderAddr, nil, policy); {
+		case err == nil:
+			rsp.Cleared = append(rsp.Cleared, c)
+		case msg.SkipUnauthorized && errorsmod.IsOf(err, sdkerrors.ErrUnauthorized):
+			rsp.Skipped = append(rsp.Skipped, c)
+		default:
+			return nil, errorsmod.Wrapf(err, "contract %s", c)
+		}
+	}
+	commit()
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeClearContractAdmins,
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+		sdk.NewAttribute(types.AttributeKeyClearedCount, strconv.Itoa(len(rsp.Cleared))),
+		sdk.NewAttribute(types.AttributeKeySkippedCount, strconv.Itoa(len(rsp.Skipped))),
+	))
+
+	return &rsp, nil
+}
+
+// UpdateAdmins sets the new admin on a list of contracts in order. The changes are only committed when
+// the sender is allowed to modify all contracts. Addresses without a contract are skipped when
+// SkipMissing is set and fail the message otherwise.
+func (m msgServer) UpdateAdmins(goCtx context.Context, msg *types.MsgUpdateAdmins) (*types.MsgUpdateAdminsResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	newAdminAddr, err := sdk.AccAddressFromBech32(msg.NewAdmin)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "new admin")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+	cacheCtx, commit := ctx.CacheContext()
+	var rsp types.MsgUpdateAdminsResponse
+	for _, c := range msg.Contracts {
+		contractAddr, err := sdk.AccAddressFromBech32(c)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "contract")
+		}
+		if msg.SkipMissing && !m.keeper.HasContractInfo(cacheCtx, contractAddr) {
+			rsp.Skipped = append(rsp.Skipped, c)
+			continue
+		}
+		if err := m.keeper.setContractAdmin(cacheCtx, contractAddr, senderAddr, newAdminAddr, policy); err != nil {
+			return nil, errorsmod.Wrapf(err, "contract %s", c)
+		}
+		rsp.Updated = append(rsp.Updated, c)
+	}
+	commit()
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateContractAdmins,
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+		sdk.NewAttribute(types.AttributeKeyNewAdmin, msg.NewAdmin),
+		sdk.NewAttribute(types.AttributeKeyUpdatedCount, strconv.Itoa(len(rsp.Updated))),
+		sdk.NewAttribute(types.AttributeKeySkippedCount, strconv.Itoa(len(rsp.Skipped))),
+	))
+
+	return &rsp, nil
+}
+
 func (m msgServer) UpdateInstantiateConfig(ctx context.Context, msg *types.MsgUpdateInstantiateConfig) (*types.MsgUpdateInstantiateConfigResponse, error) {
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
@@ -226,6 +494,31 @@ func (m msgServer) UpdateInstantiateConfig(ctx context.Context, msg *types.MsgUp
 	return &types.MsgUpdateInstantiateConfigResponse{}, nil
 }
 
+// UpdateInstantiateConfigs updates the instantiate config of multiple codes in order. The updates share one
+// cache context that is only committed when the sender is allowed to modify all of them.
+func (m msgServer) UpdateInstantiateConfigs(goCtx context.Context, msg *types.MsgUpdateInstantiateConfigs) (*types.MsgUpdateInstantiateConfigsResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	cacheCtx, commit := ctx.CacheContext()
+	for _, u := range msg.Updates {
+		if err := m.keeper.setAccessConfig(cacheCtx, u.CodeID, senderAddr, *u.NewInstantiatePermission, policy); err != nil {
+			return nil, errorsmod.Wrapf(err, "code %d", u.CodeID)
+		}
+	}
+	commit()
+
+	return &types.MsgUpdateInstantiateConfigsResponse{}, nil
+}
+
 // UpdateParams updates the module parameters
 func (m msgServer) UpdateParams(ctx context.Context, req *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
 	if err := req.ValidateBasic(); err != nil {
@@ -236,9 +529,13 @@ func (m msgServer) UpdateParams(ctx context.Context, req *types.MsgUpdateParams)
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
 	}
 
+	oldParams := m.keeper.GetParams(ctx)
 	if err := m.keeper.SetParams(ctx, req.Params); err != nil {
 		return nil, err
 	}
+	if err := m.keeper.applyAutoPinCodeHashes(ctx, oldParams.AutoPinCodeHashes, req.Params.AutoPinCodeHashes); err != nil {
+		return nil, errorsmod.Wrap(err, "auto pin code hashes")
+	}
 
 	return &types.MsgUpdateParamsResponse{}, nil
 }
@@ -288,6 +585,9 @@ func (m msgServer) SudoContract(ctx context.Context, req *types.MsgSudoContract)
 	if err := req.ValidateBasic(); err != nil {
 		return nil, err
 	}
+	if err := m.validateContractMsgSize(ctx, req.Msg, nil); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
 	authority := m.keeper.GetAuthority()
 	if authority != req.Authority {
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
@@ -300,7 +600,7 @@ func (m msgServer) SudoContract(ctx context.Context, req *types.MsgSudoContract)
 
 	data, err := m.keeper.Sudo(ctx, contractAddr, req.Msg)
 	if err != nil {
-		return nil, err
+		return nil, toSDKError(err)
 	}
 
 	return &types.MsgSudoContractResponse{Data: data}, nil
@@ -311,6 +611,9 @@ func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, req *types
 	if err := req.ValidateBasic(); err != nil {
 		return nil, err
 	}
+	if err := m.validateContractMsgSize(goCtx, req.Msg, req.Funds); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
 
 	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
 	if err != nil {
@@ -419,6 +722,51 @@ func (m msgServer) selectAuthorizationPolicy(ctx context.Context, actor string)
 	return DefaultAuthorizationPolicy{}
 }
 
+// checkDowngrade returns an error when the contract is migrated to a code id lower than its current one and the
+// downgrade is not allowed explicitly. Migrating to an older code is likely a mistake.
+func (m msgServer) checkDowngrade(ctx context.Context, contractAddr sdk.AccAddress, newCodeID uint64, allowDowngrade bool) error {
+	if info := m.keeper.GetContractInfo(ctx, contractAddr); info != nil && newCodeID < info.CodeID && !allowDowngrade {
+		return errorsmod.Wrapf(types.ErrInvalid, "code id %d is lower than current code id %d, downgrade not allowed", newCodeID, info.CodeID)
+	}
+	return nil
+}
+
+// idempotentInstance returns the contract at the predicted address when it was instantiated by the sender
+// with the same code id
+func (m msgServer) idempotentInstance(ctx context.Context, senderAddr sdk.AccAddress, msg *types.MsgInstantiateContract2) (sdk.AccAddress, bool) {
+	codeInfo := m.keeper.GetCodeInfo(ctx, msg.CodeID)
+	if codeInfo == nil {
+		return nil, false
+	}
+	contractAddr := m.keeper.PredictableAddressGenerator(senderAddr, msg.Salt, msg.Msg, msg.FixMsg)(ctx, msg.CodeID, codeInfo.CodeHash)
+	contractInfo := m.keeper.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil || contractInfo.CodeID != msg.CodeID || contractInfo.Creator != senderAddr.String() {
+		return nil, false
+	}
+	return contractAddr, true
+}
+
+// toSDKError maps the errors of a contract call to sdk errors. A call to an address without a contract fails with
+// the not found error of the sdk. Out of gas errors are sdk errors already and the contract execution errors keep
+// the ABCI code of the failed entry point.
+func toSDKError(err error) error {
+	if errors.Is(err, types.ErrContractNotFound) {
+		return errorsmod.Wrap(sdkerrors.ErrNotFound, err.Error())
+	}
+	return err
+}
+
+// validateContractMsgSize ensures the payload msg plus the encoded funds are within the max execute msg size param,
+// which applies to the payload msgs of all contract messages.
+func (m msgServer) validateContractMsgSize(ctx context.Context, msg types.RawContractMessage, funds sdk.Coins) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	maxSize := m.keeper.GetCachedParams(sdkCtx).MaxExecuteMsgSize
+	if maxSize == 0 {
+		return nil
+	}
+	return types.ValidateContractMsgSize(msg, funds, maxSize)
+}
+
 // StoreAndMigrateContract stores and migrates the contract.
 func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.MsgStoreAndMigrateContract) (*types.MsgStoreAndMigrateContractResponse, error) {
 	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
@@ -429,6 +777,9 @@ func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.Msg
 	if err = req.ValidateBasic(); err != nil {
 		return nil, err
 	}
+	if err := m.validateContractMsgSize(goCtx, req.Msg, nil); err != nil {
+		return nil, errorsmod.Wrap(err, "payload msg")
+	}
 
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
@@ -443,7 +794,7 @@ func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.Msg
 		return nil, errorsmod.Wrap(err, "contract")
 	}
 
-	data, err := m.keeper.migrate(ctx, contractAddr, authorityAddr, codeID, req.Msg, policy)
+	data, err := m.keeper.migrate(ctx, contractAddr, authorityAddr, codeID, req.Msg, nil, policy)
 	if err != nil {
 		return nil, err
 	}
@@ -477,3 +828,256 @@ func (m msgServer) UpdateContractLabel(ctx context.Context, msg *types.MsgUpdate
 
 	return &types.MsgUpdateContractLabelResponse{}, nil
 }
+
+// SetContractGasMultiplier overrides the gas multiplier of a contract.
+func (m msgServer) SetContractGasMultiplier(ctx context.Context, req *types.MsgSetContractGasMultiplier) (*types.MsgSetContractGasMultiplierResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.SetContractGasMultiplier(ctx, contractAddr, req.Multiplier); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractGasMultiplierResponse{}, nil
+}
+
+// RegisterBlockSudoHook registers a contract to be sudo called each block
+func (m msgServer) RegisterBlockSudoHook(ctx context.Context, req *types.MsgRegisterBlockSudoHook) (*types.MsgRegisterBlockSudoHookResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.RegisterBlockSudoHook(ctx, req.Phase, contractAddr, req.Msg); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRegisterBlockSudoHookResponse{}, nil
+}
+
+// RemoveBlockSudoHook removes a registered block sudo hook
+func (m msgServer) RemoveBlockSudoHook(ctx context.Context, req *types.MsgRemoveBlockSudoHook) (*types.MsgRemoveBlockSudoHookResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.RemoveBlockSudoHook(ctx, req.Phase, contractAddr); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRemoveBlockSudoHookResponse{}, nil
+}
+
+// RestoreContractState replaces the contract state with the state backup of a migration checkpoint
+func (m msgServer) RestoreContractState(ctx context.Context, req *types.MsgRestoreContractState) (*types.MsgRestoreContractStateResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.RestoreContractState(ctx, contractAddr, req.CheckpointID); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgRestoreContractStateResponse{}, nil
+}
+
+// UpdateStargateAllowlist adds and removes the stargate query paths that contracts are allowed to query
+func (m msgServer) UpdateStargateAllowlist(ctx context.Context, req *types.MsgUpdateStargateAllowlist) (*types.MsgUpdateStargateAllowlistResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	if err := m.keeper.UpdateStargateAllowlist(ctx, req.Add, req.Remove); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateStargateAllowlistResponse{}, nil
+}
+
+// SetContractStorageQuota limits the total size of the state stored by a contract.
+func (m msgServer) SetContractStorageQuota(ctx context.Context, req *types.MsgSetContractStorageQuota) (*types.MsgSetContractStorageQuotaResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.SetContractStorageQuota(ctx, contractAddr, req.MaxBytes); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractStorageQuotaResponse{}, nil
+}
+
+// PruneUnusedCodes deletes the codes without contract instances.
+func (m msgServer) PruneUnusedCodes(ctx context.Context, req *types.MsgPruneUnusedCodes) (*types.MsgPruneUnusedCodesResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	codeIDs, err := m.keeper.PruneUnusedCodes(ctx, req.OlderThanHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgPruneUnusedCodesResponse{CodeIDs: codeIDs}, nil
+}
+
+// ReplaceContractState replaces or merges the contract state with the given models
+func (m msgServer) ReplaceContractState(ctx context.Context, req *types.MsgReplaceContractState) (*types.MsgReplaceContractStateResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.ImportContractState(ctx, contractAddr, req.Models, req.Mode); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgReplaceContractStateResponse{}, nil
+}
+
+// SetContractLock locks or unlocks a contract
+func (m msgServer) SetContractLock(ctx context.Context, req *types.MsgSetContractLock) (*types.MsgSetContractLockResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.SetContractLock(ctx, contractAddr, req.Locked); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractLockResponse{}, nil
+}
+
+// UpdateInstantiateDefaultPermission updates the instantiate default permission param only
+func (m msgServer) UpdateInstantiateDefaultPermission(goCtx context.Context, req *types.MsgUpdateInstantiateDefaultPermission) (*types.MsgUpdateInstantiateDefaultPermissionResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := m.keeper.GetParams(ctx)
+	params.InstantiateDefaultPermission = req.Permission
+	if err := m.keeper.SetParams(ctx, params); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateDefaultAccess,
+		sdk.NewAttribute(types.AttributeKeyCodePermission, req.Permission.String()),
+	))
+
+	return &types.MsgUpdateInstantiateDefaultPermissionResponse{}, nil
+}
+
+// ForfeitCodeDeposit burns the storage deposit of a code
+func (m msgServer) ForfeitCodeDeposit(ctx context.Context, req *types.MsgForfeitCodeDeposit) (*types.MsgForfeitCodeDepositResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	if err := m.keeper.ForfeitCodeDeposit(ctx, req.CodeID); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgForfeitCodeDepositResponse{}, nil
+}
+
+// PruneContractDependencies deletes the recorded code dependencies of a contract
+func (m msgServer) PruneContractDependencies(ctx context.Context, req *types.MsgPruneContractDependencies) (*types.MsgPruneContractDependenciesResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.PruneContractDependencies(ctx, contractAddr); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgPruneContractDependenciesResponse{}, nil
+}
@@ -3,16 +3,21 @@ package keeper
 import (
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
 	"cosmossdk.io/store"
 	storemetrics "cosmossdk.io/store/metrics"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -57,3 +62,60 @@ func TestSelectAuthorizationPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgServerContractCallErrors(t *testing.T) {
+	var executeErr error
+	var executeResult *wasmvmtypes.ContractResult
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return executeResult, 0, executeErr
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	specs := map[string]struct {
+		contract  sdk.AccAddress
+		result    *wasmvmtypes.ContractResult
+		vmErr     error
+		expErr    error
+		expKeeper error
+	}{
+		"contract not found": {
+			contract:  RandomAccountAddress(t),
+			expErr:    sdkerrors.ErrNotFound,
+			expKeeper: types.ErrContractNotFound,
+		},
+		"out of gas": {
+			contract:  example.Contract,
+			vmErr:     wasmvmtypes.OutOfGasError{},
+			expErr:    types.ErrOutOfGas,
+			expKeeper: types.ErrOutOfGas,
+		},
+		"contract error": {
+			contract:  example.Contract,
+			result:    &wasmvmtypes.ContractResult{Err: "my error"},
+			expErr:    types.ErrExecuteFailed,
+			expKeeper: types.ErrExecuteFailed,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			executeResult, executeErr = spec.result, spec.vmErr
+			msg := &types.MsgExecuteContract{
+				Sender:   example.CreatorAddr.String(),
+				Contract: spec.contract.String(),
+				Msg:      []byte(`{}`),
+			}
+
+			// when
+			_, gotErr := NewMsgServerImpl(keepers.WasmKeeper).ExecuteContract(ctx, msg)
+			_, gotKeeperErr := keepers.ContractKeeper.Execute(ctx, spec.contract, example.CreatorAddr, msg.Msg, nil)
+
+			// then
+			require.ErrorIs(t, gotErr, spec.expErr)
+			require.ErrorIs(t, gotKeeperErr, spec.expKeeper)
+		})
+	}
+}
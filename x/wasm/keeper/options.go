@@ -3,9 +3,11 @@ package keeper
 import (
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -83,6 +85,163 @@ func WithQueryPlugins(x *QueryPlugins) Option {
 	})
 }
 
+// WithStargateAllowlistQuerier is an optional constructor parameter to accept the stargate queries in the allowlist
+// that is maintained by governance. The accept list registers the protobuf response types of the queries that
+// can be allowed.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithStargateAllowlistQuerier(acceptList AcceptedQueries, queryRouter GRPCQueryRouter, codec codec.Codec) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Stargate: AllowlistStargateQuerier(acceptList, k, queryRouter, codec)})
+	})
+}
+
+// WithHistoricalQueries is an optional constructor parameter to let contracts run bank and staking queries at a
+// past block height with the QueryAtHeight custom query. The source is the app's CommitMultiStore.
+// The available heights depend on the pruning settings of the node, so the query is only supported in smart queries
+// and rejected within transactions. The max depth is set by the historical query depth param. Other custom queries are passed to the custom querier
+// set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithHistoricalQueries(source HistoricalStateSource) Option {
+	if source == nil {
+		panic("source must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: HistoricalQuerier(source, k, q.Bank, q.Staking, q.Custom)})
+	})
+}
+
+// WithAuthQueries is an optional constructor parameter to let contracts read the account number, sequence and public
+// key of accounts with the AuthQuery custom query. The AuthQueryCapability is added to the available capabilities.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithAuthQueries() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: AuthQuerier(k.accountKeeper, k, q.Custom)})
+		if !slices.Contains(k.availableCapabilities, AuthQueryCapability) {
+			k.availableCapabilities = append(slices.Clone(k.availableCapabilities), AuthQueryCapability)
+		}
+	})
+}
+
+// WithGasPriceQueries is an optional constructor parameter to let contracts read the min gas prices with the
+// GasPriceQuery custom query. The GasPriceQueryCapability is added to the available capabilities.
+// The source provides consensus min gas prices and can be nil. See GasPriceQuerier for the determinism constraints.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithGasPriceQueries(source GasPriceSource) Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: GasPriceQuerier(source, k, q.Custom)})
+		if !slices.Contains(k.availableCapabilities, GasPriceQueryCapability) {
+			k.availableCapabilities = append(slices.Clone(k.availableCapabilities), GasPriceQueryCapability)
+		}
+	})
+}
+
+// WithBondedValidatorsQueries is an optional constructor parameter to let contracts read pages of the active validator
+// set with the bonded tokens and voting power with the BondedValidatorsQuery custom query. The source is usually the
+// staking keeper. The BondedValidatorsQueryCapability is added to the available capabilities.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithBondedValidatorsQueries(source BondedValidatorsSource) Option {
+	if source == nil {
+		panic("source must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: BondedValidatorsQuerier(source, k, q.Custom)})
+		if !slices.Contains(k.availableCapabilities, BondedValidatorsQueryCapability) {
+			k.availableCapabilities = append(slices.Clone(k.availableCapabilities), BondedValidatorsQueryCapability)
+		}
+	})
+}
+
+// WithContractMetadataQueries is an optional constructor parameter to let contracts read the code id, creator, admin,
+// label and pinned status of other contracts with the ContractMetadataQuery custom query.
+// The ContractMetadataQueryCapability is added to the available capabilities.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithContractMetadataQueries() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: ContractMetadataQuerier(k, k, q.Custom)})
+		if !slices.Contains(k.availableCapabilities, ContractMetadataQueryCapability) {
+			k.availableCapabilities = append(slices.Clone(k.availableCapabilities), ContractMetadataQueryCapability)
+		}
+	})
+}
+
+// WithBlockBeaconQueries is an optional constructor parameter to let contracts read a deterministic per block value
+// with the BlockBeaconQuery custom query. The value is not unpredictable, see BlockBeaconQuery.
+// The BlockBeaconQueryCapability is added to the available capabilities.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithBlockBeaconQueries() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: BlockBeaconQuerier(k, q.Custom)})
+		if !slices.Contains(k.availableCapabilities, BlockBeaconQueryCapability) {
+			k.availableCapabilities = append(slices.Clone(k.availableCapabilities), BlockBeaconQueryCapability)
+		}
+	})
+}
+
+// WithSubAccountQueries is an optional constructor parameter to let contracts derive the deterministic sub-account
+// addresses of a contract with the SubAccountQuery custom query. See Keeper.DeriveSubAccount.
+// The SubAccountQueryCapability is added to the available capabilities.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithSubAccountQueries() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: SubAccountQuerier(k, k, q.Custom)})
+		if !slices.Contains(k.availableCapabilities, SubAccountQueryCapability) {
+			k.availableCapabilities = append(slices.Clone(k.availableCapabilities), SubAccountQueryCapability)
+		}
+	})
+}
+
+// WithTxMemoQueries is an optional constructor parameter to let contracts read the memo of the current transaction
+// with the tx memo custom query when the forward tx memo param is enabled. The memo is set by the TxMemoDecorator.
+// Other custom queries are passed to the custom querier set before, so this option should be applied after `WithQueryPlugins`.
+// This option expects the default `QueryHandler` set and should not be combined with Option `WithQueryHandler` or `WithQueryHandlerDecorator`.
+func WithTxMemoQueries() Option {
+	return optsFn(func(k *Keeper) {
+		q, ok := k.wasmVMQueryHandler.(QueryPlugins)
+		if !ok {
+			panic(fmt.Sprintf("Unsupported query handler type: %T", k.wasmVMQueryHandler))
+		}
+		k.wasmVMQueryHandler = q.Merge(&QueryPlugins{Custom: TxMemoQuerier(k, q.Custom)})
+	})
+}
+
 // WithMessageEncoders is an optional constructor parameter to pass custom message encoder to the default wasm message handler.
 // This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
 func WithMessageEncoders(x *MessageEncoders) Option {
@@ -91,16 +250,20 @@ func WithMessageEncoders(x *MessageEncoders) Option {
 		if !ok {
 			panic(fmt.Sprintf("Unsupported message handler type: %T", k.messenger))
 		}
-		s, ok := q.handlers[0].(SDKMessageHandler)
-		if !ok {
-			panic(fmt.Sprintf("Unexpected message handler type: %T", q.handlers[0]))
-		}
-		e, ok := s.encoders.(MessageEncoders)
-		if !ok {
-			panic(fmt.Sprintf("Unsupported encoder type: %T", s.encoders))
+		for i, h := range q.handlers {
+			s, ok := h.(SDKMessageHandler)
+			if !ok {
+				continue
+			}
+			e, ok := s.encoders.(MessageEncoders)
+			if !ok {
+				panic(fmt.Sprintf("Unsupported encoder type: %T", s.encoders))
+			}
+			s.encoders = e.Merge(x)
+			q.handlers[i] = s
+			return
 		}
-		s.encoders = e.Merge(x)
-		q.handlers[0] = s
+		panic("no sdk message handler found")
 	})
 }
 
@@ -125,6 +288,32 @@ func WithAccountPruner(x AccountPruner) Option {
 	})
 }
 
+// WithInstantiateListeners is an optional constructor parameter to register listeners that are notified
+// about every successful contract instantiation in the order given.
+func WithInstantiateListeners(listeners ...ContractInstantiateListener) Option {
+	for _, l := range listeners {
+		if l == nil {
+			panic("must not be nil")
+		}
+	}
+	return optsFn(func(k *Keeper) {
+		k.instantiateListeners = append(k.instantiateListeners, listeners...)
+	})
+}
+
+// WithOutOfGasListeners is an optional constructor parameter to register listeners that are notified
+// when a contract call runs out of gas, in the order given.
+func WithOutOfGasListeners(listeners ...OutOfGasListener) Option {
+	for _, l := range listeners {
+		if l == nil {
+			panic("must not be nil")
+		}
+	}
+	return optsFn(func(k *Keeper) {
+		k.outOfGasListeners = append(k.outOfGasListeners, listeners...)
+	})
+}
+
 func WithVMCacheMetrics(r prometheus.Registerer) Option {
 	return postOptsFn(func(k *Keeper) {
 		NewWasmVMMetricsCollector(k.wasmVM).Register(r)
@@ -143,6 +332,17 @@ func WithGasRegister(x types.GasRegister) Option {
 	})
 }
 
+// WithContractAddrGenerator sets a custom generator for the addresses of new contract instances.
+// The generator must be deterministic, see ContractAddrGenerator.
+func WithContractAddrGenerator(x ContractAddrGenerator) Option {
+	if x == nil {
+		panic("must not be nil")
+	}
+	return optsFn(func(k *Keeper) {
+		k.contractAddrGenerator = x
+	})
+}
+
 // WithAPICosts sets custom api costs. Amounts are in cosmwasm gas Not SDK gas.
 func WithAPICosts(human, canonical uint64) Option {
 	return optsFn(func(_ *Keeper) {
@@ -151,8 +351,14 @@ func WithAPICosts(human, canonical uint64) Option {
 	})
 }
 
-// WithMaxQueryStackSize overwrites the default limit for maximum query stacks
+// WithMaxQueryStackSize overwrites the default limit for maximum query stacks.
+// The limit is checked for every smart query in addition to the MaxQueryRecursionDepth param, so chains with
+// deeply composed contracts can increase both deliberately. Exceeding it fails with ErrExceedMaxQueryStackSize.
+// The limit must be greater than 0.
 func WithMaxQueryStackSize(m uint32) Option {
+	if m == 0 {
+		panic("max query stack size must be greater than 0")
+	}
 	return optsFn(func(k *Keeper) {
 		k.maxQueryStackSize = m
 	})
@@ -164,6 +370,13 @@ func WithMaxCallDepth(m uint32) Option {
 	})
 }
 
+// WithBlockSudoGasLimit sets the max gas a single contract can consume when sudo called in BeginBlock or EndBlock.
+func WithBlockSudoGasLimit(limit uint64) Option {
+	return optsFn(func(k *Keeper) {
+		k.blockSudoGasLimit = limit
+	})
+}
+
 // WithAcceptedAccountTypesOnContractInstantiation sets the accepted account types. Account types of this list won't be overwritten or cause a failure
 // when they exist for an address on contract instantiation.
 //
@@ -24,6 +24,7 @@ import (
 
 func TestConstructorOptions(t *testing.T) {
 	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	tStoreKey := storetypes.NewTransientStoreKey(types.TStoreKey)
 	codec := MakeEncodingConfig(t).Codec
 
 	specs := map[string]struct {
@@ -148,6 +149,60 @@ func TestConstructorOptions(t *testing.T) {
 				assert.Equal(t, exp, k.propagateGovAuthorization)
 			},
 		},
+		"auth queries": {
+			srcOpt: WithAuthQueries(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				assert.Contains(t, k.availableCapabilities, AuthQueryCapability)
+				assert.NotContains(t, AvailableCapabilities, AuthQueryCapability)
+			},
+		},
+		"gas price queries": {
+			srcOpt: WithGasPriceQueries(nil),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				assert.Contains(t, k.availableCapabilities, GasPriceQueryCapability)
+				assert.NotContains(t, AvailableCapabilities, GasPriceQueryCapability)
+			},
+		},
+		"contract metadata queries": {
+			srcOpt: WithContractMetadataQueries(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				assert.Contains(t, k.availableCapabilities, ContractMetadataQueryCapability)
+				assert.NotContains(t, AvailableCapabilities, ContractMetadataQueryCapability)
+			},
+		},
+		"bonded validators queries": {
+			srcOpt: WithBondedValidatorsQueries(&stakingkeeper.Keeper{}),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				assert.Contains(t, k.availableCapabilities, BondedValidatorsQueryCapability)
+				assert.NotContains(t, AvailableCapabilities, BondedValidatorsQueryCapability)
+			},
+		},
+		"block beacon queries": {
+			srcOpt: WithBlockBeaconQueries(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				assert.Contains(t, k.availableCapabilities, BlockBeaconQueryCapability)
+				assert.NotContains(t, AvailableCapabilities, BlockBeaconQueryCapability)
+			},
+		},
+		"sub-account queries": {
+			srcOpt: WithSubAccountQueries(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+				assert.Contains(t, k.availableCapabilities, SubAccountQueryCapability)
+				assert.NotContains(t, AvailableCapabilities, SubAccountQueryCapability)
+			},
+		},
+		"tx memo queries": {
+			srcOpt: WithTxMemoQueries(),
+			verify: func(t *testing.T, k Keeper) {
+				assert.IsType(t, QueryPlugins{}, k.wasmVMQueryHandler)
+			},
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -155,7 +210,7 @@ func TestConstructorOptions(t *testing.T) {
 			opt := spec.srcOpt
 			_, gotPostOptMarker := opt.(postOptsFn)
 			require.Equal(t, spec.isPostOpt, gotPostOptMarker)
-			k := NewKeeper(codec, runtime.NewKVStoreService(storeKey), authkeeper.AccountKeeper{}, &bankkeeper.BaseKeeper{}, stakingkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, nil, tempDir, types.DefaultNodeConfig(), types.VMConfig{}, AvailableCapabilities, "", nil, spec.srcOpt)
+			k := NewKeeper(codec, runtime.NewKVStoreService(storeKey), runtime.NewTransientStoreService(tStoreKey), authkeeper.AccountKeeper{}, &bankkeeper.BaseKeeper{}, stakingkeeper.Keeper{}, nil, nil, nil, nil, nil, nil, nil, tempDir, types.DefaultNodeConfig(), types.VMConfig{}, AvailableCapabilities, "", nil, spec.srcOpt)
 			spec.verify(t, k)
 		})
 	}
@@ -194,3 +249,7 @@ func TestSplitOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestWithMaxQueryStackSizeRejectsZero(t *testing.T) {
+	assert.Panics(t, func() { WithMaxQueryStackSize(0) })
+}
@@ -0,0 +1,192 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetCodeStoredHeight returns the block height at which the code was stored.
+// 0 is returned for codes stored before the height was recorded or imported from genesis.
+func (k Keeper) GetCodeStoredHeight(ctx context.Context, codeID uint64) uint64 {
+	return k.getUint64(ctx, types.GetCodeStoredHeightKey(codeID))
+}
+
+func (k Keeper) setCodeStoredHeight(ctx context.Context, codeID, height uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeStoredHeightKey(codeID), sdk.Uint64ToBigEndian(height))
+}
+
+// IsUnusedCode returns true when no contract instance runs the code and, with an olderThanHeight other than 0,
+// the code was stored before this height.
+func (k Keeper) IsUnusedCode(ctx context.Context, codeID, olderThanHeight uint64) bool {
+	if k.GetContractCountByCode(ctx, codeID) != 0 {
+		return false
+	}
+	return olderThanHeight == 0 || k.GetCodeStoredHeight(ctx, codeID) < olderThanHeight
+}
+
+// MaxPrunedCodesPerBlock is the max number of codes that are deleted in a block. The remaining unused codes
+// are deleted by later prune messages.
+const MaxPrunedCodesPerBlock = 100
+
+// PruneUnusedCodes deletes the codes without contract instances, optionally restricted to codes stored
+// before olderThanHeight. The bytecode is unpinned and removed from the wasmvm at the end of the block, when
+// no other code with the same checksum exists, so that a failed tx can not leave the node without the bytecode.
+// Not more than MaxPrunedCodesPerBlock codes are deleted in a block. The ids of the deleted codes are returned.
+func (k Keeper) PruneUnusedCodes(ctx context.Context, olderThanHeight uint64) ([]uint64, error) {
+	pruned := k.getBlockPrunedCodesCount(ctx)
+	var codeIDs []uint64
+	k.IterateCodeInfos(ctx, func(codeID uint64, _ types.CodeInfo) bool {
+		if pruned+uint32(len(codeIDs)) >= MaxPrunedCodesPerBlock {
+			return true
+		}
+		if k.IsUnusedCode(ctx, codeID, olderThanHeight) {
+			codeIDs = append(codeIDs, codeID)
+		}
+		return false
+	})
+	for _, codeID := range codeIDs {
+		if err := k.deleteCode(ctx, codeID); err != nil {
+			return nil, err
+		}
+	}
+	if err := k.transientStoreService.OpenTransientStore(ctx).Set(
+		types.GetBlockPrunedCodesCounterKey(sdk.UnwrapSDKContext(ctx).BlockHeight()),
+		binary.BigEndian.AppendUint32(nil, pruned+uint32(len(codeIDs))),
+	); err != nil {
+		return nil, err
+	}
+	return codeIDs, nil
+}
+
+// getBlockPrunedCodesCount returns the number of codes deleted in the current block
+func (k Keeper) getBlockPrunedCodesCount(ctx context.Context) uint32 {
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(
+		types.GetBlockPrunedCodesCounterKey(sdk.UnwrapSDKContext(ctx).BlockHeight()),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(bz)
+}
+
+// deleteCode removes the code info and all indexes of the code. It must only be called for codes without
+// contract instances.
+func (k Keeper) deleteCode(ctx context.Context, codeID uint64) error {
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	if err := k.refundCodeDeposit(ctx, codeID); err != nil {
+		return err
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	if k.IsPinnedCode(ctx, codeID) {
+		if err := store.Delete(types.GetPinnedCodeIndexPrefix(codeID)); err != nil {
+			return err
+		}
+		if err := k.decrementModuleStat(ctx, types.KeyStatsPinnedCodeCount); err != nil {
+			return err
+		}
+	}
+	for _, key := range [][]byte{
+		types.GetCodeKey(codeID),
+		types.GetCodeStoredHeightKey(codeID),
+		types.GetCodeSizeKey(codeID),
+		types.GetCodeCapabilitiesKey(codeID),
+		types.GetCodeEntrypointsKey(codeID),
+		types.GetContractCountByCodeIDKey(codeID),
+	} {
+		if err := store.Delete(key); err != nil {
+			return err
+		}
+	}
+	countStore := prefix.NewStore(runtime.KVStoreAdapter(store), types.GetInstantiateCountPrefix(codeID))
+	iter := countStore.Iterator(nil, nil)
+	var countKeys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		countKeys = append(countKeys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range countKeys {
+		countStore.Delete(key)
+	}
+	k.deleteCodeInstantiations(ctx, codeID)
+	if err := k.decrementModuleStat(ctx, types.KeyStatsCodeCount); err != nil {
+		return err
+	}
+	inUse, err := k.decrementChecksumCodeCount(ctx, codeInfo.CodeHash)
+	if err != nil {
+		return err
+	}
+	// store 1 byte to not run into `nil` debugging issues
+	if !inUse {
+		if err := store.Set(types.GetPendingCodeRemovalKey(codeInfo.CodeHash), []byte{1}); err != nil {
+			return err
+		}
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePruneCode,
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(codeInfo.CodeHash)),
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+	))
+	return nil
+}
+
+// removePendingCodes unpins and removes the bytecode of pruned codes from the wasmvm, unless the checksum is still
+// used by another code. Failures are logged only as the wasmvm files are not part of the consensus state.
+func (k Keeper) removePendingCodes(ctx sdk.Context) {
+	pendingStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.PendingCodeRemovalPrefix)
+	iter := pendingStore.Iterator(nil, nil)
+	var checksums [][]byte
+	for ; iter.Valid(); iter.Next() {
+		checksums = append(checksums, iter.Key())
+	}
+	iter.Close()
+	for _, checksum := range checksums {
+		pendingStore.Delete(checksum)
+		// a code with the checksum can be stored again after it was pruned in the same block
+		if k.getChecksumCodeCount(ctx, checksum) != 0 {
+			continue
+		}
+		if err := k.wasmVM.Unpin(checksum); err != nil {
+			k.Logger(ctx).Error("unpin pruned code", "checksum", hex.EncodeToString(checksum), "error", err)
+		}
+		if err := k.wasmVM.RemoveCode(checksum); err != nil {
+			k.Logger(ctx).Error("remove pruned code", "checksum", hex.EncodeToString(checksum), "error", err)
+		}
+	}
+}
+
+// getChecksumCodeCount returns the number of stored codes with the checksum
+func (k Keeper) getChecksumCodeCount(ctx context.Context, checksum []byte) uint64 {
+	return k.getUint64(ctx, types.GetChecksumCodeCountKey(checksum))
+}
+
+func (k Keeper) incrementChecksumCodeCount(ctx context.Context, checksum []byte) error {
+	count := k.getChecksumCodeCount(ctx, checksum)
+	return k.storeService.OpenKVStore(ctx).Set(types.GetChecksumCodeCountKey(checksum), sdk.Uint64ToBigEndian(count+1))
+}
+
+// decrementChecksumCodeCount decrements the number of codes with the checksum and returns true when
+// other codes with the checksum exist.
+func (k Keeper) decrementChecksumCodeCount(ctx context.Context, checksum []byte) (bool, error) {
+	store := k.storeService.OpenKVStore(ctx)
+	count := k.getChecksumCodeCount(ctx, checksum)
+	if count <= 1 {
+		return false, store.Delete(types.GetChecksumCodeCountKey(checksum))
+	}
+	return true, store.Set(types.GetChecksumCodeCountKey(checksum), sdk.Uint64ToBigEndian(count-1))
+}
@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestPruneUnusedCodes(t *testing.T) {
+	specs := map[string]struct {
+		olderThanHeight  uint64
+		expPruned        []uint64
+		expChecksumCount uint64
+	}{
+		"all unused codes": {
+			expPruned:        []uint64{2, 3, 4},
+			expChecksumCount: 1,
+		},
+		"codes stored before height": {
+			olderThanHeight:  20,
+			expPruned:        []uint64{2},
+			expChecksumCount: 2,
+		},
+		"codes stored before first unused code": {
+			olderThanHeight:  10,
+			expChecksumCount: 2,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			k := keepers.WasmKeeper
+			// code 1 with a contract instance
+			example := InstantiateHackatomExampleContract(t, parentCtx.WithBlockHeight(1), keepers)
+			// code 2 pinned
+			burner := StoreBurnerExampleContract(t, parentCtx.WithBlockHeight(10), keepers)
+			require.NoError(t, k.pinCode(parentCtx, burner.CodeID))
+			// code 3 with the same checksum as code 1
+			StoreHackatomExampleContract(t, parentCtx.WithBlockHeight(20), keepers)
+			// code 4
+			reflect := StoreReflectContract(t, parentCtx.WithBlockHeight(30), keepers)
+
+			ctx, _ := parentCtx.CacheContext()
+			ctx = ctx.WithEventManager(sdk.NewEventManager())
+			require.False(t, k.IsUnusedCode(ctx, example.CodeID, 0))
+
+			// when
+			gotPruned, gotErr := k.PruneUnusedCodes(ctx, spec.olderThanHeight)
+
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expPruned, gotPruned)
+			for _, codeID := range spec.expPruned {
+				assert.Nil(t, k.GetCodeInfo(ctx, codeID))
+				assert.Zero(t, k.GetCodeStoredHeight(ctx, codeID))
+				assert.False(t, k.IsPinnedCode(ctx, codeID))
+			}
+			assert.NotNil(t, k.GetCodeInfo(ctx, example.CodeID))
+			assert.Equal(t, spec.expChecksumCount, k.getChecksumCodeCount(ctx, example.Checksum))
+			assert.Len(t, ctx.EventManager().Events(), len(spec.expPruned))
+			stats, err := k.GetModuleStats(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(4-len(spec.expPruned)), stats.CodeCount)
+
+			// and the bytecode is removed at the end of the block when no other code uses the checksum
+			require.NoError(t, k.EndBlocker(ctx))
+			_, err = k.wasmVM.GetCode(example.Checksum)
+			assert.NoError(t, err)
+			pruned := make(map[uint64]bool)
+			for _, codeID := range spec.expPruned {
+				pruned[codeID] = true
+			}
+			for codeID, checksum := range map[uint64][]byte{burner.CodeID: burner.Checksum, reflect.CodeID: reflect.Checksum} {
+				_, err = k.wasmVM.GetCode(checksum)
+				if pruned[codeID] {
+					assert.Error(t, err, "code %d", codeID)
+				} else {
+					assert.NoError(t, err, "code %d", codeID)
+				}
+			}
+		})
+	}
+}
+
+func TestPruneUnusedCodesRollback(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, parentCtx.WithBlockHeight(1), keepers)
+
+	// when pruned in a tx that is not committed
+	ctx, _ := parentCtx.CacheContext()
+	gotPruned, err := k.PruneUnusedCodes(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{example.CodeID}, gotPruned)
+
+	// then the code is kept
+	require.NoError(t, k.EndBlocker(parentCtx))
+	assert.NotNil(t, k.GetCodeInfo(parentCtx, example.CodeID))
+	_, err = k.wasmVM.GetCode(example.Checksum)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), k.GetCodeStoredHeight(parentCtx, example.CodeID))
+	assert.True(t, k.IsUnusedCode(parentCtx, example.CodeID, 2))
+	assert.False(t, k.IsUnusedCode(parentCtx, example.CodeID, 1))
+}
+
+func TestPruneUnusedCodesBlockLimit(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	ctx := parentCtx.WithBlockHeight(2)
+	for i := 0; i < MaxPrunedCodesPerBlock+1; i++ {
+		StoreHackatomExampleContract(t, ctx, keepers)
+	}
+	checksum := k.GetCodeInfo(ctx, 1).CodeHash
+	require.Equal(t, uint64(MaxPrunedCodesPerBlock+1), k.getChecksumCodeCount(ctx, checksum))
+
+	// when
+	gotPruned, err := k.PruneUnusedCodes(ctx, 0)
+
+	// then not more than the max codes are deleted in the block
+	require.NoError(t, err)
+	assert.Len(t, gotPruned, MaxPrunedCodesPerBlock)
+	assert.Equal(t, uint64(1), k.getChecksumCodeCount(ctx, checksum))
+	gotPruned, err = k.PruneUnusedCodes(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, gotPruned)
+
+	// and the remaining code is deleted in the next block
+	gotPruned, err = k.PruneUnusedCodes(ctx.WithBlockHeight(3), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{MaxPrunedCodesPerBlock + 1}, gotPruned)
+	assert.Zero(t, k.getChecksumCodeCount(ctx, checksum))
+}
@@ -28,17 +28,37 @@ import (
 // DefaultGasCostBuildAddress is the SDK gas cost to build a contract address
 const DefaultGasCostBuildAddress = 10
 
+// DefaultGasCostAnalyzeCode is the SDK gas cost to analyze the capabilities of a code
+const DefaultGasCostAnalyzeCode = 10
+
 var _ types.QueryServer = &GrpcQuerier{}
 
+// grpcQueryKeeper is the subset of the keeper used by the gRPC queries
+type grpcQueryKeeper interface {
+	types.ViewKeeper
+	types.ContractSimulator
+	CanInstantiate(ctx context.Context, codeID uint64, actor sdk.AccAddress) bool
+	IsUnusedCode(ctx context.Context, codeID, olderThanHeight uint64) bool
+	GetModuleStats(ctx context.Context) (*types.QueryModuleStatsResponse, error)
+	GetContractFunds(ctx context.Context, contractAddr sdk.AccAddress) sdk.Coins
+	GetContractsByCodeAndLabel(ctx context.Context, codeID uint64, label string) []sdk.AccAddress
+	AnalyzeCodeCapabilities(ctx context.Context, codeID uint64) ([]types.CodeCapability, error)
+	CodeExports(ctx context.Context, codeID uint64) ([]string, error)
+	GetCodeSize(ctx context.Context, codeID uint64) uint64
+	ResolveAdminChain(ctx context.Context, contractAddr sdk.AccAddress, maxDepth uint32) ([]sdk.AccAddress, bool, error)
+	GetContractStateBytes(ctx context.Context, contractAddr sdk.AccAddress) uint64
+	PredictableAddressGenerator(creator sdk.AccAddress, salt, msg []byte, fixMsg bool) AddressGenerator
+}
+
 type GrpcQuerier struct {
 	cdc           codec.Codec
 	storeService  corestoretypes.KVStoreService
-	keeper        types.ViewKeeper
+	keeper        grpcQueryKeeper
 	queryGasLimit storetypes.Gas
 }
 
 // NewGrpcQuerier constructor
-func NewGrpcQuerier(cdc codec.Codec, storeService corestoretypes.KVStoreService, keeper types.ViewKeeper, queryGasLimit storetypes.Gas) *GrpcQuerier {
+func NewGrpcQuerier(cdc codec.Codec, storeService corestoretypes.KVStoreService, keeper grpcQueryKeeper, queryGasLimit storetypes.Gas) *GrpcQuerier {
 	return &GrpcQuerier{cdc: cdc, storeService: storeService, keeper: keeper, queryGasLimit: queryGasLimit}
 }
 
@@ -61,6 +81,50 @@ func (q GrpcQuerier) ContractInfo(c context.Context, req *types.QueryContractInf
 	return rsp, nil
 }
 
+// MaxContractInfoBatchSize is the max number of addresses of a ContractInfoBatch query
+const MaxContractInfoBatchSize = 100
+
+func (q GrpcQuerier) ContractInfoBatch(c context.Context, req *types.QueryContractInfoBatchRequest) (*types.QueryContractInfoBatchResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	switch n := len(req.Addresses); {
+	case n == 0:
+		return nil, errorsmod.Wrap(types.ErrEmpty, "addresses")
+	case n > MaxContractInfoBatchSize:
+		return nil, errorsmod.Wrapf(types.ErrLimit, "max %d addresses", MaxContractInfoBatchSize)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.ContractInfoBatchEntry, len(req.Addresses))
+	for i, a := range req.Addresses {
+		contractAddr, err := sdk.AccAddressFromBech32(a)
+		if err != nil {
+			return nil, errorsmod.Wrapf(err, "address %d", i)
+		}
+		r[i].Address = contractAddr.String()
+		if r[i].ContractInfo = q.keeper.GetContractInfo(ctx, contractAddr); r[i].ContractInfo == nil {
+			r[i].NotFound = true
+		}
+	}
+	return &types.QueryContractInfoBatchResponse{Contracts: r}, nil
+}
+
+// IsContract returns whether the address is a contract and the code id of the contract
+func (q GrpcQuerier) IsContract(c context.Context, req *types.QueryIsContractRequest) (*types.QueryIsContractResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	info := q.keeper.GetContractInfo(sdk.UnwrapSDKContext(c), addr)
+	if info == nil {
+		return &types.QueryIsContractResponse{}, nil
+	}
+	return &types.QueryIsContractResponse{IsContract: true, CodeID: info.CodeID}, nil
+}
+
 func (q GrpcQuerier) ContractHistory(c context.Context, req *types.QueryContractHistoryRequest) (*types.QueryContractHistoryResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -74,20 +138,7 @@ func (q GrpcQuerier) ContractHistory(c context.Context, req *types.QueryContract
 		return nil, err
 	}
 
-	ctx := sdk.UnwrapSDKContext(c)
-	r := make([]types.ContractCodeHistoryEntry, 0)
-
-	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractCodeHistoryElementPrefix(contractAddr))
-	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
-		if accumulate {
-			var e types.ContractCodeHistoryEntry
-			if err := q.cdc.Unmarshal(value, &e); err != nil {
-				return false, err
-			}
-			r = append(r, e)
-		}
-		return true, nil
-	})
+	r, pageRes, err := q.keeper.GetContractHistoryPaginated(sdk.UnwrapSDKContext(c), contractAddr, paginationParams)
 	if err != nil {
 		return nil, err
 	}
@@ -150,17 +201,12 @@ func (q GrpcQuerier) AllContractState(c context.Context, req *types.QueryAllCont
 			Wrapf("address %s", contractAddr.String())
 	}
 
-	r := make([]types.Model, 0)
-	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(contractAddr))
-	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
-		if accumulate {
-			r = append(r, types.Model{
-				Key:   key,
-				Value: value,
-			})
-		}
-		return true, nil
-	})
+	if req.Reverse {
+		reversed := *paginationParams
+		reversed.Reverse = true
+		paginationParams = &reversed
+	}
+	r, pageRes, err := q.keeper.QueryRawPrefix(ctx, contractAddr, req.KeyPrefix, paginationParams)
 	if err != nil {
 		return nil, err
 	}
@@ -275,11 +321,13 @@ func (q GrpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*ty
 			if err := q.cdc.Unmarshal(value, &c); err != nil {
 				return false, err
 			}
+			codeID := binary.BigEndian.Uint64(key)
 			r = append(r, types.CodeInfoResponse{
-				CodeID:                binary.BigEndian.Uint64(key),
+				CodeID:                codeID,
 				Creator:               c.Creator,
 				DataHash:              c.CodeHash,
 				InstantiatePermission: c.InstantiateConfig,
+				CodeSize:              q.keeper.GetCodeSize(ctx, codeID),
 			})
 		}
 		return true, nil
@@ -306,6 +354,433 @@ func (q GrpcQuerier) CodeInfo(c context.Context, req *types.QueryCodeInfoRequest
 		Creator:               info.Creator,
 		Checksum:              info.DataHash,
 		InstantiatePermission: info.InstantiatePermission,
+		CodeSize:              info.CodeSize,
+	}, nil
+}
+
+// CodeProvenance returns the reproducible build metadata for a code id
+func (q GrpcQuerier) CodeProvenance(c context.Context, req *types.QueryCodeProvenanceRequest) (*types.QueryCodeProvenanceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	source, builder, err := q.keeper.GetCodeProvenance(sdk.UnwrapSDKContext(c), req.CodeId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeProvenanceResponse{
+		Source:  source,
+		Builder: builder,
+	}, nil
+}
+
+func (q GrpcQuerier) ContractCountByCode(c context.Context, req *types.QueryContractCountByCodeRequest) (*types.QueryContractCountByCodeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if q.keeper.GetCodeInfo(ctx, req.CodeId) == nil {
+		return nil, types.ErrNoSuchCodeFn(req.CodeId).Wrapf("code id %d", req.CodeId)
+	}
+	return &types.QueryContractCountByCodeResponse{
+		Count: q.keeper.GetContractCountByCode(ctx, req.CodeId),
+	}, nil
+}
+
+func (q GrpcQuerier) BlockSudoHooks(c context.Context, req *types.QueryBlockSudoHooksRequest) (*types.QueryBlockSudoHooksResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	return &types.QueryBlockSudoHooksResponse{
+		BeginBlock: q.keeper.GetBlockSudoHooks(ctx, types.BlockSudoPhaseBeginBlock),
+		EndBlock:   q.keeper.GetBlockSudoHooks(ctx, types.BlockSudoPhaseEndBlock),
+	}, nil
+}
+
+func (q GrpcQuerier) MigrationCheckpoints(c context.Context, req *types.QueryMigrationCheckpointsRequest) (*types.QueryMigrationCheckpointsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.HasContractInfo(ctx, contractAddr) {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	return &types.QueryMigrationCheckpointsResponse{
+		Checkpoints: q.keeper.GetMigrationCheckpoints(ctx, contractAddr),
+	}, nil
+}
+
+func (q GrpcQuerier) ContractDependencies(c context.Context, req *types.QueryContractDependenciesRequest) (*types.QueryContractDependenciesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]uint64, 0)
+
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractDependencyPrefix(contractAddr))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			r = append(r, sdk.BigEndianToUint64(key))
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractDependenciesResponse{
+		CodeIDs:    r,
+		Pagination: pageRes,
+	}, nil
+}
+
+func (q GrpcQuerier) StargateAllowlist(c context.Context, req *types.QueryStargateAllowlistRequest) (*types.QueryStargateAllowlistResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	return &types.QueryStargateAllowlistResponse{
+		Paths: q.keeper.GetStargateAllowlist(ctx),
+	}, nil
+}
+
+func (q GrpcQuerier) ModuleStats(c context.Context, req *types.QueryModuleStatsRequest) (*types.QueryModuleStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	return q.keeper.GetModuleStats(sdk.UnwrapSDKContext(c))
+}
+
+func (q GrpcQuerier) CodeExports(c context.Context, req *types.QueryCodeExportsRequest) (*types.QueryCodeExportsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	entrypoints, err := q.keeper.CodeExports(sdk.UnwrapSDKContext(c), req.CodeId)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeExportsResponse{Entrypoints: entrypoints}, nil
+}
+
+// StateBytesByCode returns the total size of the state of a page of the contracts of a code.
+// The state of contracts without a storage quota is iterated, see Keeper.GetContractStateBytes.
+func (q GrpcQuerier) StateBytesByCode(c context.Context, req *types.QueryStateBytesByCodeRequest) (*types.QueryStateBytesByCodeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if q.keeper.GetCodeInfo(ctx, req.CodeId) == nil {
+		return nil, types.ErrNoSuchCodeFn(req.CodeId).Wrapf("code id %d", req.CodeId)
+	}
+
+	rsp := types.QueryStateBytesByCodeResponse{}
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractByCodeIDSecondaryIndexPrefix(req.CodeId))
+	rsp.Pagination, err = query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+			rsp.StateBytes += q.keeper.GetContractStateBytes(ctx, contractAddr)
+			rsp.Contracts++
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (q GrpcQuerier) ContractAdminChain(c context.Context, req *types.QueryContractAdminChainRequest) (*types.QueryContractAdminChainResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	maxDepth := req.MaxDepth
+	switch {
+	case maxDepth == 0:
+		maxDepth = MaxAdminChainDepth
+	case maxDepth > MaxAdminChainDepth:
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "max depth must not exceed %d", MaxAdminChainDepth)
+	}
+	chain, topIsAccount, err := q.keeper.ResolveAdminChain(sdk.UnwrapSDKContext(c), contractAddr, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	admins := make([]string, len(chain))
+	for i, a := range chain {
+		admins[i] = a.String()
+	}
+	return &types.QueryContractAdminChainResponse{Admins: admins, TopIsAccount: topIsAccount}, nil
+}
+
+func (q GrpcQuerier) AnalyzeCode(c context.Context, req *types.QueryAnalyzeCodeRequest) (*types.QueryAnalyzeCodeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	ctx.GasMeter().ConsumeGas(DefaultGasCostAnalyzeCode, "analyze code")
+	capabilities, err := q.keeper.AnalyzeCodeCapabilities(ctx, req.CodeId)
+	if err != nil {
+		return nil, err
+	}
+	rsp := &types.QueryAnalyzeCodeResponse{RequiredCapabilities: capabilities}
+	if req.CreatorAddress == "" && req.Salt == "" {
+		return rsp, nil
+	}
+	ctx.GasMeter().ConsumeGas(DefaultGasCostBuildAddress, "build address")
+	codeHash := q.keeper.GetCodeInfo(ctx, req.CodeId).CodeHash
+	_, creator, salt, initMsg, err := parseBuildAddressRequest(&types.QueryBuildAddressRequest{
+		CodeHash:       hex.EncodeToString(codeHash),
+		CreatorAddress: req.CreatorAddress,
+		Salt:           req.Salt,
+		InitArgs:       req.InitArgs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the init msg is empty when it is not fixed
+	rsp.PredictedAddress = q.keeper.PredictableAddressGenerator(creator, salt, initMsg, true)(ctx, req.CodeId, codeHash).String()
+	return rsp, nil
+}
+
+func (q GrpcQuerier) SimulateContractCall(c context.Context, req *types.QuerySimulateContractCallRequest) (rsp *types.QuerySimulateContractCallResponse, err error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if err := req.Msg.ValidateBasic(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid msg")
+	}
+	if !req.Funds.IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "invalid funds")
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	// limit the gas to the queryGasLimit or the remaining gas, whichever is smaller
+	ctx := sdk.UnwrapSDKContext(c)
+	gasLimit := min(ctx.GasMeter().GasRemaining(), q.queryGasLimit)
+	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit))
+	// recover from out-of-gas panic
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case storetypes.ErrorOutOfGas:
+				err = errorsmod.Wrapf(sdkerrors.ErrOutOfGas,
+					"out of gas in location: %v; gasWanted: %d, gasUsed: %d",
+					rType.Descriptor, ctx.GasMeter().Limit(), ctx.GasMeter().GasConsumed(),
+				)
+			default:
+				err = sdkerrors.ErrPanic
+			}
+			rsp = nil
+			moduleLogger(ctx).
+				Debug("simulate contract call",
+					"error", "recovering panic",
+					"contract-address", req.Contract,
+					"stacktrace", string(debug.Stack()))
+		}
+	}()
+
+	if req.IncludeBalances {
+		data, events, before, after, err := q.keeper.SimulateExecuteWithBalances(ctx, contractAddr, senderAddr, req.Msg, req.Funds)
+		if err != nil {
+			return nil, err
+		}
+		return &types.QuerySimulateContractCallResponse{
+			Data:          data,
+			Events:        events.ToABCIEvents(),
+			GasUsed:       ctx.GasMeter().GasConsumed(),
+			BalanceBefore: before,
+			BalanceAfter:  after,
+		}, nil
+	}
+	data, events, err := q.keeper.SimulateExecute(ctx, contractAddr, senderAddr, req.Msg, req.Funds)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QuerySimulateContractCallResponse{
+		Data:    data,
+		Events:  events.ToABCIEvents(),
+		GasUsed: ctx.GasMeter().GasConsumed(),
+	}, nil
+}
+
+func (q GrpcQuerier) CodeAccessConfig(c context.Context, req *types.QueryCodeAccessConfigRequest) (*types.QueryCodeAccessConfigResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	info := q.keeper.GetCodeInfo(sdk.UnwrapSDKContext(c), req.CodeId)
+	if info == nil {
+		return nil, types.ErrNoSuchCodeFn(req.CodeId).Wrapf("code id %d", req.CodeId)
+	}
+	return &types.QueryCodeAccessConfigResponse{
+		Permission: info.InstantiateConfig.Permission,
+		Addresses:  info.InstantiateConfig.Addresses,
+	}, nil
+}
+
+// UnusedCodes returns the codes without contract instances that would be deleted by pruning
+func (q GrpcQuerier) UnusedCodes(c context.Context, req *types.QueryUnusedCodesRequest) (*types.QueryUnusedCodesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]uint64, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.CodeKeyPrefix)
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		codeID := binary.BigEndian.Uint64(key)
+		if !q.keeper.IsUnusedCode(ctx, codeID, req.OlderThanHeight) {
+			return false, nil
+		}
+		if accumulate {
+			r = append(r, codeID)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryUnusedCodesResponse{
+		CodeIDs:    r,
+		Pagination: pageRes,
+	}, nil
+}
+
+func (q GrpcQuerier) TotalContractFunds(c context.Context, req *types.QueryTotalContractFundsRequest) (*types.QueryTotalContractFundsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	total := sdk.NewCoins()
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.ContractKeyPrefix)
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			total = total.Add(q.keeper.GetContractFunds(ctx, key)...)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryTotalContractFundsResponse{
+		Funds:      total,
+		Pagination: pageRes,
+	}, nil
+}
+
+func (q GrpcQuerier) ContractsByLabel(c context.Context, req *types.QueryContractsByLabelRequest) (*types.QueryContractsByLabelResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]string, 0)
+
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractLabelIndexPrefix(req.CodeId, req.Label))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			r = append(r, sdk.AccAddress(key).String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByLabelResponse{
+		Contracts:  r,
+		Pagination: pageRes,
+	}, nil
+}
+
+// CodeInstantiations returns the instantiation log of the code. The log is recorded when the
+// RecordCodeInstantiations param is set and rebuilt from the contract histories on genesis import.
+func (q GrpcQuerier) CodeInstantiations(c context.Context, req *types.QueryCodeInstantiationsRequest) (*types.QueryCodeInstantiationsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.CodeInstantiation, 0)
+
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetCodeInstantiationPrefix(req.CodeId))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			r = append(r, parseCodeInstantiation(key, value))
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeInstantiationsResponse{
+		Instantiations: r,
+		Pagination:     pageRes,
 	}, nil
 }
 
@@ -321,7 +796,7 @@ func queryContractInfo(ctx sdk.Context, addr sdk.AccAddress, keeper types.ViewKe
 	}, nil
 }
 
-func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.QueryCodeResponse, error) {
+func queryCode(ctx sdk.Context, codeID uint64, keeper grpcQueryKeeper) (*types.QueryCodeResponse, error) {
 	info := queryCodeInfo(ctx, codeID, keeper)
 	if info == nil {
 		// nil, nil leads to 404 in rest handler
@@ -332,11 +807,10 @@ func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "loading wasm code")
 	}
-
 	return &types.QueryCodeResponse{CodeInfoResponse: info, Data: code}, nil
 }
 
-func queryCodeInfo(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) *types.CodeInfoResponse {
+func queryCodeInfo(ctx sdk.Context, codeID uint64, keeper grpcQueryKeeper) *types.CodeInfoResponse {
 	if codeID == 0 {
 		return nil
 	}
@@ -349,6 +823,7 @@ func queryCodeInfo(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) *typ
 		Creator:               res.Creator,
 		DataHash:              res.CodeHash,
 		InstantiatePermission: res.InstantiateConfig,
+		CodeSize:              keeper.GetCodeSize(ctx, codeID),
 	}
 	return &info
 }
@@ -458,39 +933,53 @@ func (q GrpcQuerier) WasmLimitsConfig(c context.Context, req *types.QueryWasmLim
 func (q GrpcQuerier) BuildAddress(c context.Context, req *types.QueryBuildAddressRequest) (*types.QueryBuildAddressResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
 	defer ctx.GasMeter().ConsumeGas(DefaultGasCostBuildAddress, "build address")
-	return BuildAddressPredictable(req)
+	codeHash, creator, salt, initMsg, err := parseBuildAddressRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	// the init msg is empty when it is not fixed
+	addr := q.keeper.PredictableAddressGenerator(creator, salt, initMsg, true)(ctx, 0, codeHash)
+	return &types.QueryBuildAddressResponse{Address: addr.String()}, nil
 }
 
+// BuildAddressPredictable builds the instantiate2 address with the DefaultContractAddrGenerator.
+// Chains with a custom ContractAddrGenerator must use the BuildAddress query instead.
 func BuildAddressPredictable(req *types.QueryBuildAddressRequest) (*types.QueryBuildAddressResponse, error) {
+	codeHash, creator, salt, initMsg, err := parseBuildAddressRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryBuildAddressResponse{
+		Address: BuildContractAddressPredictable(codeHash, creator, salt, initMsg).String(),
+	}, nil
+}
+
+// parseBuildAddressRequest decodes and validates the request. The init msg is empty when no init args are set.
+func parseBuildAddressRequest(req *types.QueryBuildAddressRequest) (codeHash []byte, creator sdk.AccAddress, salt []byte, initMsg types.RawContractMessage, err error) {
 	if req == nil {
-		return nil, status.Error(codes.InvalidArgument, "empty request")
+		return nil, nil, nil, nil, status.Error(codes.InvalidArgument, "empty request")
 	}
-	codeHash, err := hex.DecodeString(req.CodeHash)
+	codeHash, err = hex.DecodeString(req.CodeHash)
 	if err != nil {
-		return nil, fmt.Errorf("invalid code hash: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("invalid code hash: %w", err)
 	}
-	creator, err := sdk.AccAddressFromBech32(req.CreatorAddress)
+	creator, err = sdk.AccAddressFromBech32(req.CreatorAddress)
 	if err != nil {
-		return nil, fmt.Errorf("invalid creator address: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("invalid creator address: %w", err)
 	}
-	salt, err := hex.DecodeString(req.Salt)
+	salt, err = hex.DecodeString(req.Salt)
 	if err != nil {
-		return nil, fmt.Errorf("invalid salt: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("invalid salt: %w", err)
 	}
 	if len(salt) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "empty salt")
+		return nil, nil, nil, nil, status.Error(codes.InvalidArgument, "empty salt")
 	}
-
 	if req.InitArgs == nil {
-		return &types.QueryBuildAddressResponse{
-			Address: BuildContractAddressPredictable(codeHash, creator, salt, []byte{}).String(),
-		}, nil
+		return codeHash, creator, salt, []byte{}, nil
 	}
-	initMsg := types.RawContractMessage(req.InitArgs)
+	initMsg = types.RawContractMessage(req.InitArgs)
 	if err := initMsg.ValidateBasic(); err != nil {
-		return nil, err
+		return nil, nil, nil, nil, err
 	}
-	return &types.QueryBuildAddressResponse{
-		Address: BuildContractAddressPredictable(codeHash, creator, salt, initMsg).String(),
-	}, nil
+	return codeHash, creator, salt, initMsg, nil
 }
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
@@ -24,6 +26,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -105,6 +108,40 @@ func TestQueryAllContractState(t *testing.T) {
 				{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
 			},
 		},
+		"with key prefix": {
+			srcQuery: &types.QueryAllContractStateRequest{
+				Address:   contractAddr.String(),
+				KeyPrefix: []byte("fo"),
+			},
+			expModelContains: []types.Model{
+				{Key: []byte("foo"), Value: []byte(`"bar"`)},
+			},
+			expModelContainsNot: []types.Model{
+				{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
+			},
+		},
+		"with key prefix and pagination next key": {
+			srcQuery: &types.QueryAllContractStateRequest{
+				Address:   contractAddr.String(),
+				KeyPrefix: []byte{0x0},
+				Pagination: &query.PageRequest{
+					Key: []byte{0x1},
+				},
+			},
+			expModelContains: []types.Model{
+				{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
+			},
+			expModelContainsNot: []types.Model{
+				{Key: []byte("foo"), Value: []byte(`"bar"`)},
+			},
+		},
+		"with unknown key prefix": {
+			srcQuery: &types.QueryAllContractStateRequest{
+				Address:   contractAddr.String(),
+				KeyPrefix: []byte("unknown"),
+			},
+			expModelContainsNot: contractModel,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -125,6 +162,74 @@ func TestQueryAllContractState(t *testing.T) {
 	}
 }
 
+func TestQueryAllContractStateOrder(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	var mock wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&mock)
+	contractAddr := SeedNewContractInstance(t, ctx, keepers, &mock).Contract
+	ascending := []types.Model{
+		{Key: []byte{0x0, 0x1}, Value: []byte(`1`)},
+		{Key: []byte{0x0, 0x2}, Value: []byte(`2`)},
+		{Key: []byte("a"), Value: []byte(`3`)},
+		{Key: []byte("ab"), Value: []byte(`4`)},
+		{Key: []byte("b"), Value: []byte(`5`)},
+		{Key: []byte{0xff}, Value: []byte(`6`)},
+	}
+	// import in random order
+	for _, i := range rand.Perm(len(ascending)) {
+		require.NoError(t, keeper.importContractState(ctx, contractAddr, []types.Model{ascending[i]}))
+	}
+	descending := slices.Clone(ascending)
+	slices.Reverse(descending)
+
+	// the keeper iterates in ascending key order
+	var got []types.Model
+	keeper.IterateContractState(ctx, contractAddr, func(key, value []byte) bool {
+		got = append(got, types.Model{Key: key, Value: value})
+		return false
+	})
+	assert.Equal(t, ascending, got)
+
+	specs := map[string]struct {
+		reverse           bool
+		paginationReverse bool
+		exp               []types.Model
+	}{
+		"ascending": {
+			exp: ascending,
+		},
+		"reverse": {
+			reverse: true,
+			exp:     descending,
+		},
+		"reverse with pagination": {
+			paginationReverse: true,
+			exp:               descending,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var got []types.Model
+			var nextKey []byte
+			// read in pages of 4 to ensure the order holds across pages
+			for {
+				rsp, err := Querier(keeper).AllContractState(ctx, &types.QueryAllContractStateRequest{
+					Address:    contractAddr.String(),
+					Pagination: &query.PageRequest{Key: nextKey, Limit: 4, Reverse: spec.paginationReverse},
+					Reverse:    spec.reverse,
+				})
+				require.NoError(t, err)
+				got = append(got, rsp.Models...)
+				if nextKey = rsp.Pagination.NextKey; nextKey == nil {
+					break
+				}
+			}
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
 func TestQuerySmartContractState(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -508,6 +613,31 @@ func TestQueryContractHistory(t *testing.T) {
 				Updated:   &types.AbsoluteTxPosition{BlockHeight: 1, TxIndex: 2},
 			}},
 		},
+		"with pagination next key": {
+			srcHistory: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeInit,
+				CodeID:    1,
+				Updated:   &types.AbsoluteTxPosition{BlockHeight: 1, TxIndex: 2},
+				Msg:       []byte(`"init message"`),
+			}, {
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    2,
+				Updated:   &types.AbsoluteTxPosition{BlockHeight: 3, TxIndex: 4},
+				Msg:       []byte(`"migrate message 1"`),
+			}},
+			req: types.QueryContractHistoryRequest{
+				Address: myContractBech32Addr,
+				Pagination: &query.PageRequest{
+					Key: fromBase64("AAAAAAAAAAI="),
+				},
+			},
+			expContent: []types.ContractCodeHistoryEntry{{
+				Operation: types.ContractCodeHistoryOperationTypeMigrate,
+				CodeID:    2,
+				Msg:       []byte(`"migrate message 1"`),
+				Updated:   &types.AbsoluteTxPosition{BlockHeight: 3, TxIndex: 4},
+			}},
+		},
 		"unknown contract address": {
 			req: types.QueryContractHistoryRequest{Address: otherBech32Addr},
 			srcHistory: []types.ContractCodeHistoryEntry{{
@@ -623,6 +753,49 @@ func TestQueryCodeList(t *testing.T) {
 	}
 }
 
+func TestQueryCodeListPagingWithNewCodes(t *testing.T) {
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	storeCodes := func(codeIDs ...uint64) {
+		for _, codeID := range codeIDs {
+			require.NoError(t, keeper.importCode(ctx, codeID,
+				types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode)),
+				wasmCode),
+			)
+		}
+	}
+	storeCodes(1, 2, 3)
+	q := Querier(keeper)
+
+	// when the first page was read
+	got, err := q.Codes(ctx, &types.QueryCodesRequest{Pagination: &query.PageRequest{Limit: 2}})
+	require.NoError(t, err)
+	require.Len(t, got.CodeInfos, 2)
+	assert.EqualValues(t, 1, got.CodeInfos[0].CodeID)
+	assert.EqualValues(t, 2, got.CodeInfos[1].CodeID)
+	require.NotNil(t, got.Pagination.NextKey)
+
+	// and new codes are stored before the next page is read
+	storeCodes(4, 5)
+	got, err = q.Codes(ctx, &types.QueryCodesRequest{Pagination: &query.PageRequest{Key: got.Pagination.NextKey, Limit: 2}})
+
+	// then the next page continues in code id order
+	require.NoError(t, err)
+	require.Len(t, got.CodeInfos, 2)
+	assert.EqualValues(t, 3, got.CodeInfos[0].CodeID)
+	assert.EqualValues(t, 4, got.CodeInfos[1].CodeID)
+
+	// and the appended codes are on the last page
+	got, err = q.Codes(ctx, &types.QueryCodesRequest{Pagination: &query.PageRequest{Key: got.Pagination.NextKey, Limit: 2}})
+	require.NoError(t, err)
+	require.Len(t, got.CodeInfos, 1)
+	assert.EqualValues(t, 5, got.CodeInfos[0].CodeID)
+	assert.Nil(t, got.Pagination.NextKey)
+}
+
 func TestQueryContractInfo(t *testing.T) {
 	var (
 		contractAddr = RandomAccountAddress(t)
@@ -690,6 +863,118 @@ func TestQueryContractInfo(t *testing.T) {
 	}
 }
 
+func TestQueryContractInfoBatch(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	querier := NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+
+	contractAddr1, contractAddr2 := RandomAccountAddress(t), RandomAccountAddress(t)
+	info1, info2 := types.ContractInfoFixture(), types.ContractInfoFixture(func(info *types.ContractInfo) {
+		info.Label = "other"
+	})
+	k.mustStoreContractInfo(ctx, contractAddr1, &info1)
+	k.mustStoreContractInfo(ctx, contractAddr2, &info2)
+	unknownAddr := RandomBech32AccountAddress(t)
+
+	tooMany := make([]string, MaxContractInfoBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = contractAddr1.String()
+	}
+	specs := map[string]struct {
+		src    *types.QueryContractInfoBatchRequest
+		expRsp *types.QueryContractInfoBatchResponse
+		expErr bool
+	}{
+		"in request order": {
+			src: &types.QueryContractInfoBatchRequest{Addresses: []string{contractAddr2.String(), unknownAddr, contractAddr1.String()}},
+			expRsp: &types.QueryContractInfoBatchResponse{Contracts: []types.ContractInfoBatchEntry{
+				{Address: contractAddr2.String(), ContractInfo: &info2},
+				{Address: unknownAddr, NotFound: true},
+				{Address: contractAddr1.String(), ContractInfo: &info1},
+			}},
+		},
+		"max addresses": {
+			src: &types.QueryContractInfoBatchRequest{Addresses: tooMany[:MaxContractInfoBatchSize]},
+		},
+		"too many addresses": {
+			src:    &types.QueryContractInfoBatchRequest{Addresses: tooMany},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+		"empty addresses": {
+			src:    &types.QueryContractInfoBatchRequest{},
+			expErr: true,
+		},
+		"invalid address": {
+			src:    &types.QueryContractInfoBatchRequest{Addresses: []string{contractAddr1.String(), "invalid"}},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotRsp, gotErr := querier.ContractInfoBatch(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			if spec.expRsp != nil {
+				assert.Equal(t, spec.expRsp, gotRsp)
+				return
+			}
+			assert.Len(t, gotRsp.Contracts, len(spec.src.Addresses))
+		})
+	}
+}
+
+func TestQueryIsContract(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	querier := NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+	example := SeedNewContractInstance(t, ctx, keepers, &m)
+
+	specs := map[string]struct {
+		src    *types.QueryIsContractRequest
+		expRsp *types.QueryIsContractResponse
+		expErr bool
+	}{
+		"contract": {
+			src:    &types.QueryIsContractRequest{Address: example.Contract.String()},
+			expRsp: &types.QueryIsContractResponse{IsContract: true, CodeID: example.CodeID},
+		},
+		"account": {
+			src:    &types.QueryIsContractRequest{Address: example.CreatorAddr.String()},
+			expRsp: &types.QueryIsContractResponse{},
+		},
+		"module account": {
+			src:    &types.QueryIsContractRequest{Address: keepers.AccountKeeper.GetModuleAddress(types.ModuleName).String()},
+			expRsp: &types.QueryIsContractResponse{},
+		},
+		"invalid address": {
+			src:    &types.QueryIsContractRequest{Address: "invalid"},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotRsp, gotErr := querier.IsContract(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expRsp, gotRsp)
+		})
+	}
+}
+
 func TestQueryWasmLimitsConfig(t *testing.T) {
 	cfg := types.VMConfig{}
 
@@ -795,6 +1080,107 @@ func TestQueryPinnedCodes(t *testing.T) {
 	}
 }
 
+func TestQueryUnusedCodes(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	InstantiateHackatomExampleContract(t, ctx.WithBlockHeight(1), keepers)
+	exampleContract2 := StoreBurnerExampleContract(t, ctx.WithBlockHeight(2), keepers)
+	exampleContract3 := StoreReflectContract(t, ctx.WithBlockHeight(3), keepers)
+
+	q := Querier(keepers.WasmKeeper)
+	specs := map[string]struct {
+		srcQuery   *types.QueryUnusedCodesRequest
+		expCodeIDs []uint64
+		expErr     error
+	}{
+		"query all": {
+			srcQuery:   &types.QueryUnusedCodesRequest{},
+			expCodeIDs: []uint64{exampleContract2.CodeID, exampleContract3.CodeID},
+		},
+		"older than height": {
+			srcQuery:   &types.QueryUnusedCodesRequest{OlderThanHeight: 3},
+			expCodeIDs: []uint64{exampleContract2.CodeID},
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryUnusedCodesRequest{
+				Pagination: &query.PageRequest{
+					Limit: 1,
+				},
+			},
+			expCodeIDs: []uint64{exampleContract2.CodeID},
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryUnusedCodesRequest{
+				Pagination: &query.PageRequest{
+					Offset: 1,
+				},
+			},
+			expErr: errLegacyPaginationUnsupported,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, gotErr := q.UnusedCodes(ctx, spec.srcQuery)
+			if spec.expErr != nil {
+				assert.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCodeIDs, got.CodeIDs)
+		})
+	}
+}
+
+func TestQueryContractDependencies(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	contractAddr := RandomAccountAddress(t)
+	store := keeper.storeService.OpenKVStore(ctx)
+	for _, codeID := range []uint64{3, 1} {
+		require.NoError(t, store.Set(types.GetContractDependencyKey(contractAddr, codeID), []byte{1}))
+	}
+
+	q := Querier(keeper)
+	specs := map[string]struct {
+		srcQuery   *types.QueryContractDependenciesRequest
+		expCodeIDs []uint64
+		expErr     bool
+	}{
+		"query all": {
+			srcQuery:   &types.QueryContractDependenciesRequest{Address: contractAddr.String()},
+			expCodeIDs: []uint64{1, 3},
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryContractDependenciesRequest{
+				Address:    contractAddr.String(),
+				Pagination: &query.PageRequest{Limit: 1},
+			},
+			expCodeIDs: []uint64{1},
+		},
+		"other contract": {
+			srcQuery:   &types.QueryContractDependenciesRequest{Address: RandomAccountAddress(t).String()},
+			expCodeIDs: []uint64{},
+		},
+		"invalid address": {
+			srcQuery: &types.QueryContractDependenciesRequest{Address: "foo"},
+			expErr:   true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, gotErr := q.ContractDependencies(ctx, spec.srcQuery)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCodeIDs, got.CodeIDs)
+		})
+	}
+}
+
 func TestQueryParams(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -869,6 +1255,7 @@ func TestQueryCodeInfo(t *testing.T) {
 				Creator:               codeInfo.Creator,
 				Checksum:              codeInfo.CodeHash,
 				InstantiatePermission: spec.accessConfig,
+				CodeSize:              uint64(len(wasmCode)),
 			}
 			require.NotNil(t, got)
 			require.EqualValues(t, expectedResponse, got)
@@ -876,6 +1263,526 @@ func TestQueryCodeInfo(t *testing.T) {
 	}
 }
 
+func TestQueryCodeAccessConfig(t *testing.T) {
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	creator := RandomAccountAddress(t)
+	anyAddress := RandomAccountAddress(t)
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	codeInfo.Creator = creator.String()
+	codeInfo.InstantiateConfig = types.AllowEverybody
+	require.NoError(t, keeper.importCode(ctx, 1, codeInfo, wasmCode))
+
+	q := Querier(keeper)
+	got, err := q.CodeAccessConfig(ctx, &types.QueryCodeAccessConfigRequest{CodeId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryCodeAccessConfigResponse{Permission: types.AccessTypeEverybody}, got)
+
+	// when updated
+	newConfig := types.AccessTypeAnyOfAddresses.With(anyAddress)
+	require.NoError(t, keeper.setAccessConfig(ctx, 1, creator, newConfig, DefaultAuthorizationPolicy{}))
+
+	// then
+	got, err = q.CodeAccessConfig(ctx, &types.QueryCodeAccessConfigRequest{CodeId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryCodeAccessConfigResponse{
+		Permission: types.AccessTypeAnyOfAddresses,
+		Addresses:  []string{anyAddress.String()},
+	}, got)
+
+	// and unknown code
+	_, err = q.CodeAccessConfig(ctx, &types.QueryCodeAccessConfigRequest{CodeId: 2})
+	require.ErrorIs(t, err, types.ErrNoSuchCodeFn(2))
+	// and empty code id
+	_, err = q.CodeAccessConfig(ctx, &types.QueryCodeAccessConfigRequest{})
+	require.ErrorIs(t, err, types.ErrInvalid)
+}
+
+func TestQueryCodeProvenance(t *testing.T) {
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	require.NoError(t, keeper.importCode(ctx, 1, codeInfo, wasmCode))
+	codeInfo.Source = "https://example.com/hackatom"
+	codeInfo.Builder = "cosmwasm/optimizer:0.16.0"
+	require.NoError(t, keeper.importCode(ctx, 2, codeInfo, wasmCode))
+
+	specs := map[string]struct {
+		codeID uint64
+		exp    *types.QueryCodeProvenanceResponse
+		expErr error
+	}{
+		"with provenance": {
+			codeID: 2,
+			exp: &types.QueryCodeProvenanceResponse{
+				Source:  "https://example.com/hackatom",
+				Builder: "cosmwasm/optimizer:0.16.0",
+			},
+		},
+		"without provenance": {
+			codeID: 1,
+			exp:    &types.QueryCodeProvenanceResponse{},
+		},
+		"unknown code id": {
+			codeID: 3,
+			expErr: types.ErrNoSuchCodeFn(3).Wrapf("code id %d", 3),
+		},
+		"empty code id": {
+			codeID: 0,
+			expErr: errorsmod.Wrap(types.ErrInvalid, "code id"),
+		},
+	}
+	q := Querier(keeper)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.CodeProvenance(ctx, &types.QueryCodeProvenanceRequest{CodeId: spec.codeID})
+			if spec.expErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, spec.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestQueryContractCountByCode(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	initMsgBz := HackatomExampleInitMsg{Verifier: example.VerifierAddr, Beneficiary: example.BeneficiaryAddr}.GetBytes(t)
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, initMsgBz, "second", nil)
+	require.NoError(t, err)
+	emptyCode := StoreHackatomExampleContract(t, ctx, keepers)
+
+	specs := map[string]struct {
+		codeID uint64
+		exp    *types.QueryContractCountByCodeResponse
+		expErr error
+	}{
+		"with contracts": {
+			codeID: example.CodeID,
+			exp:    &types.QueryContractCountByCodeResponse{Count: 2},
+		},
+		"without contracts": {
+			codeID: emptyCode.CodeID,
+			exp:    &types.QueryContractCountByCodeResponse{Count: 0},
+		},
+		"unknown code id": {
+			codeID: 99,
+			expErr: types.ErrNoSuchCodeFn(99).Wrapf("code id %d", 99),
+		},
+		"empty code id": {
+			codeID: 0,
+			expErr: errorsmod.Wrap(types.ErrInvalid, "code id"),
+		},
+	}
+	q := Querier(keeper)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.ContractCountByCode(ctx, &types.QueryContractCountByCodeRequest{CodeId: spec.codeID})
+			if spec.expErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, spec.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestQueryBlockSudoHooks(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	q := Querier(keeper)
+
+	// empty
+	got, err := q.BlockSudoHooks(ctx, &types.QueryBlockSudoHooksRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryBlockSudoHooksResponse{}, got)
+
+	// with hooks
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	otherExample := InstantiateHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, keeper.RegisterBeginBlockSudo(ctx, otherExample.Contract, []byte(`{"a":{}}`)))
+	require.NoError(t, keeper.RegisterBeginBlockSudo(ctx, example.Contract, []byte(`{"b":{}}`)))
+	require.NoError(t, keeper.RegisterEndBlockSudo(ctx, example.Contract, []byte(`{"c":{}}`)))
+
+	got, err = q.BlockSudoHooks(ctx, &types.QueryBlockSudoHooksRequest{})
+	require.NoError(t, err)
+	exp := &types.QueryBlockSudoHooksResponse{
+		BeginBlock: []types.BlockSudoHook{
+			{Contract: otherExample.Contract.String(), Msg: []byte(`{"a":{}}`)},
+			{Contract: example.Contract.String(), Msg: []byte(`{"b":{}}`)},
+		},
+		EndBlock: []types.BlockSudoHook{
+			{Contract: example.Contract.String(), Msg: []byte(`{"c":{}}`)},
+		},
+	}
+	assert.Equal(t, exp, got)
+
+	// nil request
+	_, err = q.BlockSudoHooks(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestQueryMigrationCheckpoints(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	q := Querier(keeper)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	// empty
+	got, err := q.MigrationCheckpoints(ctx, &types.QueryMigrationCheckpointsRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryMigrationCheckpointsResponse{}, got)
+
+	// with checkpoint
+	params := keeper.GetParams(ctx)
+	params.MaxMigrationCheckpoints = 1
+	require.NoError(t, keeper.SetParams(ctx, params))
+	_, err = keeper.createMigrationCheckpoint(ctx, example.Contract)
+	require.NoError(t, err)
+
+	got, err = q.MigrationCheckpoints(ctx, &types.QueryMigrationCheckpointsRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	exp := &types.QueryMigrationCheckpointsResponse{
+		Checkpoints: []types.MigrationCheckpoint{
+			{ID: 1, CodeID: example.CodeID, Created: types.NewAbsoluteTxPosition(ctx)},
+		},
+	}
+	assert.Equal(t, exp, got)
+
+	// unknown contract
+	_, err = q.MigrationCheckpoints(ctx, &types.QueryMigrationCheckpointsRequest{Address: RandomBech32AccountAddress(t)})
+	require.Error(t, err)
+
+	// nil request
+	_, err = q.MigrationCheckpoints(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestQueryStargateAllowlist(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	q := Querier(keeper)
+
+	// empty
+	got, err := q.StargateAllowlist(ctx, &types.QueryStargateAllowlistRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryStargateAllowlistResponse{}, got)
+
+	// with paths
+	require.NoError(t, keeper.UpdateStargateAllowlist(ctx, []string{"/cosmos.bank.v1beta1.Query/Balance", "/cosmos.auth.v1beta1.Query/Account"}, nil))
+	got, err = q.StargateAllowlist(ctx, &types.QueryStargateAllowlistRequest{})
+	require.NoError(t, err)
+	exp := &types.QueryStargateAllowlistResponse{
+		Paths: []string{"/cosmos.auth.v1beta1.Query/Account", "/cosmos.bank.v1beta1.Query/Balance"},
+	}
+	assert.Equal(t, exp, got)
+
+	// nil request
+	_, err = q.StargateAllowlist(ctx, nil)
+	require.Error(t, err)
+}
+
+func TestQuerySimulateContractCall(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := example.Contract.String()
+	q := Querier(keeper)
+
+	specs := map[string]struct {
+		src       *types.QuerySimulateContractCallRequest
+		expEvents []string
+		expErr    bool
+	}{
+		"release": {
+			src:       &types.QuerySimulateContractCallRequest{Sender: example.VerifierAddr.String(), Contract: contractAddr, Msg: []byte(`{"release":{}}`)},
+			expEvents: []string{types.EventTypeExecute, types.WasmModuleEventType, banktypes.EventTypeTransfer},
+		},
+		"with funds": {
+			src: &types.QuerySimulateContractCallRequest{
+				Sender: example.VerifierAddr.String(), Contract: contractAddr, Msg: []byte(`{"release":{}}`),
+				Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+			},
+			expEvents: []string{types.EventTypeExecute, types.WasmModuleEventType, banktypes.EventTypeTransfer},
+		},
+		"unauthorized sender": {
+			src:    &types.QuerySimulateContractCallRequest{Sender: example.BeneficiaryAddr.String(), Contract: contractAddr, Msg: []byte(`{"release":{}}`)},
+			expErr: true,
+		},
+		"unknown contract": {
+			src:    &types.QuerySimulateContractCallRequest{Sender: example.VerifierAddr.String(), Contract: RandomBech32AccountAddress(t), Msg: []byte(`{"release":{}}`)},
+			expErr: true,
+		},
+		"invalid msg": {
+			src:    &types.QuerySimulateContractCallRequest{Sender: example.VerifierAddr.String(), Contract: contractAddr, Msg: []byte(`not a json string`)},
+			expErr: true,
+		},
+		"invalid sender": {
+			src:    &types.QuerySimulateContractCallRequest{Sender: "invalid", Contract: contractAddr, Msg: []byte(`{"release":{}}`)},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			contractBalanceBefore := keepers.BankKeeper.GetAllBalances(ctx, example.Contract)
+			em := sdk.NewEventManager()
+
+			// when
+			got, gotErr := q.SimulateContractCall(ctx.WithEventManager(em), spec.src)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var gotEvents []string
+			for _, e := range got.Events {
+				gotEvents = append(gotEvents, e.Type)
+			}
+			for _, exp := range spec.expEvents {
+				assert.Contains(t, gotEvents, exp)
+			}
+			assert.NotZero(t, got.GasUsed)
+			// and nothing was committed
+			assert.Empty(t, em.Events())
+			assert.Equal(t, contractBalanceBefore, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+			assert.Empty(t, keepers.BankKeeper.GetAllBalances(ctx, example.BeneficiaryAddr))
+		})
+	}
+}
+
+func TestQuerySimulateContractCallBalances(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	q := Querier(keepers.WasmKeeper)
+	contractBalance := keepers.BankKeeper.GetAllBalances(ctx, example.Contract)
+	require.NotEmpty(t, contractBalance)
+
+	specs := map[string]struct {
+		funds    sdk.Coins
+		noReport bool
+	}{
+		"without funds": {},
+		"with funds": {
+			funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+		},
+		"balances not requested": {
+			noReport: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			// when
+			got, gotErr := q.SimulateContractCall(ctx, &types.QuerySimulateContractCallRequest{
+				Sender:          example.VerifierAddr.String(),
+				Contract:        example.Contract.String(),
+				Msg:             []byte(`{"release":{}}`),
+				Funds:           spec.funds,
+				IncludeBalances: !spec.noReport,
+			})
+
+			// then
+			require.NoError(t, gotErr)
+			if spec.noReport {
+				assert.Empty(t, got.BalanceBefore)
+				assert.Empty(t, got.BalanceAfter)
+				return
+			}
+			assert.Equal(t, contractBalance, got.BalanceBefore)
+			// the release sends the whole balance including the funds to the beneficiary
+			var sent sdk.Coins
+			for _, e := range got.Events {
+				if e.Type != banktypes.EventTypeTransfer {
+					continue
+				}
+				attrs := make(map[string]string, len(e.Attributes))
+				for _, a := range e.Attributes {
+					attrs[a.Key] = a.Value
+				}
+				if attrs[banktypes.AttributeKeyRecipient] == example.BeneficiaryAddr.String() {
+					var err error
+					sent, err = sdk.ParseCoinsNormalized(attrs[sdk.AttributeKeyAmount])
+					require.NoError(t, err)
+				}
+			}
+			assert.Equal(t, contractBalance.Add(spec.funds...), sent)
+			assert.Equal(t, got.BalanceBefore.Add(spec.funds...).Sub(sent...), got.BalanceAfter)
+			assert.Empty(t, got.BalanceAfter)
+			// and nothing was committed
+			assert.Equal(t, contractBalance, keepers.BankKeeper.GetAllBalances(ctx, example.Contract))
+		})
+	}
+}
+
+func TestQueryModuleStats(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	q := Querier(k)
+
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	initMsgBz := HackatomExampleInitMsg{Verifier: example.VerifierAddr, Beneficiary: example.BeneficiaryAddr}.GetBytes(t)
+	_, _, err := keepers.ContractKeeper.Instantiate2(parentCtx, example.CodeID, example.CreatorAddr, nil, initMsgBz, "second", nil, []byte("salt"), false)
+	require.NoError(t, err)
+	otherCode := StoreHackatomExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		setup func(ctx sdk.Context)
+		exp   *types.QueryModuleStatsResponse
+	}{
+		"codes and contracts": {
+			setup: func(ctx sdk.Context) {},
+			exp:   &types.QueryModuleStatsResponse{CodeCount: 2, ContractCount: 2, LastCodeID: otherCode.CodeID, LastInstanceID: 1},
+		},
+		"pinned codes": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, keepers.ContractKeeper.PinCode(ctx, example.CodeID))
+				require.NoError(t, keepers.ContractKeeper.PinCode(ctx, otherCode.CodeID))
+				// pinning again is not counted
+				require.NoError(t, keepers.ContractKeeper.PinCode(ctx, otherCode.CodeID))
+			},
+			exp: &types.QueryModuleStatsResponse{CodeCount: 2, ContractCount: 2, PinnedCodeCount: 2, LastCodeID: otherCode.CodeID, LastInstanceID: 1},
+		},
+		"unpinned codes": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, keepers.ContractKeeper.PinCode(ctx, example.CodeID))
+				require.NoError(t, keepers.ContractKeeper.UnpinCode(ctx, example.CodeID))
+				// unpinning again is not counted
+				require.NoError(t, keepers.ContractKeeper.UnpinCode(ctx, example.CodeID))
+			},
+			exp: &types.QueryModuleStatsResponse{CodeCount: 2, ContractCount: 2, LastCodeID: otherCode.CodeID, LastInstanceID: 1},
+		},
+		"removed contract": {
+			setup: func(ctx sdk.Context) {
+				require.NoError(t, k.removeContract(ctx, example.Contract))
+			},
+			exp: &types.QueryModuleStatsResponse{CodeCount: 2, ContractCount: 1, LastCodeID: otherCode.CodeID, LastInstanceID: 1},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			spec.setup(ctx)
+
+			// when
+			got, err := q.ModuleStats(ctx, &types.QueryModuleStatsRequest{})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestQueryCodeExports(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	q := Querier(keepers.WasmKeeper)
+	hackatom := StoreHackatomExampleContract(t, ctx, keepers)
+
+	got, err := q.CodeExports(ctx, &types.QueryCodeExportsRequest{CodeId: hackatom.CodeID})
+	require.NoError(t, err)
+	assert.Contains(t, got.Entrypoints, "migrate")
+	report, err := keepers.WasmKeeper.wasmVM.AnalyzeCode(hackatom.Checksum)
+	require.NoError(t, err)
+	assert.Equal(t, report.Entrypoints, got.Entrypoints)
+
+	_, err = q.CodeExports(ctx, &types.QueryCodeExportsRequest{CodeId: 100})
+	assert.ErrorIs(t, err, types.ErrNoSuchCodeFn(100))
+	_, err = q.CodeExports(ctx, &types.QueryCodeExportsRequest{})
+	assert.ErrorIs(t, err, types.ErrInvalid)
+}
+
+func TestQueryAnalyzeCode(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	q := Querier(k)
+	example := StoreReflectContract(t, parentCtx, keepers)
+	creator := RandomAccountAddress(t)
+	capabilities := func(unavailable ...string) []types.CodeCapability {
+		var r []types.CodeCapability
+		for _, c := range []string{"cosmwasm_1_1", "cosmwasm_1_2", "cosmwasm_1_3", "cosmwasm_1_4", "cosmwasm_2_0", "staking", "stargate"} {
+			r = append(r, types.CodeCapability{Name: c, Available: !slices.Contains(unavailable, c)})
+		}
+		return r
+	}
+
+	specs := map[string]struct {
+		src      *types.QueryAnalyzeCodeRequest
+		disabled []string
+		exp      *types.QueryAnalyzeCodeResponse
+		expErr   error
+	}{
+		"all available": {
+			src: &types.QueryAnalyzeCodeRequest{CodeId: example.CodeID},
+			exp: &types.QueryAnalyzeCodeResponse{RequiredCapabilities: capabilities()},
+		},
+		"disabled capability": {
+			src:      &types.QueryAnalyzeCodeRequest{CodeId: example.CodeID},
+			disabled: []string{"stargate"},
+			exp:      &types.QueryAnalyzeCodeResponse{RequiredCapabilities: capabilities("stargate")},
+		},
+		"with predicted address": {
+			src: &types.QueryAnalyzeCodeRequest{CodeId: example.CodeID, CreatorAddress: creator.String(), Salt: "61"},
+			exp: &types.QueryAnalyzeCodeResponse{
+				RequiredCapabilities: capabilities(),
+				PredictedAddress:     BuildContractAddressPredictable(example.Checksum, creator, []byte("a"), []byte{}).String(),
+			},
+		},
+		"creator without salt": {
+			src:    &types.QueryAnalyzeCodeRequest{CodeId: example.CodeID, CreatorAddress: creator.String()},
+			expErr: status.Error(codes.InvalidArgument, "empty salt"),
+		},
+		"unknown code id": {
+			src:    &types.QueryAnalyzeCodeRequest{CodeId: 99},
+			expErr: types.ErrNoSuchCodeFn(99),
+		},
+		"empty code id": {
+			src:    &types.QueryAnalyzeCodeRequest{},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.DisabledCapabilities = spec.disabled
+			require.NoError(t, k.SetParams(ctx, params))
+
+			ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+			// when
+			got, gotErr := q.AnalyzeCode(ctx, spec.src)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+			assert.GreaterOrEqual(t, ctx.GasMeter().GasConsumed(), storetypes.Gas(DefaultGasCostAnalyzeCode))
+		})
+	}
+}
+
 func TestQueryCode(t *testing.T) {
 	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -922,6 +1829,7 @@ func TestQueryCode(t *testing.T) {
 					Creator:               codeInfo.Creator,
 					DataHash:              codeInfo.CodeHash,
 					InstantiatePermission: spec.accessConfig,
+					CodeSize:              uint64(len(wasmCode)),
 				},
 				Data: wasmCode,
 			}
@@ -982,6 +1890,7 @@ func TestQueryCodeInfoList(t *testing.T) {
 			Creator:               code.codeInfo.Creator,
 			DataHash:              code.codeInfo.CodeHash,
 			InstantiatePermission: code.codeInfo.InstantiateConfig,
+			CodeSize:              uint64(len(wasmCode)),
 		})
 	}
 	q := Querier(keeper)
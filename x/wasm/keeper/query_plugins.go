@@ -31,6 +31,8 @@ type QueryHandler struct {
 	Plugins     WasmVMQueryHandler
 	Caller      sdk.AccAddress
 	gasRegister types.GasRegister
+	// rejectSelfQueries rejects smart queries of the caller into itself
+	rejectSelfQueries bool
 }
 
 func NewQueryHandler(ctx sdk.Context, vmQueryHandler WasmVMQueryHandler, caller sdk.AccAddress, gasRegister types.GasRegister) QueryHandler {
@@ -50,7 +52,14 @@ type GRPCQueryRouter interface {
 
 var _ wasmvmtypes.Querier = QueryHandler{}
 
+// Query handles a query request of a contract on a branch of the calling context.
+// Queries see the uncommitted writes of the current execution, including smart queries into the calling contract
+// itself unless the reject self queries param is set. This is deterministic as the writes happened earlier in the
+// same execution on every node. Writes made in the query path are discarded.
 func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
+	if q.rejectSelfQueries && q.isSelfQuery(request) {
+		return nil, redactError(types.ErrSelfQueryRejected)
+	}
 	// set a limit for a subCtx
 	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
 	// discard all changes/ events in subCtx by not committing the cached context
@@ -58,7 +67,7 @@ func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) (
 
 	// make sure we charge the higher level context even on panic
 	defer func() {
-		q.Ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), "contract sub-query")
+		q.Ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), types.GasDescSubQuery)
 	}()
 
 	res, err := q.Plugins.HandleQuery(subCtx, q.Caller, request)
@@ -78,6 +87,14 @@ func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) (
 	return nil, redactError(err)
 }
 
+// isSelfQuery returns true for a smart query of the caller into itself
+func (q QueryHandler) isSelfQuery(request wasmvmtypes.QueryRequest) bool {
+	if request.Wasm == nil || request.Wasm.Smart == nil || q.Caller == nil {
+		return false
+	}
+	return request.Wasm.Smart.ContractAddr == q.Caller.String()
+}
+
 func (q QueryHandler) GasConsumed() uint64 {
 	return q.gasRegister.ToWasmVMGas(q.Ctx.GasMeter().GasConsumed())
 }
@@ -110,6 +127,7 @@ type wasmQueryKeeper interface {
 	QueryRaw(ctx context.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
 	IsPinnedCode(ctx context.Context, codeID uint64) bool
+	GetCachedParams(ctx context.Context) types.Params
 }
 
 func DefaultQueryPlugins(
@@ -303,6 +321,9 @@ func IBCQuerier(wasm contractMetaDataSource, channelKeeper types.ChannelKeeper)
 			}
 			return json.Marshal(res)
 		}
+		// the channel query returns the ordering and version of an open channel. Contracts use it in the IBC packet
+		// entrypoints, which get no channel metadata, with the endpoint of the packet. Closed or unknown channels are
+		// returned as nil.
 		if request.Channel != nil {
 			channelID := request.Channel.ChannelID
 			portID := request.Channel.PortID
@@ -437,6 +458,30 @@ func AcceptListStargateQuerier(acceptList AcceptedQueries, queryRouter GRPCQuery
 	}
 }
 
+// StargateAllowlist provides the stargate query paths allowed by governance
+type StargateAllowlist interface {
+	IsStargateQueryAllowed(ctx context.Context, path string) bool
+}
+
+// AllowlistStargateQuerier supports the stargate queries in the allowlist that is maintained by governance.
+// The accept list registers the protobuf response types and is consulted for the response decoding only.
+// A path must be in the allowlist and have a response type registered to be accepted.
+// All arguments must be non nil.
+//
+// This querier can be set via WithStargateAllowlistQuerier option in the wasm keeper constructor.
+func AllowlistStargateQuerier(acceptList AcceptedQueries, allowlist StargateAllowlist, queryRouter GRPCQueryRouter, codec codec.Codec) stargateQuerierFn {
+	acceptListQuerier := AcceptListStargateQuerier(acceptList, queryRouter, codec)
+	return func(ctx sdk.Context, request *wasmvmtypes.StargateQuery) ([]byte, error) {
+		if !allowlist.IsStargateQueryAllowed(ctx, request.Path) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("'%s' path is not in the stargate query allowlist", request.Path)}
+		}
+		if _, registered := acceptList[request.Path]; !registered {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("'%s' path has no response type registered", request.Path)}
+		}
+		return acceptListQuerier(ctx, request)
+	}
+}
+
 func StakingQuerier(keeper types.StakingKeeper, distKeeper types.DistributionKeeper) func(ctx sdk.Context, request *wasmvmtypes.StakingQuery) ([]byte, error) {
 	return func(ctx sdk.Context, request *wasmvmtypes.StakingQuery) ([]byte, error) {
 		if request.BondedDenom != nil {
@@ -662,7 +707,11 @@ func WasmQuerier(k wasmQueryKeeper) func(ctx sdk.Context, request *wasmvmtypes.W
 			if err := msg.ValidateBasic(); err != nil {
 				return nil, errorsmod.Wrap(err, "json msg")
 			}
-			return k.QuerySmart(ctx, addr, msg)
+			params := k.GetCachedParams(ctx)
+			if params.MaxSubQueryGas == 0 || params.MaxSubQueryGas >= ctx.GasMeter().GasRemaining() {
+				return k.QuerySmart(ctx, addr, msg)
+			}
+			return querySmartWithGasLimit(ctx, k, addr, msg, params.MaxSubQueryGas)
 		case request.Raw != nil:
 			addr, err := sdk.AccAddressFromBech32(request.Raw.ContractAddr)
 			if err != nil {
@@ -710,8 +759,33 @@ func WasmQuerier(k wasmQueryKeeper) func(ctx sdk.Context, request *wasmvmtypes.W
 	}
 }
 
+// querySmartWithGasLimit executes the smart query with a gas meter that is limited to the given gas.
+// The gas consumed is charged to the parent gas meter. Running out of gas is returned as an error
+// so that the calling contract keeps the rest of its budget.
+func querySmartWithGasLimit(ctx sdk.Context, k wasmQueryKeeper, addr sdk.AccAddress, msg []byte, gasLimit storetypes.Gas) (bz []byte, err error) {
+	subCtx := ctx.WithGasMeter(storetypes.NewGasMeter(gasLimit))
+	defer func() {
+		ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumedToLimit(), types.GasDescSubQuery)
+		if r := recover(); r != nil {
+			if _, ok := r.(storetypes.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			bz, err = nil, errorsmod.Wrapf(sdkerrors.ErrOutOfGas, "sub-query gas limit %d exceeded", gasLimit)
+		}
+	}()
+	return k.QuerySmart(subCtx, addr, msg)
+}
+
+// DistributionQuerier handles the distribution queries of contracts, which require the `cosmwasm_1_3` capability.
+// Chains without a distribution module can pass a nil keeper to reject all distribution queries.
 func DistributionQuerier(k types.DistributionKeeper) func(ctx sdk.Context, request *wasmvmtypes.DistributionQuery) ([]byte, error) {
+	if k == nil {
+		return RejectDistributionQuerier
+	}
 	return func(ctx sdk.Context, req *wasmvmtypes.DistributionQuery) ([]byte, error) {
+		// the reward queries increment the validator period in the distribution store.
+		// The changes are discarded so that the query does not modify state.
+		ctx, _ = ctx.CacheContext()
 		switch {
 		case req.DelegatorWithdrawAddress != nil:
 			got, err := k.DelegatorWithdrawAddress(ctx, &distributiontypes.QueryDelegatorWithdrawAddressRequest{DelegatorAddress: req.DelegatorWithdrawAddress.DelegatorAddress})
@@ -758,6 +832,11 @@ func DistributionQuerier(k types.DistributionKeeper) func(ctx sdk.Context, reque
 	}
 }
 
+// RejectDistributionQuerier rejects all distribution queries
+func RejectDistributionQuerier(_ sdk.Context, _ *wasmvmtypes.DistributionQuery) ([]byte, error) {
+	return nil, wasmvmtypes.UnsupportedRequest{Kind: "distribution queries are not supported"}
+}
+
 // ConvertSDKDelegatorRewardsToWasmRewards convert sdk to wasmvm type
 func ConvertSDKDelegatorRewardsToWasmRewards(rewards []distributiontypes.DelegationDelegatorReward) []wasmvmtypes.DelegatorReward {
 	r := make([]wasmvmtypes.DelegatorReward, len(rewards))
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
@@ -416,6 +418,64 @@ func TestBankQuerierAllMetadataPagination(t *testing.T) {
 	assert.Equal(t, exp, capturedPagination)
 }
 
+func TestBankQuerierMetadataWithBankKeeper(t *testing.T) {
+	ctx, keepers := keeper.CreateTestInput(t, false, keeper.AvailableCapabilities)
+	for _, denom := range []string{"ubar", "ufoo"} {
+		keepers.BankKeeper.SetDenomMetaData(ctx, banktypes.Metadata{
+			Base:       denom,
+			Display:    denom[1:],
+			Symbol:     strings.ToUpper(denom[1:]),
+			DenomUnits: []*banktypes.DenomUnit{{Denom: denom}, {Denom: denom[1:], Exponent: 6}},
+		})
+	}
+	metadata := func(denom string) wasmvmtypes.DenomMetadata {
+		return wasmvmtypes.DenomMetadata{
+			Base:       denom,
+			Display:    denom[1:],
+			Symbol:     strings.ToUpper(denom[1:]),
+			DenomUnits: []wasmvmtypes.DenomUnit{{Denom: denom, Aliases: []string{}}, {Denom: denom[1:], Exponent: 6, Aliases: []string{}}},
+		}
+	}
+	q := keeper.BankQuerier(keepers.BankKeeper)
+
+	specs := map[string]struct {
+		src    *wasmvmtypes.BankQuery
+		exp    any
+		expErr bool
+	}{
+		"registered metadata": {
+			src: &wasmvmtypes.BankQuery{DenomMetadata: &wasmvmtypes.DenomMetadataQuery{Denom: "ufoo"}},
+			exp: wasmvmtypes.DenomMetadataResponse{Metadata: metadata("ufoo")},
+		},
+		"no metadata": {
+			src:    &wasmvmtypes.BankQuery{DenomMetadata: &wasmvmtypes.DenomMetadataQuery{Denom: "unknown"}},
+			expErr: true,
+		},
+		"all metadata": {
+			src: &wasmvmtypes.BankQuery{AllDenomMetadata: &wasmvmtypes.AllDenomMetadataQuery{}},
+			exp: wasmvmtypes.AllDenomMetadataResponse{Metadata: []wasmvmtypes.DenomMetadata{metadata("ubar"), metadata("ufoo")}},
+		},
+		"all metadata paginated": {
+			src: &wasmvmtypes.BankQuery{AllDenomMetadata: &wasmvmtypes.AllDenomMetadataQuery{Pagination: &wasmvmtypes.PageRequest{Limit: 1}}},
+			exp: wasmvmtypes.AllDenomMetadataResponse{Metadata: []wasmvmtypes.DenomMetadata{metadata("ubar")}, NextKey: []byte("ufoo")},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotBz, gotErr := q(ctx, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.ErrorIs(t, gotErr, sdkerrors.ErrNotFound)
+				return
+			}
+			require.NoError(t, gotErr)
+			expBz, err := json.Marshal(spec.exp)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expBz), string(gotBz))
+		})
+	}
+}
+
 func TestContractInfoWasmQuerier(t *testing.T) {
 	myValidContractAddr := keeper.RandomBech32AccountAddress(t)
 	myCreatorAddr := keeper.RandomBech32AccountAddress(t)
@@ -624,12 +684,64 @@ func TestQueryErrors(t *testing.T) {
 	}
 }
 
+func TestSmartWasmQuerierLimits(t *testing.T) {
+	myAddr := keeper.RandomBech32AccountAddress(t)
+	specs := map[string]struct {
+		params        types.Params
+		queryGas      storetypes.Gas
+		expErr        *errorsmod.Error
+		expGasCharged storetypes.Gas
+	}{
+		"sub-query gas not limited": {
+			queryGas:      100,
+			expGasCharged: 100,
+		},
+		"within sub-query gas limit": {
+			params:        types.Params{MaxSubQueryGas: 100},
+			queryGas:      100,
+			expGasCharged: 100,
+		},
+		"exceeds sub-query gas limit": {
+			params:        types.Params{MaxSubQueryGas: 100},
+			queryGas:      101,
+			expErr:        sdkerrors.ErrOutOfGas,
+			expGasCharged: 100,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ms := store.NewCommitMultiStore(dbm.NewMemDB(), log.NewTestLogger(t), storemetrics.NewNoOpMetrics())
+			ctx := sdk.NewContext(ms, cmtproto.Header{}, false, log.NewTestLogger(t)).WithGasMeter(storetypes.NewGasMeter(1000))
+			mock := mockWasmQueryKeeper{
+				GetParamsFn: func(ctx context.Context) types.Params { return spec.params },
+				QuerySmartFn: func(ctx context.Context, contractAddr sdk.AccAddress, req types.RawContractMessage) ([]byte, error) {
+					sdk.UnwrapSDKContext(ctx).GasMeter().ConsumeGas(spec.queryGas, "testing")
+					return []byte(`{}`), nil
+				},
+			}
+			q := keeper.WasmQuerier(mock)
+
+			// when
+			_, gotErr := q(ctx, &wasmvmtypes.WasmQuery{Smart: &wasmvmtypes.SmartQuery{ContractAddr: myAddr, Msg: []byte(`{}`)}})
+
+			// then
+			assert.Equal(t, spec.expGasCharged, ctx.GasMeter().GasConsumed())
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
 type mockWasmQueryKeeper struct {
 	GetContractInfoFn func(ctx context.Context, contractAddress sdk.AccAddress) *types.ContractInfo
 	QueryRawFn        func(ctx context.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	QuerySmartFn      func(ctx context.Context, contractAddr sdk.AccAddress, req types.RawContractMessage) ([]byte, error)
 	IsPinnedCodeFn    func(ctx context.Context, codeID uint64) bool
 	GetCodeInfoFn     func(ctx context.Context, codeID uint64) *types.CodeInfo
+	GetParamsFn       func(ctx context.Context) types.Params
 }
 
 func (m mockWasmQueryKeeper) GetContractInfo(ctx context.Context, contractAddress sdk.AccAddress) *types.ContractInfo {
@@ -667,6 +779,13 @@ func (m mockWasmQueryKeeper) GetCodeInfo(ctx context.Context, codeID uint64) *ty
 	return m.GetCodeInfoFn(ctx, codeID)
 }
 
+func (m mockWasmQueryKeeper) GetCachedParams(ctx context.Context) types.Params {
+	if m.GetParamsFn == nil {
+		panic("not expected to be called")
+	}
+	return m.GetParamsFn(ctx)
+}
+
 type bankKeeperMock struct {
 	GetSupplyFn         func(ctx context.Context, denom string) sdk.Coin
 	GetBalanceFn        func(ctx context.Context, addr sdk.AccAddress, denom string) sdk.Coin
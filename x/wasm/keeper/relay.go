@@ -1,6 +1,9 @@
 package keeper
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
@@ -37,9 +40,9 @@ func (k Keeper) OnOpenChannel(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCChannelOpen(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return "", errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -70,9 +73,9 @@ func (k Keeper) OnConnectChannel(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCChannelConnect(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -81,7 +84,7 @@ func (k Keeper) OnConnectChannel(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
@@ -108,9 +111,9 @@ func (k Keeper) OnCloseChannel(
 	params := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCChannelClose(codeInfo.CodeHash, params, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -119,7 +122,7 @@ func (k Keeper) OnCloseChannel(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
@@ -130,6 +133,9 @@ func (k Keeper) OnCloseChannel(
 // of IBC. Although it is recommended to use the standard acknowledgement envelope defined in
 // https://github.com/cosmos/ibc/blob/main/spec/core/ics-004-channel-and-packet-semantics/README.md#acknowledgement-envelope
 //
+// The packet does not contain the ordering and version of the channel. Contracts get them with the IBC channel
+// query for the destination endpoint of the packet.
+//
 // For more information see: https://github.com/cosmos/ibc/blob/main/spec/core/ics-004-channel-and-packet-semantics/README.md#packet-flow--handling
 func (k Keeper) OnRecvPacket(
 	ctx sdk.Context,
@@ -145,9 +151,9 @@ func (k Keeper) OnRecvPacket(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCPacketReceive(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		panic(execErr) // let the contract fully abort an IBC packet receive.
 		// Throwing a panic here instead of an error ack will revert
@@ -160,9 +166,7 @@ func (k Keeper) OnRecvPacket(
 	}
 	if res.Err != "" {
 		// return error ACK with non-redacted contract message, state will be reverted
-		return channeltypes.Acknowledgement{
-			Response: &channeltypes.Acknowledgement_Error{Error: res.Err},
-		}, nil
+		return newContractErrorAck(res.Err), nil
 	}
 	// note submessage reply results can overwrite the `Acknowledgement` data
 	data, err := k.handleContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Acknowledgement, res.Ok.Events)
@@ -197,6 +201,70 @@ func (w ContractConfirmStateAck) Acknowledgement() []byte {
 	return w
 }
 
+var _ ibcexported.Acknowledgement = ContractErrorAck{}
+
+// ContractErrorAck is an error acknowledgement with a contract defined error code and payload,
+// so that counterparty contracts can branch on the code. State is reverted.
+//
+// A contract returns it from the IBC packet receive entrypoint as JSON encoded error message:
+// `{"error_code":<uint32>,"data":<base64>}`
+type ContractErrorAck struct {
+	ErrorCode uint32 `json:"error_code"`
+	Data      []byte `json:"data,omitempty"`
+}
+
+func (a ContractErrorAck) Success() bool {
+	return false
+}
+
+// Acknowledgement returns the ack in the ICS-4 acknowledgement envelope with the error code and
+// payload as additional fields. The json keys are sorted for deterministic encoding.
+func (a ContractErrorAck) Acknowledgement() []byte {
+	bz, err := json.Marshal(struct {
+		Error     string `json:"error"`
+		ErrorCode uint32 `json:"error_code"`
+		Data      []byte `json:"data,omitempty"`
+	}{
+		Error:     fmt.Sprintf("contract error code: %d", a.ErrorCode),
+		ErrorCode: a.ErrorCode,
+		Data:      a.Data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// parseContractErrorAck decodes a contract error message in the ContractErrorAck format.
+// Returns false for any other message.
+func parseContractErrorAck(contractErr string) (ContractErrorAck, bool) {
+	var v struct {
+		ErrorCode *uint32 `json:"error_code"`
+		Data      []byte  `json:"data"`
+	}
+	dec := json.NewDecoder(strings.NewReader(contractErr))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return ContractErrorAck{}, false
+	}
+	if v.ErrorCode == nil || *v.ErrorCode == 0 {
+		return ContractErrorAck{}, false
+	}
+	return ContractErrorAck{ErrorCode: *v.ErrorCode, Data: v.Data}, true
+}
+
+// newContractErrorAck converts the contract error message into an error acknowledgement.
+// Messages in the ContractErrorAck format result in a typed ack, others in the standard
+// error ack for backward compatibility.
+func newContractErrorAck(contractErr string) ibcexported.Acknowledgement {
+	if ack, ok := parseContractErrorAck(contractErr); ok {
+		return ack
+	}
+	return channeltypes.Acknowledgement{
+		Response: &channeltypes.Acknowledgement_Error{Error: contractErr},
+	}
+}
+
 // OnAckPacket calls the contract to handle the "acknowledgement" data which can contain success or failure of a packet
 // acknowledgement written on the receiving chain for example. This is application level data and fully owned by the
 // contract. The use of the standard acknowledgement envelope is recommended: https://github.com/cosmos/ibc/tree/main/spec/core/ics-004-channel-and-packet-semantics#acknowledgement-envelope
@@ -218,9 +286,9 @@ func (k Keeper) OnAckPacket(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCPacketAck(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -229,7 +297,7 @@ func (k Keeper) OnAckPacket(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
@@ -237,7 +305,9 @@ func (k Keeper) OnAckPacket(
 
 // OnTimeoutPacket calls the contract to let it know the packet was never received on the destination chain within
 // the timeout boundaries.
-// The contract should handle this on the application level and undo the original operation
+// The contract should handle this on the application level and undo the original operation.
+// The message contains the original packet so that the contract can send it again. The IBC channel query for the
+// source endpoint tells whether the channel is still open; ordered channels are closed on timeout.
 func (k Keeper) OnTimeoutPacket(
 	ctx sdk.Context,
 	contractAddr sdk.AccAddress,
@@ -253,9 +323,9 @@ func (k Keeper) OnTimeoutPacket(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCPacketTimeout(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -264,7 +334,7 @@ func (k Keeper) OnTimeoutPacket(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
@@ -287,9 +357,9 @@ func (k Keeper) IBCSourceCallback(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCSourceCallback(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -298,7 +368,7 @@ func (k Keeper) IBCSourceCallback(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
@@ -321,9 +391,9 @@ func (k Keeper) IBCDestinationCallback(
 	env := types.NewEnv(ctx, contractAddr)
 	querier := k.newQueryHandler(ctx, contractAddr)
 
-	gasLeft := k.runtimeGasForContract(ctx)
+	gasLeft := k.runtimeGasForContract(ctx, contractAddr)
 	res, gasUsed, execErr := k.wasmVM.IBCDestinationCallback(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, ctx.GasMeter(), gasLeft, costJSONDeserialization)
-	k.consumeRuntimeGas(ctx, gasUsed)
+	k.consumeRuntimeGas(ctx, contractAddr, gasUsed)
 	if execErr != nil {
 		return errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
@@ -332,7 +402,7 @@ func (k Keeper) IBCDestinationCallback(
 		return errorsmod.Wrap(types.ErrVMError, "internal wasmvm error")
 	}
 	if res.Err != "" {
-		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
+		return types.MarkErrorDeterministic(types.NewErrContractExecutionFailed(types.ErrExecuteFailed, res.Err))
 	}
 
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
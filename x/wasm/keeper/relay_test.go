@@ -8,6 +8,7 @@ import (
 
 	wasmvm "github.com/CosmWasm/wasmvm/v3"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -363,6 +364,22 @@ func TestOnRecvPacket(t *testing.T) {
 			},
 			expAck: []byte(`{"error":"my-error"}`), // without error msg redaction
 		},
+		"contract typed Err result converted to typed error Ack": {
+			contractAddr:   example.Contract,
+			expContractGas: myContractGas,
+			contractResp: &wasmvmtypes.IBCReceiveResult{
+				Err: `{"error_code":5,"data":"bXlEYXRh"}`,
+			},
+			expAck: []byte(`{"data":"bXlEYXRh","error":"contract error code: 5","error_code":5}`),
+		},
+		"contract Err result with other json converted to error Ack": {
+			contractAddr:   example.Contract,
+			expContractGas: myContractGas,
+			contractResp: &wasmvmtypes.IBCReceiveResult{
+				Err: `{"error_code":5,"other":"value"}`,
+			},
+			expAck: []byte(`{"error":"{\"error_code\":5,\"other\":\"value\"}"}`),
+		},
 		"contract aborts tx with error": {
 			contractAddr:   example.Contract,
 			expContractGas: myContractGas,
@@ -457,7 +474,7 @@ func TestOnRecvPacket(t *testing.T) {
 				m.ReplyFn = spec.mockReplyFn
 				h, ok := keepers.WasmKeeper.wasmVMResponseHandler.(*DefaultWasmVMContractResponseHandler)
 				require.True(t, ok)
-				h.md = NewMessageDispatcher(messenger, keepers.WasmKeeper)
+				h.md = NewMessageDispatcher(messenger, keepers.WasmKeeper, keepers.WasmKeeper)
 			}
 
 			ctx, _ := parentCtx.CacheContext()
@@ -738,6 +755,151 @@ func TestOnTimeoutPacket(t *testing.T) {
 	}
 }
 
+func TestOnTimeoutPacketRetryQuery(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeIBCInstantiable(&m)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	myPacket := wasmvmtypes.IBCPacket{
+		Data: []byte("my test packet"),
+		Src:  wasmvmtypes.IBCEndpoint{PortID: "wasm.myPort", ChannelID: "channel-0"},
+	}
+
+	specs := map[string]struct {
+		channel  *channeltypes.Channel
+		expRetry bool
+	}{
+		"open unordered channel": {
+			channel:  &channeltypes.Channel{State: channeltypes.OPEN, Ordering: channeltypes.UNORDERED, ConnectionHops: []string{"connection-0"}},
+			expRetry: true,
+		},
+		"closed ordered channel": {
+			channel: &channeltypes.Channel{State: channeltypes.CLOSED, Ordering: channeltypes.ORDERED, ConnectionHops: []string{"connection-0"}},
+		},
+		"unknown channel": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.channel != nil {
+				keepers.IBCKeeper.ChannelKeeper.SetChannel(ctx, myPacket.Src.PortID, myPacket.Src.ChannelID, *spec.channel)
+			}
+			// the contract decides about a retry with the channel query for the source endpoint of the packet
+			var gotMsg wasmvmtypes.IBCPacketTimeoutMsg
+			var gotRetry bool
+			m.IBCPacketTimeoutFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketTimeoutMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+				gotMsg = msg
+				bz, err := querier.Query(wasmvmtypes.QueryRequest{IBC: &wasmvmtypes.IBCQuery{Channel: &wasmvmtypes.ChannelQuery{
+					PortID:    msg.Packet.Src.PortID,
+					ChannelID: msg.Packet.Src.ChannelID,
+				}}}, gasLimit)
+				require.NoError(t, err)
+				var rsp wasmvmtypes.ChannelResponse
+				require.NoError(t, json.Unmarshal(bz, &rsp))
+				gotRetry = rsp.Channel != nil && rsp.Channel.Order == channeltypes.UNORDERED.String()
+				return &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}}, 0, nil
+			}
+
+			// when
+			err := keepers.WasmKeeper.OnTimeoutPacket(ctx, example.Contract, wasmvmtypes.IBCPacketTimeoutMsg{Packet: myPacket})
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, myPacket, gotMsg.Packet)
+			assert.Equal(t, spec.expRetry, gotRetry)
+		})
+	}
+}
+
+func TestOnTimeoutIBC2Packet(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeIBCInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := SeedNewContractInstance(t, ctx, keepers, &m)
+	myMsg := wasmvmtypes.IBC2PacketTimeoutMsg{
+		Payload: wasmvmtypes.IBC2Payload{
+			SourcePort:      "wasm2" + example.Contract.String(),
+			DestinationPort: "wasm2destPort",
+			Version:         "v1",
+			Encoding:        "application/json",
+			Value:           []byte("my test payload"),
+		},
+		SourceClient:      "07-tendermint-0",
+		DestinationClient: "07-tendermint-1",
+		PacketSequence:    1,
+	}
+	var gotMsg wasmvmtypes.IBC2PacketTimeoutMsg
+	m.IBC2PacketTimeoutFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBC2PacketTimeoutMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+		gotMsg = msg
+		return &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}}, 0, nil
+	}
+
+	// when
+	err := keepers.WasmKeeper.OnTimeoutIBC2Packet(ctx, example.Contract, myMsg)
+
+	// then
+	require.NoError(t, err)
+	// the original payload is passed to the contract to send it again
+	assert.Equal(t, myMsg, gotMsg)
+}
+
+func TestOnRecvPacketChannelQuery(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeIBCInstantiable(&m)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	myPacket := wasmvmtypes.IBCPacket{
+		Data: []byte("my test packet"),
+		Src:  wasmvmtypes.IBCEndpoint{PortID: "wasm.srcPort", ChannelID: "channel-0"},
+		Dest: wasmvmtypes.IBCEndpoint{PortID: "wasm.destPort", ChannelID: "channel-1"},
+	}
+
+	specs := map[string]struct {
+		channel    channeltypes.Channel
+		expOrder   string
+		expVersion string
+	}{
+		"ordered channel": {
+			channel:    channeltypes.Channel{State: channeltypes.OPEN, Ordering: channeltypes.ORDERED, Version: "v1", ConnectionHops: []string{"connection-0"}},
+			expOrder:   "ORDER_ORDERED",
+			expVersion: "v1",
+		},
+		"unordered channel": {
+			channel:    channeltypes.Channel{State: channeltypes.OPEN, Ordering: channeltypes.UNORDERED, Version: "v2", ConnectionHops: []string{"connection-0"}},
+			expOrder:   "ORDER_UNORDERED",
+			expVersion: "v2",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			keepers.IBCKeeper.ChannelKeeper.SetChannel(ctx, myPacket.Dest.PortID, myPacket.Dest.ChannelID, spec.channel)
+			// the contract queries the channel of the destination endpoint of the packet
+			var gotChannel *wasmvmtypes.IBCChannel
+			m.IBCPacketReceiveFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketReceiveMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCReceiveResult, uint64, error) {
+				bz, err := querier.Query(wasmvmtypes.QueryRequest{IBC: &wasmvmtypes.IBCQuery{Channel: &wasmvmtypes.ChannelQuery{
+					PortID:    msg.Packet.Dest.PortID,
+					ChannelID: msg.Packet.Dest.ChannelID,
+				}}}, gasLimit)
+				require.NoError(t, err)
+				var rsp wasmvmtypes.ChannelResponse
+				require.NoError(t, json.Unmarshal(bz, &rsp))
+				gotChannel = rsp.Channel
+				return &wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: []byte("ack")}}, 0, nil
+			}
+
+			// when
+			_, err := keepers.WasmKeeper.OnRecvPacket(ctx, example.Contract, wasmvmtypes.IBCPacketReceiveMsg{Packet: myPacket})
+
+			// then
+			require.NoError(t, err)
+			require.NotNil(t, gotChannel)
+			assert.Equal(t, spec.expOrder, gotChannel.Order)
+			assert.Equal(t, spec.expVersion, gotChannel.Version)
+		})
+	}
+}
+
 func stripTypes(events sdk.Events) []string {
 	var r []string
 	for _, e := range events {
@@ -745,3 +907,47 @@ func stripTypes(events sdk.Events) []string {
 	}
 	return r
 }
+
+func TestParseContractErrorAck(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expAck ContractErrorAck
+		expOK  bool
+	}{
+		"code and data": {
+			src:    `{"error_code":5,"data":"bXlEYXRh"}`,
+			expAck: ContractErrorAck{ErrorCode: 5, Data: []byte("myData")},
+			expOK:  true,
+		},
+		"code only": {
+			src:    `{"error_code":1}`,
+			expAck: ContractErrorAck{ErrorCode: 1},
+			expOK:  true,
+		},
+		"zero code": {
+			src: `{"error_code":0,"data":"bXlEYXRh"}`,
+		},
+		"without code": {
+			src: `{"data":"bXlEYXRh"}`,
+		},
+		"unknown field": {
+			src: `{"error_code":5,"other":"value"}`,
+		},
+		"trailing content": {
+			src: `{"error_code":5}{"error_code":6}`,
+		},
+		"negative code": {
+			src: `{"error_code":-1}`,
+		},
+		"plain error message": {
+			src: "my-error",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotAck, gotOK := parseContractErrorAck(spec.src)
+			assert.Equal(t, spec.expOK, gotOK)
+			assert.Equal(t, spec.expAck, gotAck)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecuteReplay is the result of a replayed contract execution
+type ExecuteReplay struct {
+	// Data is the data returned by the contract
+	Data []byte `json:"data,omitempty"`
+	// Events are the events that the execution would have emitted. They are empty when the execution failed.
+	Events []abci.Event `json:"events"`
+	// GasUsed is the gas used by the execution, up to the gas limit
+	GasUsed storetypes.Gas `json:"gas_used"`
+	// Error is the error of a failed execution
+	Error string `json:"error,omitempty"`
+}
+
+// ReplayExecute re-runs a contract execution against the state of the context for debugging. The execution runs in
+// a throwaway cache of the context with the gas limit applied, like ExecuteWithGasLimit. No state changes are
+// written to the context, also on success. Errors of the execution are returned in the result.
+func (k Keeper) ReplayExecute(ctx sdk.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins, gasLimit storetypes.Gas) ExecuteReplay {
+	em := sdk.NewEventManager()
+	ctx, _ = ctx.CacheContext()
+	ctx = ctx.WithEventManager(em).WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	data, gasUsed, err := k.ExecuteWithGasLimit(ctx, contractAddress, caller, msg, coins, gasLimit)
+	r := ExecuteReplay{Data: data, Events: em.ABCIEvents(), GasUsed: gasUsed}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestReplayExecute(t *testing.T) {
+	// the contract writes a key, emits an event and uses the wasm gas from the msg
+	var wasmGasUsed uint64
+	var contractErr string
+	mock := wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			store.Set([]byte("foo"), []byte("bar"))
+			if contractErr != "" {
+				return &wasmvmtypes.ContractResult{Err: contractErr}, wasmGasUsed, nil
+			}
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{
+				Data:       []byte("ok"),
+				Attributes: []wasmvmtypes.EventAttribute{{Key: "action", Value: "replayed"}},
+			}}, wasmGasUsed, nil
+		},
+	}
+	wasmtesting.MakeInstantiable(&mock)
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &mock)
+
+	const gasLimit storetypes.Gas = 200_000
+	specs := map[string]struct {
+		wasmGasUsed uint64
+		contractErr string
+		expErr      string
+	}{
+		"success": {
+			wasmGasUsed: 1,
+		},
+		"exceeds limit": {
+			wasmGasUsed: k.gasRegister.ToWasmVMGas(gasLimit) + 1,
+			expErr:      "out of gas",
+		},
+		"contract error": {
+			wasmGasUsed: 1,
+			contractErr: "my error",
+			expErr:      "my error",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			wasmGasUsed, contractErr = spec.wasmGasUsed, spec.contractErr
+			gasBefore := ctx.GasMeter().GasConsumed()
+
+			// when
+			got := k.ReplayExecute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil, gasLimit)
+
+			// then
+			assert.NotZero(t, got.GasUsed)
+			assert.LessOrEqual(t, got.GasUsed, gasLimit)
+			// and the gas and state of the context are not modified. The raw query is charged, so that the gas is
+			// compared before.
+			assert.Equal(t, gasBefore, ctx.GasMeter().GasConsumed())
+			assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("foo")))
+			assert.Empty(t, ctx.EventManager().Events())
+			if spec.expErr != "" {
+				assert.Contains(t, got.Error, spec.expErr)
+				assert.Nil(t, got.Data)
+				assert.Empty(t, got.Events)
+				return
+			}
+			assert.Empty(t, got.Error)
+			assert.Equal(t, []byte("ok"), got.Data)
+			var eventTypes []string
+			for _, e := range got.Events {
+				eventTypes = append(eventTypes, e.Type)
+			}
+			assert.Contains(t, eventTypes, types.EventTypeExecute)
+			assert.Contains(t, eventTypes, types.WasmModuleEventType)
+		})
+	}
+}
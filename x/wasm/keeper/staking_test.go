@@ -3,6 +3,7 @@ package keeper
 import (
 	"encoding/json"
 	"os"
+	"reflect"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
@@ -17,6 +18,7 @@ import (
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
 	distributionkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
@@ -662,6 +664,149 @@ func TestQueryStakingPlugin(t *testing.T) {
 	assert.Empty(t, res2.Delegation)
 }
 
+func TestQueryDistributionPlugin(t *testing.T) {
+	initInfo := initializeStaking(t)
+	ctx, valAddr, contractAddr := initInfo.ctx, initInfo.valAddr, initInfo.contractAddr
+	stakingKeeper := initInfo.stakingKeeper
+	distKeeper := initInfo.distKeeper
+
+	// the contract delegates 200k to a validator with 1M self-bond
+	funds := sdk.NewCoins(sdk.NewInt64Coin("stake", 200000))
+	bob := initInfo.faucet.NewFundedRandomAccount(ctx, funds...)
+	bondBz, err := json.Marshal(StakingHandleMsg{Bond: &struct{}{}})
+	require.NoError(t, err)
+	_, err = initInfo.contractKeeper.Execute(ctx, contractAddr, bob, bondBz, funds)
+	require.NoError(t, err)
+	ctx = nextBlock(ctx, stakingKeeper)
+	// the contract gets 1/6 of the rewards minus 10% commission = 36k
+	setValidatorRewards(ctx, stakingKeeper, distKeeper, valAddr, "240000")
+	expRewards := []wasmvmtypes.DecCoin{{Denom: "stake", Amount: "36000.000000000000000000"}}
+
+	specs := map[string]struct {
+		query  wasmvmtypes.DistributionQuery
+		expRsp any
+		expErr bool
+	}{
+		"delegation rewards": {
+			query: wasmvmtypes.DistributionQuery{DelegationRewards: &wasmvmtypes.DelegationRewardsQuery{
+				DelegatorAddress: contractAddr.String(),
+				ValidatorAddress: valAddr.String(),
+			}},
+			expRsp: &wasmvmtypes.DelegationRewardsResponse{Rewards: expRewards},
+		},
+		"delegation total rewards": {
+			query: wasmvmtypes.DistributionQuery{DelegationTotalRewards: &wasmvmtypes.DelegationTotalRewardsQuery{
+				DelegatorAddress: contractAddr.String(),
+			}},
+			expRsp: &wasmvmtypes.DelegationTotalRewardsResponse{
+				Rewards: []wasmvmtypes.DelegatorReward{{Reward: expRewards, ValidatorAddress: valAddr.String()}},
+				Total:   expRewards,
+			},
+		},
+		"delegator withdraw address": {
+			query: wasmvmtypes.DistributionQuery{DelegatorWithdrawAddress: &wasmvmtypes.DelegatorWithdrawAddressQuery{
+				DelegatorAddress: contractAddr.String(),
+			}},
+			expRsp: &wasmvmtypes.DelegatorWithdrawAddressResponse{WithdrawAddress: contractAddr.String()},
+		},
+		"delegation rewards without delegation": {
+			query: wasmvmtypes.DistributionQuery{DelegationRewards: &wasmvmtypes.DelegationRewardsQuery{
+				DelegatorAddress: bob.String(),
+				ValidatorAddress: valAddr.String(),
+			}},
+			expErr: true,
+		},
+		"unknown query": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			origReward, err := distKeeper.GetValidatorCurrentRewards(ctx, valAddr)
+			require.NoError(t, err)
+
+			// when
+			raw, gotErr := DistributionQuerier(distributionkeeper.NewQuerier(distKeeper))(ctx, &spec.query)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			gotRsp := reflect.New(reflect.TypeOf(spec.expRsp).Elem()).Interface()
+			mustUnmarshal(t, raw, gotRsp)
+			assert.Equal(t, spec.expRsp, gotRsp)
+			// and the rewards are not modified by the query
+			finalReward, err := distKeeper.GetValidatorCurrentRewards(ctx, valAddr)
+			require.NoError(t, err)
+			assert.Equal(t, origReward, finalReward)
+		})
+	}
+}
+
+func TestContractDistributionMsgs(t *testing.T) {
+	initInfo := initializeStaking(t)
+	ctx, valAddr := initInfo.ctx, initInfo.valAddr
+	stakingKeeper, distKeeper, bankKeeper := initInfo.stakingKeeper, initInfo.distKeeper, initInfo.bankKeeper
+
+	// the reflect contract delegates 200k to a validator with 1M self-bond
+	funds := sdk.NewCoins(sdk.NewInt64Coin("stake", 200000))
+	creator := initInfo.faucet.NewFundedRandomAccount(ctx, funds...)
+	reflectID, _, err := initInfo.contractKeeper.Create(ctx, creator, testdata.ReflectContractWasm(), nil)
+	require.NoError(t, err)
+	reflectAddr, _, err := initInfo.contractKeeper.Instantiate(ctx, reflectID, creator, nil, []byte("{}"), "reflect", funds)
+	require.NoError(t, err)
+	reflectMsgs := func(msgs ...wasmvmtypes.CosmosMsg) []byte {
+		bz, err := json.Marshal(testdata.ReflectHandleMsg{Reflect: &testdata.ReflectPayload{Msgs: msgs}})
+		require.NoError(t, err)
+		return bz
+	}
+	_, err = initInfo.contractKeeper.Execute(ctx, reflectAddr, creator, reflectMsgs(wasmvmtypes.CosmosMsg{
+		Staking: &wasmvmtypes.StakingMsg{Delegate: &wasmvmtypes.DelegateMsg{Validator: valAddr.String(), Amount: wasmvmtypes.NewCoin(200000, "stake")}},
+	}), nil)
+	require.NoError(t, err)
+	ctx = nextBlock(ctx, stakingKeeper)
+	// the contract gets 1/6 of the rewards minus 10% commission = 36k
+	setValidatorRewards(ctx, stakingKeeper, distKeeper, valAddr, "240000")
+
+	// when the contract sets a withdraw address and claims the rewards
+	withdrawAddr := RandomAccountAddress(t)
+	em := sdk.NewEventManager()
+	_, err = initInfo.contractKeeper.Execute(ctx.WithEventManager(em), reflectAddr, creator, reflectMsgs(
+		wasmvmtypes.CosmosMsg{Distribution: &wasmvmtypes.DistributionMsg{
+			SetWithdrawAddress: &wasmvmtypes.SetWithdrawAddressMsg{Address: withdrawAddr.String()},
+		}},
+		wasmvmtypes.CosmosMsg{Distribution: &wasmvmtypes.DistributionMsg{
+			WithdrawDelegatorReward: &wasmvmtypes.WithdrawDelegatorRewardMsg{Validator: valAddr.String()},
+		}},
+	), nil)
+
+	// then
+	require.NoError(t, err)
+	gotWithdrawAddr, err := distKeeper.GetDelegatorWithdrawAddr(ctx, reflectAddr)
+	require.NoError(t, err)
+	assert.Equal(t, withdrawAddr, gotWithdrawAddr)
+	assert.Equal(t, sdk.NewInt64Coin("stake", 36000), bankKeeper.GetBalance(ctx, withdrawAddr, "stake"))
+	assert.True(t, bankKeeper.GetBalance(ctx, reflectAddr, "stake").IsZero())
+	// and the distribution events are emitted with the contract as delegator
+	var gotTypes []string
+	for _, e := range em.Events() {
+		gotTypes = append(gotTypes, e.Type)
+	}
+	assert.Contains(t, gotTypes, distributiontypes.EventTypeSetWithdrawAddress)
+	assert.Contains(t, gotTypes, distributiontypes.EventTypeWithdrawRewards)
+}
+
+func TestQueryDistributionPluginWithoutKeeper(t *testing.T) {
+	query := wasmvmtypes.DistributionQuery{DelegatorWithdrawAddress: &wasmvmtypes.DelegatorWithdrawAddressQuery{
+		DelegatorAddress: RandomBech32AccountAddress(t),
+	}}
+	_, gotErr := DistributionQuerier(nil)(sdk.Context{}, &query)
+	var unsupported wasmvmtypes.UnsupportedRequest
+	require.ErrorAs(t, gotErr, &unsupported)
+}
+
 // adds a few validators and returns a list of validators that are registered
 func addValidator(t *testing.T, ctx sdk.Context, stakingKeeper *stakingkeeper.Keeper, faucet *TestFaucet, value sdk.Coin) sdk.ValAddress {
 	owner := faucet.NewFundedRandomAccount(ctx, value)
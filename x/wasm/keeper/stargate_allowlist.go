@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"context"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// UpdateStargateAllowlist adds and removes the query paths that contracts are allowed to query via Stargate.
+// Paths to add must not be allowed already, paths to remove must be allowed.
+func (k Keeper) UpdateStargateAllowlist(ctx context.Context, add, remove []string) error {
+	for _, path := range add {
+		if err := types.ValidateStargateQueryPath(path); err != nil {
+			return err
+		}
+		if k.IsStargateQueryAllowed(ctx, path) {
+			return errorsmod.Wrapf(types.ErrDuplicate, "query path %q already allowed", path)
+		}
+		if err := k.setStargateQueryAllowed(ctx, path); err != nil {
+			return err
+		}
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	for _, path := range remove {
+		if !k.IsStargateQueryAllowed(ctx, path) {
+			return errorsmod.Wrapf(types.ErrNotFound, "query path %q not allowed", path)
+		}
+		if err := store.Delete(types.GetStargateAllowlistKey(path)); err != nil {
+			return err
+		}
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeStargateAllowlist,
+		sdk.NewAttribute(types.AttributeKeyAddedQueryPaths, strings.Join(add, ",")),
+		sdk.NewAttribute(types.AttributeKeyRemovedQueryPaths, strings.Join(remove, ",")),
+	))
+	return nil
+}
+
+// IsStargateQueryAllowed returns true when contracts are allowed to query the path via Stargate
+func (k Keeper) IsStargateQueryAllowed(ctx context.Context, path string) bool {
+	ok, err := k.storeService.OpenKVStore(ctx).Has(types.GetStargateAllowlistKey(path))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+// GetStargateAllowlist returns the query paths that contracts are allowed to query via Stargate in ascending order
+func (k Keeper) GetStargateAllowlist(ctx context.Context) []string {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.StargateAllowlistPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	var r []string
+	for ; iter.Valid(); iter.Next() {
+		r = append(r, string(iter.Key()))
+	}
+	return r
+}
+
+func (k Keeper) setStargateQueryAllowed(ctx context.Context, path string) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetStargateAllowlistKey(path), []byte{1})
+}
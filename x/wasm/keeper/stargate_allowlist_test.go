@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestUpdateStargateAllowlist(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	const (
+		myPath      = "/cosmos.bank.v1beta1.Query/Balance"
+		myOtherPath = "/cosmos.auth.v1beta1.Query/Account"
+	)
+	require.NoError(t, k.UpdateStargateAllowlist(parentCtx, []string{myPath}, nil))
+
+	specs := map[string]struct {
+		add     []string
+		remove  []string
+		expErr  error
+		expList []string
+	}{
+		"add": {
+			add:     []string{myOtherPath},
+			expList: []string{myOtherPath, myPath},
+		},
+		"remove": {
+			remove: []string{myPath},
+		},
+		"add and remove": {
+			add:     []string{myOtherPath},
+			remove:  []string{myPath},
+			expList: []string{myOtherPath},
+		},
+		"add existing": {
+			add:    []string{myPath},
+			expErr: types.ErrDuplicate,
+		},
+		"remove not existing": {
+			remove: []string{myOtherPath},
+			expErr: types.ErrNotFound,
+		},
+		"add invalid path": {
+			add:    []string{"cosmos.bank.v1beta1.Query/Balance"},
+			expErr: types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+
+			// when
+			gotErr := k.UpdateStargateAllowlist(ctx, spec.add, spec.remove)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expList, k.GetStargateAllowlist(ctx))
+			for _, p := range spec.expList {
+				assert.True(t, k.IsStargateQueryAllowed(ctx, p))
+			}
+			for _, p := range spec.remove {
+				assert.False(t, k.IsStargateQueryAllowed(ctx, p))
+			}
+		})
+	}
+}
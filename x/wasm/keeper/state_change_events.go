@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+var _ wasmvm.KVStore = stateChangeEventStore{}
+
+// stateChangeEventStore is a decorator for the contract store that is passed to wasmvm. It emits an event with the
+// sha256 hash of the key for every write and delete so that indexers can track contract storage.
+// The events are not charged and do not modify any state.
+type stateChangeEventStore struct {
+	wasmvm.KVStore
+	ctx          sdk.Context
+	contractAddr sdk.AccAddress
+}
+
+func (s stateChangeEventStore) Set(key, value []byte) {
+	s.KVStore.Set(key, value)
+	s.emit(types.EventTypeDBWrite, key)
+}
+
+func (s stateChangeEventStore) Delete(key []byte) {
+	s.KVStore.Delete(key)
+	s.emit(types.EventTypeDBRemove, key)
+}
+
+func (s stateChangeEventStore) emit(eventType string, key []byte) {
+	keyHash := sha256.Sum256(key)
+	s.ctx.EventManager().EmitEvent(sdk.NewEvent(
+		eventType,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, s.contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyStateKeyHash, hex.EncodeToString(keyHash[:])),
+	))
+}
+
+// withStateChangeEvents decorates the contract store to emit state change events when enabled by the params.
+func (k Keeper) withStateChangeEvents(ctx sdk.Context, contractAddr sdk.AccAddress, store wasmvm.KVStore) wasmvm.KVStore {
+	if !k.GetCachedParams(ctx).EmitStateChangeEvents {
+		return store
+	}
+	return stateChangeEventStore{KVStore: store, ctx: ctx, contractAddr: contractAddr}
+}
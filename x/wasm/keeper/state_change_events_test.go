@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestStateChangeEvents(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	require.NoError(t, k.importContractState(parentCtx, example.Contract, []types.Model{{Key: []byte("bar"), Value: []byte("1")}}))
+
+	// the contract writes one key and deletes another
+	modifyState := func(store wasmvm.KVStore) (*wasmvmtypes.ContractResult, uint64, error) {
+		store.Set([]byte("foo"), []byte("2"))
+		store.Delete([]byte("bar"))
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	k.wasmVM = &wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return modifyState(store)
+		},
+		SudoFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			return modifyState(store)
+		},
+	}
+	keyHash := func(key string) string {
+		h := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(h[:])
+	}
+	exec := func(ctx sdk.Context) error {
+		_, err := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		return err
+	}
+	sudo := func(ctx sdk.Context) error {
+		_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+		return err
+	}
+
+	specs := map[string]struct {
+		enabled   bool
+		call      func(ctx sdk.Context) error
+		expEvents sdk.Events
+	}{
+		"execute - enabled": {
+			enabled: true,
+			call:    exec,
+			expEvents: sdk.Events{
+				sdk.NewEvent(types.EventTypeDBWrite,
+					sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+					sdk.NewAttribute(types.AttributeKeyStateKeyHash, keyHash("foo"))),
+				sdk.NewEvent(types.EventTypeDBRemove,
+					sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+					sdk.NewAttribute(types.AttributeKeyStateKeyHash, keyHash("bar"))),
+			},
+		},
+		"sudo - enabled": {
+			enabled: true,
+			call:    sudo,
+			expEvents: sdk.Events{
+				sdk.NewEvent(types.EventTypeDBWrite,
+					sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+					sdk.NewAttribute(types.AttributeKeyStateKeyHash, keyHash("foo"))),
+				sdk.NewEvent(types.EventTypeDBRemove,
+					sdk.NewAttribute(types.AttributeKeyContractAddr, example.Contract.String()),
+					sdk.NewAttribute(types.AttributeKeyStateKeyHash, keyHash("bar"))),
+			},
+		},
+		"execute - disabled": {
+			call: exec,
+		},
+		"sudo - disabled": {
+			call: sudo,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.EmitStateChangeEvents = spec.enabled
+			require.NoError(t, k.SetParams(ctx, params))
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em).WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+			// when
+			require.NoError(t, spec.call(ctx))
+
+			// then
+			gasUsed := ctx.GasMeter().GasConsumed()
+			var gotEvents sdk.Events
+			for _, e := range em.Events() {
+				if e.Type == types.EventTypeDBWrite || e.Type == types.EventTypeDBRemove {
+					gotEvents = append(gotEvents, e)
+				}
+			}
+			assert.Equal(t, spec.expEvents, gotEvents)
+			// and the state is modified the same way
+			assert.Equal(t, []byte("2"), k.QueryRaw(ctx, example.Contract, []byte("foo")))
+			assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("bar")))
+			// and no additional gas is consumed
+			params.EmitStateChangeEvents = !spec.enabled
+			otherCtx, _ := parentCtx.CacheContext()
+			require.NoError(t, k.SetParams(otherCtx, params))
+			otherCtx = otherCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+			require.NoError(t, spec.call(otherCtx))
+			assert.Equal(t, gasUsed, otherCtx.GasMeter().GasConsumed())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"strconv"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// stateCleanupTracker counts the key and value bytes that a contract call adds to and removes from the
+// contract state. Overwrites count the old entry as removed and the new entry as added.
+type stateCleanupTracker struct {
+	added, removed uint64
+	// writeCost is the gas charged for the writes and deletes of the contract state
+	writeCost storetypes.Gas
+}
+
+// netRemoved returns the number of bytes that were removed from the contract state in net
+func (t *stateCleanupTracker) netRemoved() uint64 {
+	if t.removed <= t.added {
+		return 0
+	}
+	return t.removed - t.added
+}
+
+var _ wasmvm.KVStore = stateCleanupStore{}
+
+// stateCleanupStore is a decorator for the contract store that is passed to wasmvm. It tracks the size of the
+// written and removed entries and the gas charged for them for the state cleanup refund. The read of an existing
+// entry to get its size is charged like any other read of the contract.
+type stateCleanupStore struct {
+	wasmvm.KVStore
+	tracker  *stateCleanupTracker
+	gasMeter storetypes.GasMeter
+}
+
+func (s stateCleanupStore) Set(key, value []byte) {
+	s.tracker.removed += s.size(key)
+	s.tracker.added += uint64(len(key) + len(value))
+	gasBefore := s.gasMeter.GasConsumed()
+	s.KVStore.Set(key, value)
+	s.tracker.writeCost += s.gasMeter.GasConsumed() - gasBefore
+}
+
+func (s stateCleanupStore) Delete(key []byte) {
+	s.tracker.removed += s.size(key)
+	gasBefore := s.gasMeter.GasConsumed()
+	s.KVStore.Delete(key)
+	s.tracker.writeCost += s.gasMeter.GasConsumed() - gasBefore
+}
+
+func (s stateCleanupStore) size(key []byte) uint64 {
+	old := s.KVStore.Get(key)
+	if old == nil {
+		return 0
+	}
+	return uint64(len(key) + len(old))
+}
+
+// withStateCleanupTracking decorates the contract store to track the removed state when the state cleanup refund
+// is enabled by the params. The tracker is nil otherwise.
+func (k Keeper) withStateCleanupTracking(ctx sdk.Context, store wasmvm.KVStore) (wasmvm.KVStore, *stateCleanupTracker) {
+	params := k.GetCachedParams(ctx)
+	if params.StateCleanupRefundPerByte == 0 || params.MaxStateCleanupRefund == 0 {
+		return store, nil
+	}
+	tracker := &stateCleanupTracker{}
+	return stateCleanupStore{KVStore: store, tracker: tracker, gasMeter: ctx.GasMeter()}, tracker
+}
+
+// refundStateCleanupGas refunds gas for the bytes that the contract call removed from the contract state in net.
+// The refund is calculated with the params and never exceeds the gas charged for the writes and deletes of the
+// call, so that the call can not reduce the gas consumed by other operations.
+func (k Keeper) refundStateCleanupGas(ctx sdk.Context, contractAddr sdk.AccAddress, tracker *stateCleanupTracker) {
+	if tracker == nil {
+		return
+	}
+	netRemoved := tracker.netRemoved()
+	refund := min(k.GetCachedParams(ctx).StateCleanupRefund(netRemoved), tracker.writeCost)
+	if refund == 0 {
+		return
+	}
+	ctx.GasMeter().RefundGas(refund, types.GasDescStateCleanupRefund)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeStateCleanupRefund,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyRemovedBytes, strconv.FormatUint(netRemoved, 10)),
+		sdk.NewAttribute(types.AttributeKeyRefundedGas, strconv.FormatUint(refund, 10)),
+	))
+}
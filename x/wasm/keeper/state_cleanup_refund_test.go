@@ -0,0 +1,257 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestStateCleanupRefund(t *testing.T) {
+	const (
+		entries   = 10
+		valueSize = 100
+	)
+	key := func(i int) []byte { return []byte(fmt.Sprintf("key-%02d", i)) }
+	entrySize := uint64(len(key(0)) + valueSize)
+
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	// the contract fills or cleans up its state depending on the message
+	m.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, msg []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		for i := 0; i < entries; i++ {
+			switch string(msg) {
+			case `"fill"`:
+				store.Set(key(i), bytes.Repeat([]byte{1}, valueSize))
+			case `"shrink"`:
+				store.Set(key(i), bytes.Repeat([]byte{1}, valueSize-1))
+			case `"clean"`:
+				store.Delete(key(i))
+			}
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	// the size lookup reads the entry with the contract store prefix
+	readCost := storetypes.KVGasConfig().ReadCostFlat + storetypes.KVGasConfig().ReadCostPerByte*(uint64(len(types.GetContractStorePrefix(example.Contract)))+entrySize)
+	_, err := keepers.ContractKeeper.Execute(parentCtx, example.Contract, example.CreatorAddr, []byte(`"fill"`), nil)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		msg       string
+		perByte   uint64
+		threshold uint64
+		maxRefund uint64
+		expRefund uint64
+	}{
+		"clean up": {
+			msg:       `"clean"`,
+			perByte:   5,
+			maxRefund: 1_000_000,
+			expRefund: entries * entrySize * 5,
+		},
+		"clean up capped at the write cost": {
+			msg:       `"clean"`,
+			perByte:   100,
+			maxRefund: 1_000_000,
+			expRefund: entries * storetypes.KVGasConfig().DeleteCost,
+		},
+		"clean up capped": {
+			msg:       `"clean"`,
+			perByte:   10,
+			maxRefund: 1000,
+			expRefund: 1000,
+		},
+		"clean up below threshold": {
+			msg:       `"clean"`,
+			perByte:   5,
+			threshold: entries*entrySize + 1,
+			maxRefund: 1_000_000,
+		},
+		"overwrite with smaller values": {
+			msg:       `"shrink"`,
+			perByte:   10,
+			maxRefund: 1_000_000,
+			expRefund: entries * 10,
+		},
+		"overwrite with same size": {
+			msg:       `"fill"`,
+			perByte:   10,
+			maxRefund: 1_000_000,
+		},
+		"disabled by default": {
+			msg: `"clean"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			execute := func(params types.Params) (uint64, []byte) {
+				ctx, _ := parentCtx.CacheContext()
+				require.NoError(t, k.SetParams(ctx, params))
+				ctx = ctx.WithGasMeter(storetypes.NewGasMeter(10_000_000))
+				_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(spec.msg), nil)
+				require.NoError(t, err)
+				// the state is the same with and without refund
+				return ctx.GasMeter().GasConsumed(), k.QueryRaw(ctx, example.Contract, key(0))
+			}
+			params := k.GetParams(parentCtx)
+			gasWithoutRefund, stateWithoutRefund := execute(params)
+
+			// when
+			params.StateCleanupRefundPerByte = spec.perByte
+			params.StateCleanupRefundThreshold = spec.threshold
+			params.MaxStateCleanupRefund = spec.maxRefund
+			gasWithRefund, stateWithRefund := execute(params)
+
+			// then the refund is reduced by the charged size lookup of the existing entries
+			var lookupCost uint64
+			if spec.perByte != 0 {
+				lookupCost = entries * readCost
+			}
+			assert.Equal(t, int64(spec.expRefund)-int64(lookupCost), int64(gasWithoutRefund)-int64(gasWithRefund))
+			assert.Equal(t, stateWithoutRefund, stateWithRefund)
+		})
+	}
+}
+
+func TestStateCleanupRefundEntryPoints(t *testing.T) {
+	const (
+		entries   = 10
+		valueSize = 100
+	)
+	key := func(i int) []byte { return []byte(fmt.Sprintf("key-%02d", i)) }
+	entrySize := uint64(len(key(0)) + valueSize)
+	fill := func(store wasmvm.KVStore) {
+		for i := 0; i < entries; i++ {
+			store.Set(key(i), bytes.Repeat([]byte{1}, valueSize))
+		}
+	}
+	clean := func(store wasmvm.KVStore) {
+		for i := 0; i < entries; i++ {
+			store.Delete(key(i))
+		}
+	}
+	okResult := &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}
+
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	m.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		fill(store)
+		return okResult, 0, nil
+	}
+	m.MigrateWithInfoFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, _ wasmvmtypes.MigrateInfo, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		clean(store)
+		return okResult, 0, nil
+	}
+	m.SudoFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		clean(store)
+		return okResult, 0, nil
+	}
+	m.ReplyFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.Reply, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		clean(store)
+		return okResult, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	// the size lookup reads the entry with the contract store prefix
+	readCost := storetypes.KVGasConfig().ReadCostFlat + storetypes.KVGasConfig().ReadCostPerByte*(uint64(len(types.GetContractStorePrefix(example.Contract)))+entrySize)
+	_, err := keepers.ContractKeeper.Execute(parentCtx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	specs := map[string]func(ctx sdk.Context) error{
+		"migrate": func(ctx sdk.Context) error {
+			_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, example.CodeID, []byte(`{}`))
+			return err
+		},
+		"sudo": func(ctx sdk.Context) error {
+			_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+			return err
+		},
+		"reply": func(ctx sdk.Context) error {
+			_, err := k.reply(ctx, example.Contract, wasmvmtypes.Reply{Result: wasmvmtypes.SubMsgResult{Ok: &wasmvmtypes.SubMsgResponse{}}})
+			return err
+		},
+	}
+	for name, cleanUp := range specs {
+		t.Run(name, func(t *testing.T) {
+			run := func(params types.Params) (uint64, []byte) {
+				ctx, _ := parentCtx.CacheContext()
+				require.NoError(t, k.SetParams(ctx, params))
+				ctx = ctx.WithGasMeter(storetypes.NewGasMeter(10_000_000))
+				require.NoError(t, cleanUp(ctx))
+				return ctx.GasMeter().GasConsumed(), k.QueryRaw(ctx, example.Contract, key(0))
+			}
+			params := k.GetParams(parentCtx)
+			gasWithoutRefund, stateWithoutRefund := run(params)
+
+			// when
+			params.StateCleanupRefundPerByte = 5
+			params.MaxStateCleanupRefund = 1_000_000
+			gasWithRefund, stateWithRefund := run(params)
+
+			// then the refund is reduced by the charged size lookup of the existing entries
+			assert.Equal(t, int64(entries*entrySize*5)-int64(entries*readCost), int64(gasWithoutRefund)-int64(gasWithRefund))
+			assert.Nil(t, stateWithoutRefund)
+			assert.Equal(t, stateWithoutRefund, stateWithRefund)
+		})
+	}
+}
+
+func TestStateCleanupRefundEvent(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	m.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, msg []byte, store wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		if string(msg) == `"fill"` {
+			store.Set([]byte("key"), []byte("value"))
+		} else {
+			store.Delete([]byte("key"))
+		}
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &m)
+	params := k.GetParams(ctx)
+	params.StateCleanupRefundPerByte = 10
+	params.MaxStateCleanupRefund = 1000
+	require.NoError(t, k.SetParams(ctx, params))
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`"fill"`), nil)
+	require.NoError(t, err)
+
+	// when
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	_, err = keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`"clean"`), nil)
+	require.NoError(t, err)
+
+	// then
+	var found bool
+	for _, e := range ctx.EventManager().Events() {
+		if e.Type != types.EventTypeStateCleanupRefund {
+			continue
+		}
+		found = true
+		attrs := make(map[string]string)
+		for _, a := range e.Attributes {
+			attrs[a.Key] = a.Value
+		}
+		assert.Equal(t, example.Contract.String(), attrs[types.AttributeKeyContractAddr])
+		assert.Equal(t, strconv.Itoa(len("key")+len("value")), attrs[types.AttributeKeyRemovedBytes])
+		assert.Equal(t, strconv.Itoa((len("key")+len("value"))*10), attrs[types.AttributeKeyRefundedGas])
+	}
+	assert.True(t, found)
+}
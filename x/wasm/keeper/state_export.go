@@ -0,0 +1,38 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// ContractStateRecord is a single contract state entry as written by StreamExportContractState
+type ContractStateRecord struct {
+	ContractAddress string            `json:"contract_address"`
+	Key             cmtbytes.HexBytes `json:"key"`
+	Value           []byte            `json:"value"`
+}
+
+// StreamExportContractState writes the state of all contracts as newline delimited JSON records to the writer.
+// The records are written while iterating the store so that the state is never held in memory as a whole.
+// Contracts are exported in address order, the state of a contract in key order.
+func (k Keeper) StreamExportContractState(ctx context.Context, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	var err error
+	k.IterateContractInfo(ctx, func(addr sdk.AccAddress, _ types.ContractInfo) bool {
+		k.IterateContractState(ctx, addr, func(key, value []byte) bool {
+			err = enc.Encode(ContractStateRecord{ContractAddress: addr.String(), Key: key, Value: value})
+			return err != nil
+		})
+		return err != nil
+	})
+	return errorsmod.Wrap(err, "export contract state")
+}
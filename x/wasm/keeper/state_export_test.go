@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestStreamExportContractState(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example1 := InstantiateHackatomExampleContract(t, ctx, keepers)
+	example2 := InstantiateHackatomExampleContract(t, ctx, keepers)
+
+	var buf bytes.Buffer
+
+	// when
+	require.NoError(t, k.StreamExportContractState(ctx, &buf))
+
+	// then
+	exp := make(map[string][]ContractStateRecord)
+	for _, addr := range []sdk.AccAddress{example1.Contract, example2.Contract} {
+		for _, m := range contractState(ctx, k, addr) {
+			exp[addr.String()] = append(exp[addr.String()], ContractStateRecord{ContractAddress: addr.String(), Key: m.Key, Value: m.Value})
+		}
+	}
+	got := make(map[string][]ContractStateRecord)
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var r ContractStateRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		got[r.ContractAddress] = append(got[r.ContractAddress], r)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, exp, got)
+
+	// and writer errors are returned
+	myErr := errors.New("testing")
+	gotErr := k.StreamExportContractState(ctx, failingWriter{err: myErr})
+	assert.ErrorIs(t, gotErr, myErr)
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,183 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// SetContractStorageQuota limits the total size of the keys and values stored by the contract to maxBytes.
+// The storage usage is recalculated from the current state and must not exceed the quota.
+// A quota of 0 removes the limit. The quota is stored in the contract info.
+func (k Keeper) SetContractStorageQuota(ctx context.Context, contractAddr sdk.AccAddress, maxBytes uint64) error {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	contractInfo.StorageQuota = maxBytes
+	k.mustStoreContractInfo(ctx, contractAddr, contractInfo)
+	if maxBytes == 0 {
+		if err := k.deleteContractStorageUsage(ctx, contractAddr); err != nil {
+			return err
+		}
+	} else if err := k.refreshContractStorageUsage(ctx, contractAddr, maxBytes); err != nil {
+		return err
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeUpdateStorageQuota,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyStorageQuota, strconv.FormatUint(maxBytes, 10)),
+	))
+	return nil
+}
+
+// GetContractStorageQuota returns the storage quota in bytes of the contract. 0 means unlimited.
+func (k Keeper) GetContractStorageQuota(ctx context.Context, contractAddr sdk.AccAddress) uint64 {
+	contractInfo := k.GetContractInfo(ctx, contractAddr)
+	if contractInfo == nil {
+		return 0
+	}
+	return contractInfo.StorageQuota
+}
+
+// GetContractStorageUsage returns the total size of the keys and values stored by the contract.
+// The usage is tracked for contracts with a storage quota only and 0 for all others.
+func (k Keeper) GetContractStorageUsage(ctx context.Context, contractAddr sdk.AccAddress) uint64 {
+	return k.getUint64(ctx, types.GetContractStorageUsageKey(contractAddr))
+}
+
+// GetContractStateBytes returns the total size of the keys and values stored by the contract. The tracked usage is
+// returned for contracts with a storage quota, the state of all other contracts is iterated and summed up.
+func (k Keeper) GetContractStateBytes(ctx context.Context, contractAddr sdk.AccAddress) uint64 {
+	if k.GetContractStorageQuota(ctx, contractAddr) != 0 {
+		return k.GetContractStorageUsage(ctx, contractAddr)
+	}
+	var stateBytes uint64
+	k.IterateContractState(ctx, contractAddr, func(key, value []byte) bool {
+		stateBytes += uint64(len(key) + len(value))
+		return false
+	})
+	return stateBytes
+}
+
+func (k Keeper) getUint64(ctx context.Context, key []byte) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(key)
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setContractStorageUsage(ctx context.Context, contractAddr sdk.AccAddress, usage uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractStorageUsageKey(contractAddr), sdk.Uint64ToBigEndian(usage))
+}
+
+// refreshContractStorageUsage recalculates the storage usage from the contract state, for example
+// after the state was replaced. An error is returned when the usage exceeds the quota.
+func (k Keeper) refreshContractStorageUsage(ctx context.Context, contractAddr sdk.AccAddress, quota uint64) error {
+	var usage uint64
+	k.IterateContractState(ctx, contractAddr, func(key, value []byte) bool {
+		usage += uint64(len(key) + len(value))
+		return false
+	})
+	if usage > quota {
+		return errorsmod.Wrapf(types.ErrStorageQuotaExceeded, "usage %d exceeds quota %d", usage, quota)
+	}
+	return k.setContractStorageUsage(ctx, contractAddr, usage)
+}
+
+// checkStorageQuota returns an error when the contract stores more bytes than its quota allows.
+// The quota can not be set below the current usage so that any excess was caused by a write of the last contract
+// call that was aborted.
+func (k Keeper) checkStorageQuota(ctx sdk.Context, contractAddr sdk.AccAddress, quota uint64) error {
+	if quota == 0 {
+		return nil
+	}
+	if usage := k.GetContractStorageUsage(ctx, contractAddr); usage > quota {
+		return errorsmod.Wrapf(types.ErrStorageQuotaExceeded, "contract %s: usage %d exceeds quota %d", contractAddr, usage, quota)
+	}
+	return nil
+}
+
+func (k Keeper) deleteContractStorageUsage(ctx context.Context, contractAddr sdk.AccAddress) error {
+	return k.storeService.OpenKVStore(ctx).Delete(types.GetContractStorageUsageKey(contractAddr))
+}
+
+var _ wasmvm.KVStore = storageQuotaStore{}
+
+// storageQuotaStore is a decorator for the contract store that is passed to wasmvm. It tracks the total size of the
+// keys and values stored by the contract. Overwrites add the size difference to the existing entry, deletes
+// subtract the size of the removed entry. The bookkeeping reads and writes are charged to the contract call.
+//
+// A write that exceeds the quota aborts the contract call. The exceeding usage is stored before, so that
+// contractCallError can return the storage quota error for the aborted call. The state of the failed call is
+// reverted by the caller.
+type storageQuotaStore struct {
+	wasmvm.KVStore
+	ctx          sdk.Context
+	keeper       Keeper
+	contractAddr sdk.AccAddress
+	quota        uint64
+	// contractStore is the contract store read to get the size of existing entries
+	contractStore storetypes.KVStore
+}
+
+func (s storageQuotaStore) Set(key, value []byte) {
+	if usage := s.track(key, uint64(len(key)+len(value))); usage > s.quota {
+		panic(errorsmod.Wrapf(types.ErrStorageQuotaExceeded, "contract %s: usage %d exceeds quota %d", s.contractAddr, usage, s.quota))
+	}
+	s.KVStore.Set(key, value)
+}
+
+func (s storageQuotaStore) Delete(key []byte) {
+	s.track(key, 0)
+	s.KVStore.Delete(key)
+}
+
+// track stores and returns the storage usage after the entry of the key was set to the new size
+func (s storageQuotaStore) track(key []byte, newSize uint64) uint64 {
+	var oldSize uint64
+	if old := s.contractStore.Get(key); old != nil {
+		oldSize = uint64(len(key) + len(old))
+	}
+	usage := s.keeper.GetContractStorageUsage(s.ctx, s.contractAddr) + newSize
+	if usage < oldSize {
+		usage = 0
+	} else {
+		usage -= oldSize
+	}
+	if err := s.keeper.setContractStorageUsage(s.ctx, s.contractAddr, usage); err != nil {
+		panic(err)
+	}
+	return usage
+}
+
+// withStorageQuota decorates the contract store to track the storage usage and enforce the quota when the contract
+// has a quota. The quota is taken from the contract info loaded by the caller.
+func (k Keeper) withStorageQuota(ctx sdk.Context, contractAddr sdk.AccAddress, quota uint64, store wasmvm.KVStore) wasmvm.KVStore {
+	if quota == 0 {
+		return store
+	}
+	return storageQuotaStore{
+		KVStore:       store,
+		ctx:           ctx,
+		keeper:        k,
+		contractAddr:  contractAddr,
+		quota:         quota,
+		contractStore: prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(contractAddr)),
+	}
+}
@@ -0,0 +1,332 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestStorageQuotaTracking(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	// replace the hackatom state with a single entry of 6 bytes
+	deleteAll(prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(parentCtx)), types.GetContractStorePrefix(example.Contract)))
+	require.NoError(t, k.importContractState(parentCtx, example.Contract, []types.Model{{Key: []byte("foo"), Value: []byte("bar")}}))
+
+	specs := map[string]struct {
+		quota       uint64
+		modify      func(store wasmvm.KVStore)
+		expUsage    uint64
+		expErr      error
+		expUntraced bool
+	}{
+		"new entry": {
+			quota:    100,
+			modify:   func(store wasmvm.KVStore) { store.Set([]byte("a"), []byte("bc")) },
+			expUsage: 9,
+		},
+		"overwrite with larger value": {
+			quota:    100,
+			modify:   func(store wasmvm.KVStore) { store.Set([]byte("foo"), []byte("barbaz")) },
+			expUsage: 9,
+		},
+		"overwrite with smaller value": {
+			quota:    100,
+			modify:   func(store wasmvm.KVStore) { store.Set([]byte("foo"), []byte("b")) },
+			expUsage: 4,
+		},
+		"delete": {
+			quota:    100,
+			modify:   func(store wasmvm.KVStore) { store.Delete([]byte("foo")) },
+			expUsage: 0,
+		},
+		"delete non existing": {
+			quota:    100,
+			modify:   func(store wasmvm.KVStore) { store.Delete([]byte("other")) },
+			expUsage: 6,
+		},
+		"up to quota": {
+			quota:    9,
+			modify:   func(store wasmvm.KVStore) { store.Set([]byte("a"), []byte("bc")) },
+			expUsage: 9,
+		},
+		"exceeds quota": {
+			quota:  8,
+			modify: func(store wasmvm.KVStore) { store.Set([]byte("a"), []byte("bc")) },
+			expErr: types.ErrStorageQuotaExceeded,
+		},
+		"exceeds quota temporarily": {
+			quota: 8,
+			modify: func(store wasmvm.KVStore) {
+				store.Set([]byte("a"), []byte("bc"))
+				store.Delete([]byte("a"))
+			},
+			expErr: types.ErrStorageQuotaExceeded,
+		},
+		"no quota": {
+			modify:      func(store wasmvm.KVStore) { store.Set([]byte("a"), []byte("bc")) },
+			expUntraced: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			require.NoError(t, k.SetContractStorageQuota(ctx, example.Contract, spec.quota))
+			k.wasmVM = &wasmtesting.MockWasmEngine{
+				ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (_ *wasmvmtypes.ContractResult, _ uint64, err error) {
+					// wasmvm aborts the call on a panic in the store
+					defer func() {
+						if r := recover(); r != nil {
+							err = fmt.Errorf("panic in go callback: %v", r)
+						}
+					}()
+					spec.modify(store)
+					return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+				},
+			}
+
+			// when
+			_, gotErr := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			if spec.expUntraced {
+				assert.Zero(t, k.GetContractStorageUsage(ctx, example.Contract))
+				return
+			}
+			assert.Equal(t, spec.expUsage, k.GetContractStorageUsage(ctx, example.Contract))
+			// and the tracked usage matches the state
+			require.NoError(t, k.refreshContractStorageUsage(ctx, example.Contract, spec.quota))
+			assert.Equal(t, spec.expUsage, k.GetContractStorageUsage(ctx, example.Contract))
+		})
+	}
+}
+
+func TestStorageQuotaExceededIsReverted(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	// replace the hackatom state with a single entry of 6 bytes
+	deleteAll(prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractStorePrefix(example.Contract)))
+	require.NoError(t, k.importContractState(ctx, example.Contract, []types.Model{{Key: []byte("foo"), Value: []byte("bar")}}))
+	require.NoError(t, k.SetContractStorageQuota(ctx, example.Contract, 8))
+	k.wasmVM = &wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (_ *wasmvmtypes.ContractResult, _ uint64, err error) {
+			// wasmvm aborts the call on a panic in the store
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic in go callback: %v", r)
+				}
+			}()
+			store.Set([]byte("a"), []byte("bc"))
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+		},
+	}
+	// the failed call is executed in a cache context that is not committed, like a failed tx or sub-message
+	cacheCtx, _ := ctx.CacheContext()
+
+	// when
+	_, gotErr := k.execute(cacheCtx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+
+	// then
+	require.ErrorIs(t, gotErr, types.ErrStorageQuotaExceeded)
+	// the exceeding usage was stored by the aborted call
+	assert.Equal(t, uint64(9), k.GetContractStorageUsage(cacheCtx, example.Contract))
+	// and is reverted with the state of the call
+	assert.Equal(t, uint64(6), k.GetContractStorageUsage(ctx, example.Contract))
+	assert.Nil(t, k.QueryRaw(ctx, example.Contract, []byte("a")))
+	// so that the contract can be called again
+	k.wasmVM = &wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			store.Set([]byte("a"), []byte("b"))
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+		},
+	}
+	_, gotErr = k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, gotErr)
+	assert.Equal(t, uint64(8), k.GetContractStorageUsage(ctx, example.Contract))
+}
+
+func TestStorageQuotaTrackingIsCharged(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	k.wasmVM = &wasmtesting.MockWasmEngine{
+		ExecuteFn: func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			store.Set([]byte("foo"), []byte("bar"))
+			store.Delete([]byte("foo"))
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+		},
+	}
+	exec := func(quota uint64) storetypes.Gas {
+		ctx, _ := parentCtx.CacheContext()
+		require.NoError(t, k.SetContractStorageQuota(ctx, example.Contract, quota))
+		ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := k.execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+		require.NoError(t, err)
+		return ctx.GasMeter().GasConsumed()
+	}
+	assert.Greater(t, exec(1<<20), exec(0))
+}
+
+func TestSetContractStorageQuota(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	var usage uint64
+	k.IterateContractState(parentCtx, example.Contract, func(key, value []byte) bool {
+		usage += uint64(len(key) + len(value))
+		return false
+	})
+	require.NotZero(t, usage)
+
+	specs := map[string]struct {
+		contract sdk.AccAddress
+		quota    uint64
+		expUsage uint64
+		expErr   error
+	}{
+		"quota above usage": {
+			contract: example.Contract,
+			quota:    usage + 1,
+			expUsage: usage,
+		},
+		"quota equals usage": {
+			contract: example.Contract,
+			quota:    usage,
+			expUsage: usage,
+		},
+		"quota below usage": {
+			contract: example.Contract,
+			quota:    usage - 1,
+			expErr:   types.ErrStorageQuotaExceeded,
+		},
+		"remove quota": {
+			contract: example.Contract,
+			quota:    0,
+		},
+		"unknown contract": {
+			contract: RandomAccountAddress(t),
+			quota:    1,
+			expErr:   types.ErrNoSuchContractFn(""),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			// when
+			gotErr := k.SetContractStorageQuota(ctx, spec.contract, spec.quota)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.quota, k.GetContractStorageQuota(ctx, spec.contract))
+			assert.Equal(t, spec.expUsage, k.GetContractStorageUsage(ctx, spec.contract))
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, types.EventTypeUpdateStorageQuota, em.Events()[0].Type)
+		})
+	}
+}
+
+func TestStateBytesByCode(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreRandomContract(t, ctx, keepers, &m)
+	emptyCode := StoreRandomContract(t, ctx, keepers, &m)
+
+	states := [][]types.Model{
+		{{Key: []byte("foo"), Value: []byte("bar")}},                                            // 6 bytes
+		{{Key: []byte("a"), Value: []byte("bc")}, {Key: []byte("key"), Value: []byte("value")}}, // 11 bytes
+		{{Key: []byte("x"), Value: []byte("y")}},                                                // 2 bytes, tracked
+	}
+	contracts := make([]sdk.AccAddress, len(states))
+	for i, state := range states {
+		contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, nil, []byte(`{}`), fmt.Sprintf("contract %d", i), nil)
+		require.NoError(t, err)
+		require.NoError(t, k.importContractState(ctx, contractAddr, state))
+		contracts[i] = contractAddr
+		if i == 2 {
+			require.NoError(t, k.SetContractStorageQuota(ctx, contractAddr, 100))
+			require.Equal(t, uint64(2), k.GetContractStorageUsage(ctx, contractAddr))
+		}
+	}
+	for i, exp := range []uint64{6, 11, 2} {
+		assert.Equal(t, exp, k.GetContractStateBytes(ctx, contracts[i]))
+	}
+	querier := NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+	// the pages add up to the total of all contracts
+	firstPage, err := querier.StateBytesByCode(ctx, &types.QueryStateBytesByCodeRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Limit: 2}})
+	require.NoError(t, err)
+	require.NotNil(t, firstPage.Pagination.NextKey)
+	nextPage, err := querier.StateBytesByCode(ctx, &types.QueryStateBytesByCodeRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Key: firstPage.Pagination.NextKey}})
+	require.NoError(t, err)
+	assert.Nil(t, nextPage.Pagination.NextKey)
+	assert.Equal(t, uint64(2), firstPage.Contracts)
+	assert.Equal(t, uint64(1), nextPage.Contracts)
+	assert.Equal(t, uint64(19), firstPage.StateBytes+nextPage.StateBytes)
+
+	specs := map[string]struct {
+		req          *types.QueryStateBytesByCodeRequest
+		expBytes     uint64
+		expContracts uint64
+		expErr       error
+	}{
+		"multiple instances": {
+			req:          &types.QueryStateBytesByCodeRequest{CodeId: example.CodeID},
+			expBytes:     19,
+			expContracts: 3,
+		},
+		"no instances": {
+			req: &types.QueryStateBytesByCodeRequest{CodeId: emptyCode.CodeID},
+		},
+		"unknown code": {
+			req:    &types.QueryStateBytesByCodeRequest{CodeId: 999},
+			expErr: types.ErrNoSuchCodeFn(999),
+		},
+		"legacy pagination": {
+			req:    &types.QueryStateBytesByCodeRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Offset: 1}},
+			expErr: errLegacyPaginationUnsupported,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotRsp, gotErr := querier.StateBytesByCode(ctx, spec.req)
+			if spec.expErr != nil {
+				assert.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expBytes, gotRsp.StateBytes)
+			assert.Equal(t, spec.expContracts, gotRsp.Contracts)
+			require.NotNil(t, gotRsp.Pagination)
+			assert.Nil(t, gotRsp.Pagination.NextKey)
+		})
+	}
+}
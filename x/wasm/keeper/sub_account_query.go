@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SubAccountQueryCapability is the capability that contracts declare with `requires_sub_account_query`
+// to use the sub-account query. It is added to the available capabilities by the WithSubAccountQueries
+// option and can be disabled by governance with the disabled capabilities param.
+const SubAccountQueryCapability = "sub_account_query"
+
+// SubAccountQuery is a custom query to derive the deterministic sub-account address of a contract for a salt.
+// It is sent by contracts as `{"sub_account":{"contract":<bech32 address>,"salt":<base64 salt>}}`.
+type SubAccountQuery struct {
+	Contract string `json:"contract"`
+	Salt     []byte `json:"salt"`
+}
+
+// SubAccountResponse is the response to the SubAccountQuery
+type SubAccountResponse struct {
+	Address string `json:"address"`
+}
+
+type subAccountSource interface {
+	DeriveSubAccount(ctx context.Context, contractAddr sdk.AccAddress, salt []byte) (sdk.AccAddress, error)
+}
+
+// SubAccountQuerier handles SubAccountQuery custom queries. Any other custom query is passed to the next custom querier.
+// Addresses without a contract return a no such contract error to the contract.
+func SubAccountQuerier(source subAccountSource, params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			SubAccount *SubAccountQuery `json:"sub_account,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.SubAccount == nil {
+			return next(ctx, request)
+		}
+		if slices.Contains(params.GetCachedParams(ctx).DisabledCapabilities, SubAccountQueryCapability) {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "sub-account queries are disabled on this chain"}
+		}
+		contractAddr, err := sdk.AccAddressFromBech32(msg.SubAccount.Contract)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "contract")
+		}
+		ctx.GasMeter().ConsumeGas(DefaultGasCostBuildAddress, "sub-account address")
+		addr, err := source.DeriveSubAccount(ctx, contractAddr, msg.SubAccount.Salt)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(SubAccountResponse{Address: addr.String()})
+	}
+}
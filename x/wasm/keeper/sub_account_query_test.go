@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDeriveSubAccount(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &m)
+
+	got, err := k.DeriveSubAccount(ctx, example.Contract, []byte("alice"))
+	require.NoError(t, err)
+	assert.Equal(t, BuildSubAccountAddress(example.Contract, []byte("alice")), got)
+
+	_, err = k.DeriveSubAccount(ctx, example.Contract, nil)
+	assert.ErrorIs(t, err, types.ErrEmpty)
+
+	_, err = k.DeriveSubAccount(ctx, RandomAccountAddress(t), []byte("alice"))
+	assert.ErrorIs(t, err, types.ErrNoSuchContractFn(""))
+}
+
+func TestSubAccountQuery(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	var (
+		gotResult []byte
+		gotErr    error
+	)
+	// the calling contract sends the execute msg as custom query
+	m.ExecuteFn = func(_ wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, msg []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		gotResult, gotErr = querier.Query(wasmvmtypes.QueryRequest{Custom: msg}, gasLimit)
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithSubAccountQueries())
+	k := keepers.WasmKeeper
+	caller := SeedNewContractInstance(t, parentCtx, keepers, &m)
+
+	query := func(addr, salt string) []byte {
+		return []byte(`{"sub_account":{"contract":"` + addr + `","salt":"` + salt + `"}}`)
+	}
+	specs := map[string]struct {
+		src            []byte
+		disabled       bool
+		exp            sdk.AccAddress
+		expNoSuch      bool
+		expErr         bool
+		expUnsupported bool
+	}{
+		"own contract": {
+			src: query(caller.Contract.String(), "YWxpY2U="), // base64 of "alice"
+			exp: BuildSubAccountAddress(caller.Contract, []byte("alice")),
+		},
+		"non existing contract": {
+			src:       query(RandomBech32AccountAddress(t), "YWxpY2U="),
+			expNoSuch: true,
+		},
+		"empty salt": {
+			src:    query(caller.Contract.String(), ""),
+			expErr: true,
+		},
+		"invalid address": {
+			src:    query("invalid", "YWxpY2U="),
+			expErr: true,
+		},
+		"disabled capability": {
+			src:            query(caller.Contract.String(), "YWxpY2U="),
+			disabled:       true,
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.disabled {
+				params := k.GetParams(ctx)
+				params.DisabledCapabilities = []string{SubAccountQueryCapability}
+				require.NoError(t, k.SetParams(ctx, params))
+			}
+			gotResult, gotErr = nil, nil
+
+			// when
+			_, err := keepers.ContractKeeper.Execute(ctx, caller.Contract, caller.CreatorAddr, spec.src, nil)
+			require.NoError(t, err)
+
+			// then
+			switch {
+			case spec.expNoSuch:
+				var noSuch wasmvmtypes.NoSuchContract
+				assert.ErrorAs(t, gotErr, &noSuch)
+				return
+			case spec.expUnsupported:
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			case spec.expErr:
+				assert.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			var got SubAccountResponse
+			require.NoError(t, json.Unmarshal(gotResult, &got))
+			assert.Equal(t, spec.exp.String(), got.Address)
+		})
+	}
+}
@@ -708,7 +708,7 @@ func TestMigrateGovSubMsgAuthzPropagated(t *testing.T) {
 			tCtx, _ := ctx.CacheContext()
 			instanceLevel = 0
 
-			_, gotErr := k.migrate(tCtx, example1.Contract, RandomAccountAddress(t), example2.CodeID, []byte(`{}`), spec.policy)
+			_, gotErr := k.migrate(tCtx, example1.Contract, RandomAccountAddress(t), example2.CodeID, []byte(`{}`), nil, spec.policy)
 			if spec.expErr != nil {
 				assert.ErrorIs(t, gotErr, spec.expErr)
 				return
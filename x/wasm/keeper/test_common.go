@@ -236,7 +236,7 @@ func createTestInput(
 	for _, v := range keys {
 		ms.MountStoreWithDB(v, storetypes.StoreTypeIAVL, db)
 	}
-	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey, types.TStoreKey)
 	for _, v := range tkeys {
 		ms.MountStoreWithDB(v, storetypes.StoreTypeTransient, db)
 	}
@@ -374,6 +374,7 @@ func createTestInput(
 	keeper := NewKeeper(
 		appCodec,
 		runtime.NewKVStoreService(keys[types.StoreKey]),
+		runtime.NewTransientStoreService(tkeys[types.TStoreKey]),
 		accountKeeper,
 		bankKeeper,
 		stakingKeeper,
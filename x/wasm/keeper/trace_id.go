@@ -0,0 +1,24 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// withTraceID returns the trace id of the contract call tree and a context that carries it.
+// A new id is derived for top level calls while nested calls, like submessages and replies, keep the id of
+// the call that started the tree. Outside of a tx, for example in block hooks or gov proposals, no
+// trace sequence exists and the id is derived from the block height and the contract address only.
+func withTraceID(ctx sdk.Context, contractAddr sdk.AccAddress) (sdk.Context, string) {
+	if traceID, ok := types.TraceID(ctx); ok {
+		return ctx, traceID
+	}
+	var seq uint32
+	if traceSeq, ok := types.TraceSequenceFromContext(ctx); ok {
+		seq = traceSeq.Next()
+	}
+	txIndex, _ := types.TXCounter(ctx)
+	traceID := types.NewTraceID(ctx.BlockHeight(), txIndex, seq, contractAddr)
+	return types.WithTraceID(ctx, traceID), traceID
+}
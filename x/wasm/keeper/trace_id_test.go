@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"context"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestWithTraceID(t *testing.T) {
+	myContractAddr := RandomAccountAddress(t)
+	parentCtx := sdk.Context{}.WithContext(context.Background()).WithBlockHeight(10)
+	parentCtx = types.WithTXCounter(parentCtx, 2)
+
+	// without trace sequence
+	_, gotID := withTraceID(parentCtx, myContractAddr)
+	assert.Equal(t, types.NewTraceID(10, 2, 0, myContractAddr), gotID)
+	assert.Len(t, gotID, 2*types.TraceIDLength)
+
+	// with trace sequence, each top level call gets a new id
+	ctx := types.WithTraceSequence(parentCtx, types.NewTraceSequence())
+	firstCtx, firstID := withTraceID(ctx, myContractAddr)
+	_, secondID := withTraceID(ctx, myContractAddr)
+	assert.Equal(t, types.NewTraceID(10, 2, 0, myContractAddr), firstID)
+	assert.Equal(t, types.NewTraceID(10, 2, 1, myContractAddr), secondID)
+	assert.NotEqual(t, firstID, secondID)
+
+	// nested calls keep the id
+	_, nestedID := withTraceID(firstCtx, RandomAccountAddress(t))
+	assert.Equal(t, firstID, nestedID)
+}
+
+func TestTraceIDInSubmessageEvents(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	reflect := InstantiateReflectExampleContract(t, parentCtx, keepers)
+	hackatom := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+
+	execMsg := mustMarshal(t, testdata.ReflectHandleMsg{
+		ReflectSubMsg: &testdata.ReflectSubPayload{Msgs: []wasmvmtypes.SubMsg{{
+			ID:      1,
+			ReplyOn: wasmvmtypes.ReplyAlways,
+			Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Instantiate: &wasmvmtypes.InstantiateMsg{
+				CodeID: hackatom.CodeID,
+				Msg:    HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)}.GetBytes(t),
+				Funds:  []wasmvmtypes.Coin{},
+				Label:  "child",
+			}}},
+		}}},
+	})
+	ctx, _ := parentCtx.CacheContext()
+	ctx = types.WithTraceSequence(ctx.WithEventManager(sdk.NewEventManager()), types.NewTraceSequence())
+
+	// when
+	_, err := keepers.ContractKeeper.Execute(ctx, reflect.Contract, reflect.CreatorAddr, execMsg, nil)
+	require.NoError(t, err)
+	// and a second top level message in the same tx
+	_, err = keepers.ContractKeeper.Execute(ctx, hackatom.Contract, hackatom.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+
+	// then
+	expID := types.NewTraceID(ctx.BlockHeight(), 0, 0, reflect.Contract)
+	var gotTypes []string
+	var gotIDs []string
+	for _, e := range ctx.EventManager().Events() {
+		if !tracedEventTypes[e.Type] {
+			continue
+		}
+		gotTypes = append(gotTypes, e.Type)
+		for _, a := range e.Attributes {
+			if a.Key == types.AttributeKeyTraceID {
+				gotIDs = append(gotIDs, a.Value)
+			}
+		}
+	}
+	assert.Equal(t, []string{types.EventTypeExecute, types.EventTypeInstantiate, types.EventTypeReply, types.EventTypeExecute}, gotTypes)
+	assert.Equal(t, []string{expID, expID, expID, types.NewTraceID(ctx.BlockHeight(), 0, 1, hackatom.Contract)}, gotIDs)
+}
+
+func TestSdkEventsToWasmVMEventsWithoutTraceID(t *testing.T) {
+	events := []sdk.Event{
+		sdk.NewEvent(types.EventTypeExecute,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, "myContract"),
+			sdk.NewAttribute(types.AttributeKeyTraceID, "myTraceID"),
+		),
+		sdk.NewEvent("wasm-custom", sdk.NewAttribute(types.AttributeKeyTraceID, "myValue")),
+	}
+	got := sdkEventsToWasmVMEvents(events)
+	exp := []wasmvmtypes.Event{
+		{Type: types.EventTypeExecute, Attributes: []wasmvmtypes.EventAttribute{{Key: types.AttributeKeyContractAddr, Value: "myContract"}}},
+		{Type: "wasm-custom", Attributes: []wasmvmtypes.EventAttribute{{Key: types.AttributeKeyTraceID, Value: "myValue"}}},
+	}
+	assert.Equal(t, exp, got)
+	// source events are not modified
+	assert.Equal(t, []abci.EventAttribute{
+		{Key: types.AttributeKeyContractAddr, Value: "myContract"},
+		{Key: types.AttributeKeyTraceID, Value: "myTraceID"},
+	}, events[0].Attributes)
+}
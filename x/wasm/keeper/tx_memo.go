@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"encoding/json"
+	"strings"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// MaxForwardedMemoSize is the max number of bytes of the tx memo that are passed to contracts
+const MaxForwardedMemoSize = 256
+
+// TxMemoResponse is the response to the tx memo custom query.
+// The memo is empty when the transaction has none or when the contract is called outside a transaction.
+type TxMemoResponse struct {
+	Memo string `json:"memo"`
+}
+
+// TxMemoQuerier handles tx memo custom queries that contracts send as `{"tx_memo":{}}` to read the memo of
+// the current transaction. Any other custom query is passed to the next custom querier.
+// The memo can only be read when the forward tx memo param is enabled. It is not part of the Env or MessageInfo
+// passed to the contract as their wasmvm types are fixed.
+func TxMemoQuerier(params paramsSource, next CustomQuerier) CustomQuerier {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var msg struct {
+			TxMemo *struct{} `json:"tx_memo,omitempty"`
+		}
+		if err := json.Unmarshal(request, &msg); err != nil || msg.TxMemo == nil {
+			return next(ctx, request)
+		}
+		if !params.GetCachedParams(ctx).ForwardTxMemo {
+			return nil, wasmvmtypes.UnsupportedRequest{Kind: "tx memo is not forwarded on this chain"}
+		}
+		memo, _ := types.TxMemo(ctx)
+		return json.Marshal(TxMemoResponse{Memo: memo})
+	}
+}
+
+// truncateMemo cuts the memo to MaxForwardedMemoSize bytes without splitting a multi byte character
+func truncateMemo(memo string) string {
+	if len(memo) <= MaxForwardedMemoSize {
+		return memo
+	}
+	return strings.ToValidUTF8(memo[:MaxForwardedMemoSize], "")
+}
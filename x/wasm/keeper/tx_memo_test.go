@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"encoding/json"
+	"testing"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestTxMemoQuerier(t *testing.T) {
+	next := func(ctx sdk.Context, _ json.RawMessage) ([]byte, error) {
+		return []byte("next"), nil
+	}
+	specs := map[string]struct {
+		src            json.RawMessage
+		memo           string
+		enabled        bool
+		expResult      []byte
+		expUnsupported bool
+	}{
+		"memo": {
+			src:       []byte(`{"tx_memo":{}}`),
+			memo:      "my memo",
+			enabled:   true,
+			expResult: []byte(`{"memo":"my memo"}`),
+		},
+		"no memo": {
+			src:       []byte(`{"tx_memo":{}}`),
+			enabled:   true,
+			expResult: []byte(`{"memo":""}`),
+		},
+		"other custom query": {
+			src:       []byte(`{"foo":{}}`),
+			memo:      "my memo",
+			enabled:   true,
+			expResult: []byte("next"),
+		},
+		"disabled": {
+			src:            []byte(`{"tx_memo":{}}`),
+			memo:           "my memo",
+			expUnsupported: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.NewContext(nil, tmproto.Header{}, false, log.NewNopLogger())
+			if spec.memo != "" {
+				ctx = types.WithTxMemo(ctx, spec.memo)
+			}
+			q := TxMemoQuerier(mockParamsSource{ForwardTxMemo: spec.enabled}, next)
+
+			// when
+			gotResult, gotErr := q(ctx, spec.src)
+
+			// then
+			if spec.expUnsupported {
+				var unsupported wasmvmtypes.UnsupportedRequest
+				assert.ErrorAs(t, gotErr, &unsupported)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expResult, gotResult)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+
+	"cosmossdk.io/collections"
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// vmCacheConfig are the wasmvm cache settings that can be changed with the params
+type vmCacheConfig struct {
+	// MemoryCacheSize in MiB
+	MemoryCacheSize uint32
+	// ContractMemoryLimit in MiB
+	ContractMemoryLimit uint32
+}
+
+// vmReloader recreates the wasmvm created by the keeper with other cache settings. The vm is replaced in
+// place, so that all keeper copies and engine decorators use the new vm. This is a node local operation that must
+// only run when no contract call is running, which is the case on node start.
+type vmReloader struct {
+	vm     *wasmvm.VM
+	config vmCacheConfig
+	// defaultMemoryCacheSize is the memory cache size of the node config
+	defaultMemoryCacheSize uint32
+	newVM                  func(config vmCacheConfig) (*wasmvm.VM, error)
+	// pending is the changed config that was logged last, so that it is only logged once
+	pending vmCacheConfig
+}
+
+// reload recreates the vm when the config differs from the current one. The wasmvm holds a lock on the cache
+// directory, so the current vm must be released before the new one is created. All in-memory caches start empty
+// and pinned codes must be pinned again by the caller.
+func (r *vmReloader) reload(config vmCacheConfig) error {
+	if config == r.config {
+		return nil
+	}
+	r.vm.Cleanup()
+	vm, err := r.newVM(config)
+	if err != nil {
+		return err
+	}
+	*r.vm = *vm
+	r.config = config
+	return nil
+}
+
+// effectiveConfig returns the cache settings of the params with the defaults for the unset values
+func (r *vmReloader) effectiveConfig(p types.Params) vmCacheConfig {
+	config := vmCacheConfig{MemoryCacheSize: p.MemoryCacheSize, ContractMemoryLimit: p.ContractMemoryLimit}
+	if config.MemoryCacheSize == 0 {
+		config.MemoryCacheSize = r.defaultMemoryCacheSize
+	}
+	if config.ContractMemoryLimit == 0 {
+		config.ContractMemoryLimit = contractMemoryLimit
+	}
+	return config
+}
+
+// applyVMCacheParams recreates the wasmvm when the cache params differ from the settings of the vm.
+// Nothing is done before the genesis import when no params are stored. The genesis params are applied on import.
+func (k Keeper) applyVMCacheParams(ctx context.Context) error {
+	if k.vmReloader == nil {
+		return nil
+	}
+	params, err := k.params.Get(ctx)
+	switch {
+	case errors.Is(err, collections.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	config := k.vmReloader.effectiveConfig(params)
+	if err := k.vmReloader.reload(config); err != nil {
+		return errorsmod.Wrapf(err, "recreate wasmvm with memory cache size %d MiB and contract memory limit %d MiB", config.MemoryCacheSize, config.ContractMemoryLimit)
+	}
+	return nil
+}
+
+// logChangedVMCacheParams logs a warning when the cache params differ from the settings of the vm. The vm can not be
+// recreated while contract queries may run, so that the change is applied on the next node start.
+func (k Keeper) logChangedVMCacheParams(ctx sdk.Context) {
+	if k.vmReloader == nil {
+		return
+	}
+	config := k.vmReloader.effectiveConfig(k.GetCachedParams(ctx))
+	if config == k.vmReloader.config || config == k.vmReloader.pending {
+		return
+	}
+	k.vmReloader.pending = config
+	k.Logger(ctx).Warn("wasmvm cache params changed, restart the node to apply them",
+		"memory_cache_size", config.MemoryCacheSize, "contract_memory_limit", config.ContractMemoryLimit)
+}
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestApplyVMCacheParams(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, k.pinCode(ctx, example.CodeID))
+	require.NotNil(t, k.vmReloader)
+	vm := k.vmReloader.vm
+
+	defaults := k.vmReloader.config
+
+	// when params are not set
+	require.NoError(t, k.BeginBlocker(ctx))
+	require.NoError(t, k.InitializePinnedCodes(ctx))
+	// then the vm is kept
+	assert.Equal(t, uint32(contractMemoryLimit), k.vmReloader.config.ContractMemoryLimit)
+
+	// when the params are changed
+	params := k.GetParams(ctx)
+	params.MemoryCacheSize = 10
+	params.ContractMemoryLimit = 64
+	require.NoError(t, k.SetParams(ctx, params))
+	exp := vmCacheConfig{MemoryCacheSize: 10, ContractMemoryLimit: 64}
+
+	// then the next block does not fail and keeps the vm until the node is restarted
+	require.NoError(t, k.BeginBlocker(ctx))
+	assert.Equal(t, defaults, k.vmReloader.config)
+	assert.Equal(t, exp, k.vmReloader.pending)
+
+	// when the node is started again
+	require.NoError(t, k.InitializePinnedCodes(ctx))
+
+	// then the vm is recreated in place with the new settings
+	assert.Equal(t, exp, k.vmReloader.config)
+	assert.Same(t, vm, k.wasmVM)
+	require.NoError(t, k.BeginBlocker(ctx))
+	// and the pinned codes are pinned again
+	metrics, err := k.wasmVM.GetPinnedMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics.PerModule, 1)
+	assert.Equal(t, example.Checksum, []byte(metrics.PerModule[0].Checksum))
+	// and contracts can be called
+	_, err = k.QuerySmart(ctx, example.Contract, []byte(`{"verifier":{}}`))
+	require.NoError(t, err)
+}
+
+func TestApplyVMCacheParamsOnGenesis(t *testing.T) {
+	keeper, ctx := setupKeeper(t)
+	require.NotNil(t, keeper.vmReloader)
+
+	// when the node is started before the genesis import
+	require.NoError(t, keeper.InitializePinnedCodes(ctx))
+	// then the vm is kept
+	assert.Equal(t, uint32(contractMemoryLimit), keeper.vmReloader.config.ContractMemoryLimit)
+
+	// when the genesis is imported
+	params := types.DefaultParams()
+	params.ContractMemoryLimit = 64
+	_, err := InitGenesis(ctx, keeper, types.GenesisState{Params: params})
+	require.NoError(t, err)
+	// then the limit is applied
+	assert.Equal(t, uint32(64), keeper.vmReloader.config.ContractMemoryLimit)
+	require.NoError(t, keeper.BeginBlocker(ctx))
+}
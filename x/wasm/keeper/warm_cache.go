@@ -0,0 +1,275 @@
+package keeper
+
+import (
+	"cmp"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// warmListFileName is the name of the file in the wasm directory that the warm list is written to on shutdown
+const warmListFileName = "warm_list.json"
+
+// warmList is the node local list of the most recently used code checksums.
+// It is written to a file on shutdown and the codes are pinned in the wasmvm cache on the next start so that
+// the first calls to the contracts do not have to load the modules from disk. Nothing is written to state.
+//
+// The codes pinned by the warm list are tracked separately from the codes pinned by governance. They are unpinned
+// again when they are evicted from the list, and codes pinned by governance are never unpinned by the warm list.
+type warmList struct {
+	path   string
+	size   int
+	engine types.WasmEngine
+	// seq orders the touches, the most recently used checksums have the highest sequences
+	seq atomic.Uint64
+	// lastUsed maps the checksums to the sequence of their last use. It is compacted to the list size when it
+	// has grown to twice the size so that a touch does not need the mutex.
+	lastUsed sync.Map
+	entries  atomic.Int64
+
+	mu sync.Mutex
+	// pinned are the checksums that were pinned by the warm list
+	pinned map[string]struct{}
+}
+
+func newWarmList(path string, size uint32, engine types.WasmEngine) *warmList {
+	return &warmList{path: path, size: int(size), engine: engine, pinned: make(map[string]struct{})}
+}
+
+// touch marks the checksum as the most recently used one
+func (w *warmList) touch(checksum wasmvm.Checksum) {
+	seq := w.seq.Add(1)
+	if _, loaded := w.lastUsed.Swap(string(checksum), seq); loaded {
+		return
+	}
+	if w.entries.Add(1) > int64(2*w.size) {
+		w.compact()
+	}
+}
+
+// compact drops the least recently used checksums beyond the list size. Dropped codes that were pinned by the
+// warm list are unpinned.
+func (w *warmList) compact() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.entries.Load() <= int64(2*w.size) {
+		return // compacted by a concurrent touch
+	}
+	all := w.sorted()
+	for _, e := range all[min(w.size, len(all)):] {
+		// a checksum that was touched again in the meantime is kept
+		if !w.lastUsed.CompareAndDelete(e.checksum, e.seq) {
+			continue
+		}
+		w.entries.Add(-1)
+		if _, ok := w.pinned[e.checksum]; !ok {
+			continue
+		}
+		delete(w.pinned, e.checksum)
+		// the unpinning is best effort, the module is dropped from the cache on the next start at the latest
+		_ = w.engine.Unpin(wasmvm.Checksum(e.checksum))
+	}
+}
+
+type warmListEntry struct {
+	checksum string
+	seq      uint64
+}
+
+// sorted returns all checksums of the list, most recent first
+func (w *warmList) sorted() []warmListEntry {
+	var r []warmListEntry
+	w.lastUsed.Range(func(k, v any) bool {
+		r = append(r, warmListEntry{checksum: k.(string), seq: v.(uint64)})
+		return true
+	})
+	slices.SortFunc(r, func(a, b warmListEntry) int { return cmp.Compare(b.seq, a.seq) })
+	return r
+}
+
+// list returns the checksums up to the list size, most recent first
+func (w *warmList) list() []wasmvm.Checksum {
+	all := w.sorted()
+	r := make([]wasmvm.Checksum, 0, min(w.size, len(all)))
+	for _, e := range all[:min(w.size, len(all))] {
+		r = append(r, wasmvm.Checksum(e.checksum))
+	}
+	return r
+}
+
+// write stores the list in the file. The file is replaced atomically.
+func (w *warmList) write() error {
+	list := w.list()
+	checksums := make([]string, len(list))
+	for i, c := range list {
+		checksums[i] = hex.EncodeToString(c)
+	}
+	bz, err := json.Marshal(checksums)
+	if err != nil {
+		return err
+	}
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, bz, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.path)
+}
+
+// read loads the list from the file. A missing file results in an empty list.
+func (w *warmList) read() error {
+	bz, err := os.ReadFile(w.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	}
+	var checksums []string
+	if err := json.Unmarshal(bz, &checksums); err != nil {
+		return err
+	}
+	if len(checksums) > w.size {
+		checksums = checksums[:w.size]
+	}
+	// touch the least recent first to keep the order
+	for i := len(checksums) - 1; i >= 0; i-- {
+		c, err := hex.DecodeString(checksums[i])
+		if err != nil {
+			continue
+		}
+		w.touch(c)
+	}
+	return nil
+}
+
+// pin pins the codes of the list in the wasmvm cache that are not pinned by governance
+func (w *warmList) pin(govPinned map[string]struct{}) []error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var errs []error
+	for _, checksum := range w.list() {
+		if _, ok := govPinned[string(checksum)]; ok {
+			continue
+		}
+		if err := w.engine.Pin(checksum); err != nil {
+			errs = append(errs, fmt.Errorf("checksum %s: %w", hex.EncodeToString(checksum), err))
+			continue
+		}
+		w.pinned[string(checksum)] = struct{}{}
+	}
+	return errs
+}
+
+// repin pins the codes again that were pinned by the warm list. This is required after the wasmvm was recreated.
+// Codes that can not be pinned anymore are dropped.
+func (w *warmList) repin() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for checksum := range w.pinned {
+		if err := w.engine.Pin(wasmvm.Checksum(checksum)); err != nil {
+			delete(w.pinned, checksum)
+		}
+	}
+}
+
+// release hands a code pinned by the warm list over to governance so that it is not unpinned on eviction
+func (w *warmList) release(checksum wasmvm.Checksum) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.pinned, string(checksum))
+}
+
+var _ types.WasmEngine = warmListWasmEngine{}
+
+// warmListWasmEngine is a decorator that adds the checksums of the contract calls to the warm list
+type warmListWasmEngine struct {
+	types.WasmEngine
+	warmList *warmList
+}
+
+func (e warmListWasmEngine) Instantiate(checksum wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.Instantiate(checksum, env, info, initMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e warmListWasmEngine) Execute(checksum wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.Execute(checksum, env, info, executeMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e warmListWasmEngine) Query(checksum wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.QueryResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.Query(checksum, env, queryMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e warmListWasmEngine) Migrate(checksum wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.Migrate(checksum, env, migrateMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e warmListWasmEngine) MigrateWithInfo(checksum wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, migrateInfo wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.MigrateWithInfo(checksum, env, migrateMsg, migrateInfo, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e warmListWasmEngine) Sudo(checksum wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.Sudo(checksum, env, sudoMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e warmListWasmEngine) Reply(checksum wasmvm.Checksum, env wasmvmtypes.Env, reply wasmvmtypes.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+	e.warmList.touch(checksum)
+	return e.WasmEngine.Reply(checksum, env, reply, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+// WarmCache pins the codes of the warm list that was written on the last shutdown in the wasmvm cache.
+// It should be called on startup after InitializePinnedCodes. The pinning is node local and does not change the
+// pinned codes in state or the gas cost of the contract calls. Codes pinned by governance are skipped and
+// checksums that are unknown to the wasmvm are skipped as well.
+// This is a no-op when the warm cache is disabled in the node config.
+func (k Keeper) WarmCache(ctx sdk.Context) error {
+	if k.warmList == nil {
+		return nil
+	}
+	if err := k.warmList.read(); err != nil {
+		return err
+	}
+	govPinned := make(map[string]struct{})
+	k.IteratePinnedCodes(ctx, func(codeID uint64) bool {
+		if codeInfo := k.GetCodeInfo(ctx, codeID); codeInfo != nil {
+			govPinned[string(codeInfo.CodeHash)] = struct{}{}
+		}
+		return false
+	})
+	for _, err := range k.warmList.pin(govPinned) {
+		k.Logger(ctx).Debug("skip warming code", "error", err)
+	}
+	return nil
+}
+
+// WriteWarmList writes the recently used code checksums to the warm list file. It should be called on a
+// graceful shutdown. This is a no-op when the warm cache is disabled in the node config.
+func (k Keeper) WriteWarmList() error {
+	if k.warmList == nil {
+		return nil
+	}
+	return k.warmList.write()
+}
+
+// warmListPath returns the path of the warm list file in the home dir
+func warmListPath(homeDir string) string {
+	return filepath.Join(homeDir, "wasm", warmListFileName)
+}
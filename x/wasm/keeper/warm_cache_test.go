@@ -0,0 +1,130 @@
+package keeper
+
+import (
+	"path/filepath"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v3"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+)
+
+func TestWarmListWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), warmListFileName)
+	w := newWarmList(path, 2, nil)
+	w.touch([]byte{1})
+	w.touch([]byte{2})
+	w.touch([]byte{1})
+	w.touch([]byte{3})
+	assert.Equal(t, []wasmvm.Checksum{{3}, {1}}, w.list())
+
+	// when
+	require.NoError(t, w.write())
+
+	// then
+	got := newWarmList(path, 2, nil)
+	require.NoError(t, got.read())
+	assert.Equal(t, []wasmvm.Checksum{{3}, {1}}, got.list())
+
+	// and a smaller size truncates the list
+	got = newWarmList(path, 1, nil)
+	require.NoError(t, got.read())
+	assert.Equal(t, []wasmvm.Checksum{{3}}, got.list())
+
+	// and a missing file is an empty list
+	got = newWarmList(filepath.Join(t.TempDir(), warmListFileName), 2, nil)
+	require.NoError(t, got.read())
+	assert.Empty(t, got.list())
+}
+
+func TestWarmCache(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeInstantiable(&m)
+	m.ExecuteFn = func(wasmvm.Checksum, wasmvmtypes.Env, wasmvmtypes.MessageInfo, []byte, wasmvm.KVStore, wasmvm.GoAPI, wasmvm.Querier, wasmvm.GasMeter, uint64, wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &m)
+
+	path := filepath.Join(t.TempDir(), warmListFileName)
+	k.warmList = newWarmList(path, 10, &m)
+	k.wasmVM = warmListWasmEngine{WasmEngine: &m, warmList: k.warmList}
+
+	// when the contract is called
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+	require.NoError(t, err)
+
+	// and the node shuts down
+	require.NoError(t, k.WriteWarmList())
+
+	// then the code is pinned on the next start
+	var pinned []wasmvm.Checksum
+	m.PinFn = func(checksum wasmvm.Checksum) error {
+		pinned = append(pinned, checksum)
+		return nil
+	}
+	k.warmList = newWarmList(path, 10, &m)
+	require.NoError(t, k.WarmCache(ctx))
+	assert.Equal(t, []wasmvm.Checksum{example.Checksum}, pinned)
+	assert.False(t, k.IsPinnedCode(ctx, example.CodeID))
+
+	// and pinned again when the pinned codes are initialized after the wasmvm was recreated
+	pinned = nil
+	require.NoError(t, k.InitializePinnedCodes(ctx))
+	assert.Equal(t, []wasmvm.Checksum{example.Checksum}, pinned)
+}
+
+func TestWarmListEviction(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	var pinned, unpinned []wasmvm.Checksum
+	m.PinFn = func(checksum wasmvm.Checksum) error {
+		pinned = append(pinned, checksum)
+		return nil
+	}
+	m.UnpinFn = func(checksum wasmvm.Checksum) error {
+		unpinned = append(unpinned, checksum)
+		return nil
+	}
+	specs := map[string]struct {
+		govPinned   map[string]struct{}
+		release     bool
+		expPinned   []wasmvm.Checksum
+		expUnpinned []wasmvm.Checksum
+	}{
+		"warm code unpinned on eviction": {
+			expPinned:   []wasmvm.Checksum{{1}},
+			expUnpinned: []wasmvm.Checksum{{1}},
+		},
+		"gov pinned code not pinned and not unpinned": {
+			govPinned: map[string]struct{}{string([]byte{1}): {}},
+		},
+		"code pinned by gov later not unpinned": {
+			release:   true,
+			expPinned: []wasmvm.Checksum{{1}},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			pinned, unpinned = nil, nil
+			w := newWarmList(filepath.Join(t.TempDir(), warmListFileName), 1, &m)
+			w.touch([]byte{1})
+			require.Empty(t, w.pin(spec.govPinned))
+			if spec.release {
+				w.release([]byte{1})
+			}
+
+			// when the list has grown to twice the size
+			w.touch([]byte{2})
+			w.touch([]byte{3})
+
+			// then
+			assert.Equal(t, spec.expPinned, pinned)
+			assert.Equal(t, spec.expUnpinned, unpinned)
+			assert.Equal(t, []wasmvm.Checksum{{3}}, w.list())
+		})
+	}
+}
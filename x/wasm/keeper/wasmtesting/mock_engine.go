@@ -49,6 +49,7 @@ type MockWasmEngine struct {
 	IBC2PacketSendFn         func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBC2PacketSendMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error)
 	PinFn                    func(checksum wasmvm.Checksum) error
 	UnpinFn                  func(checksum wasmvm.Checksum) error
+	RemoveCodeFn             func(checksum wasmvm.Checksum) error
 	GetMetricsFn             func() (*wasmvmtypes.Metrics, error)
 	GetPinMetricsFn          func() (*wasmvmtypes.PinnedMetrics, error)
 }
@@ -194,7 +195,7 @@ func (m *MockWasmEngine) Migrate(codeID wasmvm.Checksum, env wasmvmtypes.Env, mi
 }
 
 func (m *MockWasmEngine) MigrateWithInfo(codeID wasmvm.Checksum, env wasmvmtypes.Env, migrateMsg []byte, migrateInfo wasmvmtypes.MigrateInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
-	if m.MigrateFn == nil {
+	if m.MigrateWithInfoFn == nil {
 		panic("not supposed to be called!")
 	}
 	return m.MigrateWithInfoFn(codeID, env, migrateMsg, migrateInfo, store, goapi, querier, gasMeter, gasLimit, deserCost)
@@ -242,6 +243,13 @@ func (m *MockWasmEngine) Unpin(checksum wasmvm.Checksum) error {
 	return m.UnpinFn(checksum)
 }
 
+func (m *MockWasmEngine) RemoveCode(checksum wasmvm.Checksum) error {
+	if m.RemoveCodeFn == nil {
+		panic("not supposed to be called!")
+	}
+	return m.RemoveCodeFn(checksum)
+}
+
 func (m *MockWasmEngine) GetMetrics() (*wasmvmtypes.Metrics, error) {
 	if m.GetMetricsFn == nil {
 		panic("not expected to be called")
@@ -0,0 +1,49 @@
+package v4
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// SetContractCountFn stores the number of contract instances for a code
+type SetContractCountFn func(ctx context.Context, codeID, count uint64) error
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper             wasmKeeper
+	setContractCountFn SetContractCountFn
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fn SetContractCountFn) Migrator {
+	return Migrator{keeper: k, setContractCountFn: fn}
+}
+
+// Migrate4to5 migrates from version 4 to 5.
+// It initializes the contract counters per code id from the existing contracts.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	var codeIDs []uint64
+	counts := make(map[uint64]uint64)
+	m.keeper.IterateContractInfo(ctx, func(_ sdk.AccAddress, contractInfo types.ContractInfo) bool {
+		if _, ok := counts[contractInfo.CodeID]; !ok {
+			codeIDs = append(codeIDs, contractInfo.CodeID)
+		}
+		counts[contractInfo.CodeID]++
+		return false
+	})
+	// iterate in a deterministic order
+	for _, codeID := range codeIDs {
+		if err := m.setContractCountFn(ctx, codeID, counts[codeID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package v4_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate4To5(t *testing.T) {
+	AvailableCapabilities := []string{"iterator", "staking", "stargate", "cosmwasm_1_1"}
+	ctx, keepers := keeper.CreateTestInput(t, false, AvailableCapabilities)
+	wasmKeeper := keepers.WasmKeeper
+
+	creator := keeper.RandomAccountAddress(t)
+	example1 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	example3 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{
+		Verifier:    keeper.RandomAccountAddress(t),
+		Beneficiary: keeper.RandomAccountAddress(t),
+	})
+	require.NoError(t, err)
+
+	for _, codeID := range []uint64{example1.CodeID, example1.CodeID, example2.CodeID} {
+		_, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsgBz, "demo contract", nil)
+		require.NoError(t, err)
+	}
+
+	// remove counters
+	for _, codeID := range []uint64{example1.CodeID, example2.CodeID} {
+		ctx.KVStore(keepers.WasmStoreKey).Delete(types.GetContractCountByCodeIDKey(codeID))
+		require.Zero(t, wasmKeeper.GetContractCountByCode(ctx, codeID))
+	}
+
+	// migrator
+	err = keeper.NewMigrator(*wasmKeeper, nil).Migrate4to5(ctx)
+	require.NoError(t, err)
+
+	// check new store
+	assert.Equal(t, uint64(2), wasmKeeper.GetContractCountByCode(ctx, example1.CodeID))
+	assert.Equal(t, uint64(1), wasmKeeper.GetContractCountByCode(ctx, example2.CodeID))
+	assert.Equal(t, uint64(0), wasmKeeper.GetContractCountByCode(ctx, example3.CodeID))
+}
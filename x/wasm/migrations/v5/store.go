@@ -0,0 +1,55 @@
+package v5
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// SetModuleStatFn stores the value of a module stats counter
+type SetModuleStatFn func(ctx context.Context, key []byte, count uint64) error
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	IterateCodeInfos(ctx context.Context, cb func(uint64, types.CodeInfo) bool)
+	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
+	IteratePinnedCodes(ctx context.Context, cb func(codeID uint64) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper          wasmKeeper
+	setModuleStatFn SetModuleStatFn
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fn SetModuleStatFn) Migrator {
+	return Migrator{keeper: k, setModuleStatFn: fn}
+}
+
+// Migrate5to6 migrates from version 5 to 6.
+// It initializes the module stats counters from the existing codes, contracts and pinned codes.
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	var codes, contracts, pinned uint64
+	m.keeper.IterateCodeInfos(ctx, func(uint64, types.CodeInfo) bool {
+		codes++
+		return false
+	})
+	m.keeper.IterateContractInfo(ctx, func(sdk.AccAddress, types.ContractInfo) bool {
+		contracts++
+		return false
+	})
+	m.keeper.IteratePinnedCodes(ctx, func(uint64) bool {
+		pinned++
+		return false
+	})
+	if err := m.setModuleStatFn(ctx, types.KeyStatsCodeCount, codes); err != nil {
+		return err
+	}
+	if err := m.setModuleStatFn(ctx, types.KeyStatsContractCount, contracts); err != nil {
+		return err
+	}
+	return m.setModuleStatFn(ctx, types.KeyStatsPinnedCodeCount, pinned)
+}
@@ -0,0 +1,40 @@
+package v5_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate5To6(t *testing.T) {
+	AvailableCapabilities := []string{"iterator", "staking", "stargate", "cosmwasm_1_1"}
+	ctx, keepers := keeper.CreateTestInput(t, false, AvailableCapabilities)
+	wasmKeeper := keepers.WasmKeeper
+
+	example := keeper.InstantiateHackatomExampleContract(t, ctx, keepers)
+	keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	require.NoError(t, keepers.ContractKeeper.PinCode(ctx, example.CodeID))
+
+	// remove counters
+	for _, key := range [][]byte{types.KeyStatsCodeCount, types.KeyStatsContractCount, types.KeyStatsPinnedCodeCount} {
+		ctx.KVStore(keepers.WasmStoreKey).Delete(key)
+	}
+	stats, err := wasmKeeper.GetModuleStats(ctx)
+	require.NoError(t, err)
+	require.Zero(t, stats.CodeCount)
+
+	// migrator
+	err = keeper.NewMigrator(*wasmKeeper, nil).Migrate5to6(ctx)
+	require.NoError(t, err)
+
+	// check new store
+	stats, err = wasmKeeper.GetModuleStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), stats.CodeCount)
+	assert.Equal(t, uint64(1), stats.ContractCount)
+	assert.Equal(t, uint64(1), stats.PinnedCodeCount)
+}
@@ -0,0 +1,41 @@
+package v6_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate6To7(t *testing.T) {
+	AvailableCapabilities := []string{"iterator", "staking", "stargate", "cosmwasm_1_1"}
+	ctx, keepers := keeper.CreateTestInput(t, false, AvailableCapabilities)
+	wasmKeeper := keepers.WasmKeeper
+
+	creator := keeper.RandomAccountAddress(t)
+	example := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{
+		Verifier:    keeper.RandomAccountAddress(t),
+		Beneficiary: keeper.RandomAccountAddress(t),
+	})
+	require.NoError(t, err)
+	for _, label := range []string{"demo contract", "demo contract", "other contract"} {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, label, nil)
+		require.NoError(t, err)
+		// remove index entry
+		ctx.KVStore(keepers.WasmStoreKey).Delete(types.GetContractLabelIndexKey(example.CodeID, label, addr))
+	}
+	require.Empty(t, wasmKeeper.GetContractsByCodeAndLabel(ctx, example.CodeID, "demo contract"))
+
+	// migrator
+	err = keeper.NewMigrator(*wasmKeeper, nil).Migrate6to7(ctx)
+	require.NoError(t, err)
+
+	// check new store
+	assert.Len(t, wasmKeeper.GetContractsByCodeAndLabel(ctx, example.CodeID, "demo contract"), 2)
+	assert.Len(t, wasmKeeper.GetContractsByCodeAndLabel(ctx, example.CodeID, "other contract"), 1)
+}
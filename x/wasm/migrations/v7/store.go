@@ -0,0 +1,53 @@
+package v7
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// BackfillCodeInfoFn stores the code info with the analysis of the stored code
+type BackfillCodeInfoFn func(ctx context.Context, codeID uint64, codeInfo types.CodeInfo) error
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	GetParams(ctx context.Context) types.Params
+	SetParams(ctx context.Context, ps types.Params) error
+	IterateCodeInfos(ctx context.Context, cb func(uint64, types.CodeInfo) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper             wasmKeeper
+	backfillCodeInfoFn BackfillCodeInfoFn
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fn BackfillCodeInfoFn) Migrator {
+	return Migrator{keeper: k, backfillCodeInfoFn: fn}
+}
+
+// Migrate7to8 migrates from version 7 to 8.
+// It sets the defaults of the params that were added with this version and backfills the code infos
+// with the analysis of the stored codes.
+func (m Migrator) Migrate7to8(ctx sdk.Context) error {
+	var err error
+	m.keeper.IterateCodeInfos(ctx, func(codeID uint64, codeInfo types.CodeInfo) bool {
+		err = m.backfillCodeInfoFn(ctx, codeID, codeInfo)
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+
+	params := m.keeper.GetParams(ctx)
+	if params.MaxQueryRecursionDepth == 0 {
+		params.MaxQueryRecursionDepth = uint64(types.DefaultMaxQueryStackSize)
+	}
+	if params.MaxExecuteMsgSize == 0 {
+		params.MaxExecuteMsgSize = uint64(types.MaxContractMsgSize)
+	}
+	return m.keeper.SetParams(ctx, params)
+}
@@ -0,0 +1,78 @@
+package v7_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate7To8(t *testing.T) {
+	ctx, keepers := keeper.CreateTestInput(t, false, []string{"iterator", "staking", "stargate", "cosmwasm_1_1"})
+	wasmKeeper := keepers.WasmKeeper
+	specs := map[string]struct {
+		src types.Params
+		exp types.Params
+	}{
+		"params not set": {
+			exp: types.Params{
+				MaxQueryRecursionDepth: uint64(types.DefaultMaxQueryStackSize),
+				MaxExecuteMsgSize:      uint64(types.MaxContractMsgSize),
+			},
+		},
+		"params set": {
+			src: types.Params{MaxQueryRecursionDepth: 3, MaxExecuteMsgSize: 1024},
+			exp: types.Params{MaxQueryRecursionDepth: 3, MaxExecuteMsgSize: 1024},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			params := wasmKeeper.GetParams(ctx)
+			params.MaxQueryRecursionDepth = spec.src.MaxQueryRecursionDepth
+			params.MaxExecuteMsgSize = spec.src.MaxExecuteMsgSize
+			require.NoError(t, wasmKeeper.SetParams(ctx, params))
+
+			// when
+			err := keeper.NewMigrator(*wasmKeeper, nil).Migrate7to8(ctx)
+
+			// then
+			require.NoError(t, err)
+			got := wasmKeeper.GetParams(ctx)
+			assert.Equal(t, spec.exp.MaxQueryRecursionDepth, got.MaxQueryRecursionDepth)
+			assert.Equal(t, spec.exp.MaxExecuteMsgSize, got.MaxExecuteMsgSize)
+		})
+	}
+}
+
+func TestMigrate7To8BackfillsCodeInfo(t *testing.T) {
+	ctx, keepers := keeper.CreateTestInput(t, false, []string{"iterator", "staking", "stargate", "cosmwasm_1_1"})
+	wasmKeeper := keepers.WasmKeeper
+	example := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	exp := *wasmKeeper.GetCodeInfo(ctx, example.CodeID)
+	expSize := wasmKeeper.GetCodeSize(ctx, example.CodeID)
+	require.NotZero(t, expSize)
+	expCapabilities := wasmKeeper.GetCodeCapabilities(ctx, example.CodeID)
+	expExports, err := wasmKeeper.CodeExports(ctx, example.CodeID)
+	require.NoError(t, err)
+	require.NotEmpty(t, expExports)
+
+	ctx.KVStore(keepers.WasmStoreKey).Delete(types.GetCodeSizeKey(example.CodeID))
+	ctx.KVStore(keepers.WasmStoreKey).Delete(types.GetCodeCapabilitiesKey(example.CodeID))
+	ctx.KVStore(keepers.WasmStoreKey).Delete(types.GetCodeEntrypointsKey(example.CodeID))
+
+	// when
+	err = keeper.NewMigrator(*wasmKeeper, nil).Migrate7to8(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, exp, *wasmKeeper.GetCodeInfo(ctx, example.CodeID))
+	assert.Equal(t, expSize, wasmKeeper.GetCodeSize(ctx, example.CodeID))
+	assert.Equal(t, expCapabilities, wasmKeeper.GetCodeCapabilities(ctx, example.CodeID))
+	gotExports, err := wasmKeeper.CodeExports(ctx, example.CodeID)
+	require.NoError(t, err)
+	assert.Equal(t, expExports, gotExports)
+}
@@ -44,6 +44,9 @@ const (
 	flagWasmQueryGasLimit          = "wasm.query_gas_limit"
 	flagWasmSimulationGasLimit     = "wasm.simulation_gas_limit"
 	flagWasmSkipWasmVMVersionCheck = "wasm.skip_wasmvm_version_check"
+	flagWasmMetricsSampleInterval  = "wasm.metrics_sample_interval"
+	flagWasmWarmCacheSize          = "wasm.warm_cache_size"
+	flagWasmWasmSimulationGasLimit = "wasm.wasm_simulation_gas_limit"
 )
 
 // AppModuleBasic defines the basic application module used by the wasm module.
@@ -99,7 +102,11 @@ func (b AppModuleBasic) RegisterInterfaces(registry cdctypes.InterfaceRegistry)
 }
 
 // ____________________________________________________________________________
-var _ appmodule.AppModule = AppModule{}
+var (
+	_ appmodule.AppModule       = AppModule{}
+	_ appmodule.HasBeginBlocker = AppModule{}
+	_ appmodule.HasEndBlocker   = AppModule{}
+)
 
 // AppModule implements an application module for the wasm module.
 type AppModule struct {
@@ -148,7 +155,7 @@ func (am AppModule) IsAppModule() { // marker
 // module. It should be incremented on each consensus-breaking change
 // introduced by the module. To avoid wrong/empty versions, the initial version
 // should be set to 1.
-func (AppModule) ConsensusVersion() uint64 { return 4 }
+func (AppModule) ConsensusVersion() uint64 { return 8 }
 
 func (am AppModule) RegisterServices(cfg module.Configurator) {
 	types.RegisterMsgServer(cfg.MsgServer(), keeper.NewMsgServerImpl(am.keeper))
@@ -167,6 +174,32 @@ func (am AppModule) RegisterServices(cfg module.Configurator) {
 	if err != nil {
 		panic(err)
 	}
+	err = cfg.RegisterMigration(types.ModuleName, 4, m.Migrate4to5)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 5, m.Migrate5to6)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 6, m.Migrate6to7)
+	if err != nil {
+		panic(err)
+	}
+	err = cfg.RegisterMigration(types.ModuleName, 7, m.Migrate7to8)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// BeginBlock sudo calls the contracts registered for the BeginBlock phase.
+func (am AppModule) BeginBlock(ctx context.Context) error {
+	return am.keeper.BeginBlocker(ctx)
+}
+
+// EndBlock sudo calls the contracts registered for the EndBlock phase.
+func (am AppModule) EndBlock(ctx context.Context) error {
+	return am.keeper.EndBlocker(ctx)
 }
 
 // RegisterInvariants registers the wasm module invariants.
@@ -228,6 +261,9 @@ func AddModuleInitFlags(startCmd *cobra.Command) {
 	startCmd.Flags().Uint64(flagWasmQueryGasLimit, defaults.SmartQueryGasLimit, "Set the max gas that can be spent on executing a query with a Wasm contract")
 	startCmd.Flags().String(flagWasmSimulationGasLimit, "", "Set the max gas that can be spent when executing a simulation TX")
 	startCmd.Flags().Bool(flagWasmSkipWasmVMVersionCheck, false, "Skip check that ensures that libwasmvm version (the Rust project) and wasmvm version (the Go project) match")
+	startCmd.Flags().Uint64(flagWasmMetricsSampleInterval, defaults.MetricsSampleInterval, "Set the number of blocks between two samples of the wasmvm cache metrics. Set to 0 to disable.")
+	startCmd.Flags().Uint32(flagWasmWarmCacheSize, defaults.WarmCacheSize, "Set the number of recently used codes that are pinned in the wasmvm cache on the next start. Set to 0 to disable.")
+	startCmd.Flags().Uint64(flagWasmWasmSimulationGasLimit, defaults.WasmSimulationGasLimit, "Set the max gas that can be spent when simulating a TX that stores code or calls a contract. Set to 0 to disable.")
 
 	preCheck := func(cmd *cobra.Command, _ []string) error {
 		skip, err := cmd.Flags().GetBool(flagWasmSkipWasmVMVersionCheck)
@@ -266,6 +302,21 @@ func ReadNodeConfig(opts servertypes.AppOptions) (types.NodeConfig, error) {
 			cfg.SimulationGasLimit = &limit
 		}
 	}
+	if v := opts.Get(flagWasmMetricsSampleInterval); v != nil {
+		if cfg.MetricsSampleInterval, err = cast.ToUint64E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmWarmCacheSize); v != nil {
+		if cfg.WarmCacheSize, err = cast.ToUint32E(v); err != nil {
+			return cfg, err
+		}
+	}
+	if v := opts.Get(flagWasmWasmSimulationGasLimit); v != nil {
+		if cfg.WasmSimulationGasLimit, err = cast.ToUint64E(v); err != nil {
+			return cfg, err
+		}
+	}
 	// attach contract debugging to global "trace" flag
 	if v := opts.Get(server.FlagTrace); v != nil {
 		if cfg.ContractDebugMode, err = cast.ToBoolE(v); err != nil {
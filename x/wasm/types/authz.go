@@ -54,14 +54,14 @@ func (a *StoreCodeAuthorization) Accept(ctx context.Context, msg sdk.Msg) (authz
 	code := storeMsg.WASMByteCode
 	permission := storeMsg.InstantiatePermission
 
-	if ioutils.IsGzip(code) {
+	if ioutils.IsCompressed(code) {
 		gasRegister, ok := GasRegisterFromContext(ctx)
 		if !ok {
 			return authztypes.AcceptResponse{}, sdkerrors.ErrNotFound.Wrap("gas register")
 		}
 		sdk.UnwrapSDKContext(ctx).GasMeter().
-			ConsumeGas(gasRegister.UncompressCosts(len(code)), "Uncompress gzip bytecode")
-		wasmCode, err := ioutils.Uncompress(code, int64(MaxWasmSize))
+			ConsumeGas(gasRegister.UncompressCosts(len(code)), "Uncompress bytecode")
+		wasmCode, err := ioutils.Decompress(code, int64(MaxWasmSize))
 		if err != nil {
 			return authztypes.AcceptResponse{}, sdkerrors.ErrInvalidRequest.Wrap("uncompress wasm archive")
 		}
@@ -12,13 +12,19 @@ import (
 // RegisterLegacyAminoCodec registers the concrete types and interface
 func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgStoreCode{}, "wasm/MsgStoreCode", nil)
+	cdc.RegisterConcrete(&MsgStoreCodes{}, "wasm/MsgStoreCodes", nil)
 	cdc.RegisterConcrete(&MsgInstantiateContract{}, "wasm/MsgInstantiateContract", nil)
 	cdc.RegisterConcrete(&MsgInstantiateContract2{}, "wasm/MsgInstantiateContract2", nil)
 	cdc.RegisterConcrete(&MsgExecuteContract{}, "wasm/MsgExecuteContract", nil)
+	cdc.RegisterConcrete(&MsgExecuteContracts{}, "wasm/MsgExecuteContracts", nil)
 	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
+	cdc.RegisterConcrete(&MsgMigrateContractAndExecute{}, "wasm/MsgMigrateContractAndExecute", nil)
 	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin", nil)
 	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasm/MsgClearAdmin", nil)
+	cdc.RegisterConcrete(&MsgClearAdmins{}, "wasm/MsgClearAdmins", nil)
+	cdc.RegisterConcrete(&MsgUpdateAdmins{}, "wasm/MsgUpdateAdmins", nil)
 	cdc.RegisterConcrete(&MsgUpdateInstantiateConfig{}, "wasm/MsgUpdateInstantiateConfig", nil)
+	cdc.RegisterConcrete(&MsgUpdateInstantiateConfigs{}, "wasm/MsgUpdateInstantiateConfigs", nil)
 	cdc.RegisterConcrete(&MsgUpdateParams{}, "wasm/MsgUpdateParams", nil)
 	cdc.RegisterConcrete(&MsgSudoContract{}, "wasm/MsgSudoContract", nil)
 	cdc.RegisterConcrete(&MsgPinCodes{}, "wasm/MsgPinCodes", nil)
@@ -28,6 +34,18 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgRemoveCodeUploadParamsAddresses{}, "wasm/MsgRemoveCodeUploadParamsAddresses", nil)
 	cdc.RegisterConcrete(&MsgStoreAndMigrateContract{}, "wasm/MsgStoreAndMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateContractLabel{}, "wasm/MsgUpdateContractLabel", nil)
+	cdc.RegisterConcrete(&MsgSetContractGasMultiplier{}, "wasm/MsgSetContractGasMultiplier", nil)
+	cdc.RegisterConcrete(&MsgRegisterBlockSudoHook{}, "wasm/MsgRegisterBlockSudoHook", nil)
+	cdc.RegisterConcrete(&MsgRemoveBlockSudoHook{}, "wasm/MsgRemoveBlockSudoHook", nil)
+	cdc.RegisterConcrete(&MsgRestoreContractState{}, "wasm/MsgRestoreContractState", nil)
+	cdc.RegisterConcrete(&MsgUpdateStargateAllowlist{}, "wasm/MsgUpdateStargateAllowlist", nil)
+	cdc.RegisterConcrete(&MsgSetContractStorageQuota{}, "wasm/MsgSetContractStorageQuota", nil)
+	cdc.RegisterConcrete(&MsgPruneUnusedCodes{}, "wasm/MsgPruneUnusedCodes", nil)
+	cdc.RegisterConcrete(&MsgReplaceContractState{}, "wasm/MsgReplaceContractState", nil)
+	cdc.RegisterConcrete(&MsgSetContractLock{}, "wasm/MsgSetContractLock", nil)
+	cdc.RegisterConcrete(&MsgUpdateInstantiateDefaultPermission{}, "wasm/MsgUpdateInstantiateDefaultPermission", nil)
+	cdc.RegisterConcrete(&MsgForfeitCodeDeposit{}, "wasm/MsgForfeitCodeDeposit", nil)
+	cdc.RegisterConcrete(&MsgPruneContractDependencies{}, "wasm/MsgPruneContractDependencies", nil)
 
 	cdc.RegisterInterface((*ContractInfoExtension)(nil), nil)
 
@@ -65,15 +83,21 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 	registry.RegisterImplementations(
 		(*sdk.Msg)(nil),
 		&MsgStoreCode{},
+		&MsgStoreCodes{},
 		&MsgInstantiateContract{},
 		&MsgInstantiateContract2{},
 		&MsgExecuteContract{},
+		&MsgExecuteContracts{},
 		&MsgMigrateContract{},
+		&MsgMigrateContractAndExecute{},
 		&MsgUpdateAdmin{},
 		&MsgClearAdmin{},
+		&MsgClearAdmins{},
+		&MsgUpdateAdmins{},
 		&MsgIBCCloseChannel{},
 		&MsgIBCSend{},
 		&MsgUpdateInstantiateConfig{},
+		&MsgUpdateInstantiateConfigs{},
 		&MsgUpdateParams{},
 		&MsgSudoContract{},
 		&MsgPinCodes{},
@@ -83,6 +107,18 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgRemoveCodeUploadParamsAddresses{},
 		&MsgStoreAndMigrateContract{},
 		&MsgUpdateContractLabel{},
+		&MsgSetContractGasMultiplier{},
+		&MsgRegisterBlockSudoHook{},
+		&MsgRemoveBlockSudoHook{},
+		&MsgRestoreContractState{},
+		&MsgUpdateStargateAllowlist{},
+		&MsgSetContractStorageQuota{},
+		&MsgPruneUnusedCodes{},
+		&MsgReplaceContractState{},
+		&MsgSetContractLock{},
+		&MsgUpdateInstantiateDefaultPermission{},
+		&MsgForfeitCodeDeposit{},
+		&MsgPruneContractDependencies{},
 	)
 	registry.RegisterInterface("cosmwasm.wasm.v1.ContractInfoExtension", (*ContractInfoExtension)(nil))
 
@@ -26,6 +26,14 @@ const (
 
 	// contextKeyExecModeSimulation contextKey = iota
 	_
+
+	// memo of the current tx
+	contextKeyTxMemo contextKey = iota
+
+	// trace id of the current contract call tree
+	contextKeyTraceID contextKey = iota
+	// counter for top level contract calls in the current tx
+	contextKeyTraceSequence contextKey = iota
 )
 
 // WithTXCounter stores a transaction counter value in the context
@@ -51,6 +59,42 @@ func QueryStackSize(ctx context.Context) (uint32, bool) {
 	return val, ok
 }
 
+// WithTxMemo stores the memo of the current transaction in the context
+func WithTxMemo(ctx sdk.Context, memo string) sdk.Context {
+	return ctx.WithValue(contextKeyTxMemo, memo)
+}
+
+// TxMemo returns the memo of the current transaction and found bool from the context.
+func TxMemo(ctx context.Context) (string, bool) {
+	val, ok := ctx.Value(contextKeyTxMemo).(string)
+	return val, ok
+}
+
+// WithTraceID stores the trace id of the current contract call tree in the context
+func WithTraceID(ctx sdk.Context, traceID string) sdk.Context {
+	return ctx.WithValue(contextKeyTraceID, traceID)
+}
+
+// TraceID returns the trace id of the current contract call tree and found bool from the context.
+func TraceID(ctx context.Context) (string, bool) {
+	val, ok := ctx.Value(contextKeyTraceID).(string)
+	return val, ok
+}
+
+// WithTraceSequence stores the counter for top level contract calls of the tx into the context returned
+func WithTraceSequence(ctx sdk.Context, seq TraceSequence) sdk.Context {
+	if seq.counter == nil {
+		panic("trace sequence must not be nil")
+	}
+	return ctx.WithValue(contextKeyTraceSequence, seq)
+}
+
+// TraceSequenceFromContext reads the counter for top level contract calls of the tx from the context
+func TraceSequenceFromContext(ctx context.Context) (TraceSequence, bool) {
+	val, ok := ctx.Value(contextKeyTraceSequence).(TraceSequence)
+	return val, ok
+}
+
 func WithCallDepth(ctx sdk.Context, counter uint32) sdk.Context {
 	return ctx.WithValue(contextKeyCallDepth, counter)
 }
@@ -4,6 +4,8 @@ import (
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
 
 	errorsmod "cosmossdk.io/errors"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 // Codes for wasm contract errors
@@ -72,8 +74,12 @@ var (
 	// ErrInvalidEvent error if an attribute/event from the contract is invalid
 	ErrInvalidEvent = errorsmod.Register(DefaultCodespace, 21, "invalid event")
 
+	// ErrContractNotFound error when an address does not belong to a contract. It matches all errors built
+	// with ErrNoSuchContractFn with errors.Is.
+	ErrContractNotFound = errorsmod.Register(DefaultCodespace, 22, "no such contract")
+
 	// ErrNoSuchContractFn error factory for an error when an address does not belong to a contract
-	ErrNoSuchContractFn = WasmVMFlavouredErrorFactory(errorsmod.Register(DefaultCodespace, 22, "no such contract"),
+	ErrNoSuchContractFn = WasmVMFlavouredErrorFactory(ErrContractNotFound,
 		func(addr string) error { return wasmvmtypes.NoSuchContract{Addr: addr} },
 	)
 
@@ -92,6 +98,46 @@ var (
 
 	// ErrExceedMaxCallDepth error if max message stack size is exceeded
 	ErrExceedMaxCallDepth = errorsmod.Register(DefaultCodespace, 30, "max call depth exceeded")
+
+	// ErrExceedMaxInstantiatesPerBlock error if the instantiate budget of the block is used up.
+	// Clients can retry in the next block.
+	ErrExceedMaxInstantiatesPerBlock = errorsmod.Register(DefaultCodespace, 31, "max instantiates per block exceeded")
+
+	// ErrExceedMaxQueryRecursionDepth error if the max depth of nested smart queries is exceeded
+	ErrExceedMaxQueryRecursionDepth = errorsmod.Register(DefaultCodespace, 32, "max query recursion depth exceeded")
+
+	// ErrExceedMaxWasmInstructions error if a contract call executes more than the max number of Wasm operations
+	ErrExceedMaxWasmInstructions = errorsmod.Register(DefaultCodespace, 33, "max wasm instructions per call exceeded")
+
+	// ErrExceedMaxMsgSize error if a contract payload msg plus funds is larger than the max size
+	ErrExceedMaxMsgSize = errorsmod.Register(DefaultCodespace, 34, "max msg size exceeded")
+
+	// ErrStorageQuotaExceeded error if a contract stores more bytes than its storage quota allows
+	ErrStorageQuotaExceeded = errorsmod.Register(DefaultCodespace, 35, "storage quota exceeded")
+
+	// ErrExceedMaxSubMessages error if a contract call returns more submessages than allowed.
+	// Contracts can split the batch over multiple calls.
+	ErrExceedMaxSubMessages = errorsmod.Register(DefaultCodespace, 36, "max submessages per call exceeded")
+
+	// ErrContractLocked error if a locked contract is called
+	ErrContractLocked = errorsmod.Register(DefaultCodespace, 37, "contract locked")
+
+	// ErrContractAddressExists error if the predictable address of an instantiate2 call is taken by a contract.
+	// Deploy scripts can match it with errors.Is to treat a repeated instantiation as success.
+	ErrContractAddressExists = errorsmod.Register(DefaultCodespace, 38, "contract address already exists")
+
+	// ErrDuplicateLabel error for a label that is already used by another contract of the code
+	ErrDuplicateLabel = errorsmod.Register(DefaultCodespace, 39, "duplicate label")
+
+	// ErrExceedMaxEvents error if a contract call emits more events than allowed
+	ErrExceedMaxEvents = errorsmod.Register(DefaultCodespace, 40, "max events per call exceeded")
+
+	// ErrSelfQueryRejected error if a contract queries itself and the reject self queries param is set
+	ErrSelfQueryRejected = errorsmod.Register(DefaultCodespace, 41, "self query rejected")
+
+	// ErrOutOfGas error when a contract call runs out of gas. It is the sdk error so that errors.Is matches
+	// the out of gas errors of the sdk as well.
+	ErrOutOfGas = sdkerrors.ErrOutOfGas
 )
 
 // WasmVMErrorable mapped error type in wasmvm and are not redacted
@@ -156,6 +202,37 @@ func (e WasmVMFlavouredError) Wrapf(desc string, args ...interface{}) error {
 	return errorsmod.Wrapf(e, desc, args...)
 }
 
+// ErrContractExecutionFailed is returned when a contract call completed with an error result from the contract.
+// It carries the error message of the contract and the ABCI code of the failed entry point for errors.As.
+// The error message and ABCI code are the ones of the wrapped sdk error.
+type ErrContractExecutionFailed struct {
+	err error
+	// Code is the ABCI code of the failed entry point, for example the code of ErrExecuteFailed
+	Code uint32
+	// Msg is the error message returned by the contract
+	Msg string
+}
+
+// NewErrContractExecutionFailed constructor
+func NewErrContractExecutionFailed(sdkErr *errorsmod.Error, msg string) ErrContractExecutionFailed {
+	return ErrContractExecutionFailed{err: errorsmod.Wrap(sdkErr, msg), Code: sdkErr.ABCICode(), Msg: msg}
+}
+
+// implements stdlib error
+func (e ErrContractExecutionFailed) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e ErrContractExecutionFailed) Unwrap() error {
+	return e.err
+}
+
+// Cause is the same as unwrap but used by errors.abci
+func (e ErrContractExecutionFailed) Cause() error {
+	return e.Unwrap()
+}
+
 // DeterministicError is a wrapper type around an error that the creator guarantees to have
 // a deterministic error message.
 // This means that the `Error()` function must always return the same string on all nodes.
@@ -110,3 +110,25 @@ func TestDeterministicError(t *testing.T) {
 	assert.Equal(t, innerCodeSpace, codespace)
 	assert.Equal(t, innerCode, code)
 }
+
+func TestErrContractExecutionFailed(t *testing.T) {
+	err := MarkErrorDeterministic(NewErrContractExecutionFailed(ErrExecuteFailed, "Unauthorized"))
+
+	// keeps the message and code of the wrapped sdk error
+	assert.Equal(t, errorsmod.Wrap(ErrExecuteFailed, "Unauthorized").Error(), err.Error())
+	assert.True(t, errors.Is(err, ErrExecuteFailed))
+	codespace, code, _ := errorsmod.ABCIInfo(err, false)
+	assert.Equal(t, ErrExecuteFailed.Codespace(), codespace)
+	assert.Equal(t, ErrExecuteFailed.ABCICode(), code)
+
+	// and provides the message of the contract
+	var contractErr ErrContractExecutionFailed
+	require.True(t, errors.As(err, &contractErr))
+	assert.Equal(t, "Unauthorized", contractErr.Msg)
+	assert.Equal(t, ErrExecuteFailed.ABCICode(), contractErr.Code)
+}
+
+func TestErrContractNotFound(t *testing.T) {
+	err := ErrNoSuchContractFn("myAddr").Wrap("address myAddr")
+	assert.True(t, errors.Is(err, ErrContractNotFound))
+}
@@ -16,8 +16,10 @@ const (
 	CustomContractEventPrefix = "wasm-"
 
 	EventTypeStoreCode              = "store_code"
+	EventTypeStoreCodes             = "store_codes"
 	EventTypeInstantiate            = "instantiate"
 	EventTypeExecute                = "execute"
+	EventTypeExecuteBatch           = "execute_batch"
 	EventTypeMigrate                = "migrate"
 	EventTypePinCode                = "pin_code"
 	EventTypeUnpinCode              = "unpin_code"
@@ -25,8 +27,29 @@ const (
 	EventTypeReply                  = "reply"
 	EventTypeGovContractResult      = "gov_contract_result"
 	EventTypeUpdateContractAdmin    = "update_contract_admin"
+	EventTypeClearContractAdmins    = "clear_contract_admins"
+	EventTypeUpdateContractAdmins   = "update_contract_admins"
 	EventTypeUpdateContractLabel    = "update_contract_label"
 	EventTypeUpdateCodeAccessConfig = "update_code_access_config"
+	EventTypeUpdateDefaultAccess    = "update_instantiate_default_permission"
+	EventTypeUpdateGasMultiplier    = "update_contract_gas_multiplier"
+	EventTypeRegisterBlockSudoHook  = "register_block_sudo_hook"
+	EventTypeRemoveBlockSudoHook    = "remove_block_sudo_hook"
+	EventTypeBlockSudoFailed        = "block_sudo_failed"
+	EventTypeMigrationCheckpoint    = "migration_checkpoint"
+	EventTypeRestoreContractState   = "restore_contract_state"
+	EventTypeReplaceContractState   = "replace_contract_state"
+	EventTypeStargateAllowlist      = "update_stargate_allowlist"
+	EventTypeUpdateStorageQuota     = "update_contract_storage_quota"
+	EventTypePruneCode              = "prune_code"
+	EventTypeForfeitDeposit         = "forfeit_code_deposit"
+	EventTypeLockContract           = "lock_contract"
+	EventTypeUnlockContract         = "unlock_contract"
+	EventTypeDBWrite                = "db_write"
+	EventTypeDBRemove               = "db_remove"
+	EventTypeStateCleanupRefund     = "state_cleanup_refund"
+	EventTypeContractBurn           = "contract_burn"
+	EventTypeRawContractEvent       = "raw_contract_event"
 	EventTypePacketRecv             = "ibc_packet_received"
 	// add new types to IsAcceptedEventOnRecvPacketErrorAck
 )
@@ -68,6 +91,7 @@ const (
 
 	AttributeKeyContractAddr        = "_contract_address"
 	AttributeKeyCodeID              = "code_id"
+	AttributeKeyCodeIDs             = "code_ids"
 	AttributeKeyChecksum            = "code_checksum"
 	AttributeKeyResultDataHex       = "result"
 	AttributeKeyRequiredCapability  = "required_capability"
@@ -75,6 +99,29 @@ const (
 	AttributeKeyNewLabel            = "new_label"
 	AttributeKeyCodePermission      = "code_permission"
 	AttributeKeyAuthorizedAddresses = "authorized_addresses"
+	AttributeKeyGasMultiplier       = "gas_multiplier"
+	AttributeKeyStorageQuota        = "storage_quota"
+	AttributeKeyAmount              = "amount"
+	AttributeKeyExecutionCount      = "execution_count"
+	AttributeKeyClearedCount        = "cleared_count"
+	AttributeKeyUpdatedCount        = "updated_count"
+	AttributeKeySkippedCount        = "skipped_count"
+	AttributeKeyBlockSudoPhase      = "block_sudo_phase"
+	AttributeKeyBlockSudoError      = "error"
+	AttributeKeyCheckpointID        = "checkpoint_id"
+	AttributeKeyStateImportMode     = "import_mode"
+	AttributeKeyAddedQueryPaths     = "added_query_paths"
+	AttributeKeyRemovedQueryPaths   = "removed_query_paths"
+	AttributeKeyStateKeyHash        = "key_hash"
+	AttributeKeyRemovedBytes        = "removed_bytes"
+	AttributeKeyRefundedGas         = "refunded_gas"
+	AttributeKeyRawEventType        = "event_type"
+	AttributeKeyRawEventAttributes  = "attributes"
+	AttributeKeyRawEventTruncated   = "truncated"
 	AttributeKeyAckSuccess          = "success"
 	AttributeKeyAckError            = "error"
+	// AttributeKeyTraceID is added to the instantiate, execute, migrate, sudo and reply events. All calls that
+	// originate from the same top level message, including submessages and their replies, share the trace id
+	// so that the call tree can be reconstructed by indexers. The value is for observability only.
+	AttributeKeyTraceID = "trace_id"
 )
@@ -0,0 +1,15 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecuteResult is the outcome of a contract execution for native callers
+type ExecuteResult struct {
+	// Data is the response data after the submessages were dispatched
+	Data []byte
+	// Events are all events emitted by the execution, including those of the dispatched submessages
+	Events sdk.Events
+	// GasUsed is the SDK gas consumed by the execution
+	GasUsed uint64
+}
@@ -9,13 +9,16 @@ import (
 	ibcexported "github.com/cosmos/ibc-go/v10/modules/core/exported"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
 // ViewKeeper provides read only operations
 type ViewKeeper interface {
 	GetContractHistory(ctx context.Context, contractAddr sdk.AccAddress) []ContractCodeHistoryEntry
+	GetContractHistoryPaginated(ctx context.Context, contractAddr sdk.AccAddress, pageReq *query.PageRequest) ([]ContractCodeHistoryEntry, *query.PageResponse, error)
 	QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
 	QueryRaw(ctx context.Context, contractAddress sdk.AccAddress, key []byte) []byte
+	QueryRawPrefix(ctx context.Context, contractAddress sdk.AccAddress, keyPrefix []byte, pageReq *query.PageRequest) ([]Model, *query.PageResponse, error)
 	HasContractInfo(ctx context.Context, contractAddress sdk.AccAddress) bool
 	GetContractInfo(ctx context.Context, contractAddress sdk.AccAddress) *ContractInfo
 	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, ContractInfo) bool)
@@ -23,6 +26,11 @@ type ViewKeeper interface {
 	IterateContractsByCode(ctx context.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
 	IterateContractState(ctx context.Context, contractAddress sdk.AccAddress, cb func(key, value []byte) bool)
 	GetCodeInfo(ctx context.Context, codeID uint64) *CodeInfo
+	GetCodeProvenance(ctx context.Context, codeID uint64) (source, builder string, err error)
+	GetContractCountByCode(ctx context.Context, codeID uint64) uint64
+	GetBlockSudoHooks(ctx context.Context, phase BlockSudoPhase) []BlockSudoHook
+	GetMigrationCheckpoints(ctx context.Context, contractAddr sdk.AccAddress) []MigrationCheckpoint
+	GetStargateAllowlist(ctx context.Context) []string
 	IterateCodeInfos(ctx context.Context, cb func(uint64, CodeInfo) bool)
 	GetByteCode(ctx context.Context, codeID uint64) ([]byte, error)
 	IsPinnedCode(ctx context.Context, codeID uint64) bool
@@ -30,6 +38,12 @@ type ViewKeeper interface {
 	GetWasmLimits() wasmvmtypes.WasmLimits
 }
 
+// ContractSimulator executes contracts on a branched state that is never persisted.
+type ContractSimulator interface {
+	SimulateExecute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, sdk.Events, error)
+	SimulateExecuteWithBalances(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, sdk.Events, sdk.Coins, sdk.Coins, error)
+}
+
 // ContractOpsKeeper contains mutable operations on a contract.
 type ContractOpsKeeper interface {
 	// Create uploads and compiles a WASM contract, returning a short identifier for the contract
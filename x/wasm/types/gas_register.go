@@ -1,6 +1,9 @@
 package types
 
 import (
+	"math"
+	"math/bits"
+
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v3/types"
 
 	errorsmod "cosmossdk.io/errors"
@@ -59,8 +62,23 @@ const (
 	DefaultPerCustomEventCost uint64 = 20
 	// DefaultEventAttributeDataFreeTier number of bytes of total attribute data we do not charge.
 	DefaultEventAttributeDataFreeTier = 100
+	// VMGasPerWasmInstruction is the flat CosmWasm gas cost that the VM charges per executed Wasm operation.
+	// See [CosmWasm gas] for the cost table.
+	//
+	// [CosmWasm gas]: https://github.com/CosmWasm/cosmwasm/blob/v2.0.0/docs/GAS.md
+	VMGasPerWasmInstruction uint64 = 115
 )
 
+// WasmInstructionsToVMGas translates a number of executed Wasm operations into CosmWasm gas.
+// The result is capped at the max uint64 value.
+func WasmInstructionsToVMGas(instructions uint64) uint64 {
+	hi, lo := bits.Mul64(instructions, VMGasPerWasmInstruction)
+	if hi != 0 {
+		return math.MaxUint64
+	}
+	return lo
+}
+
 // default: 0.15 gas.
 // see https://github.com/CosmWasm/wasmd/pull/898#discussion_r937727200
 var defaultPerByteUncompressCost = wasmvmtypes.UFraction{
@@ -263,3 +281,70 @@ func (g WasmGasRegister) ToWasmVMGas(source storetypes.Gas) uint64 {
 func (g WasmGasRegister) FromWasmVMGas(source uint64) storetypes.Gas {
 	return source / g.c.GasMultiplier
 }
+
+// DefaultContractGasMultiplier is applied to all contracts without an override
+func DefaultContractGasMultiplier() GasMultiplier {
+	return GasMultiplier{Numerator: 1, Denominator: 1}
+}
+
+// ValidateBasic performs basic validation
+func (m GasMultiplier) ValidateBasic() error {
+	if m.Numerator == 0 {
+		return errorsmod.Wrap(ErrEmpty, "numerator")
+	}
+	if m.Denominator == 0 {
+		return errorsmod.Wrap(ErrEmpty, "denominator")
+	}
+	return nil
+}
+
+// IsDefault returns true when the multiplier does not scale gas
+func (m GasMultiplier) IsDefault() bool {
+	return m.Numerator == m.Denominator
+}
+
+// ContractGasRegister scales the wasmvm gas conversions of a parent GasRegister
+// by a contract specific GasMultiplier. All other costs are taken from the parent.
+type ContractGasRegister struct {
+	GasRegister
+	multiplier GasMultiplier
+}
+
+// NewContractGasRegister constructor. Returns the parent when the multiplier is the default.
+func NewContractGasRegister(parent GasRegister, multiplier GasMultiplier) GasRegister {
+	if multiplier.IsDefault() {
+		return parent
+	}
+	if err := multiplier.ValidateBasic(); err != nil {
+		panic(errorsmod.Wrap(err, "gas multiplier"))
+	}
+	return ContractGasRegister{GasRegister: parent, multiplier: multiplier}
+}
+
+// ToWasmVMGas converts from Cosmos SDK gas units to [CosmWasm gas] (aka. wasmvm gas)
+// with the inverse of the contract multiplier applied, so that the contract can spend
+// more wasmvm gas for the same amount of SDK gas when the multiplier is < 1.
+//
+// [CosmWasm gas]: https://github.com/CosmWasm/cosmwasm/blob/v1.3.1/docs/GAS.md
+func (g ContractGasRegister) ToWasmVMGas(source storetypes.Gas) uint64 {
+	return mulDivSaturating(g.GasRegister.ToWasmVMGas(source), g.multiplier.Denominator, g.multiplier.Numerator)
+}
+
+// FromWasmVMGas converts from [CosmWasm gas] (aka. wasmvm gas) to Cosmos SDK gas units
+// with the contract multiplier applied.
+//
+// [CosmWasm gas]: https://github.com/CosmWasm/cosmwasm/blob/v1.3.1/docs/GAS.md
+func (g ContractGasRegister) FromWasmVMGas(source uint64) storetypes.Gas {
+	return mulDivSaturating(g.GasRegister.FromWasmVMGas(source), g.multiplier.Numerator, g.multiplier.Denominator)
+}
+
+// mulDivSaturating returns x * num / den without intermediate overflow.
+// The result is capped at max uint64.
+func mulDivSaturating(x, num, den uint64) uint64 {
+	hi, lo := bits.Mul64(x, num)
+	if hi >= den {
+		return math.MaxUint64
+	}
+	q, _ := bits.Div64(hi, lo, den)
+	return q
+}
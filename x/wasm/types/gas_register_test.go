@@ -330,6 +330,59 @@ func TestFromWasmVMGasConversion(t *testing.T) {
 	}
 }
 
+func TestContractGasRegisterConversion(t *testing.T) {
+	specs := map[string]struct {
+		srcMultiplier GasMultiplier
+		srcSDKGas     storetypes.Gas
+		srcWasmGas    uint64
+		expWasmGas    uint64
+		expSDKGas     storetypes.Gas
+	}{
+		"default": {
+			srcMultiplier: DefaultContractGasMultiplier(),
+			srcSDKGas:     10,
+			srcWasmGas:    10 * DefaultGasMultiplier,
+			expWasmGas:    10 * DefaultGasMultiplier,
+			expSDKGas:     10,
+		},
+		"half": {
+			srcMultiplier: GasMultiplier{Numerator: 1, Denominator: 2},
+			srcSDKGas:     10,
+			srcWasmGas:    10 * DefaultGasMultiplier,
+			expWasmGas:    20 * DefaultGasMultiplier,
+			expSDKGas:     5,
+		},
+		"double": {
+			srcMultiplier: GasMultiplier{Numerator: 2, Denominator: 1},
+			srcSDKGas:     10,
+			srcWasmGas:    10 * DefaultGasMultiplier,
+			expWasmGas:    5 * DefaultGasMultiplier,
+			expSDKGas:     20,
+		},
+		"rounded down": {
+			srcMultiplier: GasMultiplier{Numerator: 2, Denominator: 3},
+			srcSDKGas:     1,
+			srcWasmGas:    1 * DefaultGasMultiplier,
+			expWasmGas:    DefaultGasMultiplier * 3 / 2,
+			expSDKGas:     0,
+		},
+		"saturated": {
+			srcMultiplier: GasMultiplier{Numerator: 1, Denominator: math.MaxUint64},
+			srcSDKGas:     math.MaxUint64 / DefaultGasMultiplier,
+			srcWasmGas:    0,
+			expWasmGas:    math.MaxUint64,
+			expSDKGas:     0,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			r := NewContractGasRegister(NewDefaultWasmGasRegister(), spec.srcMultiplier)
+			assert.Equal(t, spec.expWasmGas, r.ToWasmVMGas(spec.srcSDKGas))
+			assert.Equal(t, spec.expSDKGas, r.FromWasmVMGas(spec.srcWasmGas))
+		})
+	}
+}
+
 func TestUncompressCosts(t *testing.T) {
 	specs := map[string]struct {
 		lenIn    int
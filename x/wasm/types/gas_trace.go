@@ -0,0 +1,134 @@
+package types
+
+import (
+	"strings"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// gas consumption descriptors used by the keeper
+const (
+	GasDescWasmExecution   = "wasm contract"
+	GasDescEventAttributes = "Custom contract event attributes"
+	GasDescSubQuery        = "contract sub-query"
+	GasDescLimitedSubMsg   = "From limited Sub-Message"
+	GasDescLimitedCall     = "From gas limited contract call"
+	// GasDescStateCleanupRefund is the descriptor of the gas refunded for removed contract state
+	GasDescStateCleanupRefund = "contract state cleanup refund"
+	// GasDescSetupPrefix is the prefix of the descriptors for loading a contract instance
+	GasDescSetupPrefix = "Loading CosmWasm module: "
+)
+
+// gas trace categories
+const (
+	GasCategoryWasmExecution = "wasm_execution"
+	GasCategorySetup         = "setup"
+	GasCategoryDBRead        = "db_read"
+	GasCategoryDBWrite       = "db_write"
+	GasCategoryQueryChain    = "query_chain"
+	GasCategoryEvents        = "events"
+	GasCategorySubMsg        = "sub_message"
+	GasCategoryOther         = "other"
+)
+
+// GasTraceEntry aggregated gas consumption for a category or descriptor
+type GasTraceEntry struct {
+	Name  string `json:"name"`
+	Gas   uint64 `json:"gas"`
+	Count uint64 `json:"count"`
+}
+
+// GasTrace is a breakdown of the SDK gas consumed. Entries are in the order of first consumption.
+type GasTrace struct {
+	Total       uint64          `json:"total"`
+	Refunded    uint64          `json:"refunded,omitempty"`
+	Categories  []GasTraceEntry `json:"categories"`
+	Descriptors []GasTraceEntry `json:"descriptors"`
+}
+
+// GasCategory returns the gas trace category for a gas consumption descriptor
+func GasCategory(descriptor string) string {
+	switch descriptor {
+	case GasDescWasmExecution:
+		return GasCategoryWasmExecution
+	case GasDescEventAttributes:
+		return GasCategoryEvents
+	case GasDescSubQuery:
+		return GasCategoryQueryChain
+	case GasDescLimitedSubMsg:
+		return GasCategorySubMsg
+	case storetypes.GasReadCostFlatDesc, storetypes.GasReadPerByteDesc, storetypes.GasHasDesc,
+		storetypes.GasIterNextCostFlatDesc, storetypes.GasValuePerByteDesc:
+		return GasCategoryDBRead
+	case storetypes.GasWriteCostFlatDesc, storetypes.GasWritePerByteDesc, storetypes.GasDeleteDesc:
+		return GasCategoryDBWrite
+	}
+	if strings.HasPrefix(descriptor, GasDescSetupPrefix) {
+		return GasCategorySetup
+	}
+	return GasCategoryOther
+}
+
+var _ storetypes.GasMeter = &GasTraceMeter{}
+
+// GasTraceMeter is a gas meter decorator that records the gas consumed by descriptor.
+// It does not modify the gas accounting of the wrapped meter.
+type GasTraceMeter struct {
+	storetypes.GasMeter
+	refunded    uint64
+	categories  traceEntries
+	descriptors traceEntries
+}
+
+// NewGasTraceMeter constructor
+func NewGasTraceMeter(parent storetypes.GasMeter) *GasTraceMeter {
+	return &GasTraceMeter{GasMeter: parent}
+}
+
+// ConsumeGas records the amount and forwards it to the wrapped meter.
+// The amount is recorded before it is forwarded so that an out of gas consumption is included in the trace.
+func (m *GasTraceMeter) ConsumeGas(amount storetypes.Gas, descriptor string) {
+	m.descriptors.add(descriptor, amount)
+	m.categories.add(GasCategory(descriptor), amount)
+	m.GasMeter.ConsumeGas(amount, descriptor)
+}
+
+// RefundGas records the amount and forwards it to the wrapped meter.
+func (m *GasTraceMeter) RefundGas(amount storetypes.Gas, descriptor string) {
+	m.GasMeter.RefundGas(amount, descriptor)
+	m.refunded += amount
+}
+
+// Trace returns the recorded gas consumption
+func (m *GasTraceMeter) Trace() GasTrace {
+	var total uint64
+	for _, e := range m.descriptors.entries {
+		total += e.Gas
+	}
+	return GasTrace{
+		Total:       total,
+		Refunded:    m.refunded,
+		Categories:  append([]GasTraceEntry{}, m.categories.entries...),
+		Descriptors: append([]GasTraceEntry{}, m.descriptors.entries...),
+	}
+}
+
+// traceEntries aggregates by name and preserves the order of first occurrence
+type traceEntries struct {
+	entries []GasTraceEntry
+	pos     map[string]int
+}
+
+func (t *traceEntries) add(name string, amount uint64) {
+	if t.pos == nil {
+		t.pos = make(map[string]int)
+	}
+	i, ok := t.pos[name]
+	if !ok {
+		i = len(t.entries)
+		t.pos[name] = i
+		t.entries = append(t.entries, GasTraceEntry{Name: name})
+	}
+	t.entries[i].Gas += amount
+	t.entries[i].Count++
+}
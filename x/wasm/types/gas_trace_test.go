@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+func TestGasCategory(t *testing.T) {
+	specs := map[string]struct {
+		descriptor string
+		exp        string
+	}{
+		"wasm execution": {descriptor: GasDescWasmExecution, exp: GasCategoryWasmExecution},
+		"setup execute":  {descriptor: GasDescSetupPrefix + "execute", exp: GasCategorySetup},
+		"setup query":    {descriptor: GasDescSetupPrefix + "query", exp: GasCategorySetup},
+		"read flat":      {descriptor: storetypes.GasReadCostFlatDesc, exp: GasCategoryDBRead},
+		"read per byte":  {descriptor: storetypes.GasReadPerByteDesc, exp: GasCategoryDBRead},
+		"has":            {descriptor: storetypes.GasHasDesc, exp: GasCategoryDBRead},
+		"iterator next":  {descriptor: storetypes.GasIterNextCostFlatDesc, exp: GasCategoryDBRead},
+		"write flat":     {descriptor: storetypes.GasWriteCostFlatDesc, exp: GasCategoryDBWrite},
+		"write per byte": {descriptor: storetypes.GasWritePerByteDesc, exp: GasCategoryDBWrite},
+		"delete":         {descriptor: storetypes.GasDeleteDesc, exp: GasCategoryDBWrite},
+		"sub query":      {descriptor: GasDescSubQuery, exp: GasCategoryQueryChain},
+		"events":         {descriptor: GasDescEventAttributes, exp: GasCategoryEvents},
+		"sub message":    {descriptor: GasDescLimitedSubMsg, exp: GasCategorySubMsg},
+		"unknown":        {descriptor: "foo", exp: GasCategoryOther},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, GasCategory(spec.descriptor))
+		})
+	}
+}
+
+func TestGasTraceMeter(t *testing.T) {
+	parent := storetypes.NewGasMeter(100)
+	parent.ConsumeGas(10, "before")
+	m := NewGasTraceMeter(parent)
+
+	// when
+	m.ConsumeGas(20, GasDescWasmExecution)
+	m.ConsumeGas(5, storetypes.GasReadCostFlatDesc)
+	m.ConsumeGas(3, storetypes.GasReadPerByteDesc)
+	m.ConsumeGas(30, GasDescWasmExecution)
+	m.RefundGas(2, "refund")
+
+	// then the parent is charged
+	assert.Equal(t, storetypes.Gas(66), parent.GasConsumed())
+	assert.Equal(t, storetypes.Gas(66), m.GasConsumed())
+	// and the consumption is recorded in order of first occurrence
+	exp := GasTrace{
+		Total:    58,
+		Refunded: 2,
+		Categories: []GasTraceEntry{
+			{Name: GasCategoryWasmExecution, Gas: 50, Count: 2},
+			{Name: GasCategoryDBRead, Gas: 8, Count: 2},
+		},
+		Descriptors: []GasTraceEntry{
+			{Name: GasDescWasmExecution, Gas: 50, Count: 2},
+			{Name: storetypes.GasReadCostFlatDesc, Gas: 5, Count: 1},
+			{Name: storetypes.GasReadPerByteDesc, Gas: 3, Count: 1},
+		},
+	}
+	assert.Equal(t, exp, m.Trace())
+
+	// and the out of gas consumption is recorded
+	require.Panics(t, func() { m.ConsumeGas(50, GasDescWasmExecution) })
+	assert.Equal(t, uint64(108), m.Trace().Total)
+}
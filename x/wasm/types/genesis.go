@@ -1,6 +1,9 @@
 package types
 
 import (
+	"bytes"
+	"slices"
+
 	errorsmod "cosmossdk.io/errors"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -14,6 +17,32 @@ func (s Sequence) ValidateBasic() error {
 	return nil
 }
 
+func (c InstantiateCount) ValidateBasic() error {
+	if c.CodeID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "code id")
+	}
+	if _, err := sdk.AccAddressFromBech32(c.Address); err != nil {
+		return errorsmod.Wrap(err, "address")
+	}
+	if c.Count == 0 {
+		return errorsmod.Wrap(ErrEmpty, "count")
+	}
+	return nil
+}
+
+func (d CodeDeposit) ValidateBasic() error {
+	if d.CodeID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "code id")
+	}
+	if _, err := sdk.AccAddressFromBech32(d.Depositor); err != nil {
+		return errorsmod.Wrap(err, "depositor")
+	}
+	if !d.Amount.IsValid() {
+		return errorsmod.Wrap(ErrInvalid, "amount")
+	}
+	return nil
+}
+
 func (s GenesisState) ValidateBasic() error {
 	if err := s.Params.ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "params")
@@ -33,7 +62,120 @@ func (s GenesisState) ValidateBasic() error {
 			return errorsmod.Wrapf(err, "sequence: %d", i)
 		}
 	}
+	for i := range s.InstantiateCounts {
+		if err := s.InstantiateCounts[i].ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "instantiate count: %d", i)
+		}
+	}
+	if err := validateBlockSudoHooks(s.BeginBlockSudoHooks); err != nil {
+		return errorsmod.Wrap(err, "begin block sudo hooks")
+	}
+	if err := validateBlockSudoHooks(s.EndBlockSudoHooks); err != nil {
+		return errorsmod.Wrap(err, "end block sudo hooks")
+	}
+	if err := validateStargateQueryPaths(s.StargateAllowlist); err != nil {
+		return errorsmod.Wrap(err, "stargate allowlist")
+	}
+	for i := range s.CodeDeposits {
+		if err := s.CodeDeposits[i].ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "code deposit: %d", i)
+		}
+	}
+
+	return nil
+}
+
+// MergeContracts adds the codes, contracts and sequences of the source genesis state. Code ids and contract
+// addresses that exist already are rejected unless overwrite is set. Codes that are used by existing contracts are
+// never overwritten. The sequences are set to the max of both values. Other fields of the source are ignored.
+func (s *GenesisState) MergeContracts(src GenesisState, overwrite bool) error {
+	var maxCodeID uint64
+	for i, code := range src.Codes {
+		pos := slices.IndexFunc(s.Codes, func(c Code) bool { return c.CodeID == code.CodeID })
+		switch {
+		case pos < 0:
+			s.Codes = append(s.Codes, code)
+		case !overwrite:
+			return errorsmod.Wrapf(ErrDuplicate, "code %d with id %d exists already", i, code.CodeID)
+		case s.hasCodeInstances(code.CodeID):
+			return errorsmod.Wrapf(ErrInvalid, "code %d with id %d is used by existing contracts", i, code.CodeID)
+		default:
+			s.Codes[pos] = code
+		}
+		maxCodeID = max(maxCodeID, code.CodeID)
+	}
+	for i, contract := range src.Contracts {
+		pos := slices.IndexFunc(s.Contracts, func(c Contract) bool { return c.ContractAddress == contract.ContractAddress })
+		switch {
+		case pos < 0:
+			s.Contracts = append(s.Contracts, contract)
+		case !overwrite:
+			return errorsmod.Wrapf(ErrDuplicate, "contract number %d with address %s exists already", i, contract.ContractAddress)
+		default:
+			s.Contracts[pos] = contract
+		}
+	}
+	for _, seq := range append(slices.Clone(src.Sequences), Sequence{IDKey: KeySequenceCodeID, Value: maxCodeID + 1}) {
+		pos := slices.IndexFunc(s.Sequences, func(x Sequence) bool { return bytes.Equal(x.IDKey, seq.IDKey) })
+		switch {
+		case pos < 0:
+			s.Sequences = append(s.Sequences, seq)
+		case s.Sequences[pos].Value < seq.Value:
+			s.Sequences[pos].Value = seq.Value
+		}
+	}
+	return nil
+}
 
+// hasCodeInstances returns true when a contract runs the code or ran it before a migration
+func (s GenesisState) hasCodeInstances(codeID uint64) bool {
+	return slices.ContainsFunc(s.Contracts, func(c Contract) bool {
+		return c.ContractInfo.CodeID == codeID || slices.ContainsFunc(c.ContractCodeHistory, func(e ContractCodeHistoryEntry) bool {
+			return e.CodeID == codeID
+		})
+	})
+}
+
+// ReplaceContractState applies the models to the state of the genesis contract with the given address.
+// See StateImportMode for the supported modes.
+func (s *GenesisState) ReplaceContractState(contractAddr string, models []Model, mode StateImportMode) error {
+	if err := mode.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := ValidateModels(models); err != nil {
+		return err
+	}
+	pos := slices.IndexFunc(s.Contracts, func(c Contract) bool { return c.ContractAddress == contractAddr })
+	if pos < 0 {
+		return ErrNoSuchContractFn(contractAddr).Wrapf("address %s", contractAddr)
+	}
+	contract := &s.Contracts[pos]
+	if mode == StateImportModeOverwrite {
+		contract.ContractState = slices.Clone(models)
+		return nil
+	}
+	for _, m := range models {
+		i := slices.IndexFunc(contract.ContractState, func(x Model) bool { return bytes.Equal(x.Key, m.Key) })
+		if i < 0 {
+			contract.ContractState = append(contract.ContractState, m)
+			continue
+		}
+		contract.ContractState[i].Value = m.Value
+	}
+	return nil
+}
+
+func validateBlockSudoHooks(hooks []BlockSudoHook) error {
+	unique := make(map[string]struct{}, len(hooks))
+	for i, h := range hooks {
+		if err := h.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "hook: %d", i)
+		}
+		if _, exists := unique[h.Contract]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract: %s", h.Contract)
+		}
+		unique[h.Contract] = struct{}{}
+	}
 	return nil
 }
 
@@ -74,6 +216,33 @@ func (c Contract) ValidateBasic() error {
 			return errorsmod.Wrapf(err, "code history element %d", i)
 		}
 	}
+	if c.GasMultiplier != nil {
+		if err := c.GasMultiplier.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "gas multiplier")
+		}
+	}
+	for i, v := range c.MigrationCheckpoints {
+		if err := v.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "migration checkpoint %d", i)
+		}
+	}
+	for i, v := range c.DependencyCodeIDs {
+		if v == 0 {
+			return errorsmod.Wrapf(ErrEmpty, "dependency code id %d", i)
+		}
+	}
+	return nil
+}
+
+func (c MigrationCheckpointState) ValidateBasic() error {
+	if err := c.Checkpoint.ValidateBasic(); err != nil {
+		return err
+	}
+	for i := range c.State {
+		if err := c.State[i].ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "state %d", i)
+		}
+	}
 	return nil
 }
 
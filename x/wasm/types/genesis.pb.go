@@ -30,10 +30,16 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // GenesisState - genesis state of x/wasm
 type GenesisState struct {
-	Params    Params     `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
-	Codes     []Code     `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
-	Contracts []Contract `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
-	Sequences []Sequence `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
+	Params              Params             `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+	Codes               []Code             `protobuf:"bytes,2,rep,name=codes,proto3" json:"codes,omitempty"`
+	Contracts           []Contract         `protobuf:"bytes,3,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	Sequences           []Sequence         `protobuf:"bytes,4,rep,name=sequences,proto3" json:"sequences,omitempty"`
+	InstantiateCounts   []InstantiateCount `protobuf:"bytes,5,rep,name=instantiate_counts,json=instantiateCounts,proto3" json:"instantiate_counts,omitempty"`
+	BeginBlockSudoHooks []BlockSudoHook    `protobuf:"bytes,6,rep,name=begin_block_sudo_hooks,json=beginBlockSudoHooks,proto3" json:"begin_block_sudo_hooks,omitempty"`
+	EndBlockSudoHooks   []BlockSudoHook    `protobuf:"bytes,7,rep,name=end_block_sudo_hooks,json=endBlockSudoHooks,proto3" json:"end_block_sudo_hooks,omitempty"`
+	StargateAllowlist   []string           `protobuf:"bytes,8,rep,name=stargate_allowlist,json=stargateAllowlist,proto3" json:"stargate_allowlist,omitempty"`
+	// CodeDeposits are the deposits escrowed for stored codes
+	CodeDeposits []CodeDeposit `protobuf:"bytes,10,rep,name=code_deposits,json=codeDeposits,proto3" json:"code_deposits,omitempty"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -102,6 +108,41 @@ func (m *GenesisState) GetSequences() []Sequence {
 	return nil
 }
 
+func (m *GenesisState) GetInstantiateCounts() []InstantiateCount {
+	if m != nil {
+		return m.InstantiateCounts
+	}
+	return nil
+}
+
+func (m *GenesisState) GetBeginBlockSudoHooks() []BlockSudoHook {
+	if m != nil {
+		return m.BeginBlockSudoHooks
+	}
+	return nil
+}
+
+func (m *GenesisState) GetEndBlockSudoHooks() []BlockSudoHook {
+	if m != nil {
+		return m.EndBlockSudoHooks
+	}
+	return nil
+}
+
+func (m *GenesisState) GetStargateAllowlist() []string {
+	if m != nil {
+		return m.StargateAllowlist
+	}
+	return nil
+}
+
+func (m *GenesisState) GetCodeDeposits() []CodeDeposit {
+	if m != nil {
+		return m.CodeDeposits
+	}
+	return nil
+}
+
 // Code struct encompasses CodeInfo and CodeBytes
 type Code struct {
 	CodeID    uint64   `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
@@ -109,6 +150,9 @@ type Code struct {
 	CodeBytes []byte   `protobuf:"bytes,3,opt,name=code_bytes,json=codeBytes,proto3" json:"code_bytes,omitempty"`
 	// Pinned to wasmvm cache
 	Pinned bool `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// StoredHeight is the block height at which the code was stored, not set for
+	// codes stored before the height was recorded
+	StoredHeight uint64 `protobuf:"varint,5,opt,name=stored_height,json=storedHeight,proto3" json:"stored_height,omitempty"`
 }
 
 func (m *Code) Reset()         { *m = Code{} }
@@ -177,12 +221,25 @@ func (m *Code) GetPinned() bool {
 	return false
 }
 
+func (m *Code) GetStoredHeight() uint64 {
+	if m != nil {
+		return m.StoredHeight
+	}
+	return 0
+}
+
 // Contract struct encompasses ContractAddress, ContractInfo, and ContractState
 type Contract struct {
 	ContractAddress     string                     `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo        ContractInfo               `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState       []Model                    `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
 	ContractCodeHistory []ContractCodeHistoryEntry `protobuf:"bytes,4,rep,name=contract_code_history,json=contractCodeHistory,proto3" json:"contract_code_history"`
+	// Gas multiplier override, not set for the default multiplier
+	GasMultiplier        *GasMultiplier             `protobuf:"bytes,5,opt,name=gas_multiplier,json=gasMultiplier,proto3" json:"gas_multiplier,omitempty"`
+	MigrationCheckpoints []MigrationCheckpointState `protobuf:"bytes,6,rep,name=migration_checkpoints,json=migrationCheckpoints,proto3" json:"migration_checkpoints"`
+	// DependencyCodeIDs are the recorded code ids that the contract instantiated
+	// or migrated other contracts to
+	DependencyCodeIDs []uint64 `protobuf:"varint,9,rep,packed,name=dependency_code_ids,json=dependencyCodeIds,proto3" json:"dependency_code_ids,omitempty"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
@@ -251,6 +308,86 @@ func (m *Contract) GetContractCodeHistory() []ContractCodeHistoryEntry {
 	return nil
 }
 
+func (m *Contract) GetGasMultiplier() *GasMultiplier {
+	if m != nil {
+		return m.GasMultiplier
+	}
+	return nil
+}
+
+func (m *Contract) GetMigrationCheckpoints() []MigrationCheckpointState {
+	if m != nil {
+		return m.MigrationCheckpoints
+	}
+	return nil
+}
+
+func (m *Contract) GetDependencyCodeIDs() []uint64 {
+	if m != nil {
+		return m.DependencyCodeIDs
+	}
+	return nil
+}
+
+// MigrationCheckpointState struct encompasses a MigrationCheckpoint and the
+// backed up contract state
+type MigrationCheckpointState struct {
+	Checkpoint MigrationCheckpoint `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint"`
+	State      []Model             `protobuf:"bytes,2,rep,name=state,proto3" json:"state"`
+}
+
+func (m *MigrationCheckpointState) Reset()         { *m = MigrationCheckpointState{} }
+func (m *MigrationCheckpointState) String() string { return proto.CompactTextString(m) }
+func (*MigrationCheckpointState) ProtoMessage()    {}
+func (*MigrationCheckpointState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab3f539b23472a6, []int{3}
+}
+
+func (m *MigrationCheckpointState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *MigrationCheckpointState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MigrationCheckpointState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *MigrationCheckpointState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrationCheckpointState.Merge(m, src)
+}
+
+func (m *MigrationCheckpointState) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *MigrationCheckpointState) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrationCheckpointState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrationCheckpointState proto.InternalMessageInfo
+
+func (m *MigrationCheckpointState) GetCheckpoint() MigrationCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return MigrationCheckpoint{}
+}
+
+func (m *MigrationCheckpointState) GetState() []Model {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -261,7 +398,7 @@ func (m *Sequence) Reset()         { *m = Sequence{} }
 func (m *Sequence) String() string { return proto.CompactTextString(m) }
 func (*Sequence) ProtoMessage()    {}
 func (*Sequence) Descriptor() ([]byte, []int) {
-	return fileDescriptor_2ab3f539b23472a6, []int{3}
+	return fileDescriptor_2ab3f539b23472a6, []int{4}
 }
 
 func (m *Sequence) XXX_Unmarshal(b []byte) error {
@@ -309,54 +446,148 @@ func (m *Sequence) GetValue() uint64 {
 	return 0
 }
 
+// InstantiateCount number of contracts an address has instantiated from a code
+// with a max instances per address limit
+type InstantiateCount struct {
+	CodeID  uint64 `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Count   uint64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *InstantiateCount) Reset()         { *m = InstantiateCount{} }
+func (m *InstantiateCount) String() string { return proto.CompactTextString(m) }
+func (*InstantiateCount) ProtoMessage()    {}
+func (*InstantiateCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2ab3f539b23472a6, []int{5}
+}
+
+func (m *InstantiateCount) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *InstantiateCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InstantiateCount.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *InstantiateCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstantiateCount.Merge(m, src)
+}
+
+func (m *InstantiateCount) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *InstantiateCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstantiateCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstantiateCount proto.InternalMessageInfo
+
+func (m *InstantiateCount) GetCodeID() uint64 {
+	if m != nil {
+		return m.CodeID
+	}
+	return 0
+}
+
+func (m *InstantiateCount) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *InstantiateCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "cosmwasm.wasm.v1.GenesisState")
 	proto.RegisterType((*Code)(nil), "cosmwasm.wasm.v1.Code")
 	proto.RegisterType((*Contract)(nil), "cosmwasm.wasm.v1.Contract")
+	proto.RegisterType((*MigrationCheckpointState)(nil), "cosmwasm.wasm.v1.MigrationCheckpointState")
 	proto.RegisterType((*Sequence)(nil), "cosmwasm.wasm.v1.Sequence")
+	proto.RegisterType((*InstantiateCount)(nil), "cosmwasm.wasm.v1.InstantiateCount")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 590 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0x9b, 0xae, 0x0d, 0xad, 0x57, 0xd8, 0x30, 0x63, 0x84, 0x6a, 0xa4, 0x55, 0x91, 0x50,
-	0x35, 0x41, 0xa3, 0x8d, 0x23, 0x17, 0xc8, 0x86, 0xa0, 0x4c, 0x20, 0x94, 0x1e, 0x90, 0x76, 0xa9,
-	0xd2, 0xd8, 0xeb, 0x2c, 0x96, 0xb8, 0xc4, 0x6e, 0x21, 0xdf, 0x82, 0x4f, 0x81, 0x38, 0x72, 0xe0,
-	0x43, 0xec, 0xc6, 0x84, 0x84, 0xc4, 0xa9, 0x42, 0xed, 0x01, 0x89, 0x4f, 0x81, 0xfc, 0x27, 0x59,
-	0xd4, 0xae, 0x17, 0xab, 0xf6, 0xf3, 0xbe, 0xbf, 0xbe, 0xef, 0xe3, 0x37, 0x06, 0x76, 0x40, 0x59,
-	0xf8, 0xd1, 0x67, 0xa1, 0x23, 0x97, 0xc9, 0x9e, 0x33, 0xc4, 0x11, 0x66, 0x84, 0x75, 0x46, 0x31,
-	0xe5, 0x14, 0x6e, 0xa6, 0x7a, 0x47, 0x2e, 0x93, 0xbd, 0xfa, 0xd6, 0x90, 0x0e, 0xa9, 0x14, 0x1d,
-	0xf1, 0x4b, 0xc5, 0xd5, 0x77, 0x96, 0x38, 0x3c, 0x19, 0x61, 0x4d, 0xa9, 0xdf, 0xf4, 0x43, 0x12,
-	0x51, 0x47, 0xae, 0xfa, 0xe8, 0xae, 0x48, 0xa0, 0xac, 0xaf, 0x48, 0x6a, 0xa3, 0xa4, 0xd6, 0x8f,
-	0x22, 0xa8, 0xbd, 0x50, 0x55, 0xf4, 0xb8, 0xcf, 0x31, 0x7c, 0x02, 0xcc, 0x91, 0x1f, 0xfb, 0x21,
-	0xb3, 0x8c, 0xa6, 0xd1, 0x5e, 0xdf, 0xb7, 0x3a, 0x8b, 0x55, 0x75, 0xde, 0x4a, 0xdd, 0xad, 0x9e,
-	0x4f, 0x1b, 0x85, 0xaf, 0x7f, 0xbf, 0xed, 0x1a, 0x9e, 0x4e, 0x81, 0xaf, 0x40, 0x39, 0xa0, 0x08,
-	0x33, 0xab, 0xd8, 0x5c, 0x6b, 0xaf, 0xef, 0x6f, 0x2f, 0xe7, 0x1e, 0x50, 0x84, 0xdd, 0x1d, 0x91,
-	0xf9, 0x6f, 0xda, 0xd8, 0x90, 0xc1, 0x0f, 0x69, 0x48, 0x38, 0x0e, 0x47, 0x3c, 0x51, 0x30, 0x85,
-	0x80, 0xc7, 0xa0, 0x1a, 0xd0, 0x88, 0xc7, 0x7e, 0xc0, 0x99, 0xb5, 0x26, 0x79, 0xf5, 0xab, 0x78,
-	0x2a, 0xc4, 0x6d, 0x6a, 0xe6, 0xad, 0x2c, 0x69, 0x91, 0x7b, 0x89, 0x13, 0x6c, 0x86, 0x3f, 0x8c,
-	0x71, 0x14, 0x60, 0x66, 0x95, 0x56, 0xb1, 0x7b, 0x3a, 0xe4, 0x92, 0x9d, 0x25, 0x2d, 0xb1, 0x33,
-	0xa5, 0xf5, 0xc5, 0x00, 0x25, 0xd1, 0x25, 0xbc, 0x0f, 0xae, 0x89, 0x4e, 0xfa, 0x04, 0x49, 0x2b,
-	0x4b, 0x2e, 0x98, 0x4d, 0x1b, 0xa6, 0x90, 0xba, 0x87, 0x9e, 0x29, 0xa4, 0x2e, 0x82, 0xae, 0xe8,
-	0x52, 0x04, 0x45, 0x27, 0xd4, 0x2a, 0x4a, 0xc7, 0xeb, 0x57, 0xbb, 0xd6, 0x8d, 0x4e, 0x68, 0xde,
-	0xf3, 0x4a, 0xa0, 0x0f, 0xe1, 0x3d, 0x00, 0x24, 0x63, 0x90, 0x70, 0x2c, 0xac, 0x32, 0xda, 0x35,
-	0x4f, 0x52, 0x5d, 0x71, 0x00, 0xb7, 0x81, 0x39, 0x22, 0x51, 0x84, 0x91, 0x55, 0x6a, 0x1a, 0xed,
-	0x8a, 0xa7, 0x77, 0xad, 0x5f, 0x45, 0x50, 0x49, 0xed, 0x83, 0x07, 0x60, 0x33, 0xb5, 0xa7, 0xef,
-	0x23, 0x14, 0x63, 0xa6, 0x06, 0xa0, 0xea, 0x5a, 0x3f, 0xbf, 0x3f, 0xda, 0xd2, 0x33, 0xf3, 0x4c,
-	0x29, 0x3d, 0x1e, 0x93, 0x68, 0xe8, 0x6d, 0xa4, 0x19, 0xfa, 0x18, 0xbe, 0x01, 0xd7, 0x33, 0x48,
-	0xae, 0x21, 0x7b, 0xf5, 0xb5, 0x2d, 0x36, 0x55, 0x0b, 0x72, 0x02, 0xec, 0x82, 0x1b, 0x19, 0x8f,
-	0x89, 0xe9, 0xd4, 0x73, 0x70, 0x67, 0x19, 0xf8, 0x9a, 0x22, 0x7c, 0x96, 0x27, 0x65, 0x95, 0xa8,
-	0xb1, 0x26, 0xe0, 0x76, 0x86, 0x92, 0x66, 0x9d, 0x12, 0xc6, 0x69, 0x9c, 0xe8, 0xdb, 0xdf, 0x5d,
-	0x5d, 0xa2, 0xf0, 0xfe, 0xa5, 0x0a, 0x7e, 0x1e, 0xf1, 0x38, 0xc9, 0xff, 0x49, 0x36, 0x6c, 0xb9,
-	0xa0, 0x96, 0x0b, 0x2a, 0xe9, 0xe4, 0xc0, 0x26, 0x30, 0x09, 0xea, 0xbf, 0xc7, 0x89, 0x34, 0xb3,
-	0xe6, 0x56, 0x67, 0xd3, 0x46, 0xb9, 0x7b, 0x78, 0x84, 0x13, 0xaf, 0x4c, 0xd0, 0x11, 0x4e, 0xe0,
-	0x16, 0x28, 0x4f, 0xfc, 0xb3, 0x31, 0x96, 0x5e, 0x95, 0x3c, 0xb5, 0x71, 0x9f, 0x9e, 0xcf, 0x6c,
-	0xe3, 0x62, 0x66, 0x1b, 0x7f, 0x66, 0xb6, 0xf1, 0x79, 0x6e, 0x17, 0x2e, 0xe6, 0x76, 0xe1, 0xf7,
-	0xdc, 0x2e, 0x1c, 0x3f, 0x18, 0x12, 0x7e, 0x3a, 0x1e, 0x74, 0x02, 0x1a, 0x3a, 0x07, 0x94, 0x85,
-	0xef, 0xd2, 0x77, 0x00, 0x39, 0x9f, 0xd4, 0x7b, 0x20, 0x1f, 0x83, 0x81, 0x29, 0xbf, 0xef, 0xc7,
-	0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x86, 0xe3, 0xf2, 0x58, 0x75, 0x04, 0x00, 0x00,
+	// 981 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x41, 0x6f, 0x1b, 0x45,
+	0x14, 0xce, 0x26, 0x6b, 0xc7, 0x9e, 0x3a, 0xad, 0x3d, 0x71, 0xd2, 0x25, 0x4a, 0xed, 0x95, 0x0b,
+	0xc8, 0x14, 0x6a, 0xab, 0xe1, 0x82, 0xc4, 0x85, 0xae, 0x53, 0x1a, 0xa7, 0x0a, 0x54, 0x9b, 0x03,
+	0x52, 0x2f, 0xab, 0xf5, 0xce, 0x74, 0x3d, 0xd8, 0x3b, 0x63, 0x76, 0xc6, 0x29, 0x96, 0xe0, 0x80,
+	0x38, 0x23, 0xf1, 0x2b, 0x10, 0x47, 0x0e, 0xfc, 0x88, 0x4a, 0x5c, 0x2a, 0x4e, 0x1c, 0x90, 0x85,
+	0x9c, 0x03, 0x12, 0xbf, 0x02, 0xcd, 0xcc, 0xae, 0xed, 0x78, 0x1d, 0x35, 0x97, 0x4d, 0xe6, 0xbd,
+	0xef, 0x7d, 0xef, 0x7b, 0xe3, 0xf7, 0xde, 0x2e, 0xa8, 0x05, 0x8c, 0x47, 0xaf, 0x7c, 0x1e, 0xb5,
+	0xd5, 0xe3, 0xe2, 0x51, 0x3b, 0xc4, 0x14, 0x73, 0xc2, 0x5b, 0xa3, 0x98, 0x09, 0x06, 0xcb, 0xa9,
+	0xbf, 0xa5, 0x1e, 0x17, 0x8f, 0x0e, 0xaa, 0x21, 0x0b, 0x99, 0x72, 0xb6, 0xe5, 0x7f, 0x1a, 0x77,
+	0x70, 0x98, 0xe1, 0x11, 0x93, 0x11, 0x4e, 0x58, 0x0e, 0x2a, 0x7e, 0x44, 0x28, 0x6b, 0xab, 0x67,
+	0x62, 0x7a, 0x47, 0x06, 0x30, 0xee, 0x69, 0x26, 0x7d, 0xd0, 0xae, 0xc6, 0x4f, 0xdb, 0xa0, 0xf4,
+	0x54, 0xab, 0x38, 0x17, 0xbe, 0xc0, 0xf0, 0x53, 0x90, 0x1f, 0xf9, 0xb1, 0x1f, 0x71, 0xcb, 0xb0,
+	0x8d, 0xe6, 0xad, 0x23, 0xab, 0xb5, 0xaa, 0xaa, 0xf5, 0x5c, 0xf9, 0x9d, 0xe2, 0xeb, 0x69, 0x7d,
+	0xe3, 0xd7, 0x7f, 0x7f, 0x7b, 0x60, 0xb8, 0x49, 0x08, 0x3c, 0x05, 0xb9, 0x80, 0x21, 0xcc, 0xad,
+	0x4d, 0x7b, 0xab, 0x79, 0xeb, 0x68, 0x3f, 0x1b, 0xdb, 0x61, 0x08, 0x3b, 0x87, 0x32, 0xf2, 0xbf,
+	0x69, 0xfd, 0x8e, 0x02, 0x7f, 0xc4, 0x22, 0x22, 0x70, 0x34, 0x12, 0x13, 0x4d, 0xa6, 0x29, 0xe0,
+	0x0b, 0x50, 0x0c, 0x18, 0x15, 0xb1, 0x1f, 0x08, 0x6e, 0x6d, 0x29, 0xbe, 0x83, 0x75, 0x7c, 0x1a,
+	0xe2, 0xd8, 0x09, 0xe7, 0xee, 0x3c, 0x68, 0x95, 0x77, 0x41, 0x27, 0xb9, 0x39, 0xfe, 0x66, 0x8c,
+	0x69, 0x80, 0xb9, 0x65, 0x5e, 0xc7, 0x7d, 0x9e, 0x40, 0x16, 0xdc, 0xf3, 0xa0, 0x0c, 0xf7, 0xdc,
+	0x03, 0xbf, 0x03, 0x90, 0x50, 0x2e, 0x7c, 0x2a, 0x88, 0x2f, 0xb0, 0x17, 0xb0, 0x31, 0x15, 0xdc,
+	0xca, 0xa9, 0x24, 0x8d, 0x6c, 0x92, 0xee, 0x02, 0xdb, 0x91, 0x50, 0xe7, 0x83, 0x24, 0xd9, 0x61,
+	0x96, 0x65, 0x35, 0x6b, 0x85, 0xac, 0x04, 0x73, 0xf8, 0xa3, 0x01, 0xf6, 0x7b, 0x38, 0x24, 0xd4,
+	0xeb, 0x0d, 0x59, 0x30, 0xf0, 0xf8, 0x18, 0x31, 0xaf, 0xcf, 0xd8, 0x80, 0x5b, 0x79, 0x25, 0xa1,
+	0x9e, 0x95, 0xe0, 0x48, 0xe4, 0xf9, 0x18, 0xb1, 0x13, 0xc6, 0x06, 0xce, 0xc3, 0x24, 0xbf, 0xbd,
+	0x9e, 0x66, 0x55, 0xc3, 0xae, 0x82, 0x5d, 0xa1, 0xe0, 0xf0, 0x7b, 0x50, 0xc5, 0x14, 0x65, 0x25,
+	0x6c, 0xdf, 0x4c, 0xc2, 0x87, 0x89, 0x84, 0xda, 0x3a, 0x92, 0xcc, 0x25, 0x60, 0x8a, 0x56, 0xd2,
+	0x7f, 0x09, 0x20, 0x17, 0x7e, 0x1c, 0xca, 0x9b, 0xf3, 0x87, 0x43, 0xf6, 0x6a, 0x48, 0xb8, 0xb0,
+	0x0a, 0xf6, 0x56, 0xb3, 0xe8, 0xd8, 0xf2, 0x6a, 0xb3, 0xde, 0x05, 0xab, 0x5b, 0x49, 0xbd, 0x8f,
+	0x53, 0x27, 0xec, 0x83, 0x1d, 0xd9, 0x94, 0x1e, 0xc2, 0x23, 0xc6, 0x89, 0xe0, 0x16, 0x50, 0x85,
+	0xdc, 0x5b, 0xdf, 0xdf, 0xc7, 0x1a, 0xe5, 0xbc, 0x9b, 0x94, 0x71, 0xf7, 0x4a, 0xec, 0xaa, 0xfe,
+	0x52, 0xb0, 0x08, 0xe1, 0x8d, 0x3f, 0x0c, 0x60, 0x4a, 0x0e, 0x78, 0x1f, 0x6c, 0xab, 0x30, 0x82,
+	0xd4, 0x20, 0x9a, 0x0e, 0x98, 0x4d, 0xeb, 0x79, 0xe9, 0xea, 0x1e, 0xbb, 0x79, 0xe9, 0xea, 0x22,
+	0xe8, 0x80, 0xa2, 0x06, 0xd1, 0x97, 0xcc, 0xda, 0xb4, 0x8d, 0xf5, 0x7d, 0xac, 0x82, 0xe8, 0x4b,
+	0xb6, 0x3c, 0xb1, 0x85, 0x20, 0x31, 0xc2, 0x7b, 0x00, 0x28, 0x8e, 0xde, 0x44, 0x60, 0x39, 0x68,
+	0x46, 0xb3, 0xe4, 0x2a, 0x56, 0x47, 0x1a, 0xe0, 0x3e, 0xc8, 0x8f, 0x08, 0xa5, 0x18, 0x59, 0xa6,
+	0x6d, 0x34, 0x0b, 0x6e, 0x72, 0x82, 0xf7, 0xc1, 0x0e, 0x17, 0x2c, 0xc6, 0xc8, 0xeb, 0x63, 0x12,
+	0xf6, 0x85, 0x95, 0x93, 0x2a, 0xdd, 0x92, 0x36, 0x9e, 0x28, 0x5b, 0xe3, 0x6f, 0x13, 0x14, 0xd2,
+	0x09, 0x85, 0x1d, 0x50, 0x4e, 0x27, 0xd0, 0xf3, 0x11, 0x8a, 0x31, 0xd7, 0x3b, 0xa6, 0xe8, 0x58,
+	0x7f, 0xfe, 0xfe, 0xb0, 0x9a, 0xac, 0xa5, 0xc7, 0xda, 0x73, 0x2e, 0x62, 0x42, 0x43, 0xf7, 0x4e,
+	0x1a, 0x91, 0x98, 0xe1, 0x17, 0x60, 0x27, 0x35, 0x2d, 0x57, 0x5d, 0xbb, 0x7e, 0x33, 0xac, 0x56,
+	0x5e, 0x0a, 0x96, 0x1c, 0xb0, 0x0b, 0x6e, 0xcf, 0xf9, 0xb8, 0x5c, 0x80, 0xc9, 0xaa, 0xb9, 0x9b,
+	0x25, 0x3c, 0x63, 0x08, 0x0f, 0x97, 0x99, 0xe6, 0x4a, 0xf4, 0xe6, 0x24, 0x60, 0x6f, 0x4e, 0xa5,
+	0x6e, 0xb4, 0x4f, 0xe4, 0x65, 0x4c, 0x92, 0x05, 0xf3, 0xe0, 0x7a, 0x89, 0xf2, 0x07, 0x3a, 0xd1,
+	0xe0, 0x27, 0x54, 0xc4, 0x93, 0xe5, 0x24, 0xbb, 0x41, 0x16, 0x04, 0x3f, 0x07, 0xb7, 0x43, 0x9f,
+	0x7b, 0xd1, 0x78, 0x28, 0xc8, 0x68, 0x48, 0x70, 0xac, 0x6e, 0x7f, 0xed, 0x64, 0x3d, 0xf5, 0xf9,
+	0xd9, 0x1c, 0xe6, 0xee, 0x84, 0xcb, 0x47, 0xf8, 0x35, 0xd8, 0x8b, 0x48, 0x18, 0xfb, 0x82, 0x30,
+	0xea, 0x05, 0x7d, 0x1c, 0x0c, 0x46, 0x8c, 0x50, 0x91, 0xee, 0x8a, 0x35, 0x92, 0xcf, 0x52, 0x78,
+	0x67, 0x8e, 0x56, 0xd5, 0x2f, 0x4b, 0xae, 0x46, 0x59, 0x10, 0x87, 0x4f, 0xc0, 0x2e, 0xc2, 0x23,
+	0x4c, 0x11, 0xa6, 0xc1, 0xc4, 0x4b, 0x7a, 0x9b, 0x5b, 0x45, 0x7b, 0xab, 0x69, 0x3a, 0x7b, 0xb3,
+	0x69, 0xbd, 0x72, 0x3c, 0x77, 0xeb, 0x36, 0xe7, 0x6e, 0x05, 0x5d, 0x35, 0x21, 0x7e, 0x6a, 0x16,
+	0xb6, 0xcb, 0x85, 0x53, 0xb3, 0x50, 0x28, 0x17, 0x1b, 0xbf, 0x18, 0xc0, 0xba, 0x4e, 0x10, 0x7c,
+	0x0e, 0xc0, 0xa2, 0xa2, 0xe4, 0x65, 0xf6, 0xde, 0x8d, 0x0a, 0x5a, 0xae, 0x65, 0x89, 0x03, 0x7e,
+	0x02, 0x72, 0xba, 0x45, 0x36, 0x6f, 0xdc, 0x22, 0x3a, 0xa0, 0xe1, 0x80, 0x42, 0xfa, 0x32, 0x81,
+	0x36, 0xc8, 0x13, 0xe4, 0x0d, 0xf0, 0x44, 0x69, 0x2a, 0x39, 0xc5, 0xd9, 0xb4, 0x9e, 0xeb, 0x1e,
+	0x3f, 0xc3, 0x13, 0x37, 0x47, 0xd0, 0x33, 0x3c, 0x81, 0x55, 0x90, 0xbb, 0xf0, 0x87, 0x63, 0xac,
+	0x7a, 0xdb, 0x74, 0xf5, 0xa1, 0xf1, 0x83, 0x01, 0xca, 0xab, 0x2f, 0x8b, 0x9b, 0x6d, 0x89, 0x23,
+	0xb0, 0x9d, 0xce, 0xdb, 0xe6, 0x5b, 0xe6, 0x2d, 0x05, 0x4a, 0x0d, 0xea, 0x9d, 0xa3, 0x16, 0x82,
+	0xe9, 0xea, 0x83, 0xf3, 0xd9, 0xeb, 0x59, 0xcd, 0x78, 0x33, 0xab, 0x19, 0xff, 0xcc, 0x6a, 0xc6,
+	0xcf, 0x97, 0xb5, 0x8d, 0x37, 0x97, 0xb5, 0x8d, 0xbf, 0x2e, 0x6b, 0x1b, 0x2f, 0xde, 0x0f, 0x89,
+	0xe8, 0x8f, 0x7b, 0xad, 0x80, 0x45, 0xed, 0x0e, 0xe3, 0xd1, 0x57, 0xe9, 0xe7, 0x09, 0x6a, 0x7f,
+	0xab, 0xfe, 0xea, 0x6f, 0x94, 0x5e, 0x5e, 0x7d, 0x76, 0x7c, 0xfc, 0xff, 0x00, 0x7e, 0x45, 0x22,
+	0x73, 0x0c, 0x09, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -379,6 +610,71 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.CodeDeposits) > 0 {
+		for iNdEx := len(m.CodeDeposits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CodeDeposits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.StargateAllowlist) > 0 {
+		for iNdEx := len(m.StargateAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.StargateAllowlist[iNdEx])
+			copy(dAtA[i:], m.StargateAllowlist[iNdEx])
+			i = encodeVarintGenesis(dAtA, i, uint64(len(m.StargateAllowlist[iNdEx])))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.EndBlockSudoHooks) > 0 {
+		for iNdEx := len(m.EndBlockSudoHooks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EndBlockSudoHooks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.BeginBlockSudoHooks) > 0 {
+		for iNdEx := len(m.BeginBlockSudoHooks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BeginBlockSudoHooks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.InstantiateCounts) > 0 {
+		for iNdEx := len(m.InstantiateCounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.InstantiateCounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.Sequences) > 0 {
 		for iNdEx := len(m.Sequences) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -454,6 +750,11 @@ func (m *Code) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StoredHeight != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.StoredHeight))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.Pinned {
 		i--
 		if m.Pinned {
@@ -509,6 +810,50 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.DependencyCodeIDs) > 0 {
+		dAtA4 := make([]byte, len(m.DependencyCodeIDs)*10)
+		var j3 int
+		for _, num := range m.DependencyCodeIDs {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintGenesis(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.MigrationCheckpoints) > 0 {
+		for iNdEx := len(m.MigrationCheckpoints) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MigrationCheckpoints[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.GasMultiplier != nil {
+		{
+			size, err := m.GasMultiplier.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.ContractCodeHistory) > 0 {
 		for iNdEx := len(m.ContractCodeHistory) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -557,6 +902,53 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MigrationCheckpointState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MigrationCheckpointState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MigrationCheckpointState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.State) > 0 {
+		for iNdEx := len(m.State) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.State[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Checkpoint.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *Sequence) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -592,6 +984,46 @@ func (m *Sequence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *InstantiateCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InstantiateCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InstantiateCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGenesis(v)
 	base := offset
@@ -630,6 +1062,36 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.InstantiateCounts) > 0 {
+		for _, e := range m.InstantiateCounts {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.BeginBlockSudoHooks) > 0 {
+		for _, e := range m.BeginBlockSudoHooks {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.EndBlockSudoHooks) > 0 {
+		for _, e := range m.EndBlockSudoHooks {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.StargateAllowlist) > 0 {
+		for _, s := range m.StargateAllowlist {
+			l = len(s)
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.CodeDeposits) > 0 {
+		for _, e := range m.CodeDeposits {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -651,6 +1113,9 @@ func (m *Code) Size() (n int) {
 	if m.Pinned {
 		n += 2
 	}
+	if m.StoredHeight != 0 {
+		n += 1 + sovGenesis(uint64(m.StoredHeight))
+	}
 	return n
 }
 
@@ -678,6 +1143,40 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if m.GasMultiplier != nil {
+		l = m.GasMultiplier.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if len(m.MigrationCheckpoints) > 0 {
+		for _, e := range m.MigrationCheckpoints {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.DependencyCodeIDs) > 0 {
+		l = 0
+		for _, e := range m.DependencyCodeIDs {
+			l += sovGenesis(uint64(e))
+		}
+		n += 1 + sovGenesis(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *MigrationCheckpointState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Checkpoint.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	if len(m.State) > 0 {
+		for _, e := range m.State {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -697,6 +1196,25 @@ func (m *Sequence) Size() (n int) {
 	return n
 }
 
+func (m *InstantiateCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovGenesis(uint64(m.CodeID))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovGenesis(uint64(m.Count))
+	}
+	return n
+}
+
 func sovGenesis(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -869,6 +1387,174 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateCounts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstantiateCounts = append(m.InstantiateCounts, InstantiateCount{})
+			if err := m.InstantiateCounts[len(m.InstantiateCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlockSudoHooks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeginBlockSudoHooks = append(m.BeginBlockSudoHooks, BlockSudoHook{})
+			if err := m.BeginBlockSudoHooks[len(m.BeginBlockSudoHooks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockSudoHooks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndBlockSudoHooks = append(m.EndBlockSudoHooks, BlockSudoHook{})
+			if err := m.EndBlockSudoHooks[len(m.EndBlockSudoHooks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StargateAllowlist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StargateAllowlist = append(m.StargateAllowlist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeDeposits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeDeposits = append(m.CodeDeposits, CodeDeposit{})
+			if err := m.CodeDeposits[len(m.CodeDeposits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1026,6 +1712,25 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Pinned = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StoredHeight", wireType)
+			}
+			m.StoredHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StoredHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1210,22 +1915,286 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenesis(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasMultiplier", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
+			if m.GasMultiplier == nil {
+				m.GasMultiplier = &GasMultiplier{}
+			}
+			if err := m.GasMultiplier.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MigrationCheckpoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MigrationCheckpoints = append(m.MigrationCheckpoints, MigrationCheckpointState{})
+			if err := m.MigrationCheckpoints[len(m.MigrationCheckpoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.DependencyCodeIDs = append(m.DependencyCodeIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.DependencyCodeIDs) == 0 {
+					m.DependencyCodeIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenesis
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.DependencyCodeIDs = append(m.DependencyCodeIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field DependencyCodeIDs", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *MigrationCheckpointState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MigrationCheckpointState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MigrationCheckpointState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checkpoint", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Checkpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.State = append(m.State, Model{})
+			if err := m.State[len(m.State)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
@@ -1336,6 +2305,127 @@ func (m *Sequence) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
+func (m *InstantiateCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InstantiateCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InstantiateCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
 func skipGenesis(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"slices"
 	"testing"
 	"time"
 
@@ -49,6 +50,65 @@ func TestValidateGenesisState(t *testing.T) {
 			},
 			expError: true,
 		},
+		"instantiate count invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.InstantiateCounts = []InstantiateCount{{CodeID: 1, Address: invalidAddress, Count: 1}}
+			},
+			expError: true,
+		},
+		"block sudo hook invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.BeginBlockSudoHooks = []BlockSudoHook{{Contract: invalidAddress, Msg: []byte(`{}`)}}
+			},
+			expError: true,
+		},
+		"block sudo hook duplicate": {
+			srcMutator: func(s *GenesisState) {
+				addr := sdk.AccAddress(rand.Bytes(ContractAddrLen)).String()
+				s.EndBlockSudoHooks = []BlockSudoHook{{Contract: addr, Msg: []byte(`{}`)}, {Contract: addr, Msg: []byte(`{}`)}}
+			},
+			expError: true,
+		},
+		"stargate allowlist": {
+			srcMutator: func(s *GenesisState) {
+				s.StargateAllowlist = []string{"/cosmos.bank.v1beta1.Query/Balance"}
+			},
+		},
+		"stargate allowlist invalid": {
+			srcMutator: func(s *GenesisState) {
+				s.StargateAllowlist = []string{"cosmos.bank.v1beta1.Query/Balance"}
+			},
+			expError: true,
+		},
+		"stargate allowlist duplicate": {
+			srcMutator: func(s *GenesisState) {
+				s.StargateAllowlist = []string{"/cosmos.bank.v1beta1.Query/Balance", "/cosmos.bank.v1beta1.Query/Balance"}
+			},
+			expError: true,
+		},
+		"code deposit": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeDeposits = []CodeDeposit{{CodeID: 1, Depositor: sdk.AccAddress(rand.Bytes(ContractAddrLen)).String(), Amount: sdk.NewCoins(sdk.NewInt64Coin("denom", 1))}}
+			},
+		},
+		"code deposit without code id": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeDeposits = []CodeDeposit{{Depositor: sdk.AccAddress(rand.Bytes(ContractAddrLen)).String(), Amount: sdk.NewCoins(sdk.NewInt64Coin("denom", 1))}}
+			},
+			expError: true,
+		},
+		"code deposit invalid depositor": {
+			srcMutator: func(s *GenesisState) {
+				s.CodeDeposits = []CodeDeposit{{CodeID: 1, Depositor: "invalid", Amount: sdk.NewCoins(sdk.NewInt64Coin("denom", 1))}}
+			},
+			expError: true,
+		},
+		"instantiate count empty": {
+			srcMutator: func(s *GenesisState) {
+				s.InstantiateCounts = []InstantiateCount{{CodeID: 1, Address: sdk.AccAddress(rand.Bytes(ContractAddrLen)).String(), Count: 0}}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -149,6 +209,42 @@ func TestContractValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"migration checkpoint": {
+			srcMutator: func(c *Contract) {
+				c.MigrationCheckpoints = []MigrationCheckpointState{{
+					Checkpoint: MigrationCheckpoint{ID: 1, CodeID: 1, Created: &AbsoluteTxPosition{}},
+					State:      []Model{{Key: []byte("foo"), Value: []byte("bar")}},
+				}}
+			},
+		},
+		"migration checkpoint invalid": {
+			srcMutator: func(c *Contract) {
+				c.MigrationCheckpoints = []MigrationCheckpointState{{
+					Checkpoint: MigrationCheckpoint{CodeID: 1, Created: &AbsoluteTxPosition{}},
+				}}
+			},
+			expError: true,
+		},
+		"migration checkpoint state invalid": {
+			srcMutator: func(c *Contract) {
+				c.MigrationCheckpoints = []MigrationCheckpointState{{
+					Checkpoint: MigrationCheckpoint{ID: 1, CodeID: 1, Created: &AbsoluteTxPosition{}},
+					State:      []Model{{}},
+				}}
+			},
+			expError: true,
+		},
+		"dependency code ids": {
+			srcMutator: func(c *Contract) {
+				c.DependencyCodeIDs = []uint64{1, 2}
+			},
+		},
+		"dependency code id empty": {
+			srcMutator: func(c *Contract) {
+				c.DependencyCodeIDs = []uint64{0}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -211,3 +307,155 @@ func TestGenesisContractInfoMarshalUnmarshal(t *testing.T) {
 	require.NoError(t, dest.ReadExtension(&destExt))
 	assert.Equal(t, destExt.GetTitle(), "bar")
 }
+
+func TestGenesisStateMergeContracts(t *testing.T) {
+	const otherAddress = "cosmos1hsk6jryyqjfhp5dhc55tc9jtckygx0eph6dd02"
+	code, contract := CodeFixture(), ContractFixture()
+	code2 := CodeFixture(func(c *Code) { c.CodeID = 2 })
+	existing := func() GenesisState {
+		return GenesisState{
+			Codes:     []Code{code, code2},
+			Contracts: []Contract{contract},
+			Sequences: []Sequence{{IDKey: KeySequenceCodeID, Value: 3}, {IDKey: KeySequenceInstanceID, Value: 5}},
+		}
+	}
+	changedCode2 := CodeFixture(func(c *Code) { c.CodeID = 2; c.Pinned = true })
+	newCode := CodeFixture(func(c *Code) { c.CodeID = 3 })
+	newContract := ContractFixture(func(c *Contract) { c.ContractAddress = otherAddress })
+	changedContract := contract
+	changedContract.ContractState = []Model{{Key: []byte("foo"), Value: []byte("bar")}}
+
+	specs := map[string]struct {
+		src       GenesisState
+		overwrite bool
+		exp       GenesisState
+		expErr    error
+	}{
+		"new code and contract": {
+			src: GenesisState{
+				Codes:     []Code{newCode},
+				Contracts: []Contract{newContract},
+				Sequences: []Sequence{{IDKey: KeySequenceInstanceID, Value: 3}},
+			},
+			exp: GenesisState{
+				Codes:     []Code{code, code2, newCode},
+				Contracts: []Contract{contract, newContract},
+				Sequences: []Sequence{{IDKey: KeySequenceCodeID, Value: 4}, {IDKey: KeySequenceInstanceID, Value: 5}},
+			},
+		},
+		"existing code": {
+			src:    GenesisState{Codes: []Code{code}},
+			expErr: ErrDuplicate,
+		},
+		"existing code with instances overwritten": {
+			src:       GenesisState{Codes: []Code{code}},
+			overwrite: true,
+			expErr:    ErrInvalid,
+		},
+		"existing code without instances overwritten": {
+			src:       GenesisState{Codes: []Code{changedCode2}},
+			overwrite: true,
+			exp: GenesisState{
+				Codes:     []Code{code, changedCode2},
+				Contracts: []Contract{contract},
+				Sequences: []Sequence{{IDKey: KeySequenceCodeID, Value: 3}, {IDKey: KeySequenceInstanceID, Value: 5}},
+			},
+		},
+		"existing contract": {
+			src:    GenesisState{Contracts: []Contract{changedContract}},
+			expErr: ErrDuplicate,
+		},
+		"existing contract overwritten": {
+			src:       GenesisState{Contracts: []Contract{changedContract}, Sequences: []Sequence{{IDKey: KeySequenceInstanceID, Value: 7}}},
+			overwrite: true,
+			exp: GenesisState{
+				Codes:     []Code{code, code2},
+				Contracts: []Contract{changedContract},
+				Sequences: []Sequence{{IDKey: KeySequenceCodeID, Value: 3}, {IDKey: KeySequenceInstanceID, Value: 7}},
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := existing()
+			// when
+			gotErr := got.MergeContracts(spec.src, spec.overwrite)
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestGenesisStateReplaceContractState(t *testing.T) {
+	contract := ContractFixture()
+	existing := func() GenesisState {
+		c := contract
+		c.ContractState = slices.Clone(contract.ContractState)
+		return GenesisState{Contracts: []Contract{c}}
+	}
+	state := contract.ContractState
+	newModel := Model{Key: []byte("new"), Value: []byte("value")}
+	changedModel := Model{Key: state[0].Key, Value: []byte("changed")}
+
+	specs := map[string]struct {
+		contractAddr string
+		models       []Model
+		mode         StateImportMode
+		expState     []Model
+		expErr       error
+	}{
+		"overwrite": {
+			contractAddr: contract.ContractAddress,
+			models:       []Model{newModel},
+			mode:         StateImportModeOverwrite,
+			expState:     []Model{newModel},
+		},
+		"merge new key": {
+			contractAddr: contract.ContractAddress,
+			models:       []Model{newModel},
+			mode:         StateImportModeMerge,
+			expState:     append(slices.Clone(state), newModel),
+		},
+		"merge existing key": {
+			contractAddr: contract.ContractAddress,
+			models:       []Model{changedModel},
+			mode:         StateImportModeMerge,
+			expState:     append([]Model{changedModel}, state[1:]...),
+		},
+		"unknown contract": {
+			contractAddr: "cosmos1hsk6jryyqjfhp5dhc55tc9jtckygx0eph6dd02",
+			mode:         StateImportModeOverwrite,
+			expErr:       ErrNoSuchContractFn(""),
+		},
+		"unspecified mode": {
+			contractAddr: contract.ContractAddress,
+			expErr:       ErrEmpty,
+		},
+		"duplicate keys": {
+			contractAddr: contract.ContractAddress,
+			models:       []Model{newModel, newModel},
+			mode:         StateImportModeMerge,
+			expErr:       ErrDuplicate,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := existing()
+			// when
+			gotErr := got.ReplaceContractState(spec.contractAddr, spec.models, spec.mode)
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.Equal(t, existing(), got)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expState, got.Contracts[0].ContractState)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -36,9 +37,41 @@ var (
 	ContractsByCreatorPrefix                       = []byte{0x09}
 	ParamsKey                                      = []byte{0x10}
 	AsyncAckKeyPrefix                              = []byte{0x11}
+	ContractGasMultiplierPrefix                    = []byte{0x12}
+	ContractCountByCodeIDPrefix                    = []byte{0x13}
+	InstantiateCountPrefix                         = []byte{0x14}
+	BlockSudoHooksPrefix                           = []byte{0x15}
+	MigrationCheckpointPrefix                      = []byte{0x16}
+	MigrationCheckpointStatePrefix                 = []byte{0x17}
+	StargateAllowlistPrefix                        = []byte{0x18}
+	ContractDependencyPrefix                       = []byte{0x1a}
+	ModuleStatsPrefix                              = []byte{0x1b}
+	ContractStorageUsagePrefix                     = []byte{0x1d}
+	CodeStoredHeightPrefix                         = []byte{0x1e}
+	PendingCodeRemovalPrefix                       = []byte{0x1f}
+	ContractLabelIndexPrefix                       = []byte{0x22}
+	CodeDepositPrefix                              = []byte{0x23}
+	CodeInstantiationPrefix                        = []byte{0x24}
+	AutoPinnedCodeIndexPrefix                      = []byte{0x25}
+	ChecksumCodeCountPrefix                        = []byte{0x26}
+	CodeSizePrefix                                 = []byte{0x27}
+	CodeCapabilitiesPrefix                         = []byte{0x28}
+	CodeEntrypointsPrefix                          = []byte{0x29}
 
 	KeySequenceCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
 	KeySequenceInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
+
+	KeyStatsCodeCount       = append(ModuleStatsPrefix, []byte("codeCount")...)
+	KeyStatsContractCount   = append(ModuleStatsPrefix, []byte("contractCount")...)
+	KeyStatsPinnedCodeCount = append(ModuleStatsPrefix, []byte("pinnedCodeCount")...)
+)
+
+// prefixes of the transient store that is reset after every block
+var (
+	TransientParamsCachePrefix             = []byte{0x01}
+	TransientBlockInstantiateCounterPrefix = []byte{0x02}
+	TransientBlockPrunedCodesCounterPrefix = []byte{0x03}
+	TransientContractGasMultiplierPrefix   = []byte{0x04}
 )
 
 // GetCodeKey constructs the key for retrieving the ID for the WASM code
@@ -47,6 +80,125 @@ func GetCodeKey(codeID uint64) []byte {
 	return append(CodeKeyPrefix, contractIDBz...)
 }
 
+// GetParamsCacheKey returns the transient store key for the params cached at the given block height
+func GetParamsCacheKey(height int64) []byte {
+	return append(TransientParamsCachePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetContractGasMultiplierCacheKey returns the transient store key for the gas multiplier of the contract cached at the
+// given block height
+func GetContractGasMultiplierCacheKey(height int64, addr sdk.AccAddress) []byte {
+	return append(append(TransientContractGasMultiplierPrefix, sdk.Uint64ToBigEndian(uint64(height))...), addr...)
+}
+
+// GetBlockInstantiateCounterKey returns the transient store key for the instantiate counter of the given block height
+func GetBlockInstantiateCounterKey(height int64) []byte {
+	return append(TransientBlockInstantiateCounterPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetBlockPrunedCodesCounterKey returns the transient store key for the pruned codes counter of the given block height
+func GetBlockPrunedCodesCounterKey(height int64) []byte {
+	return append(TransientBlockPrunedCodesCounterPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCodeStoredHeightKey returns the key for the block height at which the WASM code was stored
+func GetCodeStoredHeightKey(codeID uint64) []byte {
+	return append(CodeStoredHeightPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeSizeKey returns the key for the uncompressed size of the WASM code
+func GetCodeSizeKey(codeID uint64) []byte {
+	return append(CodeSizePrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeCapabilitiesKey returns the key for the capabilities required by the WASM code
+func GetCodeCapabilitiesKey(codeID uint64) []byte {
+	return append(CodeCapabilitiesPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeEntrypointsKey returns the key for the entrypoints exported by the WASM code
+func GetCodeEntrypointsKey(codeID uint64) []byte {
+	return append(CodeEntrypointsPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeDepositKey constructs the key for the storage deposit of a code
+func GetCodeDepositKey(codeID uint64) []byte {
+	return append(CodeDepositPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetChecksumCodeCountKey returns the key for the number of codes with the checksum
+func GetChecksumCodeCountKey(checksum []byte) []byte {
+	return append(ChecksumCodeCountPrefix, checksum...)
+}
+
+// GetPendingCodeRemovalKey returns the key for a checksum whose bytecode is removed from the wasmvm at the end of the block
+func GetPendingCodeRemovalKey(checksum []byte) []byte {
+	return append(PendingCodeRemovalPrefix, checksum...)
+}
+
+// GetContractCountByCodeIDKey returns the key for the number of contract instances of the WASM code
+func GetContractCountByCodeIDKey(codeID uint64) []byte {
+	return append(ContractCountByCodeIDPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetInstantiateCountPrefix returns the prefix for the number of contracts instantiated per address from the WASM code:
+// `<prefix><codeID>`
+func GetInstantiateCountPrefix(codeID uint64) []byte {
+	return append(InstantiateCountPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetInstantiateCountKey returns the key for the number of contracts instantiated by the address from the WASM code:
+// `<prefix><codeID><addr>`
+func GetInstantiateCountKey(codeID uint64, addr sdk.AccAddress) []byte {
+	prefixLen := len(InstantiateCountPrefix)
+	r := make([]byte, prefixLen+8+len(addr))
+	copy(r[0:], InstantiateCountPrefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(codeID))
+	copy(r[prefixLen+8:], addr)
+	return r
+}
+
+// GetBlockSudoHooksKey returns the key for the ordered list of contracts sudo called in the block phase
+func GetBlockSudoHooksKey(phase BlockSudoPhase) []byte {
+	return append(BlockSudoHooksPrefix, byte(phase))
+}
+
+// GetMigrationCheckpointPrefix returns the prefix for the migration checkpoints of the contract:
+// `<prefix><len(contractAddr)><contractAddr>`
+func GetMigrationCheckpointPrefix(contractAddr sdk.AccAddress) []byte {
+	return append(MigrationCheckpointPrefix, address.MustLengthPrefix(contractAddr)...)
+}
+
+// GetMigrationCheckpointKey returns the key for a migration checkpoint of the contract:
+// `<prefix><len(contractAddr)><contractAddr><checkpointID>`
+func GetMigrationCheckpointKey(contractAddr sdk.AccAddress, checkpointID uint64) []byte {
+	return append(GetMigrationCheckpointPrefix(contractAddr), sdk.Uint64ToBigEndian(checkpointID)...)
+}
+
+// GetMigrationCheckpointStatePrefix returns the store prefix for the state backup of a migration checkpoint:
+// `<prefix><len(contractAddr)><contractAddr><checkpointID>`
+func GetMigrationCheckpointStatePrefix(contractAddr sdk.AccAddress, checkpointID uint64) []byte {
+	r := append(MigrationCheckpointStatePrefix, address.MustLengthPrefix(contractAddr)...)
+	return append(r, sdk.Uint64ToBigEndian(checkpointID)...)
+}
+
+// GetContractDependencyPrefix returns the prefix for the code ids that the contract depends on:
+// `<prefix><len(contractAddr)><contractAddr>`
+func GetContractDependencyPrefix(contractAddr sdk.AccAddress) []byte {
+	return append(ContractDependencyPrefix, address.MustLengthPrefix(contractAddr)...)
+}
+
+// GetContractDependencyKey returns the key for a code id that the contract depends on:
+// `<prefix><len(contractAddr)><contractAddr><codeID>`
+func GetContractDependencyKey(contractAddr sdk.AccAddress, codeID uint64) []byte {
+	return append(GetContractDependencyPrefix(contractAddr), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetStargateAllowlistKey returns the key for an allowed stargate query path
+func GetStargateAllowlistKey(path string) []byte {
+	return append(StargateAllowlistPrefix, []byte(path)...)
+}
+
 // GetContractAddressKey returns the key for the WASM contract instance
 func GetContractAddressKey(addr sdk.AccAddress) []byte {
 	return append(ContractKeyPrefix, addr...)
@@ -63,6 +215,49 @@ func GetContractStorePrefix(addr sdk.AccAddress) []byte {
 	return append(ContractStorePrefix, addr...)
 }
 
+// GetContractStateKey returns the store key of a contract state entry in the wasm module store.
+// This is the key that is proven with ABCI queries to the wasm store.
+func GetContractStateKey(addr sdk.AccAddress, key []byte) []byte {
+	r := make([]byte, 0, len(ContractStorePrefix)+len(addr)+len(key))
+	r = append(r, ContractStorePrefix...)
+	r = append(r, addr...)
+	return append(r, key...)
+}
+
+// GetContractStorageUsageKey returns the key for the tracked storage size of the WASM contract instance
+func GetContractStorageUsageKey(addr sdk.AccAddress) []byte {
+	return append(ContractStorageUsagePrefix, addr...)
+}
+
+// GetContractLabelIndexPrefix returns the prefix for the contracts of a code with the given label:
+// `<prefix><codeID><sha256(label)>`. The label is hashed to get a fixed length key.
+func GetContractLabelIndexPrefix(codeID uint64, label string) []byte {
+	labelHash := sha256.Sum256([]byte(label))
+	r := append(ContractLabelIndexPrefix, sdk.Uint64ToBigEndian(codeID)...)
+	return append(r, labelHash[:]...)
+}
+
+// GetContractLabelIndexKey returns the key for the label index: `<prefix><codeID><sha256(label)><contractAddr>`
+func GetContractLabelIndexKey(codeID uint64, label string, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractLabelIndexPrefix(codeID, label), contractAddr...)
+}
+
+// GetCodeInstantiationPrefix returns the prefix for the instantiation log of a code: `<prefix><codeID>`
+func GetCodeInstantiationPrefix(codeID uint64) []byte {
+	return append(CodeInstantiationPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeInstantiationKey returns the key for an instantiation log entry: `<prefix><codeID><height><contractAddr>`
+func GetCodeInstantiationKey(codeID, height uint64, contractAddr sdk.AccAddress) []byte {
+	r := append(GetCodeInstantiationPrefix(codeID), sdk.Uint64ToBigEndian(height)...)
+	return append(r, contractAddr...)
+}
+
+// GetContractGasMultiplierKey returns the key for the gas multiplier override of the WASM contract instance
+func GetContractGasMultiplierKey(addr sdk.AccAddress) []byte {
+	return append(ContractGasMultiplierPrefix, addr...)
+}
+
 // GetAsyncPacketKey returns the key for a packet that is acknowledged asynchronously
 func GetAsyncPacketKey(destChannel string, sequence uint64) []byte {
 	// key is a concatenation of length-prefixed destination channel and sequence
@@ -145,6 +340,11 @@ func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 	return r
 }
 
+// GetAutoPinnedCodeIndexKey returns the key for a code id that was pinned by the auto pin code hashes param
+func GetAutoPinnedCodeIndexKey(codeID uint64) []byte {
+	return append(AutoPinnedCodeIndexPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
 // ParsePinnedCodeIndex converts the serialized code ID back.
 func ParsePinnedCodeIndex(s []byte) uint64 {
 	return sdk.BigEndianToUint64(s)
@@ -146,3 +146,16 @@ func TestGetContractByCreatorSecondaryIndexKey(t *testing.T) {
 	}
 	assert.Equal(t, exp, got)
 }
+
+func TestGetContractStateKey(t *testing.T) {
+	addr := bytes.Repeat([]byte{4}, 20)
+	got := GetContractStateKey(addr, []byte{1, 2})
+	exp := []byte{
+		3,                            // prefix
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4, // address 20 bytes
+		4, 4, 4, 4, 4, 4, 4, 4, 4, 4,
+		1, 2, // key
+	}
+	assert.Equal(t, exp, got)
+	assert.Equal(t, append(GetContractStorePrefix(addr), 1, 2), got)
+}
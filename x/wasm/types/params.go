@@ -1,7 +1,10 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"strings"
 
 	"github.com/cosmos/gogoproto/jsonpb"
 	"github.com/pkg/errors"
@@ -12,6 +15,19 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+const (
+	// MaxMemoryCacheSize is the upper bound of the memory cache size param in MiB
+	MaxMemoryCacheSize uint32 = 16 * 1024
+	// MinContractMemoryLimit is the lower bound of the contract memory limit param in MiB
+	MinContractMemoryLimit uint32 = 16
+	// MaxContractMemoryLimit is the upper bound of the contract memory limit param in MiB
+	MaxContractMemoryLimit uint32 = 512
+	// DefaultMaxBlockSudoHooks is the max number of block sudo hooks per phase when the param is not set
+	DefaultMaxBlockSudoHooks uint32 = 10
+	// MaxBlockSudoHooksLimit is the upper bound of the max block sudo hooks param
+	MaxBlockSudoHooksLimit uint32 = 100
+)
+
 var AllAccessTypes = []AccessType{
 	AccessTypeNobody,
 	AccessTypeAnyOfAddresses,
@@ -87,6 +103,8 @@ func DefaultParams() Params {
 	return Params{
 		CodeUploadAccess:             AllowEverybody,
 		InstantiateDefaultPermission: AccessTypeEverybody,
+		MaxQueryRecursionDepth:       uint64(DefaultMaxQueryStackSize),
+		MaxExecuteMsgSize:            uint64(MaxContractMsgSize),
 	}
 }
 
@@ -106,6 +124,99 @@ func (p Params) ValidateBasic() error {
 	if err := p.CodeUploadAccess.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "upload access")
 	}
+	if err := validateAutoPinCodeHashes(p.AutoPinCodeHashes); err != nil {
+		return errors.Wrap(err, "auto pin code hashes")
+	}
+	if err := validateDisabledCapabilities(p.DisabledCapabilities); err != nil {
+		return errors.Wrap(err, "disabled capabilities")
+	}
+	if err := validateIBCSenderAllowlist(p.IBCSenderAllowlist); err != nil {
+		return errors.Wrap(err, "ibc sender allowlist")
+	}
+	if err := p.CodeStorageDeposit.Validate(); err != nil {
+		return errors.Wrap(err, "code storage deposit")
+	}
+	if p.MaxCallDepth > MaxCallDepthLimit {
+		return errorsmod.Wrapf(ErrLimit, "max call depth %d exceeds max %d", p.MaxCallDepth, MaxCallDepthLimit)
+	}
+	if p.MemoryCacheSize > MaxMemoryCacheSize {
+		return errorsmod.Wrapf(ErrLimit, "memory cache size %d MiB exceeds max %d MiB", p.MemoryCacheSize, MaxMemoryCacheSize)
+	}
+	if p.ContractMemoryLimit != 0 && (p.ContractMemoryLimit < MinContractMemoryLimit || p.ContractMemoryLimit > MaxContractMemoryLimit) {
+		return errorsmod.Wrapf(ErrInvalid, "contract memory limit %d MiB must be between %d and %d MiB", p.ContractMemoryLimit, MinContractMemoryLimit, MaxContractMemoryLimit)
+	}
+	if p.MaxBlockSudoHooks > MaxBlockSudoHooksLimit {
+		return errorsmod.Wrapf(ErrLimit, "max block sudo hooks %d exceeds max %d", p.MaxBlockSudoHooks, MaxBlockSudoHooksLimit)
+	}
+	return nil
+}
+
+// BlockSudoHooksLimit returns the max number of block sudo hooks per phase, with the default for the unset param
+func (p Params) BlockSudoHooksLimit() int {
+	if p.MaxBlockSudoHooks == 0 {
+		return int(DefaultMaxBlockSudoHooks)
+	}
+	return int(p.MaxBlockSudoHooks)
+}
+
+// StateCleanupRefund returns the gas refunded to a contract execution that removed netRemovedBytes from the
+// contract state in net. The refund is 0 below the threshold and capped by the max refund.
+func (p Params) StateCleanupRefund(netRemovedBytes uint64) uint64 {
+	if p.StateCleanupRefundPerByte == 0 || netRemovedBytes == 0 || netRemovedBytes < p.StateCleanupRefundThreshold {
+		return 0
+	}
+	if netRemovedBytes > p.MaxStateCleanupRefund/p.StateCleanupRefundPerByte {
+		return p.MaxStateCleanupRefund
+	}
+	return netRemovedBytes * p.StateCleanupRefundPerByte
+}
+
+// validateAutoPinCodeHashes ensures the hashes are unique lower case hex encoded checksums
+func validateAutoPinCodeHashes(hashes []string) error {
+	unique := make(map[string]struct{}, len(hashes))
+	for _, h := range hashes {
+		bz, err := hex.DecodeString(h)
+		if err != nil || hex.EncodeToString(bz) != h {
+			return errorsmod.Wrapf(ErrInvalid, "not a lower case hex string: %q", h)
+		}
+		if len(bz) != sha256.Size {
+			return errorsmod.Wrapf(ErrInvalid, "checksum %q must be %d bytes", h, sha256.Size)
+		}
+		if _, exists := unique[h]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "checksum %q", h)
+		}
+		unique[h] = struct{}{}
+	}
+	return nil
+}
+
+// validateDisabledCapabilities ensures the capabilities are unique and not empty
+func validateDisabledCapabilities(capabilities []string) error {
+	unique := make(map[string]struct{}, len(capabilities))
+	for _, c := range capabilities {
+		if strings.TrimSpace(c) == "" {
+			return errorsmod.Wrap(ErrEmpty, "capability")
+		}
+		if _, exists := unique[c]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "capability %q", c)
+		}
+		unique[c] = struct{}{}
+	}
+	return nil
+}
+
+// validateIBCSenderAllowlist ensures the contract addresses are valid and unique
+func validateIBCSenderAllowlist(addrs []string) error {
+	unique := make(map[string]struct{}, len(addrs))
+	for _, a := range addrs {
+		if _, err := sdk.AccAddressFromBech32(a); err != nil {
+			return errorsmod.Wrapf(err, "address %q", a)
+		}
+		if _, exists := unique[a]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "address %q", a)
+		}
+		unique[a] = struct{}{}
+	}
 	return nil
 }
 
@@ -123,6 +234,9 @@ func validateAccessType(a AccessType) error {
 
 // ValidateBasic performs basic validation
 func (a AccessConfig) ValidateBasic() error {
+	if a.MaxInstancesPerAddress != 0 && a.Permission != AccessTypeAnyOfAddresses {
+		return errorsmod.Wrapf(ErrInvalid, "max instances per address not supported for type: %q", a.Permission)
+	}
 	switch a.Permission {
 	case AccessTypeUnspecified:
 		return errorsmod.Wrap(ErrEmpty, "type")
@@ -153,3 +267,10 @@ func (a AccessConfig) Allowed(actor sdk.AccAddress) bool {
 		panic("unknown type")
 	}
 }
+
+// AllowedInstances returns if permission includes the actor and the actor has not
+// reached the max instances per address limit with the given number of instances.
+// Actor address must be valid and not nil
+func (a AccessConfig) AllowedInstances(actor sdk.AccAddress, instances uint64) bool {
+	return a.Allowed(actor) && (a.MaxInstancesPerAddress == 0 || instances < a.MaxInstancesPerAddress)
+}
@@ -3,11 +3,15 @@ package types
 import (
 	"bytes"
 	"encoding/json"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	sdkmath "cosmossdk.io/math"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -51,6 +55,12 @@ func TestValidateParams(t *testing.T) {
 				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
 			},
 		},
+		"all good with anyOf addresses and max instances": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anyAddress.String()}, MaxInstancesPerAddress: 1},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+			},
+		},
 		"reject empty type in instantiate permission": {
 			src: Params{
 				CodeUploadAccess: AllowNobody,
@@ -105,6 +115,182 @@ func TestValidateParams(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"reject max instances with everybody": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeEverybody, MaxInstancesPerAddress: 1},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+			},
+			expErr: true,
+		},
+		"reject max instances with nobody": {
+			src: Params{
+				CodeUploadAccess:             AccessConfig{Permission: AccessTypeNobody, MaxInstancesPerAddress: 1},
+				InstantiateDefaultPermission: AccessTypeAnyOfAddresses,
+			},
+			expErr: true,
+		},
+		"all good with auto pin code hashes": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				AutoPinCodeHashes:            []string{strings.Repeat("a1", 32), strings.Repeat("b2", 32)},
+			},
+		},
+		"reject upper case auto pin code hash": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				AutoPinCodeHashes:            []string{strings.Repeat("A1", 32)},
+			},
+			expErr: true,
+		},
+		"reject invalid hex auto pin code hash": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				AutoPinCodeHashes:            []string{strings.Repeat("x1", 32)},
+			},
+			expErr: true,
+		},
+		"reject auto pin code hash with invalid length": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				AutoPinCodeHashes:            []string{strings.Repeat("a1", 31)},
+			},
+			expErr: true,
+		},
+		"reject duplicate auto pin code hashes": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				AutoPinCodeHashes:            []string{strings.Repeat("a1", 32), strings.Repeat("a1", 32)},
+			},
+			expErr: true,
+		},
+		"all good with disabled capabilities": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				DisabledCapabilities:         []string{"stargate", "staking"},
+			},
+		},
+		"reject empty disabled capability": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				DisabledCapabilities:         []string{" "},
+			},
+			expErr: true,
+		},
+		"reject duplicate disabled capabilities": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				DisabledCapabilities:         []string{"stargate", "stargate"},
+			},
+			expErr: true,
+		},
+		"all good with ibc sender allowlist": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				IBCSenderAllowlist:           []string{anyAddress.String()},
+			},
+		},
+		"reject invalid ibc sender address": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				IBCSenderAllowlist:           []string{"invalid"},
+			},
+			expErr: true,
+		},
+		"reject duplicate ibc sender addresses": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				IBCSenderAllowlist:           []string{anyAddress.String(), anyAddress.String()},
+			},
+			expErr: true,
+		},
+		"all good with code storage deposit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				CodeStorageDeposit:           sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+			},
+		},
+		"reject invalid code storage deposit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				CodeStorageDeposit:           sdk.Coins{sdk.Coin{Denom: "denom", Amount: sdkmath.NewInt(-1)}},
+			},
+			expErr: true,
+		},
+		"all good with max call depth": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxCallDepth:                 MaxCallDepthLimit,
+			},
+		},
+		"reject max call depth above limit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxCallDepth:                 MaxCallDepthLimit + 1,
+			},
+			expErr: true,
+		},
+		"all good with max block sudo hooks": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxBlockSudoHooks:            MaxBlockSudoHooksLimit,
+			},
+		},
+		"reject max block sudo hooks above limit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MaxBlockSudoHooks:            MaxBlockSudoHooksLimit + 1,
+			},
+			expErr: true,
+		},
+		"all good with vm cache settings": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MemoryCacheSize:              MaxMemoryCacheSize,
+				ContractMemoryLimit:          MinContractMemoryLimit,
+			},
+		},
+		"reject memory cache size above max": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				MemoryCacheSize:              MaxMemoryCacheSize + 1,
+			},
+			expErr: true,
+		},
+		"reject contract memory limit below min": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ContractMemoryLimit:          MinContractMemoryLimit - 1,
+			},
+			expErr: true,
+		},
+		"reject contract memory limit above max": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ContractMemoryLimit:          MaxContractMemoryLimit + 1,
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -166,7 +352,9 @@ func TestParamsUnmarshalJson(t *testing.T) {
 	}{
 		"defaults": {
 			src: `{"code_upload_access": {"permission": "Everybody"},
-				"instantiate_default_permission": "Everybody"}`,
+				"instantiate_default_permission": "Everybody",
+				"max_query_recursion_depth": "10",
+				"max_execute_msg_size": "1048576"}`,
 			exp: DefaultParams(),
 		},
 	}
@@ -248,3 +436,106 @@ func TestAccessTypeWith(t *testing.T) {
 		})
 	}
 }
+
+func TestAccessConfigAllowedInstances(t *testing.T) {
+	var (
+		myAddress    sdk.AccAddress = make([]byte, ContractAddrLen)
+		otherAddress sdk.AccAddress = bytes.Repeat([]byte{1}, ContractAddrLen)
+	)
+	specs := map[string]struct {
+		src       AccessConfig
+		actor     sdk.AccAddress
+		instances uint64
+		exp       bool
+	}{
+		"no limit": {
+			src:       AccessTypeAnyOfAddresses.With(myAddress),
+			actor:     myAddress,
+			instances: 100,
+			exp:       true,
+		},
+		"below limit": {
+			src:       AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{myAddress.String()}, MaxInstancesPerAddress: 2},
+			actor:     myAddress,
+			instances: 1,
+			exp:       true,
+		},
+		"limit reached": {
+			src:       AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{myAddress.String()}, MaxInstancesPerAddress: 2},
+			actor:     myAddress,
+			instances: 2,
+		},
+		"not in addresses": {
+			src:   AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{myAddress.String()}, MaxInstancesPerAddress: 2},
+			actor: otherAddress,
+		},
+		"everybody": {
+			src:       AllowEverybody,
+			actor:     otherAddress,
+			instances: 100,
+			exp:       true,
+		},
+		"nobody": {
+			src:   AllowNobody,
+			actor: otherAddress,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, spec.src.AllowedInstances(spec.actor, spec.instances))
+		})
+	}
+}
+
+func TestParamsStateCleanupRefund(t *testing.T) {
+	specs := map[string]struct {
+		src        Params
+		netRemoved uint64
+		exp        uint64
+	}{
+		"rate applied": {
+			src:        Params{StateCleanupRefundPerByte: 10, MaxStateCleanupRefund: 1000},
+			netRemoved: 50,
+			exp:        500,
+		},
+		"capped": {
+			src:        Params{StateCleanupRefundPerByte: 10, MaxStateCleanupRefund: 1000},
+			netRemoved: 101,
+			exp:        1000,
+		},
+		"overflow capped": {
+			src:        Params{StateCleanupRefundPerByte: math.MaxUint64, MaxStateCleanupRefund: 1000},
+			netRemoved: 2,
+			exp:        1000,
+		},
+		"at threshold": {
+			src:        Params{StateCleanupRefundPerByte: 10, StateCleanupRefundThreshold: 50, MaxStateCleanupRefund: 1000},
+			netRemoved: 50,
+			exp:        500,
+		},
+		"below threshold": {
+			src:        Params{StateCleanupRefundPerByte: 10, StateCleanupRefundThreshold: 50, MaxStateCleanupRefund: 1000},
+			netRemoved: 49,
+		},
+		"nothing removed": {
+			src: Params{StateCleanupRefundPerByte: 10, MaxStateCleanupRefund: 1000},
+		},
+		"no rate": {
+			src:        Params{MaxStateCleanupRefund: 1000},
+			netRemoved: 50,
+		},
+		"no cap": {
+			src:        Params{StateCleanupRefundPerByte: 10},
+			netRemoved: 50,
+		},
+		"default params": {
+			src:        DefaultParams(),
+			netRemoved: 50,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, spec.src.StateCleanupRefund(spec.netRemoved))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TraceIDLength is the length of a trace id in bytes before hex encoding
+const TraceIDLength = 16
+
+// TraceSequence counts the top level contract calls within a tx. It is shared by all
+// messages of the tx so that each of them gets a distinct trace id.
+type TraceSequence struct {
+	counter *uint32
+}
+
+// NewTraceSequence constructor
+func NewTraceSequence() TraceSequence {
+	return TraceSequence{counter: new(uint32)}
+}
+
+// Next returns the current sequence value and increments the counter
+func (s TraceSequence) Next() uint32 {
+	v := *s.counter
+	*s.counter++
+	return v
+}
+
+// NewTraceID derives a trace id for a top level contract call from the block height, the position of the tx
+// in the block, the call sequence within the tx and the called contract.
+// The id is deterministic but is only used in events and never stored in the contract state.
+func NewTraceID(height int64, txIndex, seq uint32, contractAddr sdk.AccAddress) string {
+	buf := make([]byte, 16, 16+len(contractAddr))
+	binary.BigEndian.PutUint64(buf, uint64(height))
+	binary.BigEndian.PutUint32(buf[8:], txIndex)
+	binary.BigEndian.PutUint32(buf[12:], seq)
+	hash := sha256.Sum256(append(buf, contractAddr...))
+	return hex.EncodeToString(hash[:TraceIDLength])
+}
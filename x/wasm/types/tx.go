@@ -12,7 +12,13 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
-const maxCodeIDCount = 50
+const (
+	maxCodeIDCount            = 50
+	maxWasmCodeCount          = 10
+	maxContractExecutionCount = 50
+	maxClearAdminsCount       = 50
+	maxUpdateAdminsCount      = 50
+)
 
 // RawContractMessage defines a json message that is sent or returned by a wasm contract.
 // This type can hold any type of bytes. Until validateBasic is called there should not be
@@ -68,6 +74,42 @@ func (msg MsgStoreCode) ValidateBasic() error {
 		return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %s", err.Error())
 	}
 
+	if msg.InstantiatePermission != nil {
+		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "instantiate permission")
+		}
+	}
+
+	if err := ValidateCodeProvenance(msg.Source, msg.Builder); err != nil {
+		return errorsmod.Wrap(err, "code provenance")
+	}
+	return nil
+}
+
+func (msg MsgStoreCodes) Route() string {
+	return RouterKey
+}
+
+func (msg MsgStoreCodes) Type() string {
+	return "store-codes"
+}
+
+func (msg MsgStoreCodes) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return err
+	}
+	switch n := len(msg.WASMByteCodes); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "wasm byte codes")
+	case n > maxWasmCodeCount:
+		return errorsmod.Wrapf(ErrLimit, "total number of codes is greater than %d", maxWasmCodeCount)
+	}
+	for i, code := range msg.WASMByteCodes {
+		if err := validateWasmCode(code, MaxWasmSize); err != nil {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "code bytes %d %s", i, err.Error())
+		}
+	}
+
 	if msg.InstantiatePermission != nil {
 		if err := msg.InstantiatePermission.ValidateBasic(); err != nil {
 			return errorsmod.Wrap(err, "instantiate permission")
@@ -152,6 +194,46 @@ func (msg MsgExecuteContract) GetContract() string {
 	return msg.Contract
 }
 
+func (msg MsgExecuteContracts) Route() string {
+	return RouterKey
+}
+
+func (msg MsgExecuteContracts) Type() string {
+	return "execute-contracts"
+}
+
+func (msg MsgExecuteContracts) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	switch n := len(msg.Executions); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "executions")
+	case n > maxContractExecutionCount:
+		return errorsmod.Wrapf(ErrLimit, "total number of executions is greater than %d", maxContractExecutionCount)
+	}
+	for i, e := range msg.Executions {
+		if err := e.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "execution %d", i)
+		}
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation of a single contract call
+func (e ContractExecution) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(e.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := e.Funds.Validate(); err != nil {
+		return errorsmod.Wrap(err, "funds")
+	}
+	if err := e.Msg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
 func (msg MsgMigrateContract) Route() string {
 	return RouterKey
 }
@@ -170,6 +252,9 @@ func (msg MsgMigrateContract) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
 		return errorsmod.Wrap(err, "contract")
 	}
+	if err := msg.Funds.Validate(); err != nil {
+		return errorsmod.Wrap(err, "funds")
+	}
 
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "payload msg")
@@ -185,7 +270,7 @@ func (msg MsgMigrateContract) GetMsg() RawContractMessage {
 
 // GetFunds returns tokens send to the contract
 func (msg MsgMigrateContract) GetFunds() sdk.Coins {
-	return sdk.NewCoins()
+	return msg.Funds
 }
 
 // GetContract returns the bech32 address of the contract
@@ -193,6 +278,36 @@ func (msg MsgMigrateContract) GetContract() string {
 	return msg.Contract
 }
 
+func (msg MsgMigrateContractAndExecute) Route() string {
+	return RouterKey
+}
+
+func (msg MsgMigrateContractAndExecute) Type() string {
+	return "migrate-and-execute"
+}
+
+func (msg MsgMigrateContractAndExecute) ValidateBasic() error {
+	if msg.NewCodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := msg.Funds.Validate(); err != nil {
+		return errorsmod.Wrap(err, "funds")
+	}
+	if err := msg.MigrateMsg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "migrate msg")
+	}
+	if err := msg.ExecuteMsg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "execute msg")
+	}
+	return nil
+}
+
 func (msg MsgUpdateAdmin) Route() string {
 	return RouterKey
 }
@@ -235,6 +350,73 @@ func (msg MsgClearAdmin) ValidateBasic() error {
 	return nil
 }
 
+func (msg MsgClearAdmins) Route() string {
+	return RouterKey
+}
+
+func (msg MsgClearAdmins) Type() string {
+	return "clear-contract-admins"
+}
+
+func (msg MsgClearAdmins) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	switch n := len(msg.Contracts); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "contracts")
+	case n > maxClearAdminsCount:
+		return errorsmod.Wrapf(ErrLimit, "total number of contracts is greater than %d", maxClearAdminsCount)
+	}
+	unique := make(map[string]struct{}, len(msg.Contracts))
+	for i, c := range msg.Contracts {
+		addr, err := sdk.AccAddressFromBech32(c)
+		if err != nil {
+			return errorsmod.Wrapf(err, "contract %d", i)
+		}
+		if _, exists := unique[addr.String()]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract %s", c)
+		}
+		unique[addr.String()] = struct{}{}
+	}
+	return nil
+}
+
+func (msg MsgUpdateAdmins) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateAdmins) Type() string {
+	return "update-contract-admins"
+}
+
+func (msg MsgUpdateAdmins) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.NewAdmin); err != nil {
+		return errorsmod.Wrap(err, "new admin")
+	}
+	switch n := len(msg.Contracts); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "contracts")
+	case n > maxUpdateAdminsCount:
+		return errorsmod.Wrapf(ErrLimit, "total number of contracts is greater than %d", maxUpdateAdminsCount)
+	}
+	unique := make(map[string]struct{}, len(msg.Contracts))
+	for i, c := range msg.Contracts {
+		addr, err := sdk.AccAddressFromBech32(c)
+		if err != nil {
+			return errorsmod.Wrapf(err, "contract %d", i)
+		}
+		if _, exists := unique[addr.String()]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract %s", c)
+		}
+		unique[addr.String()] = struct{}{}
+	}
+	return nil
+}
+
 func (msg MsgIBCSend) Route() string {
 	return RouterKey
 }
@@ -328,6 +510,51 @@ func (msg MsgUpdateInstantiateConfig) ValidateBasic() error {
 	return nil
 }
 
+func (msg MsgUpdateInstantiateConfigs) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateInstantiateConfigs) Type() string {
+	return "update-instantiate-configs"
+}
+
+func (msg MsgUpdateInstantiateConfigs) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	switch n := len(msg.Updates); {
+	case n == 0:
+		return errorsmod.Wrap(ErrEmpty, "updates")
+	case n > maxCodeIDCount:
+		return errorsmod.Wrapf(ErrLimit, "total number of updates is greater than %d", maxCodeIDCount)
+	}
+	codeIDs := make([]uint64, len(msg.Updates))
+	for i, u := range msg.Updates {
+		if err := u.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "update %d", i)
+		}
+		codeIDs[i] = u.CodeID
+	}
+	if hasDuplicates(codeIDs) {
+		return errorsmod.Wrap(ErrDuplicate, "code ids")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation of a single instantiate config update
+func (u InstantiateConfigUpdate) ValidateBasic() error {
+	if u.CodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	if u.NewInstantiatePermission == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "instantiate permission is required")
+	}
+	if err := u.NewInstantiatePermission.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "instantiate permission")
+	}
+	return nil
+}
+
 func (msg MsgUpdateParams) Route() string {
 	return RouterKey
 }
@@ -553,3 +780,240 @@ func (msg MsgUpdateContractLabel) ValidateBasic() error {
 	}
 	return nil
 }
+
+func (msg MsgSetContractGasMultiplier) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractGasMultiplier) Type() string {
+	return "set-contract-gas-multiplier"
+}
+
+func (msg MsgSetContractGasMultiplier) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := msg.Multiplier.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "multiplier")
+	}
+	return nil
+}
+
+func (msg MsgRegisterBlockSudoHook) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRegisterBlockSudoHook) Type() string {
+	return "register-block-sudo-hook"
+}
+
+func (msg MsgRegisterBlockSudoHook) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if err := msg.Phase.ValidateBasic(); err != nil {
+		return err
+	}
+	return BlockSudoHook{Contract: msg.Contract, Msg: msg.Msg}.ValidateBasic()
+}
+
+func (msg MsgRemoveBlockSudoHook) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRemoveBlockSudoHook) Type() string {
+	return "remove-block-sudo-hook"
+}
+
+func (msg MsgRemoveBlockSudoHook) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if err := msg.Phase.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgRestoreContractState) Route() string {
+	return RouterKey
+}
+
+func (msg MsgRestoreContractState) Type() string {
+	return "restore-contract-state"
+}
+
+func (msg MsgRestoreContractState) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if msg.CheckpointID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "checkpoint id")
+	}
+	return nil
+}
+
+func (msg MsgUpdateStargateAllowlist) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateStargateAllowlist) Type() string {
+	return "update-stargate-allowlist"
+}
+
+func (msg MsgUpdateStargateAllowlist) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if len(msg.Add) == 0 && len(msg.Remove) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "query paths")
+	}
+	if err := validateStargateQueryPaths(msg.Add); err != nil {
+		return errorsmod.Wrap(err, "add")
+	}
+	if err := validateStargateQueryPaths(msg.Remove); err != nil {
+		return errorsmod.Wrap(err, "remove")
+	}
+	if err := validateStargateQueryPaths(append(append([]string{}, msg.Add...), msg.Remove...)); err != nil {
+		return errorsmod.Wrap(err, "path can not be added and removed")
+	}
+	return nil
+}
+
+func (msg MsgSetContractStorageQuota) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractStorageQuota) Type() string {
+	return "set-contract-storage-quota"
+}
+
+func (msg MsgSetContractStorageQuota) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgPruneUnusedCodes) Route() string {
+	return RouterKey
+}
+
+func (msg MsgPruneUnusedCodes) Type() string {
+	return "prune-unused-codes"
+}
+
+func (msg MsgPruneUnusedCodes) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	return nil
+}
+
+func (msg MsgReplaceContractState) Route() string {
+	return RouterKey
+}
+
+func (msg MsgReplaceContractState) Type() string {
+	return "replace-contract-state"
+}
+
+func (msg MsgReplaceContractState) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := msg.Mode.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "mode")
+	}
+	if err := ValidateModels(msg.Models); err != nil {
+		return errorsmod.Wrap(err, "models")
+	}
+	return nil
+}
+
+func (msg MsgSetContractLock) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractLock) Type() string {
+	return "set-contract-lock"
+}
+
+func (msg MsgSetContractLock) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgUpdateInstantiateDefaultPermission) Route() string {
+	return RouterKey
+}
+
+func (msg MsgUpdateInstantiateDefaultPermission) Type() string {
+	return "update-instantiate-default-permission"
+}
+
+func (msg MsgUpdateInstantiateDefaultPermission) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if err := validateAccessType(msg.Permission); err != nil {
+		return errorsmod.Wrap(err, "permission")
+	}
+	return nil
+}
+
+func (msg MsgForfeitCodeDeposit) Route() string {
+	return RouterKey
+}
+
+func (msg MsgForfeitCodeDeposit) Type() string {
+	return "forfeit-code-deposit"
+}
+
+func (msg MsgForfeitCodeDeposit) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	return nil
+}
+
+func (msg MsgPruneContractDependencies) Route() string {
+	return RouterKey
+}
+
+func (msg MsgPruneContractDependencies) Type() string {
+	return "prune-contract-dependencies"
+}
+
+func (msg MsgPruneContractDependencies) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
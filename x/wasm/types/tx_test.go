@@ -2,6 +2,7 @@ package types
 
 import (
 	"bytes"
+	"slices"
 	"strings"
 	"testing"
 
@@ -65,6 +66,132 @@ func TestStoreCodeValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"with source and builder": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "https://example.com/",
+				Builder:      "cosmwasm/optimizer:0.16.0",
+			},
+			valid: true,
+		},
+		"with source and builder from custom registry": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "https://example.com/",
+				Builder:      "ghcr.io/org/optimizer:v1.2.3",
+			},
+			valid: true,
+		},
+		"source without builder": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "https://example.com/",
+			},
+			valid: false,
+		},
+		"builder without source": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Builder:      "cosmwasm/optimizer:0.16.0",
+			},
+			valid: false,
+		},
+		"invalid source url": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "example.com",
+				Builder:      "cosmwasm/optimizer:0.16.0",
+			},
+			valid: false,
+		},
+		"invalid builder": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Source:       "https://example.com/",
+				Builder:      "Cosmwasm/Optimizer:0.16.0",
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestStoreCodesValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, ContractAddrLen)).String()
+	sdk.GetConfig().SetAddressVerifier(VerifyAddressLen())
+	cases := map[string]struct {
+		msg   MsgStoreCodes
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgStoreCodes{},
+			valid: false,
+		},
+		"correct": {
+			msg: MsgStoreCodes{
+				Sender:        goodAddress,
+				WASMByteCodes: [][]byte{[]byte("foo"), []byte("bar")},
+			},
+			valid: true,
+		},
+		"max codes": {
+			msg: MsgStoreCodes{
+				Sender:        goodAddress,
+				WASMByteCodes: slices.Repeat([][]byte{[]byte("foo")}, maxWasmCodeCount),
+			},
+			valid: true,
+		},
+		"too many codes": {
+			msg: MsgStoreCodes{
+				Sender:        goodAddress,
+				WASMByteCodes: slices.Repeat([][]byte{[]byte("foo")}, maxWasmCodeCount+1),
+			},
+			valid: false,
+		},
+		"no codes": {
+			msg: MsgStoreCodes{
+				Sender: goodAddress,
+			},
+			valid: false,
+		},
+		"empty code": {
+			msg: MsgStoreCodes{
+				Sender:        goodAddress,
+				WASMByteCodes: [][]byte{[]byte("foo"), {}},
+			},
+			valid: false,
+		},
+		"bad sender": {
+			msg: MsgStoreCodes{
+				Sender:        badAddress,
+				WASMByteCodes: [][]byte{[]byte("foo")},
+			},
+			valid: false,
+		},
+		"invalid InstantiatePermission": {
+			msg: MsgStoreCodes{
+				Sender:                goodAddress,
+				WASMByteCodes:         [][]byte{[]byte("foo")},
+				InstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -459,6 +586,102 @@ func TestExecuteContractValidation(t *testing.T) {
 	}
 }
 
+func TestExecuteContractsValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	goodExecution := ContractExecution{
+		Contract: goodAddress,
+		Msg:      []byte(`{"some": "data"}`),
+		Funds:    sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(200)}},
+	}
+
+	cases := map[string]struct {
+		msg   MsgExecuteContracts
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgExecuteContracts{},
+			valid: false,
+		},
+		"correct": {
+			msg: MsgExecuteContracts{
+				Sender:     goodAddress,
+				Executions: []ContractExecution{goodExecution, goodExecution},
+			},
+			valid: true,
+		},
+		"max executions": {
+			msg: MsgExecuteContracts{
+				Sender:     goodAddress,
+				Executions: slices.Repeat([]ContractExecution{goodExecution}, maxContractExecutionCount),
+			},
+			valid: true,
+		},
+		"too many executions": {
+			msg: MsgExecuteContracts{
+				Sender:     goodAddress,
+				Executions: slices.Repeat([]ContractExecution{goodExecution}, maxContractExecutionCount+1),
+			},
+			valid: false,
+		},
+		"no executions": {
+			msg: MsgExecuteContracts{
+				Sender: goodAddress,
+			},
+			valid: false,
+		},
+		"bad sender": {
+			msg: MsgExecuteContracts{
+				Sender:     badAddress,
+				Executions: []ContractExecution{goodExecution},
+			},
+			valid: false,
+		},
+		"bad contract": {
+			msg: MsgExecuteContracts{
+				Sender: goodAddress,
+				Executions: []ContractExecution{goodExecution, {
+					Contract: badAddress,
+					Msg:      []byte(`{"some": "data"}`),
+				}},
+			},
+			valid: false,
+		},
+		"negative funds": {
+			msg: MsgExecuteContracts{
+				Sender: goodAddress,
+				Executions: []ContractExecution{goodExecution, {
+					Contract: goodAddress,
+					Msg:      []byte(`{"some": "data"}`),
+					Funds:    sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(-1)}},
+				}},
+			},
+			valid: false,
+		},
+		"non json msg": {
+			msg: MsgExecuteContracts{
+				Sender: goodAddress,
+				Executions: []ContractExecution{goodExecution, {
+					Contract: goodAddress,
+					Msg:      []byte("invalid-json"),
+				}},
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestMsgUpdateAdministrator(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
@@ -576,74 +799,56 @@ func TestMsgClearAdministrator(t *testing.T) {
 	}
 }
 
-func TestMsgMigrateContract(t *testing.T) {
+func TestMsgClearAdmins(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
 	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+	tooManyContracts := make([]string, maxClearAdminsCount+1)
+	for i := range tooManyContracts {
+		tooManyContracts[i] = sdk.AccAddress(bytes.Repeat([]byte{byte(i)}, 20)).String()
+	}
 
 	specs := map[string]struct {
-		src    MsgMigrateContract
+		src    MsgClearAdmins
 		expErr bool
 	}{
 		"all good": {
-			src: MsgMigrateContract{
-				Sender:   goodAddress,
-				Contract: anotherGoodAddress,
-				CodeID:   firstCodeID,
-				Msg:      []byte("{}"),
+			src: MsgClearAdmins{
+				Sender:    goodAddress,
+				Contracts: []string{anotherGoodAddress, goodAddress},
 			},
 		},
 		"bad sender": {
-			src: MsgMigrateContract{
-				Sender:   badAddress,
-				Contract: anotherGoodAddress,
-				CodeID:   firstCodeID,
-			},
-			expErr: true,
-		},
-		"empty sender": {
-			src: MsgMigrateContract{
-				Contract: anotherGoodAddress,
-				CodeID:   firstCodeID,
-			},
-			expErr: true,
-		},
-		"empty code": {
-			src: MsgMigrateContract{
-				Sender:   goodAddress,
-				Contract: anotherGoodAddress,
+			src: MsgClearAdmins{
+				Sender:    badAddress,
+				Contracts: []string{anotherGoodAddress},
 			},
 			expErr: true,
 		},
 		"bad contract addr": {
-			src: MsgMigrateContract{
-				Sender:   goodAddress,
-				Contract: badAddress,
-				CodeID:   firstCodeID,
+			src: MsgClearAdmins{
+				Sender:    goodAddress,
+				Contracts: []string{anotherGoodAddress, badAddress},
 			},
 			expErr: true,
 		},
-		"empty contract addr": {
-			src: MsgMigrateContract{
+		"contracts missing": {
+			src: MsgClearAdmins{
 				Sender: goodAddress,
-				CodeID: firstCodeID,
 			},
 			expErr: true,
 		},
-		"non json migrateMsg": {
-			src: MsgMigrateContract{
-				Sender:   goodAddress,
-				Contract: anotherGoodAddress,
-				CodeID:   firstCodeID,
-				Msg:      []byte("invalid json"),
+		"duplicate contracts": {
+			src: MsgClearAdmins{
+				Sender:    goodAddress,
+				Contracts: []string{anotherGoodAddress, anotherGoodAddress},
 			},
 			expErr: true,
 		},
-		"empty migrateMsg": {
-			src: MsgMigrateContract{
-				Sender:   goodAddress,
-				Contract: anotherGoodAddress,
-				CodeID:   firstCodeID,
+		"too many contracts": {
+			src: MsgClearAdmins{
+				Sender:    goodAddress,
+				Contracts: tooManyContracts,
 			},
 			expErr: true,
 		},
@@ -660,49 +865,77 @@ func TestMsgMigrateContract(t *testing.T) {
 	}
 }
 
-func TestMsgUpdateInstantiateConfig(t *testing.T) {
+func TestMsgUpdateAdmins(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
 	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+	tooManyContracts := make([]string, maxUpdateAdminsCount+1)
+	for i := range tooManyContracts {
+		tooManyContracts[i] = sdk.AccAddress(bytes.Repeat([]byte{byte(i)}, 20)).String()
+	}
 
 	specs := map[string]struct {
-		src    MsgUpdateInstantiateConfig
+		src    MsgUpdateAdmins
 		expErr bool
 	}{
 		"all good": {
-			src: MsgUpdateInstantiateConfig{
-				Sender:                   goodAddress,
-				CodeID:                   1,
-				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+			src: MsgUpdateAdmins{
+				Sender:    goodAddress,
+				NewAdmin:  anotherGoodAddress,
+				Contracts: []string{anotherGoodAddress, goodAddress},
 			},
 		},
 		"bad sender": {
-			src: MsgUpdateInstantiateConfig{
-				Sender:                   badAddress,
-				CodeID:                   1,
-				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+			src: MsgUpdateAdmins{
+				Sender:    badAddress,
+				NewAdmin:  anotherGoodAddress,
+				Contracts: []string{anotherGoodAddress},
 			},
 			expErr: true,
 		},
-		"invalid NewInstantiatePermission": {
-			src: MsgUpdateInstantiateConfig{
-				Sender:                   goodAddress,
-				CodeID:                   1,
-				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+		"bad new admin": {
+			src: MsgUpdateAdmins{
+				Sender:    goodAddress,
+				NewAdmin:  badAddress,
+				Contracts: []string{anotherGoodAddress},
 			},
 			expErr: true,
 		},
-		"missing code id": {
-			src: MsgUpdateInstantiateConfig{
-				Sender:                   goodAddress,
-				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+		"new admin missing": {
+			src: MsgUpdateAdmins{
+				Sender:    goodAddress,
+				Contracts: []string{anotherGoodAddress},
 			},
 			expErr: true,
 		},
-		"missing NewInstantiatePermission": {
-			src: MsgUpdateInstantiateConfig{
-				Sender: goodAddress,
-				CodeID: 1,
+		"bad contract addr": {
+			src: MsgUpdateAdmins{
+				Sender:    goodAddress,
+				NewAdmin:  anotherGoodAddress,
+				Contracts: []string{anotherGoodAddress, badAddress},
+			},
+			expErr: true,
+		},
+		"contracts missing": {
+			src: MsgUpdateAdmins{
+				Sender:   goodAddress,
+				NewAdmin: anotherGoodAddress,
+			},
+			expErr: true,
+		},
+		"duplicate contracts": {
+			src: MsgUpdateAdmins{
+				Sender:    goodAddress,
+				NewAdmin:  anotherGoodAddress,
+				Contracts: []string{anotherGoodAddress, anotherGoodAddress},
+			},
+			expErr: true,
+		},
+		"too many contracts": {
+			src: MsgUpdateAdmins{
+				Sender:    goodAddress,
+				NewAdmin:  anotherGoodAddress,
+				Contracts: tooManyContracts,
 			},
 			expErr: true,
 		},
@@ -719,30 +952,81 @@ func TestMsgUpdateInstantiateConfig(t *testing.T) {
 	}
 }
 
-func TestMsgUpdateParamsValidation(t *testing.T) {
+func TestMsgMigrateContractAndExecute(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
 
 	specs := map[string]struct {
-		src    MsgUpdateParams
+		src    MsgMigrateContractAndExecute
 		expErr bool
 	}{
 		"all good": {
-			src: MsgUpdateParams{
-				Authority: goodAddress,
-				Params:    DefaultParams(),
+			src: MsgMigrateContractAndExecute{
+				Sender:     goodAddress,
+				Contract:   anotherGoodAddress,
+				NewCodeID:  1,
+				MigrateMsg: []byte(`{"foo":"bar"}`),
+				ExecuteMsg: []byte(`{"bar":"foo"}`),
+				Funds:      sdk.Coins{{Denom: "foobar", Amount: sdkmath.NewInt(200)}},
 			},
 		},
-		"bad authority": {
-			src: MsgUpdateParams{
-				Authority: badAddress,
-				Params:    DefaultParams(),
+		"bad sender": {
+			src: MsgMigrateContractAndExecute{
+				Sender:     badAddress,
+				Contract:   anotherGoodAddress,
+				NewCodeID:  1,
+				MigrateMsg: []byte(`{}`),
+				ExecuteMsg: []byte(`{}`),
 			},
 			expErr: true,
 		},
-		"empty authority": {
-			src: MsgUpdateParams{
-				Params: DefaultParams(),
+		"bad contract": {
+			src: MsgMigrateContractAndExecute{
+				Sender:     goodAddress,
+				Contract:   badAddress,
+				NewCodeID:  1,
+				MigrateMsg: []byte(`{}`),
+				ExecuteMsg: []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"code id missing": {
+			src: MsgMigrateContractAndExecute{
+				Sender:     goodAddress,
+				Contract:   anotherGoodAddress,
+				MigrateMsg: []byte(`{}`),
+				ExecuteMsg: []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"migrate msg missing": {
+			src: MsgMigrateContractAndExecute{
+				Sender:     goodAddress,
+				Contract:   anotherGoodAddress,
+				NewCodeID:  1,
+				ExecuteMsg: []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"execute msg not json": {
+			src: MsgMigrateContractAndExecute{
+				Sender:     goodAddress,
+				Contract:   anotherGoodAddress,
+				NewCodeID:  1,
+				MigrateMsg: []byte(`{}`),
+				ExecuteMsg: []byte("invalid json"),
+			},
+			expErr: true,
+		},
+		"negative funds": {
+			src: MsgMigrateContractAndExecute{
+				Sender:     goodAddress,
+				Contract:   anotherGoodAddress,
+				NewCodeID:  1,
+				MigrateMsg: []byte(`{}`),
+				ExecuteMsg: []byte(`{}`),
+				Funds:      sdk.Coins{{Denom: "foobar", Amount: sdkmath.NewInt(-200)}},
 			},
 			expErr: true,
 		},
@@ -759,14 +1043,311 @@ func TestMsgUpdateParamsValidation(t *testing.T) {
 	}
 }
 
-func TestMsgAddCodeUploadParamsAddressesValidation(t *testing.T) {
-	badAddress := "abcd"
+func TestMsgMigrateContract(t *testing.T) {
 	// proper address size
 	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
-	goodAddress2 := strings.ToUpper(goodAddress)
-	require.NotEqual(t, goodAddress, goodAddress2) // sanity check
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
 
-	tooManyAddresses := make([]string, MaxAddressCount+1)
+	specs := map[string]struct {
+		src    MsgMigrateContract
+		expErr bool
+	}{
+		"all good": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+				Msg:      []byte("{}"),
+			},
+		},
+		"with funds": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+				Msg:      []byte("{}"),
+				Funds:    sdk.Coins{sdk.NewInt64Coin("bar", 1), sdk.NewInt64Coin("foo", 2)},
+			},
+		},
+		"invalid funds": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+				Msg:      []byte("{}"),
+				Funds:    sdk.Coins{sdk.NewInt64Coin("foo", 2), sdk.NewInt64Coin("bar", 1)},
+			},
+			expErr: true,
+		},
+		"bad sender": {
+			src: MsgMigrateContract{
+				Sender:   badAddress,
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+			},
+			expErr: true,
+		},
+		"empty sender": {
+			src: MsgMigrateContract{
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+			},
+			expErr: true,
+		},
+		"empty code": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: badAddress,
+				CodeID:   firstCodeID,
+			},
+			expErr: true,
+		},
+		"empty contract addr": {
+			src: MsgMigrateContract{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+			},
+			expErr: true,
+		},
+		"non json migrateMsg": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+				Msg:      []byte("invalid json"),
+			},
+			expErr: true,
+		},
+		"empty migrateMsg": {
+			src: MsgMigrateContract{
+				Sender:   goodAddress,
+				Contract: anotherGoodAddress,
+				CodeID:   firstCodeID,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateInstantiateConfig(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateInstantiateConfig
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+			},
+		},
+		"bad sender": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   badAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+			},
+			expErr: true,
+		},
+		"invalid NewInstantiatePermission": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+			},
+			expErr: true,
+		},
+		"missing code id": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+			},
+			expErr: true,
+		},
+		"missing NewInstantiatePermission": {
+			src: MsgUpdateInstantiateConfig{
+				Sender: goodAddress,
+				CodeID: 1,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateInstantiateConfigs(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	anotherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x2}, 20)).String()
+	goodUpdate := func(codeID uint64) InstantiateConfigUpdate {
+		return InstantiateConfigUpdate{
+			CodeID:                   codeID,
+			NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{anotherGoodAddress}},
+		}
+	}
+	maxUpdates := make([]InstantiateConfigUpdate, maxCodeIDCount+1)
+	for i := range maxUpdates {
+		maxUpdates[i] = goodUpdate(uint64(i + 1))
+	}
+
+	specs := map[string]struct {
+		src    MsgUpdateInstantiateConfigs
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  goodAddress,
+				Updates: []InstantiateConfigUpdate{goodUpdate(1), goodUpdate(2)},
+			},
+		},
+		"max updates": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  goodAddress,
+				Updates: maxUpdates[:maxCodeIDCount],
+			},
+		},
+		"bad sender": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  badAddress,
+				Updates: []InstantiateConfigUpdate{goodUpdate(1)},
+			},
+			expErr: true,
+		},
+		"empty updates": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender: goodAddress,
+			},
+			expErr: true,
+		},
+		"exceeds max updates": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  goodAddress,
+				Updates: maxUpdates,
+			},
+			expErr: true,
+		},
+		"duplicate code id": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  goodAddress,
+				Updates: []InstantiateConfigUpdate{goodUpdate(1), goodUpdate(1)},
+			},
+			expErr: true,
+		},
+		"missing code id": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  goodAddress,
+				Updates: []InstantiateConfigUpdate{goodUpdate(0)},
+			},
+			expErr: true,
+		},
+		"invalid NewInstantiatePermission": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender: goodAddress,
+				Updates: []InstantiateConfigUpdate{{
+					CodeID:                   1,
+					NewInstantiatePermission: &AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{badAddress}},
+				}},
+			},
+			expErr: true,
+		},
+		"missing NewInstantiatePermission": {
+			src: MsgUpdateInstantiateConfigs{
+				Sender:  goodAddress,
+				Updates: []InstantiateConfigUpdate{{CodeID: 1}},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateParamsValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateParams
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateParams{
+				Authority: goodAddress,
+				Params:    DefaultParams(),
+			},
+		},
+		"bad authority": {
+			src: MsgUpdateParams{
+				Authority: badAddress,
+				Params:    DefaultParams(),
+			},
+			expErr: true,
+		},
+		"empty authority": {
+			src: MsgUpdateParams{
+				Params: DefaultParams(),
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgAddCodeUploadParamsAddressesValidation(t *testing.T) {
+	badAddress := "abcd"
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	goodAddress2 := strings.ToUpper(goodAddress)
+	require.NotEqual(t, goodAddress, goodAddress2) // sanity check
+
+	tooManyAddresses := make([]string, MaxAddressCount+1)
 	for i := range tooManyAddresses {
 		tooManyAddresses[i] = sdk.AccAddress(bytes.Repeat([]byte{byte(i)}, 20)).String()
 	}
@@ -1484,3 +2065,722 @@ func TestMsgUpdateContractLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgSetContractGasMultiplierValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSetContractGasMultiplier
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetContractGasMultiplier{
+				Authority:  goodAddress,
+				Contract:   otherGoodAddress,
+				Multiplier: GasMultiplier{Numerator: 1, Denominator: 2},
+			},
+		},
+		"default multiplier": {
+			src: MsgSetContractGasMultiplier{
+				Authority:  goodAddress,
+				Contract:   otherGoodAddress,
+				Multiplier: DefaultContractGasMultiplier(),
+			},
+		},
+		"bad authority": {
+			src: MsgSetContractGasMultiplier{
+				Authority:  badAddress,
+				Contract:   otherGoodAddress,
+				Multiplier: GasMultiplier{Numerator: 1, Denominator: 2},
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSetContractGasMultiplier{
+				Authority:  goodAddress,
+				Contract:   badAddress,
+				Multiplier: GasMultiplier{Numerator: 1, Denominator: 2},
+			},
+			expErr: true,
+		},
+		"empty numerator": {
+			src: MsgSetContractGasMultiplier{
+				Authority:  goodAddress,
+				Contract:   otherGoodAddress,
+				Multiplier: GasMultiplier{Denominator: 2},
+			},
+			expErr: true,
+		},
+		"empty denominator": {
+			src: MsgSetContractGasMultiplier{
+				Authority:  goodAddress,
+				Contract:   otherGoodAddress,
+				Multiplier: GasMultiplier{Numerator: 1},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgRegisterBlockSudoHookValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgRegisterBlockSudoHook
+		expErr bool
+	}{
+		"all good": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseBeginBlock,
+				Contract:  otherGoodAddress,
+				Msg:       []byte(`{}`),
+			},
+		},
+		"end block": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseEndBlock,
+				Contract:  otherGoodAddress,
+				Msg:       []byte(`{}`),
+			},
+		},
+		"bad authority": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: badAddress,
+				Phase:     BlockSudoPhaseBeginBlock,
+				Contract:  otherGoodAddress,
+				Msg:       []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseBeginBlock,
+				Contract:  badAddress,
+				Msg:       []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"unspecified phase": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Msg:       []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"unknown phase": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     3,
+				Contract:  otherGoodAddress,
+				Msg:       []byte(`{}`),
+			},
+			expErr: true,
+		},
+		"empty msg": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseBeginBlock,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"non json msg": {
+			src: MsgRegisterBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseBeginBlock,
+				Contract:  otherGoodAddress,
+				Msg:       []byte("invalid json"),
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgRemoveBlockSudoHookValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgRemoveBlockSudoHook
+		expErr bool
+	}{
+		"all good": {
+			src: MsgRemoveBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseEndBlock,
+				Contract:  otherGoodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgRemoveBlockSudoHook{
+				Authority: badAddress,
+				Phase:     BlockSudoPhaseEndBlock,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgRemoveBlockSudoHook{
+				Authority: goodAddress,
+				Phase:     BlockSudoPhaseEndBlock,
+				Contract:  badAddress,
+			},
+			expErr: true,
+		},
+		"unspecified phase": {
+			src: MsgRemoveBlockSudoHook{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgRestoreContractStateValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgRestoreContractState
+		expErr bool
+	}{
+		"all good": {
+			src: MsgRestoreContractState{
+				Authority:    goodAddress,
+				Contract:     otherGoodAddress,
+				CheckpointID: 1,
+			},
+		},
+		"bad authority": {
+			src: MsgRestoreContractState{
+				Authority:    badAddress,
+				Contract:     otherGoodAddress,
+				CheckpointID: 1,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgRestoreContractState{
+				Authority:    goodAddress,
+				Contract:     badAddress,
+				CheckpointID: 1,
+			},
+			expErr: true,
+		},
+		"empty checkpoint id": {
+			src: MsgRestoreContractState{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateStargateAllowlistValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	const (
+		myPath      = "/cosmos.bank.v1beta1.Query/Balance"
+		myOtherPath = "/cosmos.auth.v1beta1.Query/Account"
+	)
+
+	specs := map[string]struct {
+		src    MsgUpdateStargateAllowlist
+		expErr bool
+	}{
+		"all good": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+				Add:       []string{myPath},
+				Remove:    []string{myOtherPath},
+			},
+		},
+		"add only": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+				Add:       []string{myPath, myOtherPath},
+			},
+		},
+		"remove only": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+				Remove:    []string{myPath},
+			},
+		},
+		"bad authority": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: badAddress,
+				Add:       []string{myPath},
+			},
+			expErr: true,
+		},
+		"empty": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+		"duplicate": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+				Add:       []string{myPath, myPath},
+			},
+			expErr: true,
+		},
+		"added and removed": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+				Add:       []string{myPath},
+				Remove:    []string{myPath},
+			},
+			expErr: true,
+		},
+		"invalid path": {
+			src: MsgUpdateStargateAllowlist{
+				Authority: goodAddress,
+				Add:       []string{"/cosmos.bank.v1beta1.Query"},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetContractStorageQuotaValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSetContractStorageQuota
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetContractStorageQuota{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				MaxBytes:  1024,
+			},
+		},
+		"remove quota": {
+			src: MsgSetContractStorageQuota{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgSetContractStorageQuota{
+				Authority: badAddress,
+				Contract:  otherGoodAddress,
+				MaxBytes:  1024,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSetContractStorageQuota{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				MaxBytes:  1024,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetContractLockValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSetContractLock
+		expErr bool
+	}{
+		"lock": {
+			src: MsgSetContractLock{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Locked:    true,
+			},
+		},
+		"unlock": {
+			src: MsgSetContractLock{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgSetContractLock{
+				Authority: badAddress,
+				Contract:  otherGoodAddress,
+				Locked:    true,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSetContractLock{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				Locked:    true,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgUpdateInstantiateDefaultPermissionValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgUpdateInstantiateDefaultPermission
+		expErr bool
+	}{
+		"nobody": {
+			src: MsgUpdateInstantiateDefaultPermission{
+				Authority:  goodAddress,
+				Permission: AccessTypeNobody,
+			},
+		},
+		"everybody": {
+			src: MsgUpdateInstantiateDefaultPermission{
+				Authority:  goodAddress,
+				Permission: AccessTypeEverybody,
+			},
+		},
+		"any of addresses": {
+			src: MsgUpdateInstantiateDefaultPermission{
+				Authority:  goodAddress,
+				Permission: AccessTypeAnyOfAddresses,
+			},
+		},
+		"unspecified permission": {
+			src: MsgUpdateInstantiateDefaultPermission{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+		"unknown permission": {
+			src: MsgUpdateInstantiateDefaultPermission{
+				Authority:  goodAddress,
+				Permission: AccessType(99),
+			},
+			expErr: true,
+		},
+		"bad authority": {
+			src: MsgUpdateInstantiateDefaultPermission{
+				Authority:  badAddress,
+				Permission: AccessTypeNobody,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgPruneUnusedCodesValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgPruneUnusedCodes
+		expErr bool
+	}{
+		"all good": {
+			src: MsgPruneUnusedCodes{
+				Authority: goodAddress,
+			},
+		},
+		"with older than height": {
+			src: MsgPruneUnusedCodes{
+				Authority:       goodAddress,
+				OlderThanHeight: 100,
+			},
+		},
+		"bad authority": {
+			src: MsgPruneUnusedCodes{
+				Authority: badAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgReplaceContractStateValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	models := []Model{{Key: []byte("foo"), Value: []byte("bar")}}
+
+	specs := map[string]struct {
+		src    MsgReplaceContractState
+		expErr bool
+	}{
+		"all good": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Models:    models,
+				Mode:      StateImportModeOverwrite,
+			},
+		},
+		"merge": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Models:    models,
+				Mode:      StateImportModeMerge,
+			},
+		},
+		"empty models": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Mode:      StateImportModeOverwrite,
+			},
+		},
+		"bad authority": {
+			src: MsgReplaceContractState{
+				Authority: badAddress,
+				Contract:  goodAddress,
+				Models:    models,
+				Mode:      StateImportModeOverwrite,
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				Models:    models,
+				Mode:      StateImportModeOverwrite,
+			},
+			expErr: true,
+		},
+		"unspecified mode": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Models:    models,
+			},
+			expErr: true,
+		},
+		"unknown mode": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Models:    models,
+				Mode:      99,
+			},
+			expErr: true,
+		},
+		"empty key": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Models:    []Model{{Value: []byte("bar")}},
+				Mode:      StateImportModeOverwrite,
+			},
+			expErr: true,
+		},
+		"duplicate keys": {
+			src: MsgReplaceContractState{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				Models:    append(models, models...),
+				Mode:      StateImportModeOverwrite,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgForfeitCodeDepositValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgForfeitCodeDeposit
+		expErr bool
+	}{
+		"all good": {
+			src: MsgForfeitCodeDeposit{
+				Authority: goodAddress,
+				CodeID:    1,
+			},
+		},
+		"bad authority": {
+			src: MsgForfeitCodeDeposit{
+				Authority: badAddress,
+				CodeID:    1,
+			},
+			expErr: true,
+		},
+		"empty code id": {
+			src: MsgForfeitCodeDeposit{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgPruneContractDependenciesValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgPruneContractDependencies
+		expErr bool
+	}{
+		"all good": {
+			src: MsgPruneContractDependencies{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgPruneContractDependencies{
+				Authority: badAddress,
+				Contract:  goodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgPruneContractDependencies{
+				Authority: goodAddress,
+				Contract:  badAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
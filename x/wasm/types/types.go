@@ -16,9 +16,10 @@ import (
 )
 
 const (
-	defaultMemoryCacheSize    uint32 = 100 // in MiB
-	defaultSmartQueryGasLimit uint64 = 3_000_000
-	defaultContractDebugMode         = false
+	defaultMemoryCacheSize       uint32 = 100 // in MiB
+	defaultSmartQueryGasLimit    uint64 = 3_000_000
+	defaultContractDebugMode            = false
+	defaultMetricsSampleInterval uint64 = 10 // in blocks
 
 	// SDKAddrLen defines a valid address length that was used in sdk address generation
 	SDKAddrLen = 20
@@ -34,6 +35,21 @@ func (m Model) ValidateBasic() error {
 	return nil
 }
 
+// ValidateModels checks the models and that every key is unique
+func ValidateModels(models []Model) error {
+	unique := make(map[string]struct{}, len(models))
+	for i, m := range models {
+		if err := m.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "model %d", i)
+		}
+		if _, exists := unique[string(m.Key)]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "key: %x", m.Key)
+		}
+		unique[string(m.Key)] = struct{}{}
+	}
+	return nil
+}
+
 func (c CodeInfo) ValidateBasic() error {
 	if len(c.CodeHash) == 0 {
 		return errorsmod.Wrap(ErrEmpty, "code hash")
@@ -44,6 +60,9 @@ func (c CodeInfo) ValidateBasic() error {
 	if err := c.InstantiateConfig.ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "instantiate config")
 	}
+	if err := ValidateCodeProvenance(c.Source, c.Builder); err != nil {
+		return errorsmod.Wrap(err, "code provenance")
+	}
 	return nil
 }
 
@@ -333,14 +352,24 @@ type NodeConfig struct {
 	MemoryCacheSize uint32 `mapstructure:"memory_cache_size"`
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// MetricsSampleInterval is the number of blocks between two samples of the wasmvm cache metrics
+	// that are reported via telemetry. Set to 0 to disable.
+	MetricsSampleInterval uint64 `mapstructure:"metrics_sample_interval"`
+	// WarmCacheSize is the number of recently used codes that are written to a file on shutdown and pinned in the
+	// wasmvm cache on the next start. This is a node local optimization. Set to 0 to disable.
+	WarmCacheSize uint32 `mapstructure:"warm_cache_size"`
+	// WasmSimulationGasLimit is the max gas to be used in a simulation of a tx with store code, instantiate, execute
+	// or migrate messages. It applies in addition to the SimulationGasLimit. Set to 0 to disable.
+	WasmSimulationGasLimit uint64 `mapstructure:"wasm_simulation_gas_limit"`
 }
 
 // DefaultNodeConfig returns the default settings for NodeConfig
 func DefaultNodeConfig() NodeConfig {
 	return NodeConfig{
-		SmartQueryGasLimit: defaultSmartQueryGasLimit,
-		MemoryCacheSize:    defaultMemoryCacheSize,
-		ContractDebugMode:  defaultContractDebugMode,
+		SmartQueryGasLimit:    defaultSmartQueryGasLimit,
+		MemoryCacheSize:       defaultMemoryCacheSize,
+		ContractDebugMode:     defaultContractDebugMode,
+		MetricsSampleInterval: defaultMetricsSampleInterval,
 	}
 }
 
@@ -368,7 +397,19 @@ memory_cache_size = %d
 # Simulation gas limit is the max gas to be used in a tx simulation call.
 # When not set the consensus max block gas is used instead
 %s
-`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit)
+
+# Number of blocks between two samples of the wasmvm cache metrics reported via telemetry.
+# Set to 0 to disable.
+metrics_sample_interval = %d
+
+# Number of recently used codes that are written to a file on shutdown and pinned in the
+# wasmvm cache on the next start. Set to 0 to disable.
+warm_cache_size = %d
+
+# Max gas to be used in a simulation of a tx that stores code, instantiates, executes or migrates
+# contracts. It applies in addition to the simulation gas limit. Set to 0 to disable.
+wasm_simulation_gas_limit = %d
+`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit, c.MetricsSampleInterval, c.WarmCacheSize, c.WasmSimulationGasLimit)
 }
 
 // VerifyAddressLen ensures that the address matches the expected length
@@ -404,8 +445,9 @@ func (a AccessType) IsSubset(superSet AccessType) bool {
 func (a AccessConfig) IsSubset(superSet AccessConfig) bool {
 	switch superSet.Permission {
 	case AccessTypeAnyOfAddresses:
-		// An exact match or nobody
-		return a.Permission == AccessTypeNobody || a.Permission == AccessTypeAnyOfAddresses && isSubset(superSet.Addresses, a.Addresses)
+		// An exact match or nobody, with an equal or lower max instances limit
+		return a.Permission == AccessTypeNobody || a.Permission == AccessTypeAnyOfAddresses && isSubset(superSet.Addresses, a.Addresses) &&
+			(superSet.MaxInstancesPerAddress == 0 || a.MaxInstancesPerAddress != 0 && a.MaxInstancesPerAddress <= superSet.MaxInstancesPerAddress)
 	case AccessTypeUnspecified:
 		return false
 	default:
@@ -467,3 +509,53 @@ func (tc TxContracts) Exists(checksum []byte) bool {
 func (tc TxContracts) GetContracts() txContracts {
 	return tc.contracts
 }
+
+// DefaultBlockSudoGasLimit is the max gas a single block sudo hook can consume
+const DefaultBlockSudoGasLimit uint64 = 1_000_000
+
+// ValidateBasic syntax checks
+func (p BlockSudoPhase) ValidateBasic() error {
+	switch p {
+	case BlockSudoPhaseBeginBlock, BlockSudoPhaseEndBlock:
+		return nil
+	case BlockSudoPhaseUnspecified:
+		return errorsmod.Wrap(ErrEmpty, "phase")
+	}
+	return errorsmod.Wrapf(ErrInvalid, "unknown phase: %d", p)
+}
+
+// ValidateBasic syntax checks
+func (m StateImportMode) ValidateBasic() error {
+	switch m {
+	case StateImportModeOverwrite, StateImportModeMerge:
+		return nil
+	case StateImportModeUnspecified:
+		return errorsmod.Wrap(ErrEmpty, "state import mode")
+	}
+	return errorsmod.Wrapf(ErrInvalid, "unknown state import mode: %d", m)
+}
+
+// ValidateBasic syntax checks
+func (h BlockSudoHook) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(h.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if err := h.Msg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+// ValidateBasic syntax checks
+func (c MigrationCheckpoint) ValidateBasic() error {
+	if c.ID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "id")
+	}
+	if c.CodeID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "code id")
+	}
+	if c.Created == nil {
+		return errorsmod.Wrap(ErrEmpty, "created")
+	}
+	return nil
+}
@@ -12,7 +12,9 @@ import (
 
 	github_com_cometbft_cometbft_libs_bytes "github.com/cometbft/cometbft/libs/bytes"
 	_ "github.com/cosmos/cosmos-proto"
-	types "github.com/cosmos/cosmos-sdk/codec/types"
+	types1 "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/cosmos/gogoproto/proto"
@@ -99,6 +101,73 @@ func (ContractCodeHistoryOperationType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e6155d98fa173e02, []int{1}
 }
 
+// BlockSudoPhase block phase in which registered contracts are sudo called
+type BlockSudoPhase int32
+
+const (
+	// BlockSudoPhaseUnspecified placeholder for empty value
+	BlockSudoPhaseUnspecified BlockSudoPhase = 0
+	// BlockSudoPhaseBeginBlock sudo call in the module BeginBlock
+	BlockSudoPhaseBeginBlock BlockSudoPhase = 1
+	// BlockSudoPhaseEndBlock sudo call in the module EndBlock
+	BlockSudoPhaseEndBlock BlockSudoPhase = 2
+)
+
+var BlockSudoPhase_name = map[int32]string{
+	0: "BLOCK_SUDO_PHASE_UNSPECIFIED",
+	1: "BLOCK_SUDO_PHASE_BEGIN_BLOCK",
+	2: "BLOCK_SUDO_PHASE_END_BLOCK",
+}
+
+var BlockSudoPhase_value = map[string]int32{
+	"BLOCK_SUDO_PHASE_UNSPECIFIED": 0,
+	"BLOCK_SUDO_PHASE_BEGIN_BLOCK": 1,
+	"BLOCK_SUDO_PHASE_END_BLOCK":   2,
+}
+
+func (x BlockSudoPhase) String() string {
+	return proto.EnumName(BlockSudoPhase_name, int32(x))
+}
+
+func (BlockSudoPhase) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{2}
+}
+
+// StateImportMode defines how imported models are applied to the existing
+// contract state
+type StateImportMode int32
+
+const (
+	// StateImportModeUnspecified placeholder for empty value
+	StateImportModeUnspecified StateImportMode = 0
+	// StateImportModeOverwrite removes the existing state before the models are
+	// stored
+	StateImportModeOverwrite StateImportMode = 1
+	// StateImportModeMerge stores the models on top of the existing state.
+	// Existing keys are replaced.
+	StateImportModeMerge StateImportMode = 2
+)
+
+var StateImportMode_name = map[int32]string{
+	0: "STATE_IMPORT_MODE_UNSPECIFIED",
+	1: "STATE_IMPORT_MODE_OVERWRITE",
+	2: "STATE_IMPORT_MODE_MERGE",
+}
+
+var StateImportMode_value = map[string]int32{
+	"STATE_IMPORT_MODE_UNSPECIFIED": 0,
+	"STATE_IMPORT_MODE_OVERWRITE":   1,
+	"STATE_IMPORT_MODE_MERGE":       2,
+}
+
+func (x StateImportMode) String() string {
+	return proto.EnumName(StateImportMode_name, int32(x))
+}
+
+func (StateImportMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{3}
+}
+
 // AccessTypeParam
 type AccessTypeParam struct {
 	Value AccessType `protobuf:"varint,1,opt,name=value,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"value,omitempty" yaml:"value"`
@@ -146,6 +215,10 @@ var xxx_messageInfo_AccessTypeParam proto.InternalMessageInfo
 type AccessConfig struct {
 	Permission AccessType `protobuf:"varint,1,opt,name=permission,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"permission,omitempty" yaml:"permission"`
 	Addresses  []string   `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// MaxInstancesPerAddress limits the number of contracts each of the
+	// addresses can instantiate. Zero means unlimited. Only supported with
+	// AccessTypeAnyOfAddresses.
+	MaxInstancesPerAddress uint64 `protobuf:"varint,4,opt,name=max_instances_per_address,json=maxInstancesPerAddress,proto3" json:"max_instances_per_address,omitempty"`
 }
 
 func (m *AccessConfig) Reset()         { *m = AccessConfig{} }
@@ -190,6 +263,118 @@ var xxx_messageInfo_AccessConfig proto.InternalMessageInfo
 type Params struct {
 	CodeUploadAccess             AccessConfig `protobuf:"bytes,1,opt,name=code_upload_access,json=codeUploadAccess,proto3" json:"code_upload_access" yaml:"code_upload_access"`
 	InstantiateDefaultPermission AccessType   `protobuf:"varint,2,opt,name=instantiate_default_permission,json=instantiateDefaultPermission,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"instantiate_default_permission,omitempty" yaml:"instantiate_default_permission"`
+	// MaxMigrationCheckpoints is the number of pre-migration state checkpoints
+	// retained per contract. Zero disables migrations with backup.
+	MaxMigrationCheckpoints uint64 `protobuf:"varint,3,opt,name=max_migration_checkpoints,json=maxMigrationCheckpoints,proto3" json:"max_migration_checkpoints,omitempty" yaml:"max_migration_checkpoints"`
+	// MaxInstantiatesPerBlock is the max number of contract instantiations
+	// within a block. Zero means unlimited.
+	MaxInstantiatesPerBlock uint64 `protobuf:"varint,4,opt,name=max_instantiates_per_block,json=maxInstantiatesPerBlock,proto3" json:"max_instantiates_per_block,omitempty" yaml:"max_instantiates_per_block"`
+	// CountSubMsgInstantiates makes instantiations dispatched by contracts count
+	// against the MaxInstantiatesPerBlock budget.
+	CountSubmsgInstantiates bool `protobuf:"varint,5,opt,name=count_submsg_instantiates,json=countSubmsgInstantiates,proto3" json:"count_submsg_instantiates,omitempty" yaml:"count_submsg_instantiates"`
+	// HistoricalQueryDepth is the max number of blocks in the past that
+	// contracts can query with QueryAtHeight. Zero disables historical queries.
+	HistoricalQueryDepth uint64 `protobuf:"varint,6,opt,name=historical_query_depth,json=historicalQueryDepth,proto3" json:"historical_query_depth,omitempty" yaml:"historical_query_depth"`
+	// AutoPinCodeHashes are the lower case hex encoded checksums of the codes
+	// that are always pinned in the wasmvm cache.
+	AutoPinCodeHashes []string `protobuf:"bytes,7,rep,name=auto_pin_code_hashes,json=autoPinCodeHashes,proto3" json:"auto_pin_code_hashes,omitempty" yaml:"auto_pin_code_hashes"`
+	// MaxQueryRecursionDepth is the max number of nested smart queries between
+	// contracts. It can only lower the query stack limit of the keeper. Zero
+	// applies the keeper limit only.
+	MaxQueryRecursionDepth uint64 `protobuf:"varint,8,opt,name=max_query_recursion_depth,json=maxQueryRecursionDepth,proto3" json:"max_query_recursion_depth,omitempty" yaml:"max_query_recursion_depth"`
+	// MaxSubQueryGas is the max SDK gas a smart query from a contract to another
+	// contract can consume. Zero disables the limit.
+	MaxSubQueryGas uint64 `protobuf:"varint,9,opt,name=max_sub_query_gas,json=maxSubQueryGas,proto3" json:"max_sub_query_gas,omitempty" yaml:"max_sub_query_gas"`
+	// MaxWasmInstructionsPerCall is the max number of Wasm operations that a
+	// single contract call can execute. The limit is enforced as a VM gas
+	// ceiling of 115 CosmWasm gas per operation, the flat operation cost of the
+	// VM. Gas charged by host functions counts against the ceiling as well.
+	// Exceeding it fails the call with ErrExceedMaxWasmInstructions. Zero
+	// disables the limit.
+	MaxWasmInstructionsPerCall uint64 `protobuf:"varint,10,opt,name=max_wasm_instructions_per_call,json=maxWasmInstructionsPerCall,proto3" json:"max_wasm_instructions_per_call,omitempty" yaml:"max_wasm_instructions_per_call"`
+	// EmitStateChangeEvents enables events with the key hash for every write
+	// and delete of contract state during execute, migrate and sudo.
+	EmitStateChangeEvents bool `protobuf:"varint,11,opt,name=emit_state_change_events,json=emitStateChangeEvents,proto3" json:"emit_state_change_events,omitempty" yaml:"emit_state_change_events"`
+	// RecordContractDependencies enables recording the code ids that contracts
+	// instantiate or migrate other contracts to.
+	RecordContractDependencies bool `protobuf:"varint,12,opt,name=record_contract_dependencies,json=recordContractDependencies,proto3" json:"record_contract_dependencies,omitempty" yaml:"record_contract_dependencies"`
+	// MaxExecuteMsgSize is the max size in bytes of the payload msg plus the
+	// encoded funds of an instantiate, execute, migrate or sudo message. Zero
+	// disables the limit.
+	MaxExecuteMsgSize uint64 `protobuf:"varint,13,opt,name=max_execute_msg_size,json=maxExecuteMsgSize,proto3" json:"max_execute_msg_size,omitempty" yaml:"max_execute_msg_size"`
+	// DisabledCapabilities are the wasmvm capabilities that codes must not
+	// require to be stored or instantiated.
+	DisabledCapabilities []string `protobuf:"bytes,14,rep,name=disabled_capabilities,json=disabledCapabilities,proto3" json:"disabled_capabilities,omitempty" yaml:"disabled_capabilities"`
+	// EmitRawContractEvents enables an additional event for every custom
+	// contract event with the original event type and attributes.
+	EmitRawContractEvents bool `protobuf:"varint,15,opt,name=emit_raw_contract_events,json=emitRawContractEvents,proto3" json:"emit_raw_contract_events,omitempty" yaml:"emit_raw_contract_events"`
+	// IBCSenderAllowlist are the contract addresses that are allowed to send IBC
+	// packets and transfers. All contracts are allowed when empty.
+	IBCSenderAllowlist []string `protobuf:"bytes,16,rep,name=ibc_sender_allowlist,json=ibcSenderAllowlist,proto3" json:"ibc_sender_allowlist,omitempty" yaml:"ibc_sender_allowlist"`
+	// MaxCallDepth is the max number of nested messages dispatched by
+	// contracts. It can only lower the max call depth of the node and must not
+	// exceed 500. Zero falls back to the max call depth of the node.
+	MaxCallDepth uint32 `protobuf:"varint,17,opt,name=max_call_depth,json=maxCallDepth,proto3" json:"max_call_depth,omitempty" yaml:"max_call_depth"`
+	// RejectFloatOperations rejects the upload of codes that use float value
+	// types or instructions.
+	RejectFloatOperations bool `protobuf:"varint,18,opt,name=reject_float_operations,json=rejectFloatOperations,proto3" json:"reject_float_operations,omitempty" yaml:"reject_float_operations"`
+	// ForwardTxMemo makes the memo of the current transaction readable by
+	// contracts with the tx_memo custom query.
+	ForwardTxMemo bool `protobuf:"varint,19,opt,name=forward_tx_memo,json=forwardTxMemo,proto3" json:"forward_tx_memo,omitempty" yaml:"forward_tx_memo"`
+	// MaxSubMessagesPerCall is the max number of submessages a single contract
+	// entry point call can return. Zero disables the limit.
+	MaxSubMessagesPerCall uint32 `protobuf:"varint,20,opt,name=max_sub_messages_per_call,json=maxSubMessagesPerCall,proto3" json:"max_sub_messages_per_call,omitempty" yaml:"max_sub_messages_per_call"`
+	// MemoryCacheSize is the size of the in-memory cache of compiled contract
+	// modules in MiB. Zero falls back to the memory cache size of the node
+	// config. The wasmvm of a node is recreated with the size when the node
+	// starts, so that a change is applied on the next restart. The recreated
+	// cache starts empty.
+	MemoryCacheSize uint32 `protobuf:"varint,21,opt,name=memory_cache_size,json=memoryCacheSize,proto3" json:"memory_cache_size,omitempty" yaml:"memory_cache_size"`
+	// ContractMemoryLimit is the memory limit of each contract instance in MiB.
+	// Zero falls back to the default of 32 MiB. The wasmvm of a node is
+	// recreated with the limit when the node starts, so that a change is applied
+	// on the next restart. It only affects contract instances created
+	// afterwards.
+	ContractMemoryLimit uint32 `protobuf:"varint,22,opt,name=contract_memory_limit,json=contractMemoryLimit,proto3" json:"contract_memory_limit,omitempty" yaml:"contract_memory_limit"`
+	// RejectLockedContractQueries rejects smart queries to locked contracts.
+	// By default, locked contracts can still be queried.
+	RejectLockedContractQueries bool `protobuf:"varint,23,opt,name=reject_locked_contract_queries,json=rejectLockedContractQueries,proto3" json:"reject_locked_contract_queries,omitempty" yaml:"reject_locked_contract_queries"`
+	// CodeStorageDeposit is escrowed from the sender of a MsgStoreCode for each
+	// stored code. It is refunded when the code is pruned and forfeited on a
+	// governance decision. Empty disables the deposit.
+	CodeStorageDeposit github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,24,rep,name=code_storage_deposit,json=codeStorageDeposit,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"code_storage_deposit" yaml:"code_storage_deposit"`
+	// RecordCodeInstantiations enables the append-only log of the contract
+	// instantiations per code that is served by the CodeInstantiations query.
+	// Disabled by default.
+	RecordCodeInstantiations bool `protobuf:"varint,25,opt,name=record_code_instantiations,json=recordCodeInstantiations,proto3" json:"record_code_instantiations,omitempty" yaml:"record_code_instantiations"`
+	// StateCleanupRefundPerByte is the gas refunded per byte that a contract
+	// execution removes from the contract state in net. 0 disables the refund.
+	StateCleanupRefundPerByte uint64 `protobuf:"varint,26,opt,name=state_cleanup_refund_per_byte,json=stateCleanupRefundPerByte,proto3" json:"state_cleanup_refund_per_byte,omitempty" yaml:"state_cleanup_refund_per_byte"`
+	// StateCleanupRefundThreshold is the minimum number of bytes that a contract
+	// execution must remove from the contract state in net to get a refund.
+	StateCleanupRefundThreshold uint64 `protobuf:"varint,27,opt,name=state_cleanup_refund_threshold,json=stateCleanupRefundThreshold,proto3" json:"state_cleanup_refund_threshold,omitempty" yaml:"state_cleanup_refund_threshold"`
+	// MaxStateCleanupRefund caps the gas refunded for a single contract
+	// execution. 0 disables the refund.
+	MaxStateCleanupRefund uint64 `protobuf:"varint,28,opt,name=max_state_cleanup_refund,json=maxStateCleanupRefund,proto3" json:"max_state_cleanup_refund,omitempty" yaml:"max_state_cleanup_refund"`
+	// EnforceLabelUniquenessPerCode rejects a label that is already used by
+	// another contract of the same code on instantiate, migrate and label
+	// update. Labels of existing contracts are not checked when enabled.
+	EnforceLabelUniquenessPerCode bool `protobuf:"varint,29,opt,name=enforce_label_uniqueness_per_code,json=enforceLabelUniquenessPerCode,proto3" json:"enforce_label_uniqueness_per_code,omitempty" yaml:"enforce_label_uniqueness_per_code"`
+	// MaxEventsPerCall is the max number of events a single contract entry point
+	// call can emit, counting the wasm event of the attributes, the custom
+	// events and their raw events. Zero disables the limit.
+	MaxEventsPerCall uint32 `protobuf:"varint,30,opt,name=max_events_per_call,json=maxEventsPerCall,proto3" json:"max_events_per_call,omitempty" yaml:"max_events_per_call"`
+	// RejectSelfQueries rejects smart queries of a contract into itself while it
+	// is called. By default, such a query is handled read-only on a branch of
+	// the calling context and sees the uncommitted writes of the call. This is
+	// deterministic as the writes happened earlier in the same call on every
+	// node. Writes made in the query are discarded.
+	RejectSelfQueries bool `protobuf:"varint,31,opt,name=reject_self_queries,json=rejectSelfQueries,proto3" json:"reject_self_queries,omitempty" yaml:"reject_self_queries"`
+	// MaxBlockSudoHooks is the max number of contracts that can be registered
+	// for each of the BeginBlock and EndBlock phases. Only the first hooks up to
+	// the limit are run when it is lowered below the registered number. Zero
+	// applies the default of 10.
+	MaxBlockSudoHooks uint32 `protobuf:"varint,32,opt,name=max_block_sudo_hooks,json=maxBlockSudoHooks,proto3" json:"max_block_sudo_hooks,omitempty" yaml:"max_block_sudo_hooks"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -237,6 +422,11 @@ type CodeInfo struct {
 	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
 	// InstantiateConfig access control to apply on contract creation, optional
 	InstantiateConfig AccessConfig `protobuf:"bytes,5,opt,name=instantiate_config,json=instantiateConfig,proto3" json:"instantiate_config"`
+	// Source is an optional URL to the source code of the reproducible build
+	Source string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	// Builder is an optional docker image (with tag) that was used to compile
+	// the code reproducibly, e.g. "cosmwasm/optimizer:0.16.0"
+	Builder string `protobuf:"bytes,7,opt,name=builder,proto3" json:"builder,omitempty"`
 }
 
 func (m *CodeInfo) Reset()         { *m = CodeInfo{} }
@@ -293,7 +483,13 @@ type ContractInfo struct {
 	IBC2PortID string              `protobuf:"bytes,7,opt,name=ibc2_port_id,json=ibc2PortId,proto3" json:"ibc2_port_id,omitempty"`
 	// Extension is an extension point to store custom metadata within the
 	// persistence model.
-	Extension *types.Any `protobuf:"bytes,8,opt,name=extension,proto3" json:"extension,omitempty"`
+	Extension *types1.Any `protobuf:"bytes,8,opt,name=extension,proto3" json:"extension,omitempty"`
+	// Locked is true for contracts that reject all calls. It is kept in the
+	// contract info that every call reads, so that no extra lookup is needed.
+	Locked bool `protobuf:"varint,9,opt,name=locked,proto3" json:"locked,omitempty"`
+	// StorageQuota is the maximum number of bytes the contract may store, not
+	// set for contracts without a quota
+	StorageQuota uint64 `protobuf:"varint,10,opt,name=storage_quota,json=storageQuota,proto3" json:"storage_quota,omitempty"`
 }
 
 func (m *ContractInfo) Reset()         { *m = ContractInfo{} }
@@ -476,9 +672,242 @@ func (m *Model) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Model proto.InternalMessageInfo
 
+// GasMultiplier is a rational factor that scales the Cosmos SDK gas charged
+// for the wasm execution of a single contract.
+type GasMultiplier struct {
+	Numerator   uint64 `protobuf:"varint,1,opt,name=numerator,proto3" json:"numerator,omitempty"`
+	Denominator uint64 `protobuf:"varint,2,opt,name=denominator,proto3" json:"denominator,omitempty"`
+}
+
+func (m *GasMultiplier) Reset()         { *m = GasMultiplier{} }
+func (m *GasMultiplier) String() string { return proto.CompactTextString(m) }
+func (*GasMultiplier) ProtoMessage()    {}
+func (*GasMultiplier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{8}
+}
+
+func (m *GasMultiplier) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *GasMultiplier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GasMultiplier.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *GasMultiplier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GasMultiplier.Merge(m, src)
+}
+
+func (m *GasMultiplier) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *GasMultiplier) XXX_DiscardUnknown() {
+	xxx_messageInfo_GasMultiplier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GasMultiplier proto.InternalMessageInfo
+
+// BlockSudoHook is a contract that is sudo called with the given message each
+// block
+type BlockSudoHook struct {
+	// Contract is the address of the smart contract
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// Msg json encoded message to be passed to the contract sudo entry point
+	Msg RawContractMessage `protobuf:"bytes,2,opt,name=msg,proto3,casttype=RawContractMessage" json:"msg,omitempty"`
+}
+
+func (m *BlockSudoHook) Reset()         { *m = BlockSudoHook{} }
+func (m *BlockSudoHook) String() string { return proto.CompactTextString(m) }
+func (*BlockSudoHook) ProtoMessage()    {}
+func (*BlockSudoHook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{9}
+}
+
+func (m *BlockSudoHook) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *BlockSudoHook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockSudoHook.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *BlockSudoHook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSudoHook.Merge(m, src)
+}
+
+func (m *BlockSudoHook) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *BlockSudoHook) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSudoHook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSudoHook proto.InternalMessageInfo
+
+// BlockSudoHooks ordered list of hooks for a block phase
+type BlockSudoHooks struct {
+	Hooks []BlockSudoHook `protobuf:"bytes,1,rep,name=hooks,proto3" json:"hooks"`
+}
+
+func (m *BlockSudoHooks) Reset()         { *m = BlockSudoHooks{} }
+func (m *BlockSudoHooks) String() string { return proto.CompactTextString(m) }
+func (*BlockSudoHooks) ProtoMessage()    {}
+func (*BlockSudoHooks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{10}
+}
+
+func (m *BlockSudoHooks) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *BlockSudoHooks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockSudoHooks.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *BlockSudoHooks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockSudoHooks.Merge(m, src)
+}
+
+func (m *BlockSudoHooks) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *BlockSudoHooks) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockSudoHooks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockSudoHooks proto.InternalMessageInfo
+
+// MigrationCheckpoint records a backup of the contract state that was taken
+// before a migration
+type MigrationCheckpoint struct {
+	// ID is unique and increasing per contract
+	ID uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// CodeID is the code the contract was running when the backup was taken
+	CodeID uint64 `protobuf:"varint,2,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	// Created is the tx position when the backup was taken
+	Created *AbsoluteTxPosition `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+}
+
+func (m *MigrationCheckpoint) Reset()         { *m = MigrationCheckpoint{} }
+func (m *MigrationCheckpoint) String() string { return proto.CompactTextString(m) }
+func (*MigrationCheckpoint) ProtoMessage()    {}
+func (*MigrationCheckpoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{11}
+}
+
+func (m *MigrationCheckpoint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *MigrationCheckpoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MigrationCheckpoint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *MigrationCheckpoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigrationCheckpoint.Merge(m, src)
+}
+
+func (m *MigrationCheckpoint) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *MigrationCheckpoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigrationCheckpoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigrationCheckpoint proto.InternalMessageInfo
+
+// CodeDeposit is the deposit escrowed for a stored code
+type CodeDeposit struct {
+	CodeID uint64 `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	// Depositor is the address that paid the deposit and receives the refund
+	Depositor string `protobuf:"bytes,2,opt,name=depositor,proto3" json:"depositor,omitempty"`
+	// Amount is the escrowed deposit
+	Amount github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,3,rep,name=amount,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"amount"`
+}
+
+func (m *CodeDeposit) Reset()         { *m = CodeDeposit{} }
+func (m *CodeDeposit) String() string { return proto.CompactTextString(m) }
+func (*CodeDeposit) ProtoMessage()    {}
+func (*CodeDeposit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{12}
+}
+
+func (m *CodeDeposit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *CodeDeposit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CodeDeposit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *CodeDeposit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeDeposit.Merge(m, src)
+}
+
+func (m *CodeDeposit) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *CodeDeposit) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeDeposit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeDeposit proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("cosmwasm.wasm.v1.AccessType", AccessType_name, AccessType_value)
 	proto.RegisterEnum("cosmwasm.wasm.v1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.BlockSudoPhase", BlockSudoPhase_name, BlockSudoPhase_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.StateImportMode", StateImportMode_name, StateImportMode_value)
 	proto.RegisterType((*AccessTypeParam)(nil), "cosmwasm.wasm.v1.AccessTypeParam")
 	proto.RegisterType((*AccessConfig)(nil), "cosmwasm.wasm.v1.AccessConfig")
 	proto.RegisterType((*Params)(nil), "cosmwasm.wasm.v1.Params")
@@ -487,89 +916,191 @@ func init() {
 	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1.ContractCodeHistoryEntry")
 	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1.AbsoluteTxPosition")
 	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1.Model")
+	proto.RegisterType((*GasMultiplier)(nil), "cosmwasm.wasm.v1.GasMultiplier")
+	proto.RegisterType((*BlockSudoHook)(nil), "cosmwasm.wasm.v1.BlockSudoHook")
+	proto.RegisterType((*BlockSudoHooks)(nil), "cosmwasm.wasm.v1.BlockSudoHooks")
+	proto.RegisterType((*MigrationCheckpoint)(nil), "cosmwasm.wasm.v1.MigrationCheckpoint")
+	proto.RegisterType((*CodeDeposit)(nil), "cosmwasm.wasm.v1.CodeDeposit")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/types.proto", fileDescriptor_e6155d98fa173e02) }
 
 var fileDescriptor_e6155d98fa173e02 = []byte{
-	// 1221 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcd, 0x6f, 0x1b, 0x45,
-	0x14, 0xf7, 0xda, 0x4e, 0x62, 0x4f, 0x4c, 0xd9, 0x0e, 0xa9, 0xea, 0x98, 0xc8, 0x36, 0x4b, 0x09,
-	0x6d, 0xda, 0xda, 0xad, 0x41, 0x15, 0xea, 0xa1, 0x92, 0x3f, 0xb6, 0xcd, 0x56, 0x8a, 0x6d, 0xad,
-	0x5d, 0x4a, 0x90, 0xca, 0x6a, 0x3f, 0xc6, 0xce, 0x50, 0x7b, 0xc7, 0xda, 0x19, 0xa7, 0xf6, 0x7f,
-	0x80, 0x8c, 0x90, 0x38, 0x70, 0x40, 0x48, 0x96, 0x90, 0x40, 0xd0, 0x63, 0x0f, 0xfd, 0x07, 0xb8,
-	0x55, 0x9c, 0x2a, 0x4e, 0x9c, 0x2c, 0x70, 0x0f, 0xe5, 0x9c, 0x03, 0x87, 0x9e, 0xd0, 0xce, 0xd8,
-	0xf5, 0x8a, 0x7e, 0xc4, 0x70, 0x59, 0xcd, 0xbc, 0xf7, 0x7e, 0xbf, 0x79, 0xef, 0xf7, 0x66, 0xdf,
-	0x2e, 0xd8, 0xb2, 0x09, 0xed, 0xde, 0x33, 0x69, 0x37, 0xcf, 0x1f, 0x87, 0x97, 0xf3, 0x6c, 0xd8,
-	0x43, 0x34, 0xd7, 0xf3, 0x08, 0x23, 0x50, 0x9e, 0x7b, 0x73, 0xfc, 0x71, 0x78, 0x39, 0xb5, 0xe9,
-	0x5b, 0x08, 0x35, 0xb8, 0x3f, 0x2f, 0x36, 0x22, 0x38, 0xb5, 0xd1, 0x26, 0x6d, 0x22, 0xec, 0xfe,
-	0x6a, 0x66, 0xdd, 0x6c, 0x13, 0xd2, 0xee, 0xa0, 0x3c, 0xdf, 0x59, 0xfd, 0x56, 0xde, 0x74, 0x87,
-	0x33, 0xd7, 0x49, 0xb3, 0x8b, 0x5d, 0x92, 0xe7, 0x4f, 0x61, 0x52, 0xee, 0x80, 0x37, 0x8b, 0xb6,
-	0x8d, 0x28, 0x6d, 0x0e, 0x7b, 0xa8, 0x6e, 0x7a, 0x66, 0x17, 0x56, 0xc0, 0xca, 0xa1, 0xd9, 0xe9,
-	0xa3, 0xa4, 0x94, 0x95, 0xce, 0x9e, 0x28, 0x6c, 0xe5, 0xfe, 0x9d, 0x53, 0x6e, 0x81, 0x28, 0xc9,
-	0x47, 0x93, 0x4c, 0x62, 0x68, 0x76, 0x3b, 0x57, 0x15, 0x0e, 0x52, 0x74, 0x01, 0xbe, 0x1a, 0xfd,
-	0xf6, 0xfb, 0x8c, 0xa4, 0xfc, 0x2c, 0x81, 0x84, 0x88, 0x2e, 0x13, 0xb7, 0x85, 0xdb, 0xb0, 0x01,
-	0x40, 0x0f, 0x79, 0x5d, 0x4c, 0x29, 0x26, 0xee, 0x52, 0x27, 0x9c, 0x3a, 0x9a, 0x64, 0x4e, 0x8a,
-	0x13, 0x16, 0x48, 0x45, 0x0f, 0xd0, 0xc0, 0x2b, 0x20, 0x6e, 0x3a, 0x8e, 0x87, 0x28, 0x45, 0x34,
-	0x19, 0xc9, 0x46, 0xce, 0xc6, 0x4b, 0xc9, 0xdf, 0x1e, 0x5e, 0xdc, 0x98, 0xa9, 0x55, 0x14, 0xbe,
-	0x06, 0xf3, 0xb0, 0xdb, 0xd6, 0x17, 0xa1, 0x22, 0xc7, 0x9b, 0xd1, 0x58, 0x58, 0x8e, 0x28, 0xdf,
-	0x84, 0xc1, 0x2a, 0xaf, 0x9f, 0x42, 0x06, 0xa0, 0x4d, 0x1c, 0x64, 0xf4, 0x7b, 0x1d, 0x62, 0x3a,
-	0x86, 0xc9, 0x73, 0xe1, 0xb9, 0xae, 0x17, 0xd2, 0xaf, 0xca, 0x55, 0xd4, 0x57, 0xda, 0x7e, 0x34,
-	0xc9, 0x84, 0x8e, 0x26, 0x99, 0x4d, 0x91, 0xf1, 0x8b, 0x3c, 0xca, 0xfd, 0xa7, 0x0f, 0x76, 0x24,
-	0x5d, 0xf6, 0x3d, 0xb7, 0xb8, 0x43, 0xe0, 0xe1, 0x57, 0x12, 0x48, 0x63, 0x97, 0x32, 0xd3, 0x65,
-	0xd8, 0x64, 0xc8, 0x70, 0x50, 0xcb, 0xec, 0x77, 0x98, 0x11, 0x90, 0x2b, 0xbc, 0x84, 0x5c, 0xe7,
-	0x8e, 0x26, 0x99, 0xf7, 0xc4, 0xe1, 0xaf, 0x67, 0x53, 0xf4, 0xad, 0x40, 0x40, 0x45, 0xf8, 0xeb,
-	0xcf, 0xdd, 0x5c, 0x9c, 0x90, 0xf2, 0x8b, 0x04, 0x62, 0x65, 0xe2, 0x20, 0xcd, 0x6d, 0x11, 0xf8,
-	0x36, 0x88, 0xf3, 0x82, 0x0e, 0x4c, 0x7a, 0xc0, 0xf5, 0x48, 0xe8, 0x31, 0xdf, 0xb0, 0x6b, 0xd2,
-	0x03, 0x58, 0x00, 0x6b, 0xb6, 0x87, 0x4c, 0x46, 0x3c, 0x9e, 0xe7, 0xeb, 0x5a, 0x30, 0x0f, 0x84,
-	0x9f, 0x00, 0x18, 0x4c, 0xd2, 0xe6, 0x1a, 0x26, 0x57, 0x96, 0x52, 0x3a, 0xee, 0x2b, 0x2d, 0xc4,
-	0x3c, 0x19, 0x20, 0x11, 0xde, 0x9b, 0xd1, 0x58, 0x44, 0x8e, 0xde, 0x8c, 0xc6, 0xa2, 0xf2, 0x8a,
-	0xf2, 0x30, 0x02, 0x12, 0x65, 0xe2, 0x32, 0xcf, 0xb4, 0x19, 0xaf, 0xe3, 0x5d, 0xb0, 0xc6, 0xeb,
-	0xc0, 0x0e, 0xaf, 0x22, 0x5a, 0x02, 0xd3, 0x49, 0x66, 0x95, 0x97, 0x59, 0xd1, 0x57, 0x7d, 0x97,
-	0xe6, 0xfc, 0xaf, 0x7a, 0x72, 0x60, 0xc5, 0x74, 0xba, 0xd8, 0x4d, 0x46, 0x8e, 0x41, 0x88, 0x30,
-	0xb8, 0x01, 0x56, 0x3a, 0xa6, 0x85, 0x3a, 0xc9, 0xa8, 0x1f, 0xaf, 0x8b, 0x0d, 0xbc, 0x36, 0x3b,
-	0x19, 0x39, 0x33, 0x29, 0xce, 0xbc, 0x44, 0x0a, 0x8b, 0x92, 0x4e, 0x9f, 0xa1, 0xe6, 0xa0, 0x4e,
-	0x28, 0x66, 0x98, 0xb8, 0xfa, 0x1c, 0x04, 0x2f, 0x82, 0x75, 0x6c, 0xd9, 0x46, 0x8f, 0x78, 0xcc,
-	0x2f, 0x71, 0x95, 0xe7, 0xf2, 0xc6, 0x74, 0x92, 0x89, 0x6b, 0xa5, 0x72, 0x9d, 0x78, 0x4c, 0xab,
-	0xe8, 0x71, 0x6c, 0xd9, 0x7c, 0xe9, 0xc0, 0x4b, 0x20, 0x81, 0x2d, 0xbb, 0xf0, 0x3c, 0x7e, 0x8d,
-	0xc7, 0x9f, 0x98, 0x4e, 0x32, 0x40, 0x2b, 0x95, 0x0b, 0x33, 0x00, 0xf0, 0x63, 0x66, 0x88, 0xcf,
-	0x40, 0x1c, 0x0d, 0x18, 0x72, 0xf9, 0xa5, 0x8c, 0xf1, 0x14, 0x37, 0x72, 0x62, 0xec, 0xe4, 0xe6,
-	0x63, 0x27, 0x57, 0x74, 0x87, 0xa5, 0x9d, 0x5f, 0x1f, 0x5e, 0xdc, 0x7e, 0x21, 0xf7, 0x60, 0x2f,
-	0xd4, 0x39, 0x8f, 0xbe, 0xa0, 0xbc, 0x1a, 0xfd, 0xcb, 0x9f, 0x1d, 0x5f, 0x86, 0x41, 0x72, 0x1e,
-	0xea, 0xf7, 0x66, 0x17, 0x53, 0x46, 0xbc, 0xa1, 0xea, 0x32, 0x6f, 0x08, 0xeb, 0x20, 0x4e, 0x7a,
-	0xc8, 0x33, 0xd9, 0x62, 0x8c, 0x14, 0x72, 0xaf, 0x3c, 0x29, 0x00, 0xaf, 0xcd, 0x51, 0xfe, 0xdb,
-	0xa2, 0x2f, 0x48, 0x82, 0x97, 0x22, 0xfc, 0xca, 0x4b, 0x71, 0x0d, 0xac, 0xf5, 0x7b, 0x0e, 0x6f,
-	0x4d, 0xe4, 0xbf, 0xb4, 0x66, 0x06, 0x82, 0x1f, 0x81, 0x48, 0x97, 0xb6, 0x79, 0xbb, 0x13, 0xa5,
-	0xed, 0x67, 0x93, 0x0c, 0xd4, 0xcd, 0x7b, 0xf3, 0x2c, 0xf7, 0x10, 0xa5, 0x66, 0x1b, 0x7d, 0xf7,
-	0xf4, 0xc1, 0xce, 0x3a, 0x76, 0x3b, 0xd8, 0x45, 0xc6, 0xe7, 0x94, 0xb8, 0xba, 0x0f, 0x51, 0x74,
-	0x00, 0x5f, 0x24, 0x86, 0xef, 0x80, 0x84, 0xd5, 0x21, 0xf6, 0x5d, 0xe3, 0x00, 0xe1, 0xf6, 0x01,
-	0x13, 0xd7, 0x59, 0x5f, 0xe7, 0xb6, 0x5d, 0x6e, 0x82, 0x9b, 0x20, 0xc6, 0x06, 0x06, 0x76, 0x1d,
-	0x34, 0x10, 0x85, 0xe9, 0x6b, 0x6c, 0xa0, 0xf9, 0x5b, 0x05, 0x81, 0x95, 0x3d, 0xe2, 0xa0, 0x0e,
-	0xbc, 0x0e, 0x22, 0x77, 0xd1, 0x50, 0xbc, 0xd2, 0xa5, 0x0f, 0x9f, 0x4d, 0x32, 0x97, 0xda, 0x98,
-	0x1d, 0xf4, 0xad, 0x9c, 0x4d, 0xba, 0x79, 0x9b, 0x74, 0x11, 0xb3, 0x5a, 0x6c, 0xb1, 0xe8, 0x60,
-	0x8b, 0xe6, 0xad, 0x21, 0x43, 0x34, 0xb7, 0x8b, 0x06, 0x25, 0x7f, 0xa1, 0xfb, 0x04, 0xfe, 0x7d,
-	0x16, 0x9f, 0x8e, 0x30, 0x1f, 0x0e, 0x62, 0xb3, 0xf3, 0xb7, 0x04, 0xc0, 0x62, 0x42, 0xc1, 0x2b,
-	0xe0, 0x74, 0xb1, 0x5c, 0x56, 0x1b, 0x0d, 0xa3, 0xb9, 0x5f, 0x57, 0x8d, 0x5b, 0xd5, 0x46, 0x5d,
-	0x2d, 0x6b, 0xd7, 0x35, 0xb5, 0x22, 0x87, 0x52, 0x9b, 0xa3, 0x71, 0xf6, 0xd4, 0x22, 0xf8, 0x96,
-	0x4b, 0x7b, 0xc8, 0xc6, 0x2d, 0x8c, 0x1c, 0x78, 0x01, 0xc0, 0x20, 0xae, 0x5a, 0x2b, 0xd5, 0x2a,
-	0xfb, 0xb2, 0x94, 0xda, 0x18, 0x8d, 0xb3, 0xf2, 0x02, 0x52, 0x25, 0x16, 0x71, 0x86, 0xb0, 0x00,
-	0x4e, 0x05, 0xa3, 0xd5, 0x8f, 0x55, 0x7d, 0x9f, 0x03, 0x22, 0xa9, 0xd3, 0xa3, 0x71, 0xf6, 0xad,
-	0x05, 0x40, 0x3d, 0x44, 0xde, 0x90, 0x63, 0xae, 0x81, 0xad, 0x20, 0xa6, 0x58, 0xdd, 0x37, 0x6a,
-	0xd7, 0x8d, 0x62, 0xa5, 0xa2, 0xab, 0x8d, 0x86, 0xda, 0x90, 0xa3, 0xa9, 0xad, 0xd1, 0x38, 0x9b,
-	0x5c, 0x40, 0x8b, 0xee, 0xb0, 0xd6, 0x2a, 0xce, 0xbf, 0x27, 0xa9, 0xd8, 0x17, 0x3f, 0xa4, 0x43,
-	0xf7, 0x7f, 0x4c, 0x87, 0x14, 0xff, 0x9b, 0x12, 0xde, 0xf9, 0x29, 0x02, 0xb2, 0xc7, 0x5d, 0x41,
-	0x88, 0xc0, 0xa5, 0x72, 0xad, 0xda, 0xd4, 0x8b, 0xe5, 0xa6, 0x51, 0xae, 0x55, 0x54, 0x63, 0x57,
-	0x6b, 0x34, 0x6b, 0xfa, 0xbe, 0x51, 0xab, 0xab, 0x7a, 0xb1, 0xa9, 0xd5, 0xaa, 0x2f, 0xd3, 0x29,
-	0x3f, 0x1a, 0x67, 0xcf, 0x1f, 0xc7, 0x1d, 0x54, 0xef, 0x36, 0x38, 0xb7, 0xd4, 0x31, 0x5a, 0x55,
-	0x6b, 0xca, 0x52, 0xea, 0xec, 0x68, 0x9c, 0x3d, 0x73, 0x1c, 0xbf, 0xe6, 0x62, 0x06, 0xef, 0x80,
-	0x0b, 0x4b, 0x11, 0xef, 0x69, 0x37, 0xf4, 0x62, 0x53, 0x95, 0xc3, 0xa9, 0xf3, 0xa3, 0x71, 0xf6,
-	0xfd, 0xe3, 0xb8, 0xf7, 0x70, 0xdb, 0x33, 0x19, 0x5a, 0x9a, 0xfe, 0x86, 0x5a, 0x55, 0x1b, 0x5a,
-	0x43, 0x8e, 0x2c, 0x47, 0x7f, 0x03, 0xb9, 0x88, 0x62, 0x9a, 0x8a, 0xfa, 0x2d, 0x2b, 0xed, 0x3e,
-	0xfa, 0x33, 0x1d, 0xba, 0x3f, 0x4d, 0x4b, 0x8f, 0xa6, 0x69, 0xe9, 0xf1, 0x34, 0x2d, 0xfd, 0x31,
-	0x4d, 0x4b, 0x5f, 0x3f, 0x49, 0x87, 0x1e, 0x3f, 0x49, 0x87, 0x7e, 0x7f, 0x92, 0x0e, 0x7d, 0xba,
-	0x1d, 0x78, 0x21, 0xca, 0x84, 0x76, 0x6f, 0xcf, 0xff, 0xe0, 0x9c, 0xfc, 0x40, 0xfc, 0xc9, 0xf1,
-	0xdf, 0x38, 0x6b, 0x95, 0xcf, 0xbf, 0x0f, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x13, 0xdb, 0xf4,
-	0x60, 0xe7, 0x09, 0x00, 0x00,
+	// 2770 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x59, 0xcf, 0x6f, 0x1b, 0xc7,
+	0xf5, 0x17, 0x45, 0x4a, 0x26, 0x47, 0xb2, 0x4d, 0x8d, 0xf5, 0x63, 0x45, 0xc9, 0x5c, 0x7a, 0xed,
+	0x38, 0x8a, 0x13, 0x53, 0xb1, 0xbe, 0x49, 0xf0, 0xad, 0x81, 0x3a, 0xe5, 0x2f, 0x4b, 0x74, 0x2d,
+	0x91, 0x19, 0xd2, 0x71, 0x1d, 0x34, 0xd9, 0x2e, 0x77, 0x47, 0xe4, 0xc6, 0xbb, 0x3b, 0xcc, 0xce,
+	0x52, 0x26, 0x73, 0xe9, 0xa9, 0x40, 0xa1, 0xa2, 0x40, 0xd1, 0x53, 0x51, 0x40, 0x40, 0x8b, 0x16,
+	0x45, 0xd0, 0x53, 0x0e, 0x41, 0xff, 0x86, 0xa0, 0xa7, 0xa0, 0xed, 0xa1, 0x27, 0xb6, 0x55, 0x0e,
+	0xe9, 0xb5, 0x3c, 0xf4, 0x90, 0x53, 0x31, 0x33, 0xbb, 0xe2, 0x8a, 0xa2, 0x7e, 0x24, 0x17, 0x99,
+	0xfb, 0xde, 0xe7, 0x7d, 0x66, 0xe6, 0xcd, 0x7b, 0x6f, 0xde, 0x8c, 0xc1, 0xaa, 0x4e, 0xa8, 0xfd,
+	0x42, 0xa3, 0xf6, 0x3a, 0xff, 0xb3, 0x77, 0x6f, 0xdd, 0xeb, 0xb5, 0x31, 0xcd, 0xb6, 0x5d, 0xe2,
+	0x11, 0x98, 0x0c, 0xb4, 0x59, 0xfe, 0x67, 0xef, 0x5e, 0x6a, 0x99, 0x49, 0x08, 0x55, 0xb9, 0x7e,
+	0x5d, 0x7c, 0x08, 0x70, 0x6a, 0xbe, 0x49, 0x9a, 0x44, 0xc8, 0xd9, 0x2f, 0x5f, 0xba, 0xdc, 0x24,
+	0xa4, 0x69, 0xe1, 0x75, 0xfe, 0xd5, 0xe8, 0xec, 0xae, 0x6b, 0x4e, 0xcf, 0x57, 0xcd, 0x69, 0xb6,
+	0xe9, 0x90, 0x75, 0xfe, 0xd7, 0x17, 0xa5, 0x05, 0xe3, 0x7a, 0x43, 0xa3, 0x78, 0x7d, 0xef, 0x5e,
+	0x03, 0x7b, 0xda, 0xbd, 0x75, 0x9d, 0x98, 0x8e, 0xd0, 0x2b, 0xef, 0x83, 0xab, 0x39, 0x5d, 0xc7,
+	0x94, 0xd6, 0x7b, 0x6d, 0x5c, 0xd5, 0x5c, 0xcd, 0x86, 0x45, 0x30, 0xb5, 0xa7, 0x59, 0x1d, 0x2c,
+	0x45, 0x32, 0x91, 0xb5, 0x2b, 0x1b, 0xab, 0xd9, 0xd1, 0x39, 0x67, 0x87, 0x16, 0xf9, 0xe4, 0xa0,
+	0x2f, 0xcf, 0xf6, 0x34, 0xdb, 0xba, 0xaf, 0x70, 0x23, 0x05, 0x09, 0xe3, 0xfb, 0xb1, 0x5f, 0xfd,
+	0x46, 0x8e, 0x28, 0x87, 0x11, 0x30, 0x2b, 0xd0, 0x05, 0xe2, 0xec, 0x9a, 0x4d, 0x58, 0x03, 0xa0,
+	0x8d, 0x5d, 0xdb, 0xa4, 0xd4, 0x24, 0xce, 0x85, 0x46, 0x58, 0x18, 0xf4, 0xe5, 0x39, 0x31, 0xc2,
+	0xd0, 0x52, 0x41, 0x21, 0x1a, 0xf8, 0x16, 0x48, 0x68, 0x86, 0xe1, 0x62, 0x4a, 0x31, 0x95, 0xa2,
+	0x99, 0xe8, 0x5a, 0x22, 0x2f, 0xfd, 0xe5, 0xb3, 0xbb, 0xf3, 0xbe, 0x37, 0x73, 0x42, 0x57, 0xf3,
+	0x5c, 0xd3, 0x69, 0xa2, 0x21, 0x14, 0x7e, 0x07, 0x2c, 0xdb, 0x5a, 0x57, 0x35, 0x1d, 0xea, 0x69,
+	0x8e, 0x8e, 0xa9, 0xda, 0xc6, 0xae, 0xea, 0xab, 0xa5, 0x58, 0x26, 0xb2, 0x16, 0x43, 0x8b, 0xb6,
+	0xd6, 0x2d, 0x07, 0xfa, 0x2a, 0x76, 0x7d, 0x2e, 0xb1, 0xbc, 0x47, 0xb1, 0xf8, 0x64, 0x32, 0xaa,
+	0xfc, 0x49, 0x02, 0xd3, 0xdc, 0x75, 0x14, 0x7a, 0x00, 0xea, 0xc4, 0xc0, 0x6a, 0xa7, 0x6d, 0x11,
+	0xcd, 0x50, 0x35, 0xbe, 0x0c, 0xbe, 0xcc, 0x99, 0x8d, 0xf4, 0x69, 0xcb, 0x14, 0xae, 0xc9, 0xdf,
+	0xfe, 0xbc, 0x2f, 0x4f, 0x0c, 0xfa, 0xf2, 0xb2, 0x58, 0xec, 0x49, 0x1e, 0xe5, 0x93, 0xaf, 0x3e,
+	0xbd, 0x13, 0x41, 0x49, 0xa6, 0x79, 0xc2, 0x15, 0xc2, 0x1e, 0xfe, 0x3c, 0x02, 0xd2, 0x62, 0x11,
+	0x9e, 0xa9, 0x79, 0x58, 0x35, 0xf0, 0xae, 0xd6, 0xb1, 0x3c, 0x35, 0xe4, 0xe9, 0xc9, 0x0b, 0x78,
+	0xfa, 0x95, 0x41, 0x5f, 0x7e, 0x49, 0x0c, 0x7e, 0x36, 0x9b, 0x82, 0x56, 0x43, 0x80, 0xa2, 0xd0,
+	0x57, 0x87, 0xfb, 0xf1, 0x23, 0xe1, 0x57, 0xdb, 0x6c, 0xba, 0x9a, 0x67, 0x12, 0x47, 0xd5, 0x5b,
+	0x58, 0x7f, 0xde, 0x26, 0xa6, 0xe3, 0xb1, 0xfd, 0x89, 0xac, 0xc5, 0xf2, 0xb7, 0x06, 0x7d, 0x39,
+	0x23, 0xc6, 0x3a, 0x15, 0xaa, 0xa0, 0x25, 0x5b, 0xeb, 0x6e, 0x07, 0xaa, 0xc2, 0x50, 0x03, 0x1b,
+	0x20, 0x35, 0xdc, 0x39, 0x3e, 0x0b, 0xb1, 0x79, 0x0d, 0x8b, 0xe8, 0xcf, 0xc5, 0xd6, 0xe5, 0x5f,
+	0x1a, 0xf4, 0xe5, 0x1b, 0xc3, 0x21, 0xc6, 0x63, 0xc5, 0x18, 0xe5, 0x90, 0xae, 0x8a, 0xdd, 0x3c,
+	0xd3, 0xb0, 0x55, 0xe8, 0xa4, 0xe3, 0x78, 0x2a, 0xed, 0x34, 0x6c, 0xda, 0x3c, 0x46, 0x20, 0x4d,
+	0x65, 0x22, 0x6b, 0xf1, 0xf0, 0x2a, 0x4e, 0x85, 0x2a, 0x68, 0x89, 0xeb, 0x6a, 0x5c, 0x15, 0x1e,
+	0x09, 0x3e, 0x05, 0x8b, 0x2d, 0x93, 0x7a, 0xc4, 0x35, 0x75, 0xcd, 0x52, 0x3f, 0xea, 0x60, 0xb7,
+	0xa7, 0x1a, 0xb8, 0xed, 0xb5, 0xa4, 0x69, 0xbe, 0x82, 0x1b, 0x83, 0xbe, 0x7c, 0x5d, 0xd0, 0x8f,
+	0xc7, 0x29, 0x68, 0x7e, 0xa8, 0x78, 0x87, 0xc9, 0x8b, 0x4c, 0x0c, 0xab, 0x60, 0x5e, 0xeb, 0x78,
+	0x44, 0x6d, 0x9b, 0x8e, 0xca, 0xe3, 0xa8, 0xa5, 0xd1, 0x16, 0xa6, 0xd2, 0x25, 0x9e, 0x1b, 0xf2,
+	0xa0, 0x2f, 0xaf, 0x08, 0xda, 0x71, 0x28, 0x05, 0xcd, 0x31, 0x71, 0xd5, 0x74, 0x0a, 0xc4, 0xc0,
+	0x5b, 0x5c, 0x06, 0x55, 0xb1, 0xa5, 0x62, 0x6c, 0x17, 0xeb, 0x1d, 0x97, 0xed, 0xb4, 0x3f, 0xdb,
+	0xf8, 0xb8, 0x2d, 0x1d, 0x0b, 0x55, 0x78, 0x42, 0xf1, 0x99, 0xa2, 0x40, 0x23, 0xa6, 0xbc, 0x09,
+	0xe6, 0x98, 0x15, 0xed, 0x34, 0x7c, 0xcb, 0xa6, 0x46, 0xa5, 0x04, 0x27, 0x5e, 0x1d, 0xf4, 0x65,
+	0x69, 0x48, 0x7c, 0x0c, 0xa2, 0xa0, 0x2b, 0xb6, 0xd6, 0xad, 0x75, 0x1a, 0x9c, 0x73, 0x53, 0xa3,
+	0xd0, 0x06, 0x69, 0x86, 0x62, 0xf1, 0xcd, 0xf7, 0xc1, 0xed, 0xe8, 0x2c, 0x7a, 0xc4, 0x9e, 0xeb,
+	0x9a, 0x65, 0x49, 0x80, 0xb3, 0x86, 0xa2, 0xfd, 0x6c, 0xbc, 0x82, 0x58, 0xac, 0x3d, 0xd5, 0xa8,
+	0x5d, 0x0e, 0xa9, 0xab, 0xd8, 0x2d, 0x68, 0x96, 0x05, 0x7f, 0x08, 0x24, 0x6c, 0x9b, 0x9e, 0x4a,
+	0x3d, 0x96, 0x2b, 0x7a, 0x4b, 0x73, 0x9a, 0x58, 0xc5, 0x7b, 0x98, 0x85, 0xfa, 0x0c, 0x0f, 0x92,
+	0x9b, 0x83, 0xbe, 0x2c, 0x8b, 0x81, 0x4e, 0x43, 0x2a, 0x68, 0x81, 0xa9, 0x6a, 0x4c, 0x53, 0xe0,
+	0x8a, 0x12, 0x97, 0x43, 0x13, 0xac, 0xba, 0x58, 0x27, 0xae, 0xa1, 0xea, 0xc4, 0xf1, 0x5c, 0x4d,
+	0xf7, 0x98, 0x1f, 0xb1, 0x63, 0x60, 0x47, 0x37, 0x31, 0x95, 0x66, 0xf9, 0x08, 0x2f, 0x0f, 0xfa,
+	0xf2, 0x4d, 0x31, 0xc2, 0x59, 0x68, 0x05, 0xa5, 0x84, 0xba, 0xe0, 0x6b, 0x8b, 0x21, 0x25, 0x8b,
+	0x19, 0xe6, 0x07, 0xdc, 0xc5, 0x7a, 0xc7, 0xc3, 0x2a, 0x0b, 0x63, 0x6a, 0x7e, 0x8c, 0xa5, 0xcb,
+	0xdc, 0x5b, 0xa1, 0x98, 0x19, 0x87, 0x52, 0x10, 0xdb, 0xbd, 0x92, 0x90, 0x6e, 0xd3, 0x66, 0xcd,
+	0xfc, 0x18, 0xc3, 0x27, 0x60, 0xc1, 0x30, 0xa9, 0xd6, 0xb0, 0xb0, 0xa1, 0xea, 0x5a, 0x5b, 0x6b,
+	0x98, 0x96, 0xe9, 0xb1, 0x59, 0x5f, 0xe1, 0x61, 0x98, 0x19, 0xf4, 0xe5, 0x55, 0x41, 0x39, 0x16,
+	0xa6, 0xa0, 0xf9, 0x40, 0x5e, 0x08, 0x89, 0x8f, 0x3c, 0xee, 0x6a, 0x2f, 0x86, 0xeb, 0xf4, 0x3d,
+	0x7e, 0x75, 0xac, 0xc7, 0xc7, 0x20, 0x7d, 0x8f, 0x23, 0xed, 0x45, 0xe0, 0x0c, 0xdf, 0xe3, 0x4d,
+	0x30, 0x6f, 0x36, 0x74, 0x95, 0x32, 0xc7, 0xb8, 0xaa, 0x66, 0x59, 0xe4, 0x85, 0x65, 0x52, 0x4f,
+	0x4a, 0xf2, 0x39, 0xbf, 0x79, 0xd8, 0x97, 0x61, 0x39, 0x5f, 0xa8, 0x71, 0x75, 0x2e, 0xd0, 0x0e,
+	0x9d, 0x33, 0xce, 0x56, 0x41, 0xd0, 0x6c, 0xe8, 0x23, 0x26, 0xf0, 0x6d, 0xc0, 0x22, 0x97, 0x47,
+	0x98, 0x9f, 0x46, 0x73, 0x99, 0xc8, 0xda, 0xe5, 0xfc, 0xf2, 0xa0, 0x2f, 0x2f, 0x0c, 0x3d, 0x3d,
+	0xd4, 0x2b, 0x68, 0xd6, 0xd6, 0xba, 0x2c, 0xe8, 0x44, 0xc6, 0xbc, 0x07, 0x96, 0x5c, 0xfc, 0x21,
+	0xd6, 0x3d, 0x75, 0xd7, 0x22, 0x9a, 0xa7, 0x92, 0x36, 0x16, 0x85, 0x92, 0x4a, 0x90, 0xbb, 0x41,
+	0x19, 0xf4, 0xe5, 0x74, 0x10, 0x16, 0x63, 0x81, 0x0a, 0x5a, 0x10, 0x9a, 0x87, 0x4c, 0x51, 0x39,
+	0x92, 0xc3, 0x3c, 0xb8, 0xba, 0x4b, 0xdc, 0x17, 0x9a, 0x6b, 0xa8, 0x5e, 0x57, 0xb5, 0xb1, 0x4d,
+	0xa4, 0x6b, 0x9c, 0x33, 0x35, 0xe8, 0xcb, 0x8b, 0x82, 0x73, 0x04, 0xa0, 0xa0, 0xcb, 0xbe, 0xa4,
+	0xde, 0xdd, 0xc6, 0x36, 0x81, 0x1f, 0x80, 0xe5, 0x20, 0x5d, 0x6d, 0x4c, 0xa9, 0xd6, 0xc4, 0xa1,
+	0x1c, 0x9c, 0xe7, 0x6b, 0x1d, 0x29, 0x19, 0x63, 0xa1, 0x0a, 0x5a, 0x10, 0x19, 0xbe, 0xed, 0x6b,
+	0x82, 0xcc, 0xdb, 0x02, 0x73, 0x6c, 0x5c, 0xb7, 0xa7, 0xea, 0x9a, 0xde, 0xc2, 0x22, 0x5a, 0x17,
+	0x38, 0x6f, 0xb8, 0x62, 0x8c, 0x42, 0x14, 0x74, 0x55, 0xc8, 0x0a, 0x4c, 0xc4, 0x03, 0xb5, 0x0e,
+	0x16, 0x8e, 0xc2, 0xc3, 0xc7, 0x5b, 0xa6, 0x6d, 0x7a, 0xd2, 0x22, 0x67, 0x0b, 0x05, 0xea, 0x58,
+	0x98, 0x82, 0xae, 0x05, 0xf2, 0x6d, 0x2e, 0x7e, 0xcc, 0xa4, 0xd0, 0x01, 0x69, 0xdf, 0xed, 0xec,
+	0x38, 0xc1, 0xa1, 0xa4, 0x64, 0xd5, 0x8b, 0xe5, 0xc1, 0x12, 0x77, 0x69, 0xa8, 0x10, 0x9d, 0x8d,
+	0x57, 0xd0, 0x8a, 0x00, 0x3c, 0xe6, 0xfa, 0x20, 0x70, 0xdf, 0x11, 0x5a, 0xf8, 0xdb, 0x08, 0x98,
+	0xe7, 0x65, 0x9c, 0x1d, 0x08, 0x5a, 0x93, 0x1d, 0xdc, 0x6d, 0x42, 0x4d, 0x4f, 0x92, 0x32, 0xd1,
+	0xb5, 0x99, 0x8d, 0xe5, 0xac, 0xdf, 0x0e, 0xb1, 0x56, 0x30, 0xeb, 0xb7, 0x82, 0xd9, 0x02, 0x31,
+	0x9d, 0x7c, 0xdd, 0xef, 0x3c, 0x56, 0x42, 0x9d, 0xc7, 0x08, 0x89, 0xf2, 0xc7, 0x7f, 0xc8, 0x6b,
+	0x4d, 0xd3, 0x6b, 0x75, 0x1a, 0x59, 0x9d, 0xd8, 0x7e, 0xa3, 0xea, 0xff, 0x73, 0x97, 0x1a, 0xcf,
+	0xfd, 0x36, 0x97, 0xf1, 0x51, 0xd1, 0xa7, 0xf0, 0x4e, 0xa8, 0x26, 0x68, 0x8a, 0x82, 0x05, 0xea,
+	0x20, 0x75, 0x54, 0xa1, 0x0c, 0x1c, 0x3a, 0x27, 0x79, 0xd8, 0x2e, 0x73, 0x7f, 0x84, 0xce, 0xed,
+	0xd3, 0xb1, 0x0a, 0x92, 0x82, 0x5a, 0x66, 0xe0, 0xf2, 0x31, 0x15, 0xfc, 0x10, 0x5c, 0xf7, 0x6b,
+	0xac, 0x85, 0x35, 0xa7, 0xd3, 0x56, 0x5d, 0xbc, 0xdb, 0x71, 0x0c, 0x71, 0xe8, 0xf7, 0x3c, 0x2c,
+	0xa5, 0x78, 0x49, 0x5b, 0x1b, 0xf4, 0xe5, 0x5b, 0x62, 0x9c, 0x33, 0xe1, 0x0a, 0x5a, 0xe6, 0xfa,
+	0x82, 0x50, 0x23, 0xae, 0x65, 0x5d, 0x42, 0xcf, 0xc3, 0x6c, 0x93, 0xc7, 0x1a, 0x7b, 0x2d, 0x17,
+	0xd3, 0x16, 0xb1, 0x0c, 0x69, 0x65, 0xf4, 0xb4, 0x39, 0x1b, 0xaf, 0xa0, 0x95, 0x93, 0xa3, 0xd5,
+	0x03, 0x2d, 0x2b, 0x7e, 0x3c, 0x53, 0xc6, 0x70, 0x48, 0xab, 0x7c, 0xa4, 0x50, 0xf1, 0x3b, 0x0d,
+	0xe9, 0xa7, 0xd4, 0x89, 0x61, 0xe0, 0x1e, 0xb8, 0x81, 0x9d, 0x5d, 0xe2, 0xea, 0x58, 0xb5, 0xb4,
+	0x06, 0xb6, 0xd4, 0x8e, 0x63, 0x7e, 0xd4, 0xc1, 0x0e, 0xa6, 0x7e, 0x3e, 0x12, 0x03, 0x4b, 0xd7,
+	0xf9, 0x2e, 0xbd, 0x36, 0xe8, 0xcb, 0x6b, 0x62, 0x98, 0x73, 0x4d, 0x14, 0x74, 0xdd, 0xc7, 0x3c,
+	0x66, 0x90, 0x27, 0x47, 0x08, 0x96, 0xca, 0xc4, 0xc0, 0x70, 0x1b, 0x5c, 0xe3, 0xa7, 0x0a, 0x2f,
+	0xc1, 0xc3, 0x22, 0x91, 0xe6, 0xe9, 0x97, 0x1e, 0xf4, 0xe5, 0xd4, 0x70, 0x41, 0x23, 0x20, 0x05,
+	0x25, 0xd9, 0xc9, 0xc3, 0x85, 0x41, 0x65, 0xd8, 0x01, 0xd7, 0xfc, 0x4c, 0xa2, 0xd8, 0xda, 0x3d,
+	0x4a, 0x37, 0x99, 0x4f, 0x3c, 0x44, 0x37, 0x06, 0xa4, 0xa0, 0x39, 0x21, 0xad, 0x61, 0x6b, 0x37,
+	0xc8, 0x2c, 0xff, 0x68, 0xe4, 0x0d, 0xa3, 0x4a, 0x3b, 0x06, 0x51, 0x5b, 0x84, 0x3c, 0xa7, 0x52,
+	0x86, 0xcf, 0x6f, 0xe4, 0x68, 0x1c, 0x45, 0x89, 0xa3, 0x91, 0xb7, 0x94, 0xb5, 0x8e, 0x41, 0xb6,
+	0x98, 0x8c, 0x5f, 0x1f, 0x26, 0x94, 0x9f, 0x4c, 0x82, 0xb8, 0x88, 0xdf, 0x5d, 0x02, 0x57, 0x40,
+	0xe2, 0xa8, 0x09, 0xe3, 0x37, 0x86, 0x59, 0x14, 0xd7, 0xfd, 0x06, 0x0c, 0x6e, 0x80, 0x4b, 0xba,
+	0x8b, 0x35, 0x8f, 0xb8, 0xbc, 0x93, 0x3f, 0xeb, 0x7e, 0x13, 0x00, 0xe1, 0x0f, 0x00, 0x0c, 0xb7,
+	0xf1, 0x3a, 0xbf, 0x65, 0x48, 0x53, 0x17, 0xba, 0x8b, 0x24, 0x58, 0x45, 0x10, 0x69, 0x3c, 0x17,
+	0x22, 0x11, 0x5a, 0xb8, 0x08, 0xa6, 0x29, 0xe9, 0xb8, 0x3a, 0xe6, 0x7d, 0x6a, 0x02, 0xf9, 0x5f,
+	0x50, 0x02, 0x97, 0x1a, 0x1d, 0xd3, 0x32, 0xb0, 0x2b, 0x5d, 0xe2, 0x8a, 0xe0, 0xf3, 0x51, 0x2c,
+	0x1e, 0x4d, 0xc6, 0x1e, 0xc5, 0xe2, 0xb1, 0xe4, 0xd4, 0xa3, 0x58, 0x3c, 0x9e, 0x4c, 0x3c, 0x8a,
+	0xc5, 0x13, 0x49, 0xf0, 0x28, 0x16, 0x07, 0xc9, 0x19, 0xe5, 0x3f, 0x51, 0x30, 0x1b, 0x54, 0x33,
+	0xee, 0x8b, 0x9b, 0xe0, 0x92, 0xc8, 0x79, 0x83, 0x7b, 0x22, 0x96, 0x07, 0x87, 0x7d, 0x79, 0x9a,
+	0xbb, 0xaa, 0x88, 0xa6, 0x99, 0xaa, 0x6c, 0x7c, 0x2b, 0x9f, 0x64, 0xc1, 0x94, 0x66, 0xd8, 0xa6,
+	0x23, 0x45, 0xcf, 0xb1, 0x10, 0x30, 0x38, 0x0f, 0xa6, 0x78, 0x54, 0xf3, 0x2b, 0x45, 0x02, 0x89,
+	0x0f, 0xf8, 0xc0, 0x1f, 0x19, 0x1b, 0xbe, 0x3b, 0x6f, 0x8d, 0x71, 0x67, 0x83, 0x12, 0xab, 0xe3,
+	0xe1, 0x7a, 0xb7, 0xca, 0x2a, 0x9f, 0x49, 0x1c, 0x14, 0x18, 0xc1, 0xbb, 0x60, 0x86, 0xf5, 0x09,
+	0x6d, 0xe2, 0x7a, 0x6c, 0x89, 0xdc, 0x89, 0xf9, 0xcb, 0x87, 0x7d, 0x39, 0x51, 0xce, 0x17, 0xaa,
+	0xc4, 0xf5, 0xca, 0x45, 0x94, 0x30, 0x1b, 0x3a, 0xff, 0x69, 0xc0, 0xd7, 0xc1, 0xac, 0xd9, 0xd0,
+	0x37, 0x8e, 0xf0, 0xdc, 0xb7, 0xf9, 0x2b, 0x87, 0x7d, 0x19, 0x94, 0xf3, 0x85, 0x0d, 0xdf, 0x00,
+	0x30, 0x8c, 0x6f, 0xf1, 0x01, 0x48, 0xe0, 0xae, 0x87, 0x1d, 0x7e, 0xf5, 0x8b, 0xf3, 0x29, 0xce,
+	0x67, 0xc5, 0xbb, 0x41, 0x36, 0x78, 0x37, 0xc8, 0xe6, 0x9c, 0x5e, 0xfe, 0xce, 0x9f, 0x3f, 0xbb,
+	0x7b, 0xfb, 0xc4, 0xdc, 0xc3, 0x7b, 0x51, 0x0a, 0x78, 0xd0, 0x90, 0x92, 0x05, 0x80, 0x38, 0xa3,
+	0x78, 0x87, 0x1e, 0x47, 0xfe, 0x17, 0xbc, 0x09, 0x2e, 0x07, 0xe7, 0xc6, 0x47, 0x1d, 0xe2, 0x69,
+	0xa2, 0xd5, 0x46, 0xb3, 0xbe, 0xf0, 0x1d, 0x26, 0xbb, 0x1f, 0xfb, 0x37, 0x7b, 0x19, 0xf8, 0xd9,
+	0x24, 0x90, 0x82, 0x71, 0xf8, 0x3d, 0x83, 0xdf, 0x63, 0x7a, 0x25, 0xc7, 0x73, 0x7b, 0xb0, 0x0a,
+	0x12, 0x47, 0x4d, 0x8a, 0xff, 0x48, 0xb0, 0x91, 0x3d, 0x75, 0x9a, 0x21, 0xf3, 0xa3, 0x16, 0x86,
+	0x5d, 0x68, 0xd1, 0x90, 0x24, 0x1c, 0x51, 0x93, 0xa7, 0x46, 0xd4, 0x03, 0x70, 0xa9, 0xd3, 0x36,
+	0xf8, 0xbe, 0x46, 0xbf, 0xc9, 0xbe, 0xfa, 0x46, 0xf0, 0xff, 0x41, 0xd4, 0xa6, 0x4d, 0x1e, 0x2b,
+	0xb3, 0xf9, 0xdb, 0x5f, 0xf7, 0x65, 0x18, 0xea, 0x2f, 0xfd, 0xf6, 0xe5, 0xd7, 0x5f, 0x7d, 0x7a,
+	0x67, 0xc6, 0x74, 0x2c, 0xd3, 0xc1, 0xea, 0x87, 0x94, 0x38, 0x88, 0x99, 0x28, 0x08, 0xc0, 0x93,
+	0xc4, 0xf0, 0x06, 0x98, 0x15, 0xd5, 0xa4, 0x85, 0xcd, 0x66, 0xcb, 0x13, 0xb9, 0x80, 0x66, 0xb8,
+	0x6c, 0x8b, 0x8b, 0xe0, 0x32, 0x88, 0x7b, 0xec, 0x6e, 0x6b, 0xe0, 0xae, 0x58, 0x18, 0xba, 0xe4,
+	0x75, 0xcb, 0xec, 0x53, 0xc1, 0x60, 0x6a, 0x9b, 0x18, 0xd8, 0x82, 0x0f, 0x41, 0xf4, 0x39, 0xee,
+	0x89, 0x9a, 0x92, 0x7f, 0xe3, 0xeb, 0xbe, 0xfc, 0xfa, 0xb1, 0x83, 0xdc, 0xc6, 0x5e, 0x63, 0xd7,
+	0x1b, 0xfe, 0xb0, 0xcc, 0x06, 0x5d, 0x67, 0x07, 0x1f, 0xcd, 0x6e, 0xe1, 0x2e, 0x3b, 0xe5, 0x28,
+	0x62, 0x04, 0x2c, 0x19, 0xc4, 0xc3, 0xd0, 0x24, 0xaf, 0x4e, 0xe2, 0x43, 0xa9, 0x80, 0xcb, 0x9b,
+	0x1a, 0xdd, 0xee, 0x58, 0x9e, 0xd9, 0xb6, 0x4c, 0xec, 0xc2, 0x55, 0x90, 0x70, 0x3a, 0x36, 0x73,
+	0x3c, 0x71, 0xfd, 0x29, 0x0f, 0x05, 0x30, 0x03, 0x66, 0x0c, 0xec, 0x10, 0xdb, 0x74, 0x8e, 0x32,
+	0x37, 0x86, 0xc2, 0x22, 0xe5, 0xc7, 0xe0, 0xf2, 0xb1, 0x6a, 0x09, 0xdf, 0x00, 0xf1, 0xa0, 0x15,
+	0x92, 0x22, 0xe7, 0xe4, 0xed, 0x11, 0x32, 0xd8, 0x8c, 0xc9, 0x6f, 0xb3, 0x19, 0x57, 0x8e, 0x97,
+	0x6b, 0xf8, 0x3d, 0x30, 0x25, 0x2a, 0x7e, 0x84, 0xb7, 0x52, 0xf2, 0xc9, 0xb0, 0x38, 0x66, 0x10,
+	0x2e, 0x9f, 0xc2, 0x50, 0xf9, 0x65, 0x04, 0x5c, 0x1b, 0xf3, 0x92, 0x01, 0x17, 0xc1, 0xe4, 0x51,
+	0x91, 0x9b, 0x3e, 0xec, 0xcb, 0x93, 0xe5, 0x22, 0x9a, 0x34, 0x8d, 0x0b, 0xc7, 0x6b, 0x50, 0x87,
+	0xa2, 0xdf, 0xa2, 0x0e, 0x29, 0x7f, 0x8b, 0x80, 0x19, 0x46, 0x19, 0x74, 0x67, 0x17, 0x2a, 0xbb,
+	0x6f, 0x81, 0x84, 0xdf, 0x13, 0x5e, 0xa0, 0xf0, 0x0e, 0xa1, 0xb0, 0x05, 0xa6, 0x35, 0x9b, 0x3d,
+	0x84, 0x48, 0xd1, 0xf3, 0xfa, 0xd1, 0x37, 0x99, 0xfb, 0xbe, 0x79, 0xc3, 0xe9, 0xf3, 0xdf, 0xf9,
+	0x6f, 0x04, 0x80, 0xe1, 0xb3, 0x16, 0x7c, 0x0b, 0x2c, 0xe5, 0x0a, 0x85, 0x52, 0xad, 0xa6, 0xd6,
+	0x9f, 0x55, 0x4b, 0xea, 0x93, 0x9d, 0x5a, 0xb5, 0x54, 0x28, 0x3f, 0x2c, 0x97, 0x8a, 0xc9, 0x89,
+	0xd4, 0xf2, 0xfe, 0x41, 0x66, 0x61, 0x08, 0x7e, 0xe2, 0xd0, 0x36, 0xd6, 0xcd, 0x5d, 0x13, 0x1b,
+	0xf0, 0x35, 0x00, 0xc3, 0x76, 0x3b, 0x95, 0x7c, 0xa5, 0xf8, 0x2c, 0x19, 0x49, 0xcd, 0xef, 0x1f,
+	0x64, 0x92, 0x43, 0x93, 0x1d, 0xd2, 0x20, 0x46, 0x0f, 0x6e, 0x80, 0x85, 0x30, 0xba, 0xf4, 0x6e,
+	0x09, 0x3d, 0xe3, 0x06, 0xd1, 0xd4, 0xd2, 0xfe, 0x41, 0xe6, 0xda, 0xd0, 0xa0, 0xb4, 0x87, 0xdd,
+	0x1e, 0xb7, 0x79, 0x00, 0x56, 0xc3, 0x36, 0xb9, 0x9d, 0x67, 0x6a, 0xe5, 0xa1, 0x9a, 0x2b, 0x16,
+	0x51, 0xa9, 0x56, 0x2b, 0xd5, 0x92, 0xb1, 0xd4, 0xea, 0xfe, 0x41, 0x46, 0x1a, 0x9a, 0xe6, 0x9c,
+	0x5e, 0x65, 0x37, 0x17, 0xbc, 0x5f, 0xa6, 0xe2, 0x3f, 0xfd, 0x5d, 0x7a, 0xe2, 0x93, 0xdf, 0xa7,
+	0x27, 0x14, 0xf6, 0x10, 0x39, 0x79, 0xe7, 0x0f, 0x51, 0x90, 0x39, 0xaf, 0x28, 0x42, 0x0c, 0x5e,
+	0x2f, 0x54, 0x76, 0xea, 0x28, 0x57, 0xa8, 0xab, 0x85, 0x4a, 0xb1, 0xa4, 0x6e, 0x95, 0x6b, 0xf5,
+	0x0a, 0x7a, 0xa6, 0x56, 0xaa, 0x25, 0x94, 0xab, 0x97, 0x2b, 0x3b, 0xe3, 0xfc, 0xb4, 0xbe, 0x7f,
+	0x90, 0x79, 0xf5, 0x3c, 0xee, 0xb0, 0xf7, 0x9e, 0x82, 0x57, 0x2e, 0x34, 0x4c, 0x79, 0xa7, 0x5c,
+	0x4f, 0x46, 0x52, 0x6b, 0xfb, 0x07, 0x99, 0x5b, 0xe7, 0xf1, 0x97, 0x1d, 0xd3, 0x83, 0xef, 0x83,
+	0xd7, 0x2e, 0x44, 0xbc, 0x5d, 0xde, 0x44, 0xb9, 0x7a, 0x29, 0x39, 0x99, 0x7a, 0x75, 0xff, 0x20,
+	0xf3, 0xf2, 0x79, 0xdc, 0x22, 0x39, 0xf1, 0x85, 0xe9, 0x37, 0x4b, 0x3b, 0xa5, 0x5a, 0xb9, 0x96,
+	0x8c, 0x5e, 0x8c, 0x7e, 0x13, 0x3b, 0x98, 0x9a, 0x34, 0x15, 0x63, 0x5b, 0x76, 0xe7, 0xaf, 0x91,
+	0x50, 0x89, 0xa9, 0xb6, 0x34, 0x8a, 0xe1, 0xdb, 0x60, 0x35, 0xff, 0xb8, 0x52, 0xf8, 0xbe, 0x5a,
+	0x7b, 0x52, 0xac, 0xa8, 0xd5, 0xad, 0x5c, 0x6d, 0x74, 0x0b, 0xae, 0xef, 0x1f, 0x64, 0x96, 0x8f,
+	0x5b, 0x85, 0x1d, 0xfe, 0x60, 0x0c, 0x41, 0xbe, 0xb4, 0x59, 0xde, 0x51, 0xb9, 0x38, 0x19, 0x11,
+	0xc1, 0x74, 0x9c, 0x20, 0x8f, 0x9b, 0xa6, 0xc3, 0x45, 0xf0, 0x3e, 0x48, 0x9d, 0xb0, 0x2f, 0xed,
+	0x14, 0x7d, 0xeb, 0xc9, 0x54, 0x6a, 0xff, 0x20, 0xb3, 0x78, 0xdc, 0xba, 0xe4, 0x18, 0x5c, 0xe0,
+	0xaf, 0xea, 0x8b, 0x08, 0xb8, 0xca, 0x2f, 0x15, 0x65, 0x9b, 0xb5, 0x2a, 0xec, 0xf0, 0x81, 0x39,
+	0x70, 0xbd, 0x56, 0xcf, 0xd5, 0x4b, 0x6a, 0x79, 0xbb, 0x5a, 0x41, 0x75, 0x75, 0xbb, 0x52, 0x1c,
+	0x5d, 0x57, 0x7a, 0xff, 0x20, 0x93, 0x1a, 0xb1, 0x0b, 0x2f, 0xec, 0xbb, 0x60, 0xe5, 0x24, 0x45,
+	0xe5, 0xdd, 0x12, 0x7a, 0x8a, 0xca, 0xf5, 0x52, 0xb0, 0xae, 0x11, 0x82, 0xca, 0x1e, 0x76, 0x5f,
+	0xb8, 0xa6, 0x87, 0xe1, 0x9b, 0x60, 0xe9, 0xa4, 0xf9, 0x76, 0x09, 0x6d, 0xb2, 0xd0, 0x90, 0xf6,
+	0x0f, 0x32, 0xf3, 0x23, 0xa6, 0xdb, 0xd8, 0x6d, 0x62, 0xb1, 0xa4, 0xfc, 0xd6, 0xe7, 0xff, 0x4a,
+	0x4f, 0x7c, 0x72, 0x98, 0x8e, 0x7c, 0x7e, 0x98, 0x8e, 0x7c, 0x71, 0x98, 0x8e, 0xfc, 0xf3, 0x30,
+	0x1d, 0xf9, 0xc5, 0x97, 0xe9, 0x89, 0x2f, 0xbe, 0x4c, 0x4f, 0xfc, 0xfd, 0xcb, 0xf4, 0xc4, 0x7b,
+	0xb7, 0x43, 0x35, 0xaa, 0x40, 0xa8, 0xfd, 0x34, 0xf8, 0xaf, 0x1f, 0x63, 0xbd, 0xcb, 0xff, 0x15,
+	0x75, 0xaa, 0x31, 0xcd, 0xfb, 0xae, 0xff, 0xfb, 0xdf, 0x00, 0x56, 0x8b, 0xc8, 0x26, 0x20, 0x1a,
+	0x00, 0x00,
 }
 
 func (this *AccessTypeParam) Equal(that interface{}) bool {
@@ -627,6 +1158,9 @@ func (this *AccessConfig) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.MaxInstancesPerAddress != that1.MaxInstancesPerAddress {
+		return false
+	}
 	return true
 }
 
@@ -655,6 +1189,116 @@ func (this *Params) Equal(that interface{}) bool {
 	if this.InstantiateDefaultPermission != that1.InstantiateDefaultPermission {
 		return false
 	}
+	if this.MaxMigrationCheckpoints != that1.MaxMigrationCheckpoints {
+		return false
+	}
+	if this.MaxInstantiatesPerBlock != that1.MaxInstantiatesPerBlock {
+		return false
+	}
+	if this.CountSubmsgInstantiates != that1.CountSubmsgInstantiates {
+		return false
+	}
+	if this.HistoricalQueryDepth != that1.HistoricalQueryDepth {
+		return false
+	}
+	if len(this.AutoPinCodeHashes) != len(that1.AutoPinCodeHashes) {
+		return false
+	}
+	for i := range this.AutoPinCodeHashes {
+		if this.AutoPinCodeHashes[i] != that1.AutoPinCodeHashes[i] {
+			return false
+		}
+	}
+	if this.MaxQueryRecursionDepth != that1.MaxQueryRecursionDepth {
+		return false
+	}
+	if this.MaxSubQueryGas != that1.MaxSubQueryGas {
+		return false
+	}
+	if this.MaxWasmInstructionsPerCall != that1.MaxWasmInstructionsPerCall {
+		return false
+	}
+	if this.EmitStateChangeEvents != that1.EmitStateChangeEvents {
+		return false
+	}
+	if this.RecordContractDependencies != that1.RecordContractDependencies {
+		return false
+	}
+	if this.MaxExecuteMsgSize != that1.MaxExecuteMsgSize {
+		return false
+	}
+	if len(this.DisabledCapabilities) != len(that1.DisabledCapabilities) {
+		return false
+	}
+	for i := range this.DisabledCapabilities {
+		if this.DisabledCapabilities[i] != that1.DisabledCapabilities[i] {
+			return false
+		}
+	}
+	if this.EmitRawContractEvents != that1.EmitRawContractEvents {
+		return false
+	}
+	if len(this.IBCSenderAllowlist) != len(that1.IBCSenderAllowlist) {
+		return false
+	}
+	for i := range this.IBCSenderAllowlist {
+		if this.IBCSenderAllowlist[i] != that1.IBCSenderAllowlist[i] {
+			return false
+		}
+	}
+	if this.MaxCallDepth != that1.MaxCallDepth {
+		return false
+	}
+	if this.RejectFloatOperations != that1.RejectFloatOperations {
+		return false
+	}
+	if this.ForwardTxMemo != that1.ForwardTxMemo {
+		return false
+	}
+	if this.MaxSubMessagesPerCall != that1.MaxSubMessagesPerCall {
+		return false
+	}
+	if this.MemoryCacheSize != that1.MemoryCacheSize {
+		return false
+	}
+	if this.ContractMemoryLimit != that1.ContractMemoryLimit {
+		return false
+	}
+	if this.RejectLockedContractQueries != that1.RejectLockedContractQueries {
+		return false
+	}
+	if len(this.CodeStorageDeposit) != len(that1.CodeStorageDeposit) {
+		return false
+	}
+	for i := range this.CodeStorageDeposit {
+		if !this.CodeStorageDeposit[i].Equal(&that1.CodeStorageDeposit[i]) {
+			return false
+		}
+	}
+	if this.RecordCodeInstantiations != that1.RecordCodeInstantiations {
+		return false
+	}
+	if this.StateCleanupRefundPerByte != that1.StateCleanupRefundPerByte {
+		return false
+	}
+	if this.StateCleanupRefundThreshold != that1.StateCleanupRefundThreshold {
+		return false
+	}
+	if this.MaxStateCleanupRefund != that1.MaxStateCleanupRefund {
+		return false
+	}
+	if this.EnforceLabelUniquenessPerCode != that1.EnforceLabelUniquenessPerCode {
+		return false
+	}
+	if this.MaxEventsPerCall != that1.MaxEventsPerCall {
+		return false
+	}
+	if this.RejectSelfQueries != that1.RejectSelfQueries {
+		return false
+	}
+	if this.MaxBlockSudoHooks != that1.MaxBlockSudoHooks {
+		return false
+	}
 	return true
 }
 
@@ -686,6 +1330,12 @@ func (this *CodeInfo) Equal(that interface{}) bool {
 	if !this.InstantiateConfig.Equal(&that1.InstantiateConfig) {
 		return false
 	}
+	if this.Source != that1.Source {
+		return false
+	}
+	if this.Builder != that1.Builder {
+		return false
+	}
 	return true
 }
 
@@ -732,6 +1382,12 @@ func (this *ContractInfo) Equal(that interface{}) bool {
 	if !this.Extension.Equal(that1.Extension) {
 		return false
 	}
+	if this.Locked != that1.Locked {
+		return false
+	}
+	if this.StorageQuota != that1.StorageQuota {
+		return false
+	}
 	return true
 }
 
@@ -825,22 +1481,175 @@ func (this *Model) Equal(that interface{}) bool {
 	return true
 }
 
-func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *GasMultiplier) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	that1, ok := that.(*GasMultiplier)
+	if !ok {
+		that2, ok := that.(GasMultiplier)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Numerator != that1.Numerator {
+		return false
+	}
+	if this.Denominator != that1.Denominator {
+		return false
+	}
+	return true
+}
+
+func (this *BlockSudoHook) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BlockSudoHook)
+	if !ok {
+		that2, ok := that.(BlockSudoHook)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Contract != that1.Contract {
+		return false
+	}
+	if !bytes.Equal(this.Msg, that1.Msg) {
+		return false
+	}
+	return true
+}
+
+func (this *BlockSudoHooks) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*BlockSudoHooks)
+	if !ok {
+		that2, ok := that.(BlockSudoHooks)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Hooks) != len(that1.Hooks) {
+		return false
+	}
+	for i := range this.Hooks {
+		if !this.Hooks[i].Equal(&that1.Hooks[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *MigrationCheckpoint) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*MigrationCheckpoint)
+	if !ok {
+		that2, ok := that.(MigrationCheckpoint)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ID != that1.ID {
+		return false
+	}
+	if this.CodeID != that1.CodeID {
+		return false
+	}
+	if !this.Created.Equal(that1.Created) {
+		return false
+	}
+	return true
+}
+
+func (this *CodeDeposit) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*CodeDeposit)
+	if !ok {
+		that2, ok := that.(CodeDeposit)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.CodeID != that1.CodeID {
+		return false
+	}
+	if this.Depositor != that1.Depositor {
+		return false
+	}
+	if len(this.Amount) != len(that1.Amount) {
+		return false
+	}
+	for i := range this.Amount {
+		if !this.Amount[i].Equal(&that1.Amount[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -873,6 +1682,11 @@ func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxInstancesPerAddress != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxInstancesPerAddress))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Addresses) > 0 {
 		for iNdEx := len(m.Addresses) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Addresses[iNdEx])
@@ -910,6 +1724,261 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.MaxBlockSudoHooks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxBlockSudoHooks))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.RejectSelfQueries {
+		i--
+		if m.RejectSelfQueries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.MaxEventsPerCall != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxEventsPerCall))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.EnforceLabelUniquenessPerCode {
+		i--
+		if m.EnforceLabelUniquenessPerCode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.MaxStateCleanupRefund != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxStateCleanupRefund))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.StateCleanupRefundThreshold != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StateCleanupRefundThreshold))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.StateCleanupRefundPerByte != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StateCleanupRefundPerByte))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.RecordCodeInstantiations {
+		i--
+		if m.RecordCodeInstantiations {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if len(m.CodeStorageDeposit) > 0 {
+		for iNdEx := len(m.CodeStorageDeposit) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CodeStorageDeposit[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xc2
+		}
+	}
+	if m.RejectLockedContractQueries {
+		i--
+		if m.RejectLockedContractQueries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	if m.ContractMemoryLimit != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ContractMemoryLimit))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
+	}
+	if m.MemoryCacheSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MemoryCacheSize))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if m.MaxSubMessagesPerCall != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxSubMessagesPerCall))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.ForwardTxMemo {
+		i--
+		if m.ForwardTxMemo {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.RejectFloatOperations {
+		i--
+		if m.RejectFloatOperations {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.MaxCallDepth != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxCallDepth))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if len(m.IBCSenderAllowlist) > 0 {
+		for iNdEx := len(m.IBCSenderAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.IBCSenderAllowlist[iNdEx])
+			copy(dAtA[i:], m.IBCSenderAllowlist[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.IBCSenderAllowlist[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if m.EmitRawContractEvents {
+		i--
+		if m.EmitRawContractEvents {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.DisabledCapabilities) > 0 {
+		for iNdEx := len(m.DisabledCapabilities) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.DisabledCapabilities[iNdEx])
+			copy(dAtA[i:], m.DisabledCapabilities[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.DisabledCapabilities[iNdEx])))
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if m.MaxExecuteMsgSize != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxExecuteMsgSize))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.RecordContractDependencies {
+		i--
+		if m.RecordContractDependencies {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.EmitStateChangeEvents {
+		i--
+		if m.EmitStateChangeEvents {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.MaxWasmInstructionsPerCall != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxWasmInstructionsPerCall))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.MaxSubQueryGas != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxSubQueryGas))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.MaxQueryRecursionDepth != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxQueryRecursionDepth))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.AutoPinCodeHashes) > 0 {
+		for iNdEx := len(m.AutoPinCodeHashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AutoPinCodeHashes[iNdEx])
+			copy(dAtA[i:], m.AutoPinCodeHashes[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.AutoPinCodeHashes[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.HistoricalQueryDepth != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.HistoricalQueryDepth))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.CountSubmsgInstantiates {
+		i--
+		if m.CountSubmsgInstantiates {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MaxInstantiatesPerBlock != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxInstantiatesPerBlock))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxMigrationCheckpoints != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxMigrationCheckpoints))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.InstantiateDefaultPermission != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InstantiateDefaultPermission))
 		i--
@@ -948,9 +2017,23 @@ func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	{
-		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
+	if len(m.Builder) > 0 {
+		i -= len(m.Builder)
+		copy(dAtA[i:], m.Builder)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Builder)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x32
+	}
+	{
+		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
 			return 0, err
 		}
 		i -= size
@@ -995,6 +2078,21 @@ func (m *ContractInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.StorageQuota != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.StorageQuota))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Locked {
+		i--
+		if m.Locked {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
 	if m.Extension != nil {
 		{
 			size, err := m.Extension.MarshalToSizedBuffer(dAtA[:i])
@@ -1184,6 +2282,207 @@ func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *GasMultiplier) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GasMultiplier) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GasMultiplier) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Denominator != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Denominator))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Numerator != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Numerator))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockSudoHook) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockSudoHook) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockSudoHook) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockSudoHooks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockSudoHooks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockSudoHooks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hooks) > 0 {
+		for iNdEx := len(m.Hooks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Hooks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MigrationCheckpoint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MigrationCheckpoint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MigrationCheckpoint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Created != nil {
+		{
+			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeDeposit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeDeposit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeDeposit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Amount) > 0 {
+		for iNdEx := len(m.Amount) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Amount[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Depositor) > 0 {
+		i -= len(m.Depositor)
+		copy(dAtA[i:], m.Depositor)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Depositor)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1223,6 +2522,9 @@ func (m *AccessConfig) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.MaxInstancesPerAddress != 0 {
+		n += 1 + sovTypes(uint64(m.MaxInstancesPerAddress))
+	}
 	return n
 }
 
@@ -1237,6 +2539,108 @@ func (m *Params) Size() (n int) {
 	if m.InstantiateDefaultPermission != 0 {
 		n += 1 + sovTypes(uint64(m.InstantiateDefaultPermission))
 	}
+	if m.MaxMigrationCheckpoints != 0 {
+		n += 1 + sovTypes(uint64(m.MaxMigrationCheckpoints))
+	}
+	if m.MaxInstantiatesPerBlock != 0 {
+		n += 1 + sovTypes(uint64(m.MaxInstantiatesPerBlock))
+	}
+	if m.CountSubmsgInstantiates {
+		n += 2
+	}
+	if m.HistoricalQueryDepth != 0 {
+		n += 1 + sovTypes(uint64(m.HistoricalQueryDepth))
+	}
+	if len(m.AutoPinCodeHashes) > 0 {
+		for _, s := range m.AutoPinCodeHashes {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxQueryRecursionDepth != 0 {
+		n += 1 + sovTypes(uint64(m.MaxQueryRecursionDepth))
+	}
+	if m.MaxSubQueryGas != 0 {
+		n += 1 + sovTypes(uint64(m.MaxSubQueryGas))
+	}
+	if m.MaxWasmInstructionsPerCall != 0 {
+		n += 1 + sovTypes(uint64(m.MaxWasmInstructionsPerCall))
+	}
+	if m.EmitStateChangeEvents {
+		n += 2
+	}
+	if m.RecordContractDependencies {
+		n += 2
+	}
+	if m.MaxExecuteMsgSize != 0 {
+		n += 1 + sovTypes(uint64(m.MaxExecuteMsgSize))
+	}
+	if len(m.DisabledCapabilities) > 0 {
+		for _, s := range m.DisabledCapabilities {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.EmitRawContractEvents {
+		n += 2
+	}
+	if len(m.IBCSenderAllowlist) > 0 {
+		for _, s := range m.IBCSenderAllowlist {
+			l = len(s)
+			n += 2 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxCallDepth != 0 {
+		n += 2 + sovTypes(uint64(m.MaxCallDepth))
+	}
+	if m.RejectFloatOperations {
+		n += 3
+	}
+	if m.ForwardTxMemo {
+		n += 3
+	}
+	if m.MaxSubMessagesPerCall != 0 {
+		n += 2 + sovTypes(uint64(m.MaxSubMessagesPerCall))
+	}
+	if m.MemoryCacheSize != 0 {
+		n += 2 + sovTypes(uint64(m.MemoryCacheSize))
+	}
+	if m.ContractMemoryLimit != 0 {
+		n += 2 + sovTypes(uint64(m.ContractMemoryLimit))
+	}
+	if m.RejectLockedContractQueries {
+		n += 3
+	}
+	if len(m.CodeStorageDeposit) > 0 {
+		for _, e := range m.CodeStorageDeposit {
+			l = e.Size()
+			n += 2 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.RecordCodeInstantiations {
+		n += 3
+	}
+	if m.StateCleanupRefundPerByte != 0 {
+		n += 2 + sovTypes(uint64(m.StateCleanupRefundPerByte))
+	}
+	if m.StateCleanupRefundThreshold != 0 {
+		n += 2 + sovTypes(uint64(m.StateCleanupRefundThreshold))
+	}
+	if m.MaxStateCleanupRefund != 0 {
+		n += 2 + sovTypes(uint64(m.MaxStateCleanupRefund))
+	}
+	if m.EnforceLabelUniquenessPerCode {
+		n += 3
+	}
+	if m.MaxEventsPerCall != 0 {
+		n += 2 + sovTypes(uint64(m.MaxEventsPerCall))
+	}
+	if m.RejectSelfQueries {
+		n += 3
+	}
+	if m.MaxBlockSudoHooks != 0 {
+		n += 2 + sovTypes(uint64(m.MaxBlockSudoHooks))
+	}
 	return n
 }
 
@@ -1256,6 +2660,14 @@ func (m *CodeInfo) Size() (n int) {
 	}
 	l = m.InstantiateConfig.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Builder)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -1296,6 +2708,12 @@ func (m *ContractInfo) Size() (n int) {
 		l = m.Extension.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Locked {
+		n += 2
+	}
+	if m.StorageQuota != 0 {
+		n += 1 + sovTypes(uint64(m.StorageQuota))
+	}
 	return n
 }
 
@@ -1354,26 +2772,114 @@ func (m *Model) Size() (n int) {
 	return n
 }
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *GasMultiplier) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Numerator != 0 {
+		n += 1 + sovTypes(uint64(m.Numerator))
+	}
+	if m.Denominator != 0 {
+		n += 1 + sovTypes(uint64(m.Denominator))
+	}
+	return n
 }
 
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *BlockSudoHook) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
 }
 
-func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+func (m *BlockSudoHooks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Hooks) > 0 {
+		for _, e := range m.Hooks {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MigrationCheckpoint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovTypes(uint64(m.ID))
+	}
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *CodeDeposit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	l = len(m.Depositor)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Amount) > 0 {
+		for _, e := range m.Amount {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+
+func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
 			b := dAtA[iNdEx]
 			iNdEx++
@@ -1480,11 +2986,1479 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 3:
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addresses = append(m.Addresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxInstancesPerAddress", wireType)
+			}
+			m.MaxInstancesPerAddress = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxInstancesPerAddress |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+			}
+			m.InstantiateDefaultPermission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMigrationCheckpoints", wireType)
+			}
+			m.MaxMigrationCheckpoints = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMigrationCheckpoints |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxInstantiatesPerBlock", wireType)
+			}
+			m.MaxInstantiatesPerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxInstantiatesPerBlock |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CountSubmsgInstantiates", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CountSubmsgInstantiates = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoricalQueryDepth", wireType)
+			}
+			m.HistoricalQueryDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HistoricalQueryDepth |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AutoPinCodeHashes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AutoPinCodeHashes = append(m.AutoPinCodeHashes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxQueryRecursionDepth", wireType)
+			}
+			m.MaxQueryRecursionDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxQueryRecursionDepth |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSubQueryGas", wireType)
+			}
+			m.MaxSubQueryGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSubQueryGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxWasmInstructionsPerCall", wireType)
+			}
+			m.MaxWasmInstructionsPerCall = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxWasmInstructionsPerCall |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmitStateChangeEvents", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EmitStateChangeEvents = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordContractDependencies", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RecordContractDependencies = bool(v != 0)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxExecuteMsgSize", wireType)
+			}
+			m.MaxExecuteMsgSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxExecuteMsgSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisabledCapabilities", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisabledCapabilities = append(m.DisabledCapabilities, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EmitRawContractEvents", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EmitRawContractEvents = bool(v != 0)
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCSenderAllowlist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IBCSenderAllowlist = append(m.IBCSenderAllowlist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCallDepth", wireType)
+			}
+			m.MaxCallDepth = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxCallDepth |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectFloatOperations", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RejectFloatOperations = bool(v != 0)
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForwardTxMemo", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ForwardTxMemo = bool(v != 0)
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSubMessagesPerCall", wireType)
+			}
+			m.MaxSubMessagesPerCall = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSubMessagesPerCall |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryCacheSize", wireType)
+			}
+			m.MemoryCacheSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemoryCacheSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractMemoryLimit", wireType)
+			}
+			m.ContractMemoryLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContractMemoryLimit |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectLockedContractQueries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RejectLockedContractQueries = bool(v != 0)
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeStorageDeposit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeStorageDeposit = append(m.CodeStorageDeposit, types.Coin{})
+			if err := m.CodeStorageDeposit[len(m.CodeStorageDeposit)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordCodeInstantiations", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RecordCodeInstantiations = bool(v != 0)
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateCleanupRefundPerByte", wireType)
+			}
+			m.StateCleanupRefundPerByte = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StateCleanupRefundPerByte |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateCleanupRefundThreshold", wireType)
+			}
+			m.StateCleanupRefundThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StateCleanupRefundThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStateCleanupRefund", wireType)
+			}
+			m.MaxStateCleanupRefund = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxStateCleanupRefund |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnforceLabelUniquenessPerCode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EnforceLabelUniquenessPerCode = bool(v != 0)
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEventsPerCall", wireType)
+			}
+			m.MaxEventsPerCall = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxEventsPerCall |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 31:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectSelfQueries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RejectSelfQueries = bool(v != 0)
+		case 32:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBlockSudoHooks", wireType)
+			}
+			m.MaxBlockSudoHooks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBlockSudoHooks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CodeHash == nil {
+				m.CodeHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Builder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Builder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Admin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &AbsoluteTxPosition{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IBCPortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBC2PortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IBC2PortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Extension == nil {
+				m.Extension = &types1.Any{}
+			}
+			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locked", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Locked = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageQuota", wireType)
+			}
+			m.StorageQuota = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StorageQuota |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			m.Operation = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Updated == nil {
+				m.Updated = &AbsoluteTxPosition{}
+			}
+			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1494,23 +4468,25 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addresses = append(m.Addresses, string(dAtA[iNdEx:postIndex]))
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1534,7 +4510,7 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *Params) Unmarshal(dAtA []byte) error {
+func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1557,17 +4533,17 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
 			}
-			var msglen int
+			m.BlockHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1577,30 +4553,16 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.BlockHeight |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
 			}
-			m.InstantiateDefaultPermission = 0
+			m.TxIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1610,7 +4572,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				m.TxIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1637,7 +4599,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+func (m *Model) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1660,15 +4622,15 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Model: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1695,48 +4657,16 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.CodeHash == nil {
-				m.CodeHash = []byte{}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1746,23 +4676,24 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1787,7 +4718,7 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+func (m *GasMultiplier) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1810,17 +4741,17 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: GasMultiplier: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GasMultiplier: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Numerator", wireType)
 			}
-			m.CodeID = 0
+			m.Numerator = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1830,148 +4761,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
+				m.Numerator |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Admin = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Label = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Created == nil {
-				m.Created = &AbsoluteTxPosition{}
-			}
-			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denominator", wireType)
 			}
-			var stringLen uint64
+			m.Denominator = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1981,27 +4780,65 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Denominator |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IBCPortID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 7:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *BlockSudoHook) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockSudoHook: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockSudoHook: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IBC2PortID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2029,13 +4866,13 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IBC2PortID = string(dAtA[iNdEx:postIndex])
+			m.Contract = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 8:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2045,26 +4882,24 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Extension == nil {
-				m.Extension = &types.Any{}
-			}
-			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -2089,7 +4924,7 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+func (m *BlockSudoHooks) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2112,53 +4947,15 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockSudoHooks: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockSudoHooks: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
-			}
-			m.Operation = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
-			}
-			m.CodeID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hooks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2185,47 +4982,11 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Updated == nil {
-				m.Updated = &AbsoluteTxPosition{}
-			}
-			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Hooks = append(m.Hooks, BlockSudoHook{})
+			if err := m.Hooks[len(m.Hooks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
-			if m.Msg == nil {
-				m.Msg = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2248,7 +5009,7 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
+func (m *MigrationCheckpoint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2271,17 +5032,17 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
+			return fmt.Errorf("proto: MigrationCheckpoint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MigrationCheckpoint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
-			m.BlockHeight = 0
+			m.ID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2291,16 +5052,16 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockHeight |= uint64(b&0x7F) << shift
+				m.ID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
 			}
-			m.TxIndex = 0
+			m.CodeID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2310,11 +5071,47 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxIndex |= uint64(b&0x7F) << shift
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &AbsoluteTxPosition{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2337,7 +5134,7 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *Model) Unmarshal(dAtA []byte) error {
+func (m *CodeDeposit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2360,17 +5157,36 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Model: wiretype end group for non-group")
+			return fmt.Errorf("proto: CodeDeposit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CodeDeposit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Depositor", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2380,31 +5196,29 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
+			m.Depositor = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2414,24 +5228,24 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Amount = append(m.Amount, types.Coin{})
+			if err := m.Amount[len(m.Amount)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
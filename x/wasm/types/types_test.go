@@ -434,6 +434,31 @@ func TestAccessConfigSubset(t *testing.T) {
 			check:    AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner", "other"}},
 			isSubSet: false,
 		},
+		"anyOf(max instances) < anyOf": {
+			superSet: AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}},
+			check:    AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 1},
+			isSubSet: true,
+		},
+		"anyOf(lower max instances) < anyOf(max instances)": {
+			superSet: AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 2},
+			check:    AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 1},
+			isSubSet: true,
+		},
+		"anyOf(higher max instances) !< anyOf(max instances)": {
+			superSet: AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 1},
+			check:    AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 2},
+			isSubSet: false,
+		},
+		"anyOf !< anyOf(max instances)": {
+			superSet: AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 1},
+			check:    AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}},
+			isSubSet: false,
+		},
+		"nobody < anyOf(max instances)": {
+			superSet: AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}, MaxInstancesPerAddress: 1},
+			check:    AccessConfig{Permission: AccessTypeNobody},
+			isSubSet: true,
+		},
 		"everybody !< anyOf": {
 			superSet: AccessConfig{Permission: AccessTypeAnyOfAddresses, Addresses: []string{"owner"}},
 			check:    AccessConfig{Permission: AccessTypeEverybody},
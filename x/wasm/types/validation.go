@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 	"unicode"
 
@@ -30,6 +31,10 @@ var (
 
 	// MaxAddressCount is the maximum number of addresses allowed within a message
 	MaxAddressCount = 50
+
+	// MaxContractMsgSize is the default of the max execute msg size param.
+	// It defaults to the max tx size of CometBFT.
+	MaxContractMsgSize = 1024 * 1024 // extension point for chains to customize via compile flag.
 )
 
 func validateWasmCode(s []byte, maxSize int) error {
@@ -42,6 +47,20 @@ func validateWasmCode(s []byte, maxSize int) error {
 	return nil
 }
 
+// ValidateContractMsgSize ensures the payload msg plus the encoded funds are not larger than the max size.
+// The funds are sized as a repeated field of a message, including the field tag and length prefix of each coin.
+func ValidateContractMsgSize(msg RawContractMessage, funds sdk.Coins, maxSize uint64) error {
+	size := len(msg)
+	for _, c := range funds {
+		l := c.Size()
+		size += 1 + l + sovTx(uint64(l))
+	}
+	if uint64(size) > maxSize {
+		return errorsmod.Wrapf(ErrExceedMaxMsgSize, "%d bytes is greater than %d", size, maxSize)
+	}
+	return nil
+}
+
 // ValidateLabel ensure label constraints
 func ValidateLabel(label string) error {
 	if label == "" {
@@ -80,17 +99,8 @@ func ValidateSalt(salt []byte) error {
 func ValidateVerificationInfo(source, builder string, codeHash []byte) error {
 	// if any set require others to be set
 	if len(source) != 0 || len(builder) != 0 || len(codeHash) != 0 {
-		if source == "" {
-			return errors.New("source is required")
-		}
-		if _, err := url.ParseRequestURI(source); err != nil {
-			return fmt.Errorf("source: %s", err)
-		}
-		if builder == "" {
-			return errors.New("builder is required")
-		}
-		if _, err := reference.ParseDockerRef(builder); err != nil {
-			return fmt.Errorf("builder: %s", err)
+		if err := validateSourceAndBuilder(source, builder); err != nil {
+			return err
 		}
 		if codeHash == nil {
 			return errors.New("code hash is required")
@@ -100,6 +110,59 @@ func ValidateVerificationInfo(source, builder string, codeHash []byte) error {
 	return nil
 }
 
+// ValidateCodeProvenance ensure source and builder constraints. Both are optional but must be set together.
+func ValidateCodeProvenance(source, builder string) error {
+	if len(source) == 0 && len(builder) == 0 {
+		return nil
+	}
+	return validateSourceAndBuilder(source, builder)
+}
+
+func validateSourceAndBuilder(source, builder string) error {
+	if source == "" {
+		return errors.New("source is required")
+	}
+	if _, err := url.ParseRequestURI(source); err != nil {
+		return fmt.Errorf("source: %s", err)
+	}
+	if builder == "" {
+		return errors.New("builder is required")
+	}
+	if _, err := reference.ParseDockerRef(builder); err != nil {
+		return fmt.Errorf("builder: %s", err)
+	}
+	return nil
+}
+
+// stargateQueryPathRegexp matches fully qualified gRPC method names like "/cosmos.bank.v1beta1.Query/Balance"
+var stargateQueryPathRegexp = regexp.MustCompile(`^/[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*/[A-Za-z0-9_]+$`)
+
+// ValidateStargateQueryPath ensures the path is a fully qualified gRPC method name
+func ValidateStargateQueryPath(path string) error {
+	if path == "" {
+		return errorsmod.Wrap(ErrEmpty, "query path")
+	}
+	if !stargateQueryPathRegexp.MatchString(path) {
+		return errorsmod.Wrapf(ErrInvalid, "query path %q", path)
+	}
+	return nil
+}
+
+// validateStargateQueryPaths ensures all paths are valid and unique
+func validateStargateQueryPaths(paths []string) error {
+	index := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		if err := ValidateStargateQueryPath(path); err != nil {
+			return err
+		}
+		if _, found := index[path]; found {
+			return errorsmod.Wrapf(ErrDuplicate, "query path %q", path)
+		}
+		index[path] = struct{}{}
+	}
+	return nil
+}
+
 // validateBech32Addresses ensures the list is not empty, has no duplicates
 // and does not exceed the max number of addresses
 func validateBech32Addresses(addresses []string) error {
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestValidateContractMsgSize(t *testing.T) {
+	const maxSize = 100
+	coin := sdk.NewCoin("foobar", sdkmath.NewInt(1))
+	// a coin is encoded with a field tag and a length prefix in the funds of a message
+	bz, err := (&MsgExecuteContract{Funds: sdk.Coins{coin}}).Marshal()
+	require.NoError(t, err)
+	fundsSize := len(bz)
+
+	specs := map[string]struct {
+		msg    RawContractMessage
+		funds  sdk.Coins
+		expErr bool
+	}{
+		"msg at max size": {
+			msg: paddedJSONMsg(maxSize),
+		},
+		"msg exceeds max size": {
+			msg:    paddedJSONMsg(maxSize + 1),
+			expErr: true,
+		},
+		"msg plus funds at max size": {
+			msg:   paddedJSONMsg(maxSize - fundsSize),
+			funds: sdk.Coins{coin},
+		},
+		"msg plus funds exceed max size": {
+			msg:    paddedJSONMsg(maxSize - fundsSize + 1),
+			funds:  sdk.Coins{coin},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := ValidateContractMsgSize(spec.msg, spec.funds, maxSize)
+			if spec.expErr {
+				require.ErrorIs(t, gotErr, ErrExceedMaxMsgSize)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+// paddedJSONMsg returns a valid json msg of the given size
+func paddedJSONMsg(size int) RawContractMessage {
+	return RawContractMessage("{}" + strings.Repeat(" ", size-2))
+}
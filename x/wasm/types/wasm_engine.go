@@ -12,6 +12,10 @@ const DefaultMaxQueryStackSize uint32 = 10
 
 const DefaultMaxCallDepth uint32 = 500
 
+// MaxCallDepthLimit is the upper bound of the max call depth param. The param can only lower the max call depth
+// of the node.
+const MaxCallDepthLimit = DefaultMaxCallDepth
+
 // WasmEngine defines the WASM contract runtime engine.
 type WasmEngine interface {
 	// StoreCode will compile the Wasm code, and store the resulting compiled module
@@ -357,6 +361,10 @@ type WasmEngine interface {
 	// Unpin is idempotent.
 	Unpin(checksum wasmvm.Checksum) error
 
+	// RemoveCode removes the Wasm code and its compiled module for the given checksum from the
+	// file system and the caches.
+	RemoveCode(checksum wasmvm.Checksum) error
+
 	// GetMetrics some internal metrics for monitoring purposes.
 	GetMetrics() (*wasmvmtypes.Metrics, error)
 